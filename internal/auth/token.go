@@ -1,8 +1,17 @@
 package auth
 
 import (
+	"bytes"
+	"context"
+	"errors"
 	"fmt"
 	"os"
+	"os/exec"
+	"regexp"
+	"runtime"
+	"sort"
+	"strings"
+	"time"
 
 	"github.com/marckohlbrugge/fastmail-cli/internal/keyring"
 )
@@ -10,53 +19,294 @@ import (
 const (
 	// KeyringService is the service name used in the system keychain
 	KeyringService = "fm-cli"
+
 	// KeyringUser is the user/account name in the keychain
 	KeyringUser = "fastmail-token"
+
+	// KeyringProfilesUser holds the names of non-default profiles in the keychain
+	KeyringProfilesUser = "fastmail-profiles"
+
+	// DefaultProfile is the profile used when none is selected
+	DefaultProfile = "default"
 )
 
+// tokenCommandTimeout bounds how long FM_TOKEN_CMD may run (it may prompt for unlock)
+const tokenCommandTimeout = 60 * time.Second
+
+var profileNameRe = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
 // TokenSource provides authentication tokens for the JMAP API.
 type TokenSource struct {
-	envToken string
+	envToken  string
+	tokenFile string
+	tokenCmd  string
+	profile   string
 }
 
 // NewTokenSource creates a new TokenSource.
 func NewTokenSource() *TokenSource {
 	return &TokenSource{
-		envToken: os.Getenv("FASTMAIL_TOKEN"),
+		envToken:  os.Getenv("FASTMAIL_TOKEN"),
+		tokenFile: os.Getenv("FASTMAIL_TOKEN_FILE"),
+		tokenCmd:  os.Getenv("FM_TOKEN_CMD"),
+		profile:   os.Getenv("FM_PROFILE"),
 	}
 }
 
-// GetToken retrieves the API token from environment or system keychain.
-// Priority: FASTMAIL_TOKEN env var > system keychain
+// Profile returns the name of the selected profile.
+func (ts *TokenSource) Profile() string {
+	if ts.profile == "" {
+		return DefaultProfile
+	}
+	return ts.profile
+}
+
+// SetProfile selects which keychain profile the token is loaded from.
+func (ts *TokenSource) SetProfile(profile string) {
+	ts.profile = profile
+}
+
+// Token sources reported by TokenInfo.
+const (
+	SourceEnv     = "env"
+	SourceFile    = "file"
+	SourceCommand = "command"
+	SourceKeyring = "keyring"
+)
+
+// TokenInfo describes a resolved token and where it came from.
+type TokenInfo struct {
+	Token    string
+	Source   string // One of the Source* constants
+	Location string // Env var name, file path, command, or keychain entry
+	Profile  string // Only set for keychain tokens
+}
+
+// GetToken retrieves the API token from environment, a token file, a secret command, or system keychain.
+// Priority: FASTMAIL_TOKEN env var > FASTMAIL_TOKEN_FILE > FM_TOKEN_CMD > system keychain (selected profile)
 func (ts *TokenSource) GetToken() (string, error) {
+	info, err := ts.GetTokenInfo()
+	if err != nil {
+		return "", err
+	}
+	return info.Token, nil
+}
+
+// GetTokenInfo resolves the API token like GetToken and reports its source.
+func (ts *TokenSource) GetTokenInfo() (*TokenInfo, error) {
 	// 1. Environment variable takes precedence
 	if ts.envToken != "" {
-		return ts.envToken, nil
+		return &TokenInfo{Token: ts.envToken, Source: SourceEnv, Location: "FASTMAIL_TOKEN"}, nil
 	}
 
-	// 2. Try system keychain
-	token, err := GetTokenFromKeyring()
+	// 2. Read the token file
+	if ts.tokenFile != "" {
+		token, err := ReadTokenFile(ts.tokenFile)
+		if err != nil {
+			return nil, err
+		}
+		return &TokenInfo{Token: token, Source: SourceFile, Location: ts.tokenFile}, nil
+	}
+
+	// 3. Run the configured secret command
+	if ts.tokenCmd != "" {
+		token, err := RunTokenCommand(ts.tokenCmd)
+		if err != nil {
+			return nil, err
+		}
+		return &TokenInfo{Token: token, Source: SourceCommand, Location: ts.tokenCmd}, nil
+	}
+
+	// 4. Try system keychain
+	profile := ts.Profile()
+	token, err := GetTokenFromKeyring(profile)
 	if err == nil && token != "" {
-		return token, nil
+		return &TokenInfo{
+			Token:    token,
+			Source:   SourceKeyring,
+			Location: KeyringService + "/" + KeyringUserForProfile(profile),
+			Profile:  profile,
+		}, nil
+	}
+
+	if profile != DefaultProfile {
+		return nil, fmt.Errorf("not authenticated for profile '%s'.\n\n"+
+			"Run 'fm auth login --profile %s' to authenticate, or set FASTMAIL_TOKEN environment variable.", profile, profile)
 	}
 
-	return "", fmt.Errorf("not authenticated.\n\n" +
+	return nil, fmt.Errorf("not authenticated.\n\n" +
 		"Run 'fm auth login' to authenticate, or set FASTMAIL_TOKEN environment variable.")
 }
 
-// GetTokenFromKeyring retrieves the token from the system keychain.
-func GetTokenFromKeyring() (string, error) {
-	return keyring.Get(KeyringService, KeyringUser)
+// MaskToken returns the token with everything but its first and last four
+// characters hidden. Short tokens are fully masked.
+func MaskToken(token string) string {
+	if len(token) < 12 {
+		return strings.Repeat("*", len(token))
+	}
+	return token[:4] + "..." + token[len(token)-4:]
+}
+
+// ReadTokenFile reads the token from a file, trimming surrounding whitespace.
+// This is the usual way Docker and Kubernetes secrets are mounted.
+func ReadTokenFile(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read FASTMAIL_TOKEN_FILE: %w", err)
+	}
+
+	token := strings.TrimSpace(string(data))
+	if token == "" {
+		return "", fmt.Errorf("FASTMAIL_TOKEN_FILE %s is empty", path)
+	}
+	return token, nil
+}
+
+// RunTokenCommand runs a shell command and returns its trimmed stdout as the token.
+// This allows any secret store to be used, e.g. "pass show fastmail/token".
+func RunTokenCommand(command string) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), tokenCommandTimeout)
+	defer cancel()
+
+	var cmd *exec.Cmd
+	if runtime.GOOS == "windows" {
+		cmd = exec.CommandContext(ctx, "cmd", "/C", command)
+	} else {
+		cmd = exec.CommandContext(ctx, "sh", "-c", command)
+	}
+
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	output, err := cmd.Output()
+	if ctx.Err() == context.DeadlineExceeded {
+		return "", fmt.Errorf("FM_TOKEN_CMD timed out after %s", tokenCommandTimeout)
+	}
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return "", fmt.Errorf("FM_TOKEN_CMD failed: %w: %s", err, msg)
+		}
+		return "", fmt.Errorf("FM_TOKEN_CMD failed: %w", err)
+	}
+
+	token := strings.TrimSpace(string(output))
+	if token == "" {
+		return "", fmt.Errorf("FM_TOKEN_CMD returned an empty token")
+	}
+	return token, nil
+}
+
+// ValidateProfileName checks that a profile name is safe to use as a keychain key.
+func ValidateProfileName(profile string) error {
+	if !profileNameRe.MatchString(profile) {
+		return fmt.Errorf("invalid profile name %q: use letters, digits, '-' or '_'", profile)
+	}
+	return nil
 }
 
-// SetTokenInKeyring stores the token in the system keychain.
-func SetTokenInKeyring(token string) error {
-	return keyring.Set(KeyringService, KeyringUser, token)
+// KeyringUserForProfile returns the keychain user name that stores a profile's token.
+func KeyringUserForProfile(profile string) string {
+	if profile == "" || profile == DefaultProfile {
+		return KeyringUser
+	}
+	return KeyringUser + "-" + profile
 }
 
-// DeleteTokenFromKeyring removes the token from the system keychain.
-func DeleteTokenFromKeyring() error {
-	return keyring.Delete(KeyringService, KeyringUser)
+// GetTokenFromKeyring retrieves a profile's token from the system keychain.
+func GetTokenFromKeyring(profile string) (string, error) {
+	return keyring.Get(KeyringService, KeyringUserForProfile(profile))
+}
+
+// SetTokenInKeyring stores a profile's token in the system keychain.
+func SetTokenInKeyring(profile, token string) error {
+	if err := keyring.Set(KeyringService, KeyringUserForProfile(profile), token); err != nil {
+		return err
+	}
+	if profile == "" || profile == DefaultProfile {
+		return nil
+	}
+
+	names, err := namedProfiles()
+	if err != nil {
+		return err
+	}
+	for _, name := range names {
+		if name == profile {
+			return nil
+		}
+	}
+	return saveNamedProfiles(append(names, profile))
+}
+
+// DeleteTokenFromKeyring removes a profile's token from the system keychain.
+func DeleteTokenFromKeyring(profile string) error {
+	if err := keyring.Delete(KeyringService, KeyringUserForProfile(profile)); err != nil {
+		return err
+	}
+	if profile == "" || profile == DefaultProfile {
+		return nil
+	}
+
+	names, err := namedProfiles()
+	if err != nil {
+		return err
+	}
+	var remaining []string
+	for _, name := range names {
+		if name != profile {
+			remaining = append(remaining, name)
+		}
+	}
+	return saveNamedProfiles(remaining)
+}
+
+// ListProfiles returns the profiles that have a token stored in the keychain.
+// The default profile, if present, is listed first.
+func ListProfiles() ([]string, error) {
+	var profiles []string
+
+	if token, err := GetTokenFromKeyring(DefaultProfile); err == nil && token != "" {
+		profiles = append(profiles, DefaultProfile)
+	}
+
+	names, err := namedProfiles()
+	if err != nil {
+		return profiles, err
+	}
+	sort.Strings(names)
+
+	return append(profiles, names...), nil
+}
+
+// namedProfiles reads the list of non-default profiles from the keychain.
+func namedProfiles() ([]string, error) {
+	value, err := keyring.Get(KeyringService, KeyringProfilesUser)
+	if errors.Is(err, keyring.ErrNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var names []string
+	for _, name := range strings.Split(value, "\n") {
+		if name = strings.TrimSpace(name); name != "" {
+			names = append(names, name)
+		}
+	}
+	return names, nil
+}
+
+// saveNamedProfiles writes the list of non-default profiles to the keychain.
+func saveNamedProfiles(names []string) error {
+	if len(names) == 0 {
+		err := keyring.Delete(KeyringService, KeyringProfilesUser)
+		if err != nil && !errors.Is(err, keyring.ErrNotFound) {
+			return err
+		}
+		return nil
+	}
+	return keyring.Set(KeyringService, KeyringProfilesUser, strings.Join(names, "\n"))
 }
 
 // IsAuthenticated returns true if a token is available.
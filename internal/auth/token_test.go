@@ -1,6 +1,8 @@
 package auth
 
 import (
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
@@ -64,6 +66,91 @@ func TestGetToken(t *testing.T) {
 	})
 }
 
+func TestTokenFile(t *testing.T) {
+	t.Run("reads and trims token file", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "token")
+		require.NoError(t, os.WriteFile(path, []byte("fmu1-file-token\n"), 0o600))
+
+		t.Setenv("FASTMAIL_TOKEN", "")
+		t.Setenv("FASTMAIL_TOKEN_FILE", path)
+		t.Setenv("FM_TOKEN_CMD", "echo cmd-token")
+
+		ts := NewTokenSource()
+		token, err := ts.GetToken()
+
+		require.NoError(t, err)
+		assert.Equal(t, "fmu1-file-token", token)
+	})
+
+	t.Run("env token takes priority over file", func(t *testing.T) {
+		t.Setenv("FASTMAIL_TOKEN", "env-token")
+		t.Setenv("FASTMAIL_TOKEN_FILE", "/nonexistent/token")
+
+		ts := NewTokenSource()
+		token, err := ts.GetToken()
+
+		require.NoError(t, err)
+		assert.Equal(t, "env-token", token)
+	})
+
+	t.Run("reports missing file", func(t *testing.T) {
+		_, err := ReadTokenFile(filepath.Join(t.TempDir(), "missing"))
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "FASTMAIL_TOKEN_FILE")
+	})
+
+	t.Run("rejects empty file", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "token")
+		require.NoError(t, os.WriteFile(path, []byte("  \n"), 0o600))
+
+		_, err := ReadTokenFile(path)
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "is empty")
+	})
+}
+
+func TestTokenCommand(t *testing.T) {
+	t.Run("uses command output as token", func(t *testing.T) {
+		keyring.MockInit()
+		t.Setenv("FASTMAIL_TOKEN", "")
+		t.Setenv("FM_TOKEN_CMD", "echo '  fmu1-cmd-token  '")
+
+		ts := NewTokenSource()
+		token, err := ts.GetToken()
+
+		require.NoError(t, err)
+		assert.Equal(t, "fmu1-cmd-token", token)
+	})
+
+	t.Run("env token takes priority over command", func(t *testing.T) {
+		t.Setenv("FASTMAIL_TOKEN", "env-token")
+		t.Setenv("FM_TOKEN_CMD", "echo cmd-token")
+
+		ts := NewTokenSource()
+		token, err := ts.GetToken()
+
+		require.NoError(t, err)
+		assert.Equal(t, "env-token", token)
+	})
+
+	t.Run("reports command failure", func(t *testing.T) {
+		_, err := RunTokenCommand("echo 'secret store locked' >&2; exit 1")
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "FM_TOKEN_CMD failed")
+		assert.Contains(t, err.Error(), "secret store locked")
+	})
+
+	t.Run("rejects empty output", func(t *testing.T) {
+		_, err := RunTokenCommand("true")
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "empty token")
+	})
+}
+
 func TestIsAuthenticated(t *testing.T) {
 	t.Run("true when env token set", func(t *testing.T) {
 		t.Setenv("FASTMAIL_TOKEN", "auth-token")
@@ -84,6 +171,111 @@ func TestIsAuthenticated(t *testing.T) {
 	})
 }
 
+func TestProfiles(t *testing.T) {
+	t.Run("defaults to default profile", func(t *testing.T) {
+		t.Setenv("FM_PROFILE", "")
+
+		ts := NewTokenSource()
+
+		assert.Equal(t, DefaultProfile, ts.Profile())
+	})
+
+	t.Run("reads profile from FM_PROFILE", func(t *testing.T) {
+		t.Setenv("FM_PROFILE", "work")
+
+		ts := NewTokenSource()
+
+		assert.Equal(t, "work", ts.Profile())
+	})
+
+	t.Run("loads token for selected profile", func(t *testing.T) {
+		keyring.MockInit()
+		t.Setenv("FASTMAIL_TOKEN", "")
+
+		require.NoError(t, SetTokenInKeyring(DefaultProfile, "personal-token"))
+		require.NoError(t, SetTokenInKeyring("work", "work-token"))
+
+		ts := NewTokenSource()
+		ts.SetProfile("work")
+		token, err := ts.GetToken()
+
+		require.NoError(t, err)
+		assert.Equal(t, "work-token", token)
+
+		stored, err := keyring.Get(KeyringService, "fastmail-token-work")
+		require.NoError(t, err)
+		assert.Equal(t, "work-token", stored)
+	})
+
+	t.Run("error mentions missing profile", func(t *testing.T) {
+		keyring.MockInit()
+		t.Setenv("FASTMAIL_TOKEN", "")
+
+		ts := NewTokenSource()
+		ts.SetProfile("work")
+		_, err := ts.GetToken()
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "fm auth login --profile work")
+	})
+
+	t.Run("lists stored profiles", func(t *testing.T) {
+		keyring.MockInit()
+
+		require.NoError(t, SetTokenInKeyring("work", "work-token"))
+		require.NoError(t, SetTokenInKeyring(DefaultProfile, "personal-token"))
+		require.NoError(t, SetTokenInKeyring("side", "side-token"))
+		require.NoError(t, SetTokenInKeyring("work", "work-token-2"))
+
+		profiles, err := ListProfiles()
+		require.NoError(t, err)
+		assert.Equal(t, []string{"default", "side", "work"}, profiles)
+
+		require.NoError(t, DeleteTokenFromKeyring("side"))
+		profiles, err = ListProfiles()
+		require.NoError(t, err)
+		assert.Equal(t, []string{"default", "work"}, profiles)
+	})
+
+	t.Run("validates profile names", func(t *testing.T) {
+		assert.NoError(t, ValidateProfileName("work_2-a"))
+		assert.Error(t, ValidateProfileName(""))
+		assert.Error(t, ValidateProfileName("my profile"))
+		assert.Error(t, ValidateProfileName("../etc"))
+	})
+}
+
+func TestGetTokenInfo(t *testing.T) {
+	t.Run("reports env source", func(t *testing.T) {
+		t.Setenv("FASTMAIL_TOKEN", "fmu1-env-token")
+
+		info, err := NewTokenSource().GetTokenInfo()
+
+		require.NoError(t, err)
+		assert.Equal(t, SourceEnv, info.Source)
+		assert.Equal(t, "FASTMAIL_TOKEN", info.Location)
+	})
+
+	t.Run("reports keychain entry and profile", func(t *testing.T) {
+		keyring.MockInit()
+		t.Setenv("FASTMAIL_TOKEN", "")
+		require.NoError(t, SetTokenInKeyring(DefaultProfile, "keychain-token"))
+
+		info, err := NewTokenSource().GetTokenInfo()
+
+		require.NoError(t, err)
+		assert.Equal(t, SourceKeyring, info.Source)
+		assert.Equal(t, "fm-cli/fastmail-token", info.Location)
+		assert.Equal(t, DefaultProfile, info.Profile)
+	})
+}
+
+func TestMaskToken(t *testing.T) {
+	assert.Equal(t, "fmu1...5678", MaskToken("fmu1-abcdef-5678"))
+	assert.Equal(t, "*****", MaskToken("short"))
+	assert.Equal(t, "", MaskToken(""))
+}
+
 func TestKeyringConstants(t *testing.T) {
 	// Verify the keyring constants are set correctly
 	assert.Equal(t, "fm-cli", KeyringService)
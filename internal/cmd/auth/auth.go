@@ -18,6 +18,7 @@ The token is stored securely in your system's credential store
 Alternatively, set the FASTMAIL_TOKEN environment variable.`,
 		Example: `  $ fm auth login
   $ fm auth status
+  $ fm auth token
   $ fm auth logout`,
 		GroupID: "auth",
 	}
@@ -25,6 +26,7 @@ Alternatively, set the FASTMAIL_TOKEN environment variable.`,
 	cmd.AddCommand(NewCmdLogin(f))
 	cmd.AddCommand(NewCmdStatus(f))
 	cmd.AddCommand(NewCmdLogout(f))
+	cmd.AddCommand(NewCmdToken(f))
 
 	return cmd
 }
@@ -2,9 +2,11 @@ package auth
 
 import (
 	"bytes"
+	"encoding/json"
 	"testing"
 
 	"github.com/jarcoal/httpmock"
+	"github.com/marckohlbrugge/fastmail-cli/internal/auth"
 	"github.com/marckohlbrugge/fastmail-cli/internal/cmdutil"
 	"github.com/marckohlbrugge/fastmail-cli/internal/iostreams"
 	"github.com/stretchr/testify/assert"
@@ -71,6 +73,97 @@ func TestLoginCommand(t *testing.T) {
 		assert.Equal(t, "fmu1-test-token-12345678", token)
 	})
 
+	t.Run("warns when the token is read-only", func(t *testing.T) {
+		keyring.MockInit()
+
+		f, in, out, errOut := setupTest(t)
+
+		httpmock.RegisterResponder("GET", "https://api.fastmail.com/jmap/session",
+			httpmock.NewJsonResponderOrPanic(200, map[string]interface{}{
+				"apiUrl": "https://api.fastmail.com/jmap/api",
+				"accounts": map[string]interface{}{
+					"u12345": map[string]interface{}{"isReadOnly": true},
+				},
+			}))
+
+		in.WriteString("fmu1-read-only-token-1234\n")
+
+		cmd := NewCmdLogin(f)
+		cmd.SetArgs([]string{})
+		cmd.SetOut(out)
+		cmd.SetErr(&bytes.Buffer{})
+
+		err := cmd.Execute()
+
+		require.NoError(t, err)
+		assert.Contains(t, out.String(), "Logged in")
+		assert.Contains(t, errOut.String(), "this token is read-only")
+
+		token, err := keyring.Get("fm-cli", "fastmail-token")
+		require.NoError(t, err)
+		assert.Equal(t, "fmu1-read-only-token-1234", token)
+	})
+
+	t.Run("does not warn for writable tokens", func(t *testing.T) {
+		keyring.MockInit()
+
+		f, in, out, errOut := setupTest(t)
+
+		httpmock.RegisterResponder("GET", "https://api.fastmail.com/jmap/session",
+			httpmock.NewJsonResponderOrPanic(200, map[string]interface{}{
+				"apiUrl": "https://api.fastmail.com/jmap/api",
+				"accounts": map[string]interface{}{
+					"u12345": map[string]interface{}{"isReadOnly": false},
+				},
+			}))
+
+		in.WriteString("fmu1-test-token-12345678\n")
+
+		cmd := NewCmdLogin(f)
+		cmd.SetArgs([]string{})
+		cmd.SetOut(out)
+		cmd.SetErr(&bytes.Buffer{})
+
+		err := cmd.Execute()
+
+		require.NoError(t, err)
+		assert.NotContains(t, errOut.String(), "read-only")
+	})
+
+	t.Run("stores token under selected profile", func(t *testing.T) {
+		keyring.MockInit()
+
+		f, in, out, _ := setupTest(t)
+		require.NoError(t, f.SetProfile("work"))
+
+		httpmock.RegisterResponder("GET", "https://api.fastmail.com/jmap/session",
+			httpmock.NewJsonResponderOrPanic(200, map[string]interface{}{
+				"apiUrl": "https://api.fastmail.com/jmap/api",
+				"accounts": map[string]interface{}{
+					"u67890": map[string]interface{}{},
+				},
+			}))
+
+		in.WriteString("fmu1-work-token-12345678\n")
+
+		cmd := NewCmdLogin(f)
+		cmd.SetArgs([]string{})
+		cmd.SetOut(out)
+		cmd.SetErr(&bytes.Buffer{})
+
+		err := cmd.Execute()
+
+		require.NoError(t, err)
+		assert.Contains(t, out.String(), "profile: work")
+
+		token, err := keyring.Get("fm-cli", "fastmail-token-work")
+		require.NoError(t, err)
+		assert.Equal(t, "fmu1-work-token-12345678", token)
+
+		_, err = keyring.Get("fm-cli", "fastmail-token")
+		assert.Error(t, err, "default profile should be untouched")
+	})
+
 	t.Run("rejects empty token", func(t *testing.T) {
 		f, in, _, _ := setupTest(t)
 
@@ -168,6 +261,65 @@ func TestStatusCommand(t *testing.T) {
 		assert.Contains(t, output, "Account ID: u12345")
 	})
 
+	t.Run("lists all keychain profiles", func(t *testing.T) {
+		keyring.MockInit()
+		t.Setenv("FASTMAIL_TOKEN", "")
+		_ = keyring.Set("fm-cli", "fastmail-token", "fmu1-personal-token")
+		_ = keyring.Set("fm-cli", "fastmail-token-work", "fmu1-work-token")
+		_ = keyring.Set("fm-cli", "fastmail-profiles", "work")
+
+		f, _, out, _ := setupTest(t)
+		require.NoError(t, f.SetProfile("work"))
+
+		httpmock.RegisterResponder("GET", "https://api.fastmail.com/jmap/session",
+			httpmock.NewJsonResponderOrPanic(200, map[string]interface{}{
+				"apiUrl": "https://api.fastmail.com/jmap/api",
+				"accounts": map[string]interface{}{
+					"u12345": map[string]interface{}{},
+				},
+			}))
+
+		cmd := NewCmdStatus(f)
+		cmd.SetArgs([]string{})
+		cmd.SetOut(out)
+		cmd.SetErr(&bytes.Buffer{})
+
+		err := cmd.Execute()
+
+		require.NoError(t, err)
+		output := out.String()
+		assert.Contains(t, output, "Profile: default\n")
+		assert.Contains(t, output, "Profile: work (active)")
+		assert.Contains(t, output, "Account ID: u12345")
+	})
+
+	t.Run("validates token from FM_TOKEN_CMD", func(t *testing.T) {
+		f, _, out, _ := setupTest(t)
+
+		httpmock.RegisterResponder("GET", "https://api.fastmail.com/jmap/session",
+			httpmock.NewJsonResponderOrPanic(200, map[string]interface{}{
+				"apiUrl": "https://api.fastmail.com/jmap/api",
+				"accounts": map[string]interface{}{
+					"u12345": map[string]interface{}{},
+				},
+			}))
+
+		t.Setenv("FASTMAIL_TOKEN", "")
+		t.Setenv("FM_TOKEN_CMD", "echo fmu1-cmd-token")
+
+		cmd := NewCmdStatus(f)
+		cmd.SetArgs([]string{})
+		cmd.SetOut(out)
+		cmd.SetErr(&bytes.Buffer{})
+
+		err := cmd.Execute()
+
+		require.NoError(t, err)
+		output := out.String()
+		assert.Contains(t, output, "Authenticated via FM_TOKEN_CMD")
+		assert.Contains(t, output, "Account ID: u12345")
+	})
+
 	t.Run("shows error for invalid env token", func(t *testing.T) {
 		f, _, out, _ := setupTest(t)
 
@@ -188,6 +340,63 @@ func TestStatusCommand(t *testing.T) {
 		assert.Contains(t, output, "Token validation failed")
 	})
 
+	t.Run("outputs JSON for valid token", func(t *testing.T) {
+		f, _, out, _ := setupTest(t)
+
+		httpmock.RegisterResponder("GET", "https://api.fastmail.com/jmap/session",
+			httpmock.NewJsonResponderOrPanic(200, map[string]interface{}{
+				"apiUrl": "https://api.fastmail.com/jmap/api",
+				"accounts": map[string]interface{}{
+					"u12345": map[string]interface{}{},
+				},
+			}))
+
+		t.Setenv("FASTMAIL_TOKEN", "fmu1-test-token-5678")
+		f.TokenSource = auth.NewTokenSource()
+
+		cmd := NewCmdStatus(f)
+		cmd.SetArgs([]string{"--json"})
+		cmd.SetOut(out)
+		cmd.SetErr(&bytes.Buffer{})
+
+		err := cmd.Execute()
+
+		require.NoError(t, err)
+
+		var result map[string]interface{}
+		require.NoError(t, json.Unmarshal(out.Bytes(), &result))
+		assert.Equal(t, "env", result["source"])
+		assert.Equal(t, "u12345", result["accountId"])
+		assert.Equal(t, "fmu1...5678", result["maskedToken"])
+		assert.Equal(t, true, result["valid"])
+		assert.NotContains(t, result, "error")
+	})
+
+	t.Run("outputs JSON error for invalid token", func(t *testing.T) {
+		f, _, out, _ := setupTest(t)
+
+		httpmock.RegisterResponder("GET", "https://api.fastmail.com/jmap/session",
+			httpmock.NewStringResponder(401, "Unauthorized"))
+
+		t.Setenv("FASTMAIL_TOKEN", "invalid-token-1234")
+		f.TokenSource = auth.NewTokenSource()
+
+		cmd := NewCmdStatus(f)
+		cmd.SetArgs([]string{"--json"})
+		cmd.SetOut(out)
+		cmd.SetErr(&bytes.Buffer{})
+
+		err := cmd.Execute()
+
+		assert.Equal(t, cmdutil.SilentError, err)
+
+		// Cobra appends usage text after a failed run, so decode the first value only
+		var result map[string]interface{}
+		require.NoError(t, json.NewDecoder(out).Decode(&result))
+		assert.Equal(t, false, result["valid"])
+		assert.Contains(t, result["error"], "401")
+	})
+
 	t.Run("accepts no arguments", func(t *testing.T) {
 		f := &cmdutil.Factory{}
 		cmd := NewCmdStatus(f)
@@ -243,6 +452,26 @@ func TestLogoutCommand(t *testing.T) {
 		assert.Contains(t, output, "Logged out")
 	})
 
+	t.Run("notes env token still applies", func(t *testing.T) {
+		keyring.MockInit()
+		_ = keyring.Set("fm-cli", "fastmail-token", "test-token")
+		t.Setenv("FASTMAIL_TOKEN", "fmu1-env-token")
+
+		f, _, out, _ := setupTest(t)
+
+		cmd := NewCmdLogout(f)
+		cmd.SetArgs([]string{})
+		cmd.SetOut(out)
+		cmd.SetErr(&bytes.Buffer{})
+
+		err := cmd.Execute()
+
+		require.NoError(t, err)
+		output := out.String()
+		assert.Contains(t, output, "Token removed from system keychain")
+		assert.Contains(t, output, "FASTMAIL_TOKEN is still set")
+	})
+
 	t.Run("accepts no arguments", func(t *testing.T) {
 		f := &cmdutil.Factory{}
 		cmd := NewCmdLogout(f)
@@ -256,3 +485,71 @@ func TestLogoutCommand(t *testing.T) {
 		assert.Contains(t, err.Error(), "unknown command")
 	})
 }
+
+// Token command tests
+
+func TestTokenCommand(t *testing.T) {
+	t.Run("reports env token source", func(t *testing.T) {
+		t.Setenv("FASTMAIL_TOKEN", "fmu1-env-token-12345678")
+
+		f, _, out, _ := setupTest(t)
+		f.TokenSource = auth.NewTokenSource()
+
+		cmd := NewCmdToken(f)
+		cmd.SetArgs([]string{})
+		cmd.SetOut(out)
+		cmd.SetErr(&bytes.Buffer{})
+
+		err := cmd.Execute()
+
+		require.NoError(t, err)
+		output := out.String()
+		assert.Contains(t, output, "Source:   env")
+		assert.Contains(t, output, "FASTMAIL_TOKEN")
+		assert.Contains(t, output, "fmu1...5678")
+		assert.NotContains(t, output, "fmu1-env-token-12345678")
+	})
+
+	t.Run("outputs JSON for keychain profile", func(t *testing.T) {
+		keyring.MockInit()
+		t.Setenv("FASTMAIL_TOKEN", "")
+		_ = keyring.Set("fm-cli", "fastmail-token-work", "fmu1-work-token-abcd")
+
+		f, _, out, _ := setupTest(t)
+		require.NoError(t, f.SetProfile("work"))
+
+		cmd := NewCmdToken(f)
+		cmd.SetArgs([]string{"--json"})
+		cmd.SetOut(out)
+		cmd.SetErr(&bytes.Buffer{})
+
+		err := cmd.Execute()
+
+		require.NoError(t, err)
+
+		var result map[string]interface{}
+		require.NoError(t, json.Unmarshal(out.Bytes(), &result))
+		assert.Equal(t, "keyring", result["source"])
+		assert.Equal(t, "fm-cli/fastmail-token-work", result["location"])
+		assert.Equal(t, "work", result["profile"])
+		assert.Equal(t, "fmu1...abcd", result["maskedToken"])
+	})
+
+	t.Run("errors when not authenticated", func(t *testing.T) {
+		keyring.MockInit()
+		t.Setenv("FASTMAIL_TOKEN", "")
+
+		f, _, out, _ := setupTest(t)
+		f.TokenSource = auth.NewTokenSource()
+
+		cmd := NewCmdToken(f)
+		cmd.SetArgs([]string{})
+		cmd.SetOut(out)
+		cmd.SetErr(&bytes.Buffer{})
+
+		err := cmd.Execute()
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "not authenticated")
+	})
+}
@@ -7,7 +7,6 @@ import (
 
 	"github.com/marckohlbrugge/fastmail-cli/internal/auth"
 	"github.com/marckohlbrugge/fastmail-cli/internal/cmdutil"
-	"github.com/marckohlbrugge/fastmail-cli/internal/jmap"
 	"github.com/spf13/cobra"
 )
 
@@ -30,7 +29,9 @@ To create an API token:
   3. Give it a name and select permissions (Mail Read/Write recommended)
   4. Copy the generated token
 
-The token will be stored securely in your system's credential store.`,
+The token will be stored securely in your system's credential store.
+
+Use --profile to store tokens for several accounts side by side.`,
 		Example: `  # Interactive login (prompts for token)
   $ fm auth login
 
@@ -38,7 +39,10 @@ The token will be stored securely in your system's credential store.`,
   $ echo "fmu1-xxx" | fm auth login --with-token
 
   # Login with token from file
-  $ fm auth login --with-token < token.txt`,
+  $ fm auth login --with-token < token.txt
+
+  # Login to a separate work account
+  $ fm auth login --profile work`,
 		Args: cobra.NoArgs,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return runLogin(f, opts)
@@ -83,19 +87,37 @@ func runLogin(f *cmdutil.Factory, opts *loginOptions) error {
 
 	// Validate token by making a test request
 	fmt.Fprintln(errOut, "Validating token...")
-	client := jmap.NewClient(token)
+	client := f.NewJMAPClient(token)
 	session, err := client.GetSession()
 	if err != nil {
 		return fmt.Errorf("authentication failed: %w", err)
 	}
 
 	// Store token in keychain
-	if err := auth.SetTokenInKeyring(token); err != nil {
+	profile := f.Profile()
+	if err := auth.SetTokenInKeyring(profile, token); err != nil {
 		return fmt.Errorf("failed to store token in keychain: %w", err)
 	}
 
-	fmt.Fprintf(out, "✓ Logged in to Fastmail (account: %s)\n", session.AccountID)
+	if profile != auth.DefaultProfile {
+		fmt.Fprintf(out, "✓ Logged in to Fastmail (account: %s, profile: %s)\n", session.AccountID, profile)
+	} else {
+		fmt.Fprintf(out, "✓ Logged in to Fastmail (account: %s)\n", session.AccountID)
+	}
 	fmt.Fprintln(out, "Token stored in system keychain.")
 
+	// A read-only token still logs in, but every change will be rejected
+	if session.IsReadOnly() {
+		fmt.Fprintln(errOut, "Warning: this token is read-only. Sending, moving, and deleting emails will fail.")
+		fmt.Fprintln(errOut, "Create a token with write access to use those commands.")
+	}
+
+	// Create a starter config file so the available settings are discoverable
+	if created, err := f.EnsureConfigFile(); err != nil {
+		fmt.Fprintf(errOut, "Warning: could not create config file: %v\n", err)
+	} else if created {
+		fmt.Fprintf(errOut, "Created config file at %s\n", f.ConfigPath)
+	}
+
 	return nil
 }
@@ -2,6 +2,7 @@ package auth
 
 import (
 	"fmt"
+	"os"
 
 	"github.com/marckohlbrugge/fastmail-cli/internal/auth"
 	"github.com/marckohlbrugge/fastmail-cli/internal/cmdutil"
@@ -15,8 +16,13 @@ func NewCmdLogout(f *cmdutil.Factory) *cobra.Command {
 		Short: "Remove authentication",
 		Long: `Remove the stored authentication token from your system keychain.
 
+Only the token for the selected profile (--profile or FM_PROFILE) is removed.
+
 Note: This does not revoke the token on Fastmail's side. To fully revoke
-access, delete the API token in Fastmail Settings → Privacy & Security → Integrations.`,
+access, delete the API token in Fastmail Settings → Privacy & Security → Integrations.
+
+The FASTMAIL_TOKEN environment variable is not affected by logout and
+continues to take precedence if set.`,
 		Args: cobra.NoArgs,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return runLogout(f)
@@ -29,15 +35,24 @@ access, delete the API token in Fastmail Settings → Privacy & Security → Int
 func runLogout(f *cmdutil.Factory) error {
 	out := f.IOStreams.Out
 
-	err := auth.DeleteTokenFromKeyring()
+	profile := f.Profile()
+
+	err := auth.DeleteTokenFromKeyring(profile)
 	if err != nil {
 		// Check if it's just "not found" which is fine
 		fmt.Fprintln(out, "Not logged in.")
-		return nil
+	} else if profile != auth.DefaultProfile {
+		fmt.Fprintf(out, "Logged out of Fastmail (profile: %s).\n", profile)
+		fmt.Fprintln(out, "Token removed from system keychain.")
+	} else {
+		fmt.Fprintln(out, "Logged out of Fastmail.")
+		fmt.Fprintln(out, "Token removed from system keychain.")
 	}
 
-	fmt.Fprintln(out, "Logged out of Fastmail.")
-	fmt.Fprintln(out, "Token removed from system keychain.")
+	if os.Getenv("FASTMAIL_TOKEN") != "" {
+		fmt.Fprintln(out)
+		fmt.Fprintln(out, "Note: FASTMAIL_TOKEN is still set and will continue to be used.")
+	}
 
 	return nil
 }
@@ -1,75 +1,223 @@
 package auth
 
 import (
+	"encoding/json"
 	"fmt"
+	"io"
 	"os"
+	"strings"
 
 	"github.com/marckohlbrugge/fastmail-cli/internal/auth"
 	"github.com/marckohlbrugge/fastmail-cli/internal/cmdutil"
-	"github.com/marckohlbrugge/fastmail-cli/internal/jmap"
 	"github.com/spf13/cobra"
 )
 
+type statusOptions struct {
+	JSON bool
+}
+
 // NewCmdStatus creates the auth status command.
 func NewCmdStatus(f *cmdutil.Factory) *cobra.Command {
+	opts := &statusOptions{}
+
 	cmd := &cobra.Command{
 		Use:   "status",
 		Short: "Display authentication status",
-		Long:  `Display the current authentication status and token source.`,
-		Args:  cobra.NoArgs,
+		Long: `Display the current authentication status and token source.
+
+All profiles stored in the system keychain are listed; the one selected
+with --profile or FM_PROFILE is marked as active.
+
+With --json, only the active token is reported. The exit code is non-zero
+when it is missing or invalid.`,
+		Example: `  $ fm auth status
+  $ fm auth status --json`,
+		Args: cobra.NoArgs,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runStatus(f)
+			return runStatus(f, opts)
 		},
 	}
 
+	cmd.Flags().BoolVar(&opts.JSON, "json", false, "Output in JSON format")
+
 	return cmd
 }
 
-func runStatus(f *cmdutil.Factory) error {
+type statusJSON struct {
+	Source      string `json:"source,omitempty"`
+	Profile     string `json:"profile,omitempty"`
+	AccountID   string `json:"accountId,omitempty"`
+	MaskedToken string `json:"maskedToken,omitempty"`
+	Valid       bool   `json:"valid"`
+	Error       string `json:"error,omitempty"`
+}
+
+func runStatus(f *cmdutil.Factory, opts *statusOptions) error {
+	if opts.JSON {
+		return runStatusJSON(f)
+	}
+
 	out := f.IOStreams.Out
 
+	fmt.Fprintln(out, "api.fastmail.com")
+
 	// Check environment variable first
-	envToken := os.Getenv("FASTMAIL_TOKEN")
-	if envToken != "" {
-		fmt.Fprintln(out, "api.fastmail.com")
-		fmt.Fprintln(out, "  ✓ Authenticated via FASTMAIL_TOKEN environment variable")
-		fmt.Fprintf(out, "  - Token: %s...%s\n", envToken[:4], envToken[len(envToken)-4:])
-
-		// Validate token
-		client := jmap.NewClient(envToken)
-		session, err := client.GetSession()
-		if err != nil {
-			fmt.Fprintf(out, "  ✗ Token validation failed: %v\n", err)
-			return cmdutil.SilentError
+	if envToken := os.Getenv("FASTMAIL_TOKEN"); envToken != "" {
+		return printSourceStatus(f, out, "FASTMAIL_TOKEN environment variable", envToken, nil)
+	}
+
+	// Then a token file (e.g. a mounted Docker/Kubernetes secret)
+	if path := os.Getenv("FASTMAIL_TOKEN_FILE"); path != "" {
+		token, err := auth.ReadTokenFile(path)
+		return printSourceStatus(f, out, fmt.Sprintf("FASTMAIL_TOKEN_FILE (%s)", path), token, err)
+	}
+
+	// Then the secret command
+	if tokenCmd := os.Getenv("FM_TOKEN_CMD"); tokenCmd != "" {
+		token, err := auth.RunTokenCommand(tokenCmd)
+		return printSourceStatus(f, out, "FM_TOKEN_CMD", token, err)
+	}
+
+	// Check keychain profiles
+	active := f.Profile()
+	profiles, _ := auth.ListProfiles()
+
+	if !containsProfile(profiles, active) {
+		if active != auth.DefaultProfile {
+			fmt.Fprintf(out, "  ✗ Not authenticated (profile: %s)\n", active)
+		} else {
+			fmt.Fprintln(out, "  ✗ Not authenticated")
+		}
+		if len(profiles) > 0 {
+			fmt.Fprintf(out, "  - Other profiles: %s\n", strings.Join(profiles, ", "))
 		}
-		fmt.Fprintf(out, "  - Account ID: %s\n", session.AccountID)
-		return nil
+		fmt.Fprintln(out)
+		fmt.Fprintf(out, "  Run '%s' to authenticate.\n", loginCommand(active))
+		return cmdutil.SilentError
 	}
 
-	// Check keychain
-	token, err := auth.GetTokenFromKeyring()
-	if err != nil || token == "" {
-		fmt.Fprintln(out, "api.fastmail.com")
-		fmt.Fprintln(out, "  ✗ Not authenticated")
+	activeValid := true
+	for i, profile := range profiles {
+		if i > 0 {
+			fmt.Fprintln(out)
+		}
+		valid := printProfileStatus(f, out, profile, profile == active)
+		if profile == active {
+			activeValid = valid
+		}
+	}
+
+	if !activeValid {
 		fmt.Fprintln(out)
-		fmt.Fprintln(out, "  Run 'fm auth login' to authenticate.")
+		fmt.Fprintf(out, "  Run '%s' to re-authenticate.\n", loginCommand(active))
 		return cmdutil.SilentError
 	}
 
-	fmt.Fprintln(out, "api.fastmail.com")
-	fmt.Fprintln(out, "  ✓ Authenticated via system keychain")
-	fmt.Fprintf(out, "  - Token: %s...%s\n", token[:4], token[len(token)-4:])
+	return nil
+}
+
+// runStatusJSON validates the active token and reports the result as JSON.
+func runStatusJSON(f *cmdutil.Factory) error {
+	ts := f.TokenSource
+	if ts == nil {
+		ts = auth.NewTokenSource()
+	}
+
+	var result statusJSON
+	info, err := ts.GetTokenInfo()
+	if err != nil {
+		result.Error = err.Error()
+	} else {
+		result.Source = info.Source
+		result.Profile = info.Profile
+		result.MaskedToken = auth.MaskToken(info.Token)
+
+		session, err := f.NewJMAPClient(info.Token).GetSession()
+		if err != nil {
+			result.Error = err.Error()
+		} else {
+			result.Valid = true
+			result.AccountID = session.AccountID
+		}
+	}
+
+	encoder := json.NewEncoder(f.IOStreams.Out)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(result); err != nil {
+		return err
+	}
+
+	if !result.Valid {
+		return cmdutil.SilentError
+	}
+	return nil
+}
+
+// printSourceStatus prints and validates a token read from a non-keychain source.
+func printSourceStatus(f *cmdutil.Factory, out io.Writer, source, token string, readErr error) error {
+	if readErr != nil {
+		fmt.Fprintf(out, "  ✗ %v\n", readErr)
+		return cmdutil.SilentError
+	}
+
+	fmt.Fprintf(out, "  ✓ Authenticated via %s\n", source)
+	fmt.Fprintf(out, "  - Token: %s\n", auth.MaskToken(token))
 
 	// Validate token
-	client := jmap.NewClient(token)
+	client := f.NewJMAPClient(token)
 	session, err := client.GetSession()
 	if err != nil {
 		fmt.Fprintf(out, "  ✗ Token validation failed: %v\n", err)
-		fmt.Fprintln(out)
-		fmt.Fprintln(out, "  Run 'fm auth login' to re-authenticate.")
 		return cmdutil.SilentError
 	}
 	fmt.Fprintf(out, "  - Account ID: %s\n", session.AccountID)
 
 	return nil
 }
+
+// printProfileStatus prints the keychain status of a single profile and
+// reports whether its token is valid.
+func printProfileStatus(f *cmdutil.Factory, out io.Writer, profile string, active bool) bool {
+	marker := ""
+	if active {
+		marker = " (active)"
+	}
+	fmt.Fprintf(out, "  Profile: %s%s\n", profile, marker)
+
+	token, err := auth.GetTokenFromKeyring(profile)
+	if err != nil || token == "" {
+		fmt.Fprintln(out, "  ✗ Token could not be read from system keychain")
+		return false
+	}
+
+	fmt.Fprintln(out, "  ✓ Authenticated via system keychain")
+	fmt.Fprintf(out, "  - Token: %s\n", auth.MaskToken(token))
+
+	// Validate token
+	client := f.NewJMAPClient(token)
+	session, err := client.GetSession()
+	if err != nil {
+		fmt.Fprintf(out, "  ✗ Token validation failed: %v\n", err)
+		return false
+	}
+	fmt.Fprintf(out, "  - Account ID: %s\n", session.AccountID)
+
+	return true
+}
+
+func containsProfile(profiles []string, profile string) bool {
+	for _, p := range profiles {
+		if p == profile {
+			return true
+		}
+	}
+	return false
+}
+
+// loginCommand returns the login invocation for a profile.
+func loginCommand(profile string) string {
+	if profile == auth.DefaultProfile {
+		return "fm auth login"
+	}
+	return "fm auth login --profile " + profile
+}
@@ -0,0 +1,86 @@
+package auth
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/marckohlbrugge/fastmail-cli/internal/auth"
+	"github.com/marckohlbrugge/fastmail-cli/internal/cmdutil"
+	"github.com/spf13/cobra"
+)
+
+type tokenOptions struct {
+	JSON bool
+}
+
+// NewCmdToken creates the auth token command.
+func NewCmdToken(f *cmdutil.Factory) *cobra.Command {
+	opts := &tokenOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "token",
+		Short: "Show where the active token comes from",
+		Long: `Show which source the active API token is loaded from, without
+printing the full secret.
+
+Sources are checked in order: FASTMAIL_TOKEN, FASTMAIL_TOKEN_FILE,
+FM_TOKEN_CMD, then the system keychain for the selected profile.
+Unlike 'fm auth status', the token is not validated against Fastmail.`,
+		Example: `  # Show the active token source
+  $ fm auth token
+
+  # Check which token a profile resolves to
+  $ fm auth token --profile work
+
+  # Machine-readable output
+  $ fm auth token --json`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runToken(f, opts)
+		},
+	}
+
+	cmd.Flags().BoolVar(&opts.JSON, "json", false, "Output in JSON format")
+
+	return cmd
+}
+
+func runToken(f *cmdutil.Factory, opts *tokenOptions) error {
+	ts := f.TokenSource
+	if ts == nil {
+		ts = auth.NewTokenSource()
+	}
+
+	info, err := ts.GetTokenInfo()
+	if err != nil {
+		return err
+	}
+
+	if opts.JSON {
+		output := struct {
+			Source      string `json:"source"`
+			Location    string `json:"location"`
+			Profile     string `json:"profile,omitempty"`
+			MaskedToken string `json:"maskedToken"`
+		}{
+			Source:      info.Source,
+			Location:    info.Location,
+			Profile:     info.Profile,
+			MaskedToken: auth.MaskToken(info.Token),
+		}
+
+		encoder := json.NewEncoder(f.IOStreams.Out)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(output)
+	}
+
+	out := f.IOStreams.Out
+	fmt.Fprintf(out, "Source:   %s\n", info.Source)
+	fmt.Fprintf(out, "Location: %s\n", info.Location)
+	if info.Profile != "" {
+		fmt.Fprintf(out, "Profile:  %s\n", info.Profile)
+	}
+	fmt.Fprintf(out, "Token:    %s\n", auth.MaskToken(info.Token))
+
+	return nil
+}
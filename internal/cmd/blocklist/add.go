@@ -0,0 +1,70 @@
+package blocklist
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/marckohlbrugge/fastmail-cli/internal/cmdutil"
+	"github.com/spf13/cobra"
+)
+
+// NewCmdAdd creates the blocklist add command.
+func NewCmdAdd(f *cmdutil.Factory) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "add <address>...",
+		Short: "Block senders",
+		Long: `Block one or more sender addresses.
+
+New mail from a blocked address is discarded without notice. Addresses
+are matched case-insensitively against the From header.`,
+		Example: `  fm blocklist add user@spam.com
+  fm blocklist add a@spam.com b@spam.com`,
+		Args: cmdutil.MinimumArgs(1, "address required\n\nUsage: fm blocklist add <address>..."),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runAdd(f, args)
+		},
+	}
+
+	return cmd
+}
+
+func runAdd(f *cmdutil.Factory, args []string) error {
+	for _, addr := range args {
+		if !strings.Contains(addr, "@") {
+			return cmdutil.FlagErrorf("invalid email address %q", addr)
+		}
+	}
+
+	client, err := f.JMAPClient()
+	if err != nil {
+		return err
+	}
+
+	script, content, addrs, err := loadBlocklist(client)
+	if err != nil {
+		return err
+	}
+
+	var added []string
+	for _, addr := range args {
+		addr = strings.ToLower(strings.TrimSpace(addr))
+		if slices.Contains(addrs, addr) {
+			fmt.Fprintf(f.IOStreams.ErrOut, "Already blocked: %s\n", addr)
+			continue
+		}
+		addrs = append(addrs, addr)
+		added = append(added, addr)
+	}
+
+	if len(added) == 0 {
+		return nil
+	}
+
+	if err := saveBlocklist(client, script, content, addrs); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(f.IOStreams.Out, "Blocked %s.\n", strings.Join(added, ", "))
+	return nil
+}
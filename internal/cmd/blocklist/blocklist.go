@@ -0,0 +1,56 @@
+package blocklist
+
+import (
+	"github.com/marckohlbrugge/fastmail-cli/internal/cmdutil"
+	"github.com/marckohlbrugge/fastmail-cli/internal/jmap"
+	"github.com/spf13/cobra"
+)
+
+// NewCmdBlocklist creates the blocklist command group.
+func NewCmdBlocklist(f *cmdutil.Factory) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "blocklist <command>",
+		Short: "Manage blocked senders",
+		Long: `View and change the senders whose mail is discarded.
+
+Blocked senders are kept in a single rule in your active sieve script,
+marked so fm can find it again. The rest of the script is left untouched.
+If no script is active, one named "fm-blocklist" is created.`,
+		GroupID: "settings",
+		Example: `  $ fm blocklist list
+  $ fm blocklist add user@spam.com
+  $ fm blocklist remove user@spam.com`,
+	}
+
+	cmd.AddCommand(NewCmdList(f))
+	cmd.AddCommand(NewCmdAdd(f))
+	cmd.AddCommand(NewCmdRemove(f))
+
+	return cmd
+}
+
+// loadBlocklist returns the active sieve script (nil if none), its source,
+// and the addresses currently blocked.
+func loadBlocklist(client *jmap.Client) (*jmap.SieveScript, string, []string, error) {
+	script, err := client.GetActiveSieveScript()
+	if err != nil || script == nil {
+		return nil, "", nil, err
+	}
+
+	content, err := client.GetSieveScriptContent(script)
+	if err != nil {
+		return nil, "", nil, err
+	}
+
+	return script, content, parseBlocked(content), nil
+}
+
+// saveBlocklist writes addrs back as the blocklist rule of script, creating
+// a new active script if there is none.
+func saveBlocklist(client *jmap.Client, script *jmap.SieveScript, content string, addrs []string) error {
+	updated := setBlocked(content, addrs)
+	if script == nil {
+		return client.CreateSieveScript(scriptName, updated)
+	}
+	return client.UpdateSieveScript(script.ID, updated)
+}
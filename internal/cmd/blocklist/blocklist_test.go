@@ -0,0 +1,268 @@
+package blocklist
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/marckohlbrugge/fastmail-cli/internal/cmdutil"
+	"github.com/marckohlbrugge/fastmail-cli/internal/iostreams"
+	"github.com/marckohlbrugge/fastmail-cli/internal/jmap"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const uploadURL = "https://api.test.com/jmap/upload/account-1/"
+
+func setupTest(t *testing.T) (*cmdutil.Factory, *bytes.Buffer, *bytes.Buffer) {
+	t.Helper()
+
+	httpmock.Activate()
+	t.Cleanup(httpmock.DeactivateAndReset)
+
+	httpmock.RegisterResponder("GET", "https://api.test.com/jmap/session",
+		httpmock.NewJsonResponderOrPanic(200, map[string]interface{}{
+			"apiUrl":      "https://api.test.com/jmap/api",
+			"downloadUrl": "https://api.test.com/jmap/download/{accountId}/{blobId}/{name}?type={type}",
+			"uploadUrl":   "https://api.test.com/jmap/upload/{accountId}/",
+			"accounts": map[string]interface{}{
+				"account-1": map[string]interface{}{},
+			},
+			"capabilities": map[string]interface{}{
+				jmap.CoreCapability:  map[string]interface{}{},
+				jmap.SieveCapability: map[string]interface{}{},
+			},
+		}))
+
+	client := jmap.NewClient("test-token")
+	client.SetBaseURL("https://api.test.com")
+
+	ios, _, stdout, stderr := iostreams.Test()
+	f := &cmdutil.Factory{
+		IOStreams: ios,
+	}
+	f.SetJMAPClient(client)
+
+	return f, stdout, stderr
+}
+
+// sieveServer mocks the sieve endpoints around a single script. A nil
+// script means no script is active. It records uploaded sources and the
+// SieveScript/set arguments.
+type sieveServer struct {
+	script   *string
+	uploaded []string
+	sets     []map[string]interface{}
+}
+
+func (s *sieveServer) register(t *testing.T) {
+	t.Helper()
+
+	httpmock.RegisterResponder("POST", "https://api.test.com/jmap/api",
+		func(req *http.Request) (*http.Response, error) {
+			var jmapReq jmap.Request
+			require.NoError(t, json.NewDecoder(req.Body).Decode(&jmapReq))
+
+			method := jmapReq.MethodCalls[0][0].(string)
+			args := jmapReq.MethodCalls[0][1].(map[string]interface{})
+
+			switch method {
+			case "SieveScript/get":
+				list := []map[string]interface{}{}
+				if s.script != nil {
+					list = append(list, map[string]interface{}{"id": "S1", "name": "rules", "blobId": "B1", "isActive": true})
+				}
+				return httpmock.NewJsonResponse(200, map[string]interface{}{
+					"methodResponses": [][]interface{}{
+						{"SieveScript/get", map[string]interface{}{"list": list}, "sieveScripts"},
+					},
+				})
+			case "SieveScript/set":
+				s.sets = append(s.sets, args)
+				return httpmock.NewJsonResponse(200, map[string]interface{}{
+					"methodResponses": [][]interface{}{
+						{"SieveScript/set", map[string]interface{}{}, "setSieveScript"},
+					},
+				})
+			}
+			t.Fatalf("unexpected method %s", method)
+			return nil, nil
+		})
+
+	httpmock.RegisterResponder("GET", `=~^https://api\.test\.com/jmap/download/account-1/B1/`,
+		func(req *http.Request) (*http.Response, error) {
+			return httpmock.NewStringResponse(200, *s.script), nil
+		})
+
+	httpmock.RegisterResponder("POST", uploadURL,
+		func(req *http.Request) (*http.Response, error) {
+			assert.Equal(t, "application/sieve", req.Header.Get("Content-Type"))
+			body, _ := io.ReadAll(req.Body)
+			s.uploaded = append(s.uploaded, string(body))
+			return httpmock.NewJsonResponse(201, map[string]interface{}{"blobId": "B2"})
+		})
+}
+
+const existingScript = `require ["fileinto"];
+if header :contains "subject" "invoice" {
+  fileinto "Receipts";
+}
+`
+
+func TestListCommand(t *testing.T) {
+	t.Run("lists blocked senders from the active script", func(t *testing.T) {
+		f, stdout, _ := setupTest(t)
+		script := setBlocked(existingScript, []string{"a@spam.com", "b@spam.com"})
+		(&sieveServer{script: &script}).register(t)
+
+		cmd := NewCmdList(f)
+		cmd.SetArgs([]string{})
+		cmd.SetOut(stdout)
+
+		require.NoError(t, cmd.Execute())
+		assert.Equal(t, "a@spam.com\nb@spam.com\n", stdout.String())
+	})
+
+	t.Run("reports no blocked senders without an active script", func(t *testing.T) {
+		f, stdout, _ := setupTest(t)
+		(&sieveServer{}).register(t)
+
+		cmd := NewCmdList(f)
+		cmd.SetArgs([]string{"--json"})
+		cmd.SetOut(stdout)
+
+		require.NoError(t, cmd.Execute())
+		assert.Equal(t, "[]\n", stdout.String())
+	})
+}
+
+func TestAddCommand(t *testing.T) {
+	t.Run("adds rule to the active script", func(t *testing.T) {
+		f, stdout, _ := setupTest(t)
+		script := existingScript
+		server := &sieveServer{script: &script}
+		server.register(t)
+
+		cmd := NewCmdAdd(f)
+		cmd.SetArgs([]string{"User@Spam.com"})
+		cmd.SetOut(stdout)
+
+		require.NoError(t, cmd.Execute())
+		assert.Equal(t, "Blocked user@spam.com.\n", stdout.String())
+
+		require.Len(t, server.uploaded, 1)
+		assert.Equal(t, `require ["fileinto"];
+`+ruleStart+`
+if address :is "from" ["user@spam.com"] {
+  discard;
+  stop;
+}
+`+ruleEnd+`
+if header :contains "subject" "invoice" {
+  fileinto "Receipts";
+}
+`, server.uploaded[0])
+
+		require.Len(t, server.sets, 1)
+		assert.Equal(t, map[string]interface{}{
+			"S1": map[string]interface{}{"blobId": "B2"},
+		}, server.sets[0]["update"])
+	})
+
+	t.Run("creates an active script when none exists", func(t *testing.T) {
+		f, _, _ := setupTest(t)
+		server := &sieveServer{}
+		server.register(t)
+
+		cmd := NewCmdAdd(f)
+		cmd.SetArgs([]string{"user@spam.com"})
+		cmd.SetOut(&bytes.Buffer{})
+
+		require.NoError(t, cmd.Execute())
+		require.Len(t, server.uploaded, 1)
+		assert.Equal(t, []string{"user@spam.com"}, parseBlocked(server.uploaded[0]))
+
+		require.Len(t, server.sets, 1)
+		assert.Equal(t, "#newScript", server.sets[0]["onSuccessActivateScript"])
+		created := server.sets[0]["create"].(map[string]interface{})["newScript"].(map[string]interface{})
+		assert.Equal(t, "fm-blocklist", created["name"])
+	})
+
+	t.Run("skips addresses already blocked", func(t *testing.T) {
+		f, stdout, stderr := setupTest(t)
+		script := setBlocked(existingScript, []string{"user@spam.com"})
+		server := &sieveServer{script: &script}
+		server.register(t)
+
+		cmd := NewCmdAdd(f)
+		cmd.SetArgs([]string{"user@spam.com"})
+		cmd.SetOut(stdout)
+
+		require.NoError(t, cmd.Execute())
+		assert.Contains(t, stderr.String(), "Already blocked: user@spam.com")
+		assert.Empty(t, stdout.String())
+		assert.Empty(t, server.uploaded)
+	})
+
+	t.Run("rejects invalid address", func(t *testing.T) {
+		f, _, _ := setupTest(t)
+
+		cmd := NewCmdAdd(f)
+		cmd.SetArgs([]string{"spam.com"})
+		err := cmd.Execute()
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `invalid email address "spam.com"`)
+	})
+}
+
+func TestRemoveCommand(t *testing.T) {
+	t.Run("removes the rule with the last address", func(t *testing.T) {
+		f, stdout, _ := setupTest(t)
+		script := setBlocked(existingScript, []string{"user@spam.com"})
+		server := &sieveServer{script: &script}
+		server.register(t)
+
+		cmd := NewCmdRemove(f)
+		cmd.SetArgs([]string{"user@spam.com"})
+		cmd.SetOut(stdout)
+
+		require.NoError(t, cmd.Execute())
+		assert.Equal(t, "Unblocked user@spam.com.\n", stdout.String())
+		require.Len(t, server.uploaded, 1)
+		assert.Equal(t, existingScript, server.uploaded[0])
+	})
+
+	t.Run("errors on address not blocked", func(t *testing.T) {
+		f, _, _ := setupTest(t)
+		script := existingScript
+		(&sieveServer{script: &script}).register(t)
+
+		cmd := NewCmdRemove(f)
+		cmd.SetArgs([]string{"user@spam.com"})
+		err := cmd.Execute()
+
+		require.Error(t, err)
+		assert.Equal(t, "user@spam.com is not blocked", err.Error())
+	})
+}
+
+func TestSetBlocked(t *testing.T) {
+	t.Run("replaces an existing rule in place", func(t *testing.T) {
+		script := setBlocked("keep;\n", []string{"a@spam.com"})
+		script = setBlocked(script, []string{"a@spam.com", `odd"name@spam.com`})
+
+		assert.Equal(t, []string{"a@spam.com", `odd"name@spam.com`}, parseBlocked(script))
+		assert.Contains(t, script, `"odd\"name@spam.com"`)
+		assert.Equal(t, 1, bytes.Count([]byte(script), []byte(ruleStart)))
+		assert.Contains(t, script, "keep;\n")
+	})
+
+	t.Run("ignores scripts without a rule", func(t *testing.T) {
+		assert.Nil(t, parseBlocked(existingScript))
+		assert.Equal(t, existingScript, setBlocked(existingScript, nil))
+	})
+}
@@ -0,0 +1,68 @@
+package blocklist
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/marckohlbrugge/fastmail-cli/internal/cmdutil"
+	"github.com/spf13/cobra"
+)
+
+type listOptions struct {
+	JSON bool
+}
+
+// NewCmdList creates the blocklist list command.
+func NewCmdList(f *cmdutil.Factory) *cobra.Command {
+	opts := &listOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List blocked senders",
+		Example: `  # List blocked senders
+  fm blocklist list
+
+  # Output as JSON
+  fm blocklist list --json`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runList(f, opts)
+		},
+	}
+
+	cmd.Flags().BoolVar(&opts.JSON, "json", false, "Output in JSON format")
+
+	return cmd
+}
+
+func runList(f *cmdutil.Factory, opts *listOptions) error {
+	client, err := f.JMAPClient()
+	if err != nil {
+		return err
+	}
+
+	_, _, addrs, err := loadBlocklist(client)
+	if err != nil {
+		return err
+	}
+
+	if opts.JSON {
+		if addrs == nil {
+			addrs = []string{}
+		}
+		encoder := json.NewEncoder(f.IOStreams.Out)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(addrs)
+	}
+
+	out := f.IOStreams.Out
+	if len(addrs) == 0 {
+		fmt.Fprintln(out, "No blocked senders.")
+		return nil
+	}
+
+	for _, addr := range addrs {
+		fmt.Fprintln(out, addr)
+	}
+	return nil
+}
@@ -0,0 +1,56 @@
+package blocklist
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/marckohlbrugge/fastmail-cli/internal/cmdutil"
+	"github.com/spf13/cobra"
+)
+
+// NewCmdRemove creates the blocklist remove command.
+func NewCmdRemove(f *cmdutil.Factory) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "remove <address>...",
+		Aliases: []string{"rm"},
+		Short:   "Unblock senders",
+		Example: `  fm blocklist remove user@spam.com`,
+		Args:    cmdutil.MinimumArgs(1, "address required\n\nUsage: fm blocklist remove <address>..."),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runRemove(f, args)
+		},
+	}
+
+	return cmd
+}
+
+func runRemove(f *cmdutil.Factory, args []string) error {
+	client, err := f.JMAPClient()
+	if err != nil {
+		return err
+	}
+
+	script, content, addrs, err := loadBlocklist(client)
+	if err != nil {
+		return err
+	}
+
+	var removed []string
+	for _, addr := range args {
+		addr = strings.ToLower(strings.TrimSpace(addr))
+		i := slices.Index(addrs, addr)
+		if i < 0 {
+			return fmt.Errorf("%s is not blocked", addr)
+		}
+		addrs = slices.Delete(addrs, i, i+1)
+		removed = append(removed, addr)
+	}
+
+	if err := saveBlocklist(client, script, content, addrs); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(f.IOStreams.Out, "Unblocked %s.\n", strings.Join(removed, ", "))
+	return nil
+}
@@ -0,0 +1,112 @@
+package blocklist
+
+import (
+	"regexp"
+	"strings"
+)
+
+// The blocklist is a single rule in the active sieve script, kept between
+// these marker comments so it can be found and rewritten without touching
+// the rest of the script.
+const (
+	ruleStart = "# fm blocklist: managed by 'fm blocklist', do not edit"
+	ruleEnd   = "# end fm blocklist"
+)
+
+// scriptName is the name given to the sieve script created to hold the
+// blocklist when no script is active.
+const scriptName = "fm-blocklist"
+
+var sieveString = regexp.MustCompile(`"((?:[^"\\]|\\.)*)"`)
+
+// parseBlocked returns the addresses in the blocklist rule of a sieve script.
+func parseBlocked(script string) []string {
+	rule, _, _, ok := findRule(script)
+	if !ok {
+		return nil
+	}
+
+	start := strings.Index(rule, "[")
+	end := strings.LastIndex(rule, "]")
+	if start < 0 || end < start {
+		return nil
+	}
+
+	var addrs []string
+	for _, m := range sieveString.FindAllStringSubmatch(rule[start:end], -1) {
+		addrs = append(addrs, unquoteSieve(m[1]))
+	}
+	return addrs
+}
+
+// setBlocked returns script with its blocklist rule replaced by one
+// discarding mail from addrs. The rule is removed if addrs is empty, and
+// added after any require commands if the script has none yet.
+func setBlocked(script string, addrs []string) string {
+	rule := renderRule(addrs)
+
+	if _, start, end, ok := findRule(script); ok {
+		return script[:start] + rule + script[end:]
+	}
+	if rule == "" {
+		return script
+	}
+
+	lines := strings.SplitAfter(script, "\n")
+	at := 0
+	for i, line := range lines {
+		if strings.HasPrefix(strings.TrimSpace(line), "require") {
+			at = i + 1
+		}
+	}
+
+	head := strings.Join(lines[:at], "")
+	if head != "" && !strings.HasSuffix(head, "\n") {
+		head += "\n"
+	}
+	return head + rule + strings.Join(lines[at:], "")
+}
+
+// findRule locates the blocklist rule, including its marker lines and the
+// trailing newline.
+func findRule(script string) (rule string, start, end int, ok bool) {
+	start = strings.Index(script, ruleStart)
+	if start < 0 {
+		return "", 0, 0, false
+	}
+	rel := strings.Index(script[start:], ruleEnd)
+	if rel < 0 {
+		return "", 0, 0, false
+	}
+	end = start + rel + len(ruleEnd)
+	if end < len(script) && script[end] == '\n' {
+		end++
+	}
+	return script[start:end], start, end, true
+}
+
+func renderRule(addrs []string) string {
+	if len(addrs) == 0 {
+		return ""
+	}
+
+	quoted := make([]string, len(addrs))
+	for i, a := range addrs {
+		quoted[i] = `"` + quoteSieve(a) + `"`
+	}
+
+	return ruleStart + "\n" +
+		`if address :is "from" [` + strings.Join(quoted, ", ") + "] {\n" +
+		"  discard;\n" +
+		"  stop;\n" +
+		"}\n" +
+		ruleEnd + "\n"
+}
+
+func quoteSieve(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s)
+}
+
+func unquoteSieve(s string) string {
+	return strings.NewReplacer(`\\`, `\`, `\"`, `"`).Replace(s)
+}
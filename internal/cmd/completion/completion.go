@@ -2,7 +2,6 @@ package completion
 
 import (
 	"fmt"
-	"os"
 
 	"github.com/marckohlbrugge/fastmail-cli/internal/cmdutil"
 	"github.com/spf13/cobra"
@@ -10,6 +9,8 @@ import (
 
 // NewCmdCompletion creates the completion command.
 func NewCmdCompletion(f *cmdutil.Factory) *cobra.Command {
+	var noDescriptions bool
+
 	cmd := &cobra.Command{
 		Use:   "completion <shell>",
 		Short: "Generate shell completion scripts",
@@ -17,6 +18,10 @@ func NewCmdCompletion(f *cmdutil.Factory) *cobra.Command {
 
 The output can be sourced directly or saved to a file for later use.
 
+zsh, fish, and PowerShell show a short description next to each
+suggestion; --no-descriptions turns them off. Bash completions never
+include descriptions.
+
 SUPPORTED SHELLS
   bash, zsh, fish, powershell`,
 		Example: `  # Bash - add to ~/.bashrc:
@@ -29,28 +34,41 @@ SUPPORTED SHELLS
   fm completion zsh > ~/.zsh/completions/_fm
 
   # Fish:
-  fm completion fish > ~/.config/fish/completions/fm.fish`,
+  fm completion fish > ~/.config/fish/completions/fm.fish
+
+  # PowerShell - add to your $PROFILE:
+  fm completion powershell | Out-String | Invoke-Expression`,
 		GroupID:           "utility",
 		Args:              cmdutil.ExactArgs(1, "shell type required: bash, zsh, fish, or powershell"),
 		ValidArgsFunction: completeShellTypes,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			shell := args[0]
 			rootCmd := cmd.Root()
+			out := f.IOStreams.Out
 
 			switch shell {
 			case "bash":
-				return rootCmd.GenBashCompletion(os.Stdout)
+				return rootCmd.GenBashCompletion(out)
 			case "zsh":
-				return rootCmd.GenZshCompletion(os.Stdout)
+				if noDescriptions {
+					return rootCmd.GenZshCompletionNoDesc(out)
+				}
+				return rootCmd.GenZshCompletion(out)
 			case "fish":
-				return rootCmd.GenFishCompletion(os.Stdout, true)
+				return rootCmd.GenFishCompletion(out, !noDescriptions)
 			case "powershell":
-				return rootCmd.GenPowerShellCompletionWithDesc(os.Stdout)
+				if noDescriptions {
+					return rootCmd.GenPowerShellCompletion(out)
+				}
+				return rootCmd.GenPowerShellCompletionWithDesc(out)
 			default:
 				return fmt.Errorf("unsupported shell type: %s\n\nSupported: bash, zsh, fish, powershell", shell)
 			}
 		},
 	}
+
+	cmd.Flags().BoolVar(&noDescriptions, "no-descriptions", false, "Leave descriptions out of the completions")
+
 	return cmd
 }
 
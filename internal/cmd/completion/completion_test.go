@@ -0,0 +1,79 @@
+package completion
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/marckohlbrugge/fastmail-cli/internal/cmdutil"
+	"github.com/marckohlbrugge/fastmail-cli/internal/iostreams"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// runCompletion runs fm completion with args under a bare root command and
+// returns the generated script.
+func runCompletion(t *testing.T, args ...string) (string, error) {
+	t.Helper()
+
+	ios, _, stdout, _ := iostreams.Test()
+	f := &cmdutil.Factory{IOStreams: ios}
+
+	root := &cobra.Command{Use: "fm"}
+	root.AddGroup(&cobra.Group{ID: "utility", Title: "Utility"})
+	root.AddCommand(NewCmdCompletion(f))
+	root.SetArgs(append([]string{"completion"}, args...))
+	root.SetOut(&bytes.Buffer{})
+	root.SetErr(&bytes.Buffer{})
+
+	err := root.Execute()
+	return stdout.String(), err
+}
+
+func TestCompletionCommand(t *testing.T) {
+	t.Run("generates a script for each shell", func(t *testing.T) {
+		tests := []struct {
+			shell  string
+			marker string
+		}{
+			{"bash", "# bash completion for fm"},
+			{"zsh", "#compdef fm"},
+			{"fish", "complete -c fm"},
+			{"powershell", "Register-ArgumentCompleter"},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.shell, func(t *testing.T) {
+				script, err := runCompletion(t, tt.shell)
+
+				require.NoError(t, err)
+				assert.Contains(t, script, tt.marker)
+			})
+		}
+	})
+
+	t.Run("includes descriptions by default", func(t *testing.T) {
+		for _, shell := range []string{"zsh", "fish", "powershell"} {
+			script, err := runCompletion(t, shell)
+
+			require.NoError(t, err)
+			assert.NotContains(t, script, "__completeNoDesc", shell)
+		}
+	})
+
+	t.Run("leaves descriptions out with --no-descriptions", func(t *testing.T) {
+		for _, shell := range []string{"zsh", "fish", "powershell"} {
+			script, err := runCompletion(t, shell, "--no-descriptions")
+
+			require.NoError(t, err)
+			assert.Contains(t, script, "__completeNoDesc", shell)
+		}
+	})
+
+	t.Run("rejects unknown shells", func(t *testing.T) {
+		_, err := runCompletion(t, "tcsh")
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "unsupported shell type: tcsh")
+	})
+}
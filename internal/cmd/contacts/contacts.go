@@ -0,0 +1,24 @@
+package contacts
+
+import (
+	"github.com/marckohlbrugge/fastmail-cli/internal/cmdutil"
+	"github.com/spf13/cobra"
+)
+
+// NewCmdContacts creates the contacts command group.
+func NewCmdContacts(f *cmdutil.Factory) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "contacts <command>",
+		Short:   "View and export contacts",
+		Long:    "View and export your Fastmail contacts.",
+		GroupID: "contacts",
+		Example: `  $ fm contacts list
+  $ fm contacts list --json
+  $ fm contacts export --out contacts.vcf`,
+	}
+
+	cmd.AddCommand(NewCmdList(f))
+	cmd.AddCommand(NewCmdExport(f))
+
+	return cmd
+}
@@ -0,0 +1,258 @@
+package contacts
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/marckohlbrugge/fastmail-cli/internal/cmdutil"
+	"github.com/marckohlbrugge/fastmail-cli/internal/iostreams"
+	"github.com/marckohlbrugge/fastmail-cli/internal/jmap"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupTest(t *testing.T, capabilities map[string]interface{}) (*cmdutil.Factory, *bytes.Buffer, *bytes.Buffer) {
+	t.Helper()
+
+	httpmock.Activate()
+	t.Cleanup(httpmock.DeactivateAndReset)
+
+	httpmock.RegisterResponder("GET", "https://api.test.com/jmap/session",
+		httpmock.NewJsonResponderOrPanic(200, map[string]interface{}{
+			"apiUrl": "https://api.test.com/jmap/api",
+			"accounts": map[string]interface{}{
+				"account-1": map[string]interface{}{},
+			},
+			"capabilities": capabilities,
+		}))
+
+	client := jmap.NewClient("test-token")
+	client.SetBaseURL("https://api.test.com")
+
+	ios, _, stdout, stderr := iostreams.Test()
+	f := &cmdutil.Factory{
+		IOStreams: ios,
+	}
+	f.SetJMAPClient(client)
+
+	return f, stdout, stderr
+}
+
+var contactsCapabilities = map[string]interface{}{
+	jmap.CoreCapability:     map[string]interface{}{},
+	jmap.ContactsCapability: map[string]interface{}{},
+}
+
+func mockContactsResponse() httpmock.Responder {
+	return httpmock.NewJsonResponderOrPanic(200, map[string]interface{}{
+		"methodResponses": [][]interface{}{
+			{"ContactCard/get", map[string]interface{}{
+				"list": []map[string]interface{}{
+					{
+						"id":   "c-2",
+						"kind": "individual",
+						"name": map[string]interface{}{"full": "Bob Jones"},
+						"emails": map[string]interface{}{
+							"e1": map[string]interface{}{"address": "bob@example.com"},
+						},
+					},
+					{
+						"id":   "c-1",
+						"kind": "individual",
+						"name": map[string]interface{}{
+							"components": []map[string]interface{}{
+								{"kind": "given", "value": "Alice"},
+								{"kind": "surname", "value": "Smith"},
+							},
+						},
+						"emails": map[string]interface{}{
+							"e1": map[string]interface{}{"address": "alice@work.example.com"},
+							"e2": map[string]interface{}{"address": "alice@example.com", "pref": 1},
+						},
+					},
+					{"id": "g-1", "kind": "group", "name": map[string]interface{}{"full": "Friends"}},
+				},
+			}, "contacts"},
+		},
+	})
+}
+
+// List command tests
+
+func TestListCommand(t *testing.T) {
+	t.Run("lists contacts in human format", func(t *testing.T) {
+		f, stdout, _ := setupTest(t, contactsCapabilities)
+
+		httpmock.RegisterResponder("POST", "https://api.test.com/jmap/api", mockContactsResponse())
+
+		cmd := NewCmdList(f)
+		cmd.SetArgs([]string{})
+		cmd.SetOut(stdout)
+		cmd.SetErr(&bytes.Buffer{})
+
+		err := cmd.Execute()
+
+		require.NoError(t, err)
+		output := stdout.String()
+		assert.Contains(t, output, "Alice Smith")
+		assert.Contains(t, output, "alice@example.com")
+		assert.NotContains(t, output, "alice@work.example.com")
+		assert.Contains(t, output, "Bob Jones")
+		assert.NotContains(t, output, "Friends")
+		assert.Less(t, bytes.Index(stdout.Bytes(), []byte("Alice")), bytes.Index(stdout.Bytes(), []byte("Bob")))
+	})
+
+	t.Run("outputs JSON", func(t *testing.T) {
+		f, stdout, _ := setupTest(t, contactsCapabilities)
+
+		httpmock.RegisterResponder("POST", "https://api.test.com/jmap/api", mockContactsResponse())
+
+		cmd := NewCmdList(f)
+		cmd.SetArgs([]string{"--json"})
+		cmd.SetOut(stdout)
+		cmd.SetErr(&bytes.Buffer{})
+
+		err := cmd.Execute()
+
+		require.NoError(t, err)
+
+		var result []map[string]string
+		require.NoError(t, json.Unmarshal(stdout.Bytes(), &result))
+		require.Len(t, result, 2)
+		assert.Equal(t, "c-1", result[0]["id"])
+		assert.Equal(t, "alice@example.com", result[0]["email"])
+	})
+
+	t.Run("reports missing contacts capability", func(t *testing.T) {
+		f, _, _ := setupTest(t, map[string]interface{}{
+			jmap.CoreCapability: map[string]interface{}{},
+		})
+
+		cmd := NewCmdList(f)
+		cmd.SetArgs([]string{})
+		cmd.SetOut(&bytes.Buffer{})
+		cmd.SetErr(&bytes.Buffer{})
+
+		err := cmd.Execute()
+
+		require.Error(t, err)
+		assert.ErrorIs(t, err, jmap.ErrContactsUnavailable)
+		assert.Equal(t, "contacts not available for this account", err.Error())
+		assert.Equal(t, 0, httpmock.GetCallCountInfo()["POST https://api.test.com/jmap/api"])
+	})
+}
+
+func mockExportResponse() httpmock.Responder {
+	return httpmock.NewJsonResponderOrPanic(200, map[string]interface{}{
+		"methodResponses": [][]interface{}{
+			{"ContactCard/get", map[string]interface{}{
+				"list": []map[string]interface{}{
+					{
+						"id":   "c-1",
+						"kind": "individual",
+						"name": map[string]interface{}{
+							"components": []map[string]interface{}{
+								{"kind": "given", "value": "Alice"},
+								{"kind": "surname", "value": "Smith"},
+							},
+						},
+						"emails": map[string]interface{}{
+							"e1": map[string]interface{}{"address": "alice@work.example.com"},
+							"e2": map[string]interface{}{"address": "alice@example.com", "pref": 1},
+						},
+						"phones": map[string]interface{}{
+							"p1": map[string]interface{}{
+								"number":   "+1 555 0100",
+								"features": map[string]interface{}{"mobile": true},
+								"contexts": map[string]interface{}{"private": true},
+							},
+						},
+					},
+					{"id": "g-1", "kind": "group", "name": map[string]interface{}{"full": "Friends"}},
+				},
+			}, "contacts"},
+		},
+	})
+}
+
+func TestExportCommand(t *testing.T) {
+	t.Run("writes vcard with one EMAIL line per address", func(t *testing.T) {
+		f, stdout, _ := setupTest(t, contactsCapabilities)
+		httpmock.RegisterResponder("POST", "https://api.test.com/jmap/api", mockExportResponse())
+
+		cmd := NewCmdExport(f)
+		cmd.SetArgs([]string{})
+		cmd.SetOut(stdout)
+		require.NoError(t, cmd.Execute())
+
+		assert.Equal(t, "BEGIN:VCARD\r\n"+
+			"VERSION:3.0\r\n"+
+			"UID:c-1\r\n"+
+			"FN:Alice Smith\r\n"+
+			"N:Smith;Alice;;;\r\n"+
+			"EMAIL;TYPE=INTERNET,PREF:alice@example.com\r\n"+
+			"EMAIL;TYPE=INTERNET:alice@work.example.com\r\n"+
+			"TEL;TYPE=CELL,HOME:+1 555 0100\r\n"+
+			"END:VCARD\r\n", stdout.String())
+	})
+
+	t.Run("writes csv", func(t *testing.T) {
+		f, stdout, _ := setupTest(t, contactsCapabilities)
+		httpmock.RegisterResponder("POST", "https://api.test.com/jmap/api", mockExportResponse())
+
+		cmd := NewCmdExport(f)
+		cmd.SetArgs([]string{"--format", "csv"})
+		cmd.SetOut(stdout)
+		require.NoError(t, cmd.Execute())
+
+		assert.Equal(t, "name,given,surname,emails,phones\n"+
+			"Alice Smith,Alice,Smith,alice@example.com; alice@work.example.com,+1 555 0100\n", stdout.String())
+	})
+
+	t.Run("writes to --out file", func(t *testing.T) {
+		f, stdout, stderr := setupTest(t, contactsCapabilities)
+		httpmock.RegisterResponder("POST", "https://api.test.com/jmap/api", mockExportResponse())
+
+		path := filepath.Join(t.TempDir(), "contacts.vcf")
+		cmd := NewCmdExport(f)
+		cmd.SetArgs([]string{"--out", path})
+		cmd.SetOut(stdout)
+		require.NoError(t, cmd.Execute())
+
+		data, err := os.ReadFile(path)
+		require.NoError(t, err)
+		assert.Contains(t, string(data), "FN:Alice Smith\r\n")
+		assert.Empty(t, stdout.String())
+		assert.Contains(t, stderr.String(), "Exported 1 contacts to "+path)
+	})
+
+	t.Run("rejects unknown format", func(t *testing.T) {
+		f, _, _ := setupTest(t, contactsCapabilities)
+
+		cmd := NewCmdExport(f)
+		cmd.SetArgs([]string{"--format", "ldif"})
+		err := cmd.Execute()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `invalid format "ldif"`)
+	})
+}
+
+func TestVCardLineFolding(t *testing.T) {
+	assert.Equal(t, `a\,b\;c\\d\ne`, vCardEscape("a,b;c\\d\ne"))
+
+	var buf bytes.Buffer
+	w := bufio.NewWriter(&buf)
+	writeVCardLine(w, "NOTE:"+strings.Repeat("x", 100))
+	require.NoError(t, w.Flush())
+
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\r\n"), "\r\n")
+	require.Len(t, lines, 2)
+	assert.Len(t, lines[0], 75)
+	assert.Equal(t, " "+strings.Repeat("x", 30), lines[1])
+}
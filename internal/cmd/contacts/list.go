@@ -0,0 +1,98 @@
+package contacts
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/marckohlbrugge/fastmail-cli/internal/cmdutil"
+	"github.com/spf13/cobra"
+)
+
+type listOptions struct {
+	JSON bool
+}
+
+// contactJSON is the JSON output shape for a contact.
+type contactJSON struct {
+	ID    string `json:"id"`
+	Name  string `json:"name"`
+	Email string `json:"email"`
+}
+
+// NewCmdList creates the contacts list command.
+func NewCmdList(f *cmdutil.Factory) *cobra.Command {
+	opts := &listOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List contacts",
+		Long: `List your contacts with their name and primary email address.
+
+Contact groups are not shown.`,
+		Example: `  # List contacts
+  fm contacts list
+
+  # Output as JSON
+  fm contacts list --json`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runList(f, opts)
+		},
+	}
+
+	cmd.Flags().BoolVar(&opts.JSON, "json", false, "Output in JSON format")
+
+	return cmd
+}
+
+func runList(f *cmdutil.Factory, opts *listOptions) error {
+	client, err := f.JMAPClient()
+	if err != nil {
+		return err
+	}
+
+	all, err := client.GetContacts()
+	if err != nil {
+		return err
+	}
+
+	contacts := make([]contactJSON, 0, len(all))
+	for _, c := range all {
+		if c.Kind == "group" {
+			continue
+		}
+		contacts = append(contacts, contactJSON{ID: c.ID, Name: c.FullName(), Email: c.PrimaryEmail()})
+	}
+	sort.SliceStable(contacts, func(i, j int) bool {
+		return strings.ToLower(contacts[i].Name) < strings.ToLower(contacts[j].Name)
+	})
+
+	if opts.JSON {
+		encoder := json.NewEncoder(f.IOStreams.Out)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(contacts)
+	}
+
+	out := f.IOStreams.Out
+
+	if len(contacts) == 0 {
+		fmt.Fprintln(out, "No contacts found.")
+		return nil
+	}
+
+	for _, c := range contacts {
+		name := c.Name
+		if name == "" {
+			name = "(no name)"
+		}
+		email := c.Email
+		if email == "" {
+			email = "-"
+		}
+		fmt.Fprintf(out, "%-30s  %s\n", name, email)
+	}
+
+	return nil
+}
@@ -1,9 +1,7 @@
 package draft
 
 import (
-	"bufio"
 	"fmt"
-	"strings"
 
 	"github.com/marckohlbrugge/fastmail-cli/internal/cmdutil"
 	"github.com/spf13/cobra"
@@ -19,9 +17,10 @@ func NewCmdDraftDelete(f *cmdutil.Factory) *cobra.Command {
 	opts := &deleteOptions{}
 
 	cmd := &cobra.Command{
-		Use:   "delete <draft-id>",
-		Short: "Delete a draft email",
-		Long: `Delete a draft email.
+		Use:   "delete <draft-id>...",
+		Short: "Delete draft emails",
+		Long: `Delete one or more draft emails by moving them to Trash. All given drafts
+are moved in a single request.
 
 This action requires confirmation unless --yes is provided.
 In non-interactive mode (scripts, AI), this command is blocked unless --unsafe is specified.`,
@@ -29,10 +28,13 @@ In non-interactive mode (scripts, AI), this command is blocked unless --unsafe i
   fm draft delete M1234567890
 
   # Delete without confirmation
-  fm draft delete M1234567890 --yes`,
-		Args: cmdutil.ExactArgs(1, "draft ID required\n\nUsage: fm draft delete <draft-id>"),
+  fm draft delete M1234567890 --yes
+
+  # Clear out several abandoned drafts at once
+  fm draft delete M1234567890 M0987654321 M1122334455`,
+		Args: cmdutil.MinimumArgs(1, "draft ID required\n\nUsage: fm draft delete <draft-id>..."),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runDraftDelete(f, opts, args[0])
+			return runDraftDelete(f, opts, args)
 		},
 	}
 
@@ -42,7 +44,7 @@ In non-interactive mode (scripts, AI), this command is blocked unless --unsafe i
 	return cmd
 }
 
-func runDraftDelete(f *cmdutil.Factory, opts *deleteOptions, draftID string) error {
+func runDraftDelete(f *cmdutil.Factory, opts *deleteOptions, draftIDs []string) error {
 	// Check safe mode
 	if f.IOStreams.IsSafeMode() && !opts.Unsafe {
 		return &cmdutil.SafeModeError{Command: "draft delete"}
@@ -55,35 +57,50 @@ func runDraftDelete(f *cmdutil.Factory, opts *deleteOptions, draftID string) err
 
 	// Require confirmation unless --yes
 	if !opts.Yes && f.IOStreams.IsInteractive() {
-		// Get draft info for confirmation
-		draft, err := client.GetEmailByID(draftID)
-		if err != nil {
-			return err
-		}
-
-		subject := draft.Subject
-		if subject == "" {
-			subject = "(no subject)"
+		if f.Quiet {
+			return cmdutil.QuietConfirmError
 		}
 
-		fmt.Fprintf(f.IOStreams.ErrOut, "Subject: %s\n", subject)
-		fmt.Fprintf(f.IOStreams.ErrOut, "Delete this draft? [y/N] ")
-
-		scanner := bufio.NewScanner(f.IOStreams.In)
-		response := ""
-		if scanner.Scan() {
-			response = scanner.Text()
+		prompt := fmt.Sprintf("Delete %d drafts?", len(draftIDs))
+		if len(draftIDs) == 1 {
+			// Get draft info for confirmation
+			draft, err := client.GetEmailByID(draftIDs[0])
+			if err != nil {
+				return err
+			}
+
+			subject := draft.Subject
+			if subject == "" {
+				subject = "(no subject)"
+			}
+
+			fmt.Fprintf(f.IOStreams.ErrOut, "Subject: %s\n", subject)
+			prompt = "Delete this draft?"
 		}
 
-		if !strings.HasPrefix(strings.ToLower(strings.TrimSpace(response)), "y") {
-			return cmdutil.CancelError
+		if ok, err := cmdutil.Confirm(f.IOStreams, prompt); !ok {
+			return err
 		}
 	}
 
-	if err := client.DeleteDraft(draftID); err != nil {
+	deleted, failed, err := client.DeleteDrafts(draftIDs)
+	if err != nil {
 		return err
 	}
 
-	fmt.Fprintln(f.IOStreams.Out, "Draft deleted.")
+	out := f.IOStreams.Out
+	if len(failed) > 0 {
+		fmt.Fprintf(out, "Deleted %d drafts. Failed: %d\n", deleted, len(failed))
+		for _, id := range failed {
+			fmt.Fprintf(f.IOStreams.ErrOut, "  Failed: %s\n", id)
+		}
+		return nil
+	}
+
+	if len(draftIDs) == 1 {
+		fmt.Fprintln(out, "Draft deleted.")
+		return nil
+	}
+	fmt.Fprintf(out, "Deleted %d drafts.\n", deleted)
 	return nil
 }
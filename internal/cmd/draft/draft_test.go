@@ -3,9 +3,11 @@ package draft
 import (
 	"bytes"
 	"encoding/json"
+	"io"
 	"net/http"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/jarcoal/httpmock"
@@ -28,6 +30,11 @@ func setupTest(t *testing.T) (*cmdutil.Factory, *bytes.Buffer, *bytes.Buffer) {
 			"accounts": map[string]interface{}{
 				"account-1": map[string]interface{}{},
 			},
+			"capabilities": map[string]interface{}{
+				jmap.CoreCapability:       map[string]interface{}{},
+				jmap.MailCapability:       map[string]interface{}{},
+				jmap.SubmissionCapability: map[string]interface{}{},
+			},
 		}))
 
 	client := jmap.NewClient("test-token")
@@ -192,6 +199,466 @@ func TestNewCommand(t *testing.T) {
 	})
 }
 
+// registerContactsSession replaces the test session with one that
+// advertises the contacts capability.
+func registerContactsSession() {
+	httpmock.RegisterResponder("GET", "https://api.test.com/jmap/session",
+		httpmock.NewJsonResponderOrPanic(200, map[string]interface{}{
+			"apiUrl": "https://api.test.com/jmap/api",
+			"accounts": map[string]interface{}{
+				"account-1": map[string]interface{}{},
+			},
+			"capabilities": map[string]interface{}{
+				jmap.CoreCapability:       map[string]interface{}{},
+				jmap.MailCapability:       map[string]interface{}{},
+				jmap.SubmissionCapability: map[string]interface{}{},
+				jmap.ContactsCapability:   map[string]interface{}{},
+			},
+		}))
+}
+
+// mockDraftWithContacts serves contacts, an original email to forward, and
+// draft creation, recording the created draft.
+func mockDraftWithContacts(created *map[string]interface{}) httpmock.Responder {
+	return func(req *http.Request) (*http.Response, error) {
+		var jmapReq jmap.Request
+		json.NewDecoder(req.Body).Decode(&jmapReq)
+
+		method := jmapReq.MethodCalls[0][0].(string)
+
+		switch method {
+		case "ContactCard/get":
+			return httpmock.NewJsonResponse(200, map[string]interface{}{
+				"methodResponses": [][]interface{}{
+					{"ContactCard/get", map[string]interface{}{
+						"list": []map[string]interface{}{
+							{"id": "c-1", "name": map[string]interface{}{"full": "Alice Smith"},
+								"emails": map[string]interface{}{"e": map[string]interface{}{"address": "alice@example.com"}}},
+							{"id": "c-2", "name": map[string]interface{}{"full": "Alice Jones"},
+								"emails": map[string]interface{}{"e": map[string]interface{}{"address": "ajones@example.com"}}},
+							{"id": "c-3", "name": map[string]interface{}{"full": "Bob Brown"},
+								"emails": map[string]interface{}{"e": map[string]interface{}{"address": "bob@example.com"}}},
+						},
+					}, "contacts"},
+				},
+			})
+		case "Email/get":
+			return httpmock.NewJsonResponse(200, map[string]interface{}{
+				"methodResponses": [][]interface{}{
+					{"Email/get", map[string]interface{}{
+						"list": []map[string]interface{}{
+							{"id": "email-1", "subject": "Quarterly numbers", "receivedAt": "2024-01-15T10:30:00Z"},
+						},
+					}, "email"},
+				},
+			})
+		case "Mailbox/get":
+			return httpmock.NewJsonResponse(200, map[string]interface{}{
+				"methodResponses": [][]interface{}{
+					{"Mailbox/get", map[string]interface{}{
+						"list": []map[string]interface{}{
+							{"id": "drafts-1", "role": "drafts"},
+						},
+					}, "mailboxes"},
+				},
+			})
+		case "Identity/get":
+			return httpmock.NewJsonResponse(200, map[string]interface{}{
+				"methodResponses": [][]interface{}{
+					{"Identity/get", map[string]interface{}{
+						"list": []map[string]interface{}{
+							{"id": "id-1", "email": "me@example.com"},
+						},
+					}, "identities"},
+				},
+			})
+		case "Email/set":
+			args := jmapReq.MethodCalls[0][1].(map[string]interface{})
+			*created = args["create"].(map[string]interface{})["draft"].(map[string]interface{})
+			return httpmock.NewJsonResponse(200, map[string]interface{}{
+				"methodResponses": [][]interface{}{
+					{"Email/set", map[string]interface{}{
+						"created": map[string]interface{}{
+							"draft": map[string]interface{}{"id": "draft-3"},
+						},
+					}, "createDraft"},
+				},
+			})
+		default:
+			return httpmock.NewStringResponse(400, "unexpected: "+method), nil
+		}
+	}
+}
+
+// mockDraftCreate serves draft creation, recording the created email object.
+func mockDraftCreate(created *map[string]interface{}) httpmock.Responder {
+	return func(req *http.Request) (*http.Response, error) {
+		var jmapReq jmap.Request
+		json.NewDecoder(req.Body).Decode(&jmapReq)
+
+		method := jmapReq.MethodCalls[0][0].(string)
+
+		switch method {
+		case "Mailbox/get":
+			return httpmock.NewJsonResponse(200, map[string]interface{}{
+				"methodResponses": [][]interface{}{
+					{"Mailbox/get", map[string]interface{}{
+						"list": []map[string]interface{}{
+							{"id": "drafts-1", "role": "drafts"},
+						},
+					}, "mailboxes"},
+				},
+			})
+		case "Identity/get":
+			return httpmock.NewJsonResponse(200, map[string]interface{}{
+				"methodResponses": [][]interface{}{
+					{"Identity/get", map[string]interface{}{
+						"list": []map[string]interface{}{
+							{"id": "id-1", "email": "me@example.com"},
+						},
+					}, "identities"},
+				},
+			})
+		case "Email/set":
+			args := jmapReq.MethodCalls[0][1].(map[string]interface{})
+			*created = args["create"].(map[string]interface{})["draft"].(map[string]interface{})
+			return httpmock.NewJsonResponse(200, map[string]interface{}{
+				"methodResponses": [][]interface{}{
+					{"Email/set", map[string]interface{}{
+						"created": map[string]interface{}{
+							"draft": map[string]interface{}{"id": "draft-4"},
+						},
+					}, "createDraft"},
+				},
+			})
+		default:
+			return httpmock.NewStringResponse(400, "unexpected: "+method), nil
+		}
+	}
+}
+
+func TestNewCommandHTMLBody(t *testing.T) {
+	htmlFile := filepath.Join(t.TempDir(), "body.html")
+	require.NoError(t, os.WriteFile(htmlFile, []byte("<p>Hello <b>Bob</b></p>"), 0644))
+
+	t.Run("sends both parts with --html and --body", func(t *testing.T) {
+		f, stdout, _ := setupTest(t)
+
+		var created map[string]interface{}
+		httpmock.RegisterResponder("POST", "https://api.test.com/jmap/api", mockDraftCreate(&created))
+
+		cmd := NewCmdNew(f)
+		cmd.SetArgs([]string{"--to", "bob@example.com", "--subject", "Hi", "--html", htmlFile, "--body", "Hello Bob (text)"})
+		cmd.SetOut(stdout)
+		cmd.SetErr(&bytes.Buffer{})
+
+		err := cmd.Execute()
+
+		require.NoError(t, err)
+		assert.Equal(t, []interface{}{map[string]interface{}{"partId": "html", "type": "text/html"}}, created["htmlBody"])
+		assert.Equal(t, []interface{}{map[string]interface{}{"partId": "text", "type": "text/plain"}}, created["textBody"])
+		assert.Equal(t, map[string]interface{}{
+			"html": map[string]interface{}{"value": "<p>Hello <b>Bob</b></p>"},
+			"text": map[string]interface{}{"value": "Hello Bob (text)"},
+		}, created["bodyValues"])
+	})
+
+	t.Run("generates a text part from --html alone", func(t *testing.T) {
+		f, stdout, _ := setupTest(t)
+
+		var created map[string]interface{}
+		httpmock.RegisterResponder("POST", "https://api.test.com/jmap/api", mockDraftCreate(&created))
+
+		cmd := NewCmdNew(f)
+		cmd.SetArgs([]string{"--to", "bob@example.com", "--subject", "Hi", "--html", htmlFile})
+		cmd.SetOut(stdout)
+		cmd.SetErr(&bytes.Buffer{})
+
+		err := cmd.Execute()
+
+		require.NoError(t, err)
+		assert.Contains(t, created, "htmlBody")
+		assert.Contains(t, created, "textBody")
+		bodyValues := created["bodyValues"].(map[string]interface{})
+		assert.Equal(t, map[string]interface{}{"value": "Hello Bob"}, bodyValues["text"])
+	})
+}
+
+func TestNewCommandReplyTo(t *testing.T) {
+	t.Run("sets replyTo on the draft", func(t *testing.T) {
+		f, _, _ := setupTest(t)
+
+		var created map[string]interface{}
+		httpmock.RegisterResponder("POST", "https://api.test.com/jmap/api", mockDraftCreate(&created))
+
+		cmd := NewCmdNew(f)
+		cmd.SetArgs([]string{"--to", "bob@example.com", "--subject", "Hi", "--from", "alias@example.com", "--reply-to", "me@example.com"})
+		cmd.SetOut(&bytes.Buffer{})
+		cmd.SetErr(&bytes.Buffer{})
+
+		err := cmd.Execute()
+
+		require.NoError(t, err)
+		assert.Equal(t, []interface{}{map[string]interface{}{"email": "me@example.com"}}, created["replyTo"])
+		assert.Equal(t, []interface{}{map[string]interface{}{"email": "alias@example.com"}}, created["from"])
+	})
+
+	t.Run("omits replyTo by default", func(t *testing.T) {
+		f, _, _ := setupTest(t)
+
+		var created map[string]interface{}
+		httpmock.RegisterResponder("POST", "https://api.test.com/jmap/api", mockDraftCreate(&created))
+
+		cmd := NewCmdNew(f)
+		cmd.SetArgs([]string{"--to", "bob@example.com", "--subject", "Hi"})
+		cmd.SetOut(&bytes.Buffer{})
+		cmd.SetErr(&bytes.Buffer{})
+
+		err := cmd.Execute()
+
+		require.NoError(t, err)
+		require.NotNil(t, created)
+		assert.NotContains(t, created, "replyTo")
+	})
+}
+
+func TestNewCommandSnippet(t *testing.T) {
+	t.Run("uses the snippet as the body", func(t *testing.T) {
+		f, _, _ := setupTest(t)
+		f.ConfigPath = filepath.Join(t.TempDir(), "config.yml")
+		store, err := f.Snippets()
+		require.NoError(t, err)
+		require.NoError(t, store.Save("invoice", "Please find the invoice attached."))
+
+		var created map[string]interface{}
+		httpmock.RegisterResponder("POST", "https://api.test.com/jmap/api", mockDraftCreate(&created))
+
+		cmd := NewCmdNew(f)
+		cmd.SetArgs([]string{"--to", "bob@example.com", "--subject", "Invoice", "--snippet", "invoice"})
+		cmd.SetOut(&bytes.Buffer{})
+		cmd.SetErr(&bytes.Buffer{})
+
+		err = cmd.Execute()
+
+		require.NoError(t, err)
+		bodyValues := created["bodyValues"].(map[string]interface{})
+		assert.Equal(t, "Please find the invoice attached.", bodyValues["text"].(map[string]interface{})["value"])
+	})
+
+	t.Run("appends the snippet to --body", func(t *testing.T) {
+		f, _, _ := setupTest(t)
+		f.ConfigPath = filepath.Join(t.TempDir(), "config.yml")
+		store, err := f.Snippets()
+		require.NoError(t, err)
+		require.NoError(t, store.Save("sig", "Cheers,\nMe"))
+
+		var created map[string]interface{}
+		httpmock.RegisterResponder("POST", "https://api.test.com/jmap/api", mockDraftCreate(&created))
+
+		cmd := NewCmdNew(f)
+		cmd.SetArgs([]string{"--to", "bob@example.com", "--subject", "Hi", "--body", "See you soon.", "--snippet", "sig"})
+		cmd.SetOut(&bytes.Buffer{})
+		cmd.SetErr(&bytes.Buffer{})
+
+		err = cmd.Execute()
+
+		require.NoError(t, err)
+		bodyValues := created["bodyValues"].(map[string]interface{})
+		assert.Equal(t, "See you soon.\n\nCheers,\nMe", bodyValues["text"].(map[string]interface{})["value"])
+	})
+
+	t.Run("errors on an unknown snippet", func(t *testing.T) {
+		f, _, _ := setupTest(t)
+		f.ConfigPath = filepath.Join(t.TempDir(), "config.yml")
+
+		cmd := NewCmdNew(f)
+		cmd.SetArgs([]string{"--to", "bob@example.com", "--subject", "Hi", "--snippet", "nope"})
+		cmd.SetOut(&bytes.Buffer{})
+		cmd.SetErr(&bytes.Buffer{})
+
+		err := cmd.Execute()
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `snippet "nope" not found`)
+	})
+}
+
+func TestNewCommandContactRecipients(t *testing.T) {
+	t.Run("resolves a unique contact name", func(t *testing.T) {
+		f, stdout, stderr := setupTest(t)
+		registerContactsSession()
+
+		var created map[string]interface{}
+		httpmock.RegisterResponder("POST", "https://api.test.com/jmap/api", mockDraftWithContacts(&created))
+
+		cmd := NewCmdNew(f)
+		cmd.SetArgs([]string{"--to", "bob", "--subject", "Hello"})
+		cmd.SetOut(stdout)
+		cmd.SetErr(&bytes.Buffer{})
+
+		err := cmd.Execute()
+
+		require.NoError(t, err)
+		assert.Equal(t, []interface{}{map[string]interface{}{"email": "bob@example.com"}}, created["to"])
+		assert.Contains(t, stderr.String(), `Resolved "bob" to bob@example.com`)
+	})
+
+	t.Run("prefers an exact name match", func(t *testing.T) {
+		f, _, _ := setupTest(t)
+		registerContactsSession()
+
+		var created map[string]interface{}
+		httpmock.RegisterResponder("POST", "https://api.test.com/jmap/api", mockDraftWithContacts(&created))
+
+		cmd := NewCmdNew(f)
+		cmd.SetArgs([]string{"--to", "alice smith", "--subject", "Hello"})
+		cmd.SetOut(&bytes.Buffer{})
+		cmd.SetErr(&bytes.Buffer{})
+
+		err := cmd.Execute()
+
+		require.NoError(t, err)
+		assert.Equal(t, []interface{}{map[string]interface{}{"email": "alice@example.com"}}, created["to"])
+	})
+
+	t.Run("errors on ambiguous name when not interactive", func(t *testing.T) {
+		f, _, _ := setupTest(t)
+		registerContactsSession()
+
+		var created map[string]interface{}
+		httpmock.RegisterResponder("POST", "https://api.test.com/jmap/api", mockDraftWithContacts(&created))
+
+		cmd := NewCmdNew(f)
+		cmd.SetArgs([]string{"--to", "Alice", "--subject", "Hello"})
+		cmd.SetOut(&bytes.Buffer{})
+		cmd.SetErr(&bytes.Buffer{})
+
+		err := cmd.Execute()
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "matches multiple contacts")
+		assert.Contains(t, err.Error(), "ajones@example.com")
+		assert.Nil(t, created)
+	})
+
+	t.Run("prompts on ambiguous name when interactive", func(t *testing.T) {
+		f, _, stderr := setupTest(t)
+		registerContactsSession()
+		f.IOStreams.SetStdinTTY(true)
+		f.IOStreams.SetStdoutTTY(true)
+		f.IOStreams.In = strings.NewReader("2\n")
+
+		var created map[string]interface{}
+		httpmock.RegisterResponder("POST", "https://api.test.com/jmap/api", mockDraftWithContacts(&created))
+
+		cmd := NewCmdNew(f)
+		cmd.SetArgs([]string{"--to", "Alice", "--subject", "Hello"})
+		cmd.SetOut(&bytes.Buffer{})
+		cmd.SetErr(&bytes.Buffer{})
+
+		err := cmd.Execute()
+
+		require.NoError(t, err)
+		assert.Contains(t, stderr.String(), "Multiple contacts match")
+		assert.Equal(t, []interface{}{map[string]interface{}{"email": "ajones@example.com"}}, created["to"])
+	})
+
+	t.Run("errors when no contact matches", func(t *testing.T) {
+		f, _, _ := setupTest(t)
+		registerContactsSession()
+
+		var created map[string]interface{}
+		httpmock.RegisterResponder("POST", "https://api.test.com/jmap/api", mockDraftWithContacts(&created))
+
+		cmd := NewCmdNew(f)
+		cmd.SetArgs([]string{"--to", "Carol", "--subject", "Hello"})
+		cmd.SetOut(&bytes.Buffer{})
+		cmd.SetErr(&bytes.Buffer{})
+
+		err := cmd.Execute()
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `no contact matches "Carol"`)
+	})
+
+	t.Run("resolves --cc and --bcc names too", func(t *testing.T) {
+		f, _, _ := setupTest(t)
+		registerContactsSession()
+
+		var created map[string]interface{}
+		httpmock.RegisterResponder("POST", "https://api.test.com/jmap/api", mockDraftWithContacts(&created))
+
+		cmd := NewCmdNew(f)
+		cmd.SetArgs([]string{"--to", "carol@example.com", "--cc", "Bob Brown", "--bcc", "alice smith", "--subject", "Hello"})
+		cmd.SetOut(&bytes.Buffer{})
+		cmd.SetErr(&bytes.Buffer{})
+
+		err := cmd.Execute()
+
+		require.NoError(t, err)
+		assert.Equal(t, []interface{}{map[string]interface{}{"email": "carol@example.com"}}, created["to"])
+		assert.Equal(t, []interface{}{map[string]interface{}{"email": "bob@example.com"}}, created["cc"])
+		assert.Equal(t, []interface{}{map[string]interface{}{"email": "alice@example.com"}}, created["bcc"])
+	})
+
+	t.Run("errors on an ambiguous --cc name when not interactive", func(t *testing.T) {
+		f, _, _ := setupTest(t)
+		registerContactsSession()
+
+		var created map[string]interface{}
+		httpmock.RegisterResponder("POST", "https://api.test.com/jmap/api", mockDraftWithContacts(&created))
+
+		cmd := NewCmdNew(f)
+		cmd.SetArgs([]string{"--to", "bob@example.com", "--cc", "Alice", "--subject", "Hello"})
+		cmd.SetOut(&bytes.Buffer{})
+		cmd.SetErr(&bytes.Buffer{})
+
+		err := cmd.Execute()
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "matches multiple contacts")
+		assert.Nil(t, created)
+	})
+
+	t.Run("keeps display names", func(t *testing.T) {
+		f, _, _ := setupTest(t)
+
+		var created map[string]interface{}
+		httpmock.RegisterResponder("POST", "https://api.test.com/jmap/api", mockDraftWithContacts(&created))
+
+		cmd := NewCmdNew(f)
+		cmd.SetArgs([]string{"--to", `"Alice Smith" <alice@example.com>`, "--cc", "Bob Brown <bob@example.com>", "--subject", "Hello"})
+		cmd.SetOut(&bytes.Buffer{})
+		cmd.SetErr(&bytes.Buffer{})
+
+		err := cmd.Execute()
+
+		require.NoError(t, err)
+		assert.Equal(t, []interface{}{map[string]interface{}{"name": "Alice Smith", "email": "alice@example.com"}}, created["to"])
+		assert.Equal(t, []interface{}{map[string]interface{}{"name": "Bob Brown", "email": "bob@example.com"}}, created["cc"])
+	})
+}
+
+func TestForwardCommandContactRecipients(t *testing.T) {
+	f, stdout, _ := setupTest(t)
+	registerContactsSession()
+
+	var created map[string]interface{}
+	httpmock.RegisterResponder("POST", "https://api.test.com/jmap/api", mockDraftWithContacts(&created))
+
+	cmd := NewCmdForward(f)
+	cmd.SetArgs([]string{"email-1", "--to", "bob", "--cc", "alice smith"})
+	cmd.SetOut(stdout)
+	cmd.SetErr(&bytes.Buffer{})
+
+	err := cmd.Execute()
+
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{map[string]interface{}{"email": "bob@example.com"}}, created["to"])
+	assert.Equal(t, []interface{}{map[string]interface{}{"email": "alice@example.com"}}, created["cc"])
+	assert.Equal(t, "Fwd: Quarterly numbers", created["subject"])
+}
+
 // Reply command tests
 
 func TestReplyCommand(t *testing.T) {
@@ -268,7 +735,7 @@ func TestReplyCommand(t *testing.T) {
 		assert.Contains(t, stdout.String(), "Reply draft created")
 	})
 
-	t.Run("requires --body or --body-file", func(t *testing.T) {
+	t.Run("requires --body, --body-file, or --snippet", func(t *testing.T) {
 		f, _, _ := setupTest(t)
 
 		cmd := NewCmdReply(f)
@@ -279,7 +746,7 @@ func TestReplyCommand(t *testing.T) {
 		err := cmd.Execute()
 
 		require.Error(t, err)
-		assert.Contains(t, err.Error(), "--body or --body-file required")
+		assert.Contains(t, err.Error(), "--body, --body-file, or --snippet required")
 	})
 
 	t.Run("requires email ID argument", func(t *testing.T) {
@@ -296,6 +763,154 @@ func TestReplyCommand(t *testing.T) {
 	})
 }
 
+// mockReplyWithAttachment serves a reply to an original email carrying one
+// attachment, recording the created draft.
+func mockReplyWithAttachment(created *map[string]interface{}) httpmock.Responder {
+	createDraft := mockDraftCreate(created)
+	return func(req *http.Request) (*http.Response, error) {
+		body, _ := io.ReadAll(req.Body)
+		req.Body = io.NopCloser(bytes.NewReader(body))
+
+		var jmapReq jmap.Request
+		json.Unmarshal(body, &jmapReq)
+
+		if jmapReq.MethodCalls[0][0].(string) != "Email/get" {
+			return createDraft(req)
+		}
+		return httpmock.NewJsonResponse(200, map[string]interface{}{
+			"methodResponses": [][]interface{}{
+				{"Email/get", map[string]interface{}{
+					"list": []map[string]interface{}{
+						{
+							"id":        "original-1",
+							"subject":   "Contract",
+							"from":      []map[string]string{{"email": "alice@example.com"}},
+							"to":        []map[string]string{{"email": "me@example.com"}},
+							"messageId": []string{"<msg-1@example.com>"},
+							"attachments": []map[string]interface{}{
+								{"partId": "2", "blobId": "blob-contract", "name": "contract.pdf", "type": "application/pdf", "size": 1234},
+							},
+						},
+					},
+				}, "email"},
+			},
+		})
+	}
+}
+
+func TestReplyCommandSignature(t *testing.T) {
+	// mockSigned serves an original whose text body ends in a signature.
+	mockSigned := func(created *map[string]interface{}) httpmock.Responder {
+		createDraft := mockDraftCreate(created)
+		return func(req *http.Request) (*http.Response, error) {
+			body, _ := io.ReadAll(req.Body)
+			req.Body = io.NopCloser(bytes.NewReader(body))
+
+			var jmapReq jmap.Request
+			json.Unmarshal(body, &jmapReq)
+
+			if jmapReq.MethodCalls[0][0].(string) != "Email/get" {
+				return createDraft(req)
+			}
+			return httpmock.NewJsonResponse(200, map[string]interface{}{
+				"methodResponses": [][]interface{}{
+					{"Email/get", map[string]interface{}{
+						"list": []map[string]interface{}{
+							{
+								"id":         "original-1",
+								"subject":    "Lunch",
+								"from":       []map[string]string{{"email": "alice@example.com"}},
+								"textBody":   []map[string]string{{"partId": "1", "type": "text/plain"}},
+								"bodyValues": map[string]interface{}{"1": map[string]string{"value": "Noon works.\n-- \nAlice\nACME Corp"}},
+							},
+						},
+					}, "email"},
+				},
+			})
+		}
+	}
+
+	quotedText := func(created map[string]interface{}) string {
+		bodyValues := created["bodyValues"].(map[string]interface{})
+		return bodyValues["text"].(map[string]interface{})["value"].(string)
+	}
+
+	t.Run("strips the quoted signature by default", func(t *testing.T) {
+		f, _, _ := setupTest(t)
+
+		var created map[string]interface{}
+		httpmock.RegisterResponder("POST", "https://api.test.com/jmap/api", mockSigned(&created))
+
+		cmd := NewCmdReply(f)
+		cmd.SetArgs([]string{"original-1", "--body", "See you."})
+		cmd.SetOut(&bytes.Buffer{})
+		cmd.SetErr(&bytes.Buffer{})
+
+		err := cmd.Execute()
+
+		require.NoError(t, err)
+		text := quotedText(created)
+		assert.Contains(t, text, "> Noon works.")
+		assert.NotContains(t, text, "ACME Corp")
+	})
+
+	t.Run("keeps it with --no-strip-signature", func(t *testing.T) {
+		f, _, _ := setupTest(t)
+
+		var created map[string]interface{}
+		httpmock.RegisterResponder("POST", "https://api.test.com/jmap/api", mockSigned(&created))
+
+		cmd := NewCmdReply(f)
+		cmd.SetArgs([]string{"original-1", "--body", "See you.", "--no-strip-signature"})
+		cmd.SetOut(&bytes.Buffer{})
+		cmd.SetErr(&bytes.Buffer{})
+
+		err := cmd.Execute()
+
+		require.NoError(t, err)
+		assert.Contains(t, quotedText(created), "> -- \n> Alice\n> ACME Corp")
+	})
+}
+
+func TestReplyCommandKeepAttachments(t *testing.T) {
+	t.Run("references original attachment blobs", func(t *testing.T) {
+		f, _, _ := setupTest(t)
+
+		var created map[string]interface{}
+		httpmock.RegisterResponder("POST", "https://api.test.com/jmap/api", mockReplyWithAttachment(&created))
+
+		cmd := NewCmdReply(f)
+		cmd.SetArgs([]string{"original-1", "--body", "Signed.", "--keep-attachments"})
+		cmd.SetOut(&bytes.Buffer{})
+		cmd.SetErr(&bytes.Buffer{})
+
+		err := cmd.Execute()
+
+		require.NoError(t, err)
+		assert.Equal(t, []interface{}{
+			map[string]interface{}{"blobId": "blob-contract", "type": "application/pdf", "name": "contract.pdf"},
+		}, created["attachments"])
+	})
+
+	t.Run("drops attachments by default", func(t *testing.T) {
+		f, _, _ := setupTest(t)
+
+		var created map[string]interface{}
+		httpmock.RegisterResponder("POST", "https://api.test.com/jmap/api", mockReplyWithAttachment(&created))
+
+		cmd := NewCmdReply(f)
+		cmd.SetArgs([]string{"original-1", "--body", "Thanks."})
+		cmd.SetOut(&bytes.Buffer{})
+		cmd.SetErr(&bytes.Buffer{})
+
+		err := cmd.Execute()
+
+		require.NoError(t, err)
+		require.NotNil(t, created)
+		assert.NotContains(t, created, "attachments")
+	})
+}
+
 // Send command tests
 
 func TestSendCommand(t *testing.T) {
@@ -314,6 +929,38 @@ func TestSendCommand(t *testing.T) {
 		assert.ErrorAs(t, err, &safeModeErr)
 	})
 
+	t.Run("fails up front without the submission capability", func(t *testing.T) {
+		f, _, _ := setupTest(t)
+
+		httpmock.RegisterResponder("GET", "https://api.test.com/jmap/session",
+			httpmock.NewJsonResponderOrPanic(200, map[string]interface{}{
+				"apiUrl": "https://api.test.com/jmap/api",
+				"accounts": map[string]interface{}{
+					"account-1": map[string]interface{}{},
+				},
+				"capabilities": map[string]interface{}{
+					jmap.CoreCapability: map[string]interface{}{},
+					jmap.MailCapability: map[string]interface{}{},
+				},
+			}))
+		httpmock.RegisterResponder("POST", "https://api.test.com/jmap/api",
+			httpmock.NewStringResponder(400, "unexpected API call"))
+
+		cmd := NewCmdSend(f)
+		cmd.SetArgs([]string{"draft-1", "--unsafe", "--yes"})
+		cmd.SetOut(&bytes.Buffer{})
+		cmd.SetErr(&bytes.Buffer{})
+
+		err := cmd.Execute()
+
+		require.Error(t, err)
+		var capErr *jmap.CapabilityError
+		require.ErrorAs(t, err, &capErr)
+		assert.Equal(t, jmap.SubmissionCapability, capErr.Capability)
+		assert.Contains(t, err.Error(), "your account doesn't support sending email")
+		assert.Zero(t, httpmock.GetCallCountInfo()["POST https://api.test.com/jmap/api"])
+	})
+
 	t.Run("sends draft with --unsafe and --yes", func(t *testing.T) {
 		f, stdout, _ := setupTest(t)
 
@@ -495,6 +1142,61 @@ func TestDeleteCommand(t *testing.T) {
 		assert.Contains(t, stdout.String(), "Draft deleted")
 	})
 
+	t.Run("moves multiple drafts to trash in one update", func(t *testing.T) {
+		f, stdout, stderr := setupTest(t)
+
+		var update map[string]interface{}
+		httpmock.RegisterResponder("POST", "https://api.test.com/jmap/api",
+			func(req *http.Request) (*http.Response, error) {
+				var jmapReq jmap.Request
+				json.NewDecoder(req.Body).Decode(&jmapReq)
+
+				method := jmapReq.MethodCalls[0][0].(string)
+				args := jmapReq.MethodCalls[0][1].(map[string]interface{})
+
+				switch method {
+				case "Mailbox/get":
+					return httpmock.NewJsonResponse(200, map[string]interface{}{
+						"methodResponses": [][]interface{}{
+							{"Mailbox/get", map[string]interface{}{
+								"list": []map[string]interface{}{
+									{"id": "trash-1", "name": "Trash", "role": "trash"},
+								},
+							}, "mailboxes"},
+						},
+					})
+				case "Email/set":
+					assert.NotContains(t, args, "destroy")
+					update = args["update"].(map[string]interface{})
+					return httpmock.NewJsonResponse(200, map[string]interface{}{
+						"methodResponses": [][]interface{}{
+							{"Email/set", map[string]interface{}{
+								"updated": map[string]interface{}{"draft-1": nil, "draft-2": nil},
+								"notUpdated": map[string]interface{}{
+									"draft-3": map[string]interface{}{"type": "notFound"},
+								},
+							}, "bulkMove"},
+						},
+					})
+				default:
+					return httpmock.NewStringResponse(400, "unexpected: "+method), nil
+				}
+			})
+
+		cmd := NewCmdDraftDelete(f)
+		cmd.SetArgs([]string{"draft-1", "draft-2", "draft-3", "--unsafe", "--yes"})
+		cmd.SetOut(stdout)
+		cmd.SetErr(&bytes.Buffer{})
+
+		err := cmd.Execute()
+
+		require.NoError(t, err)
+		require.Len(t, update, 3)
+		assert.Equal(t, map[string]interface{}{"mailboxIds": map[string]interface{}{"trash-1": true}}, update["draft-1"])
+		assert.Contains(t, stdout.String(), "Deleted 2 drafts. Failed: 1")
+		assert.Contains(t, stderr.String(), "Failed: draft-3")
+	})
+
 	t.Run("requires draft ID argument", func(t *testing.T) {
 		f := &cmdutil.Factory{}
 		cmd := NewCmdDraftDelete(f)
@@ -27,13 +27,19 @@ func NewCmdForward(f *cmdutil.Factory) *cobra.Command {
 		Long: `Create a forward draft with the original message.
 
 The forwarded message includes the original headers and body. Any attachments
-from the original email are also included.`,
+from the original email are also included.
+
+Recipients without an '@' are looked up by name in your contacts, as with
+'fm draft new'.`,
 		Example: `  # Forward to someone
   fm draft forward M1234567890 --to bob@example.com
 
   # Forward with an introduction
   fm draft forward M1234567890 --to bob@example.com --body "FYI, see below"
 
+  # Forward to a contact by name
+  fm draft forward M1234567890 --to "Bob Jones"
+
   # Forward to multiple recipients
   fm draft forward M1234567890 --to alice@example.com --to bob@example.com`,
 		Args: cmdutil.ExactArgs(1, "email ID required\n\nUsage: fm draft forward <email-id> --to <recipient>"),
@@ -42,8 +48,8 @@ from the original email are also included.`,
 		},
 	}
 
-	cmd.Flags().StringArrayVar(&opts.To, "to", nil, "Recipient email address (can be repeated)")
-	cmd.Flags().StringArrayVar(&opts.CC, "cc", nil, "CC recipient (can be repeated)")
+	cmd.Flags().StringArrayVar(&opts.To, "to", nil, "Recipient email address or contact name (can be repeated)")
+	cmd.Flags().StringArrayVar(&opts.CC, "cc", nil, "CC recipient email address or contact name (can be repeated)")
 	cmd.Flags().StringVar(&opts.Body, "body", "", "Introduction text before forwarded message")
 	cmd.Flags().StringVar(&opts.BodyFile, "body-file", "", "Read introduction from file")
 	cmd.Flags().StringVar(&opts.From, "from", "", "Sender email (default: primary identity)")
@@ -73,10 +79,20 @@ func runForward(f *cmdutil.Factory, opts *forwardOptions, emailID string) error
 		return err
 	}
 
+	resolver := newRecipientResolver(f, client)
+	to, err := resolver.resolveRecipients(opts.To)
+	if err != nil {
+		return err
+	}
+	cc, err := resolver.resolveRecipients(opts.CC)
+	if err != nil {
+		return err
+	}
+
 	draftID, err := client.CreateForwardDraft(jmap.ForwardOptions{
 		EmailID: emailID,
-		To:      opts.To,
-		CC:      opts.CC,
+		To:      to,
+		CC:      cc,
 		Body:    body,
 		From:    opts.From,
 	})
@@ -13,9 +13,12 @@ type newOptions struct {
 	To       []string
 	CC       []string
 	BCC      []string
+	ReplyTo  []string
 	Subject  string
 	Body     string
 	BodyFile string
+	HTMLFile string
+	Snippet  string
 	From     string
 }
 
@@ -29,7 +32,12 @@ func NewCmdNew(f *cmdutil.Factory) *cobra.Command {
 		Long: `Create a new draft email.
 
 The draft will be saved to your Drafts folder. You can then edit it
-in Fastmail or send it with 'fm draft send'.`,
+in Fastmail or send it with 'fm draft send'.
+
+Recipients without an '@' (in --to, --cc, --bcc, or --reply-to) are looked
+up by name in your contacts. If several contacts match you are asked to
+choose; in non-interactive mode this is an error and an email address must
+be given instead.`,
 		Example: `  # Create a simple draft
   fm draft new --to bob@example.com --subject "Hello" --body "Hi Bob!"
 
@@ -39,20 +47,38 @@ in Fastmail or send it with 'fm draft send'.`,
   # Create with body from file
   fm draft new --to bob@example.com --subject "Report" --body-file report.txt
 
+  # Create with an HTML body (a plain-text part is generated from it)
+  fm draft new --to bob@example.com --subject "Newsletter" --html newsletter.html
+
+  # Use a saved snippet as the body
+  fm draft new --to bob@example.com --subject "Invoice" --snippet invoice
+
   # Create with CC
-  fm draft new --to bob@example.com --cc manager@example.com --subject "Update"`,
+  fm draft new --to bob@example.com --cc manager@example.com --subject "Update"
+
+  # Include a display name
+  fm draft new --to "Bob Smith <bob@example.com>" --subject "Hello"
+
+  # Send from an alias but have replies go to your main address
+  fm draft new --from alias@example.com --reply-to me@example.com --to bob@example.com --subject "Hi"
+
+  # Address a contact by name
+  fm draft new --to "Alice" --cc "Bob Jones" --subject "Lunch?"`,
 		Args: cobra.NoArgs,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return runNew(f, opts)
 		},
 	}
 
-	cmd.Flags().StringArrayVar(&opts.To, "to", nil, "Recipient email address (can be repeated)")
-	cmd.Flags().StringArrayVar(&opts.CC, "cc", nil, "CC recipient (can be repeated)")
-	cmd.Flags().StringArrayVar(&opts.BCC, "bcc", nil, "BCC recipient (can be repeated)")
+	cmd.Flags().StringArrayVar(&opts.To, "to", nil, "Recipient email address or contact name (can be repeated)")
+	cmd.Flags().StringArrayVar(&opts.CC, "cc", nil, "CC recipient email address or contact name (can be repeated)")
+	cmd.Flags().StringArrayVar(&opts.BCC, "bcc", nil, "BCC recipient email address or contact name (can be repeated)")
+	cmd.Flags().StringArrayVar(&opts.ReplyTo, "reply-to", nil, "Address replies should go to (can be repeated)")
 	cmd.Flags().StringVar(&opts.Subject, "subject", "", "Email subject")
 	cmd.Flags().StringVar(&opts.Body, "body", "", "Email body text")
 	cmd.Flags().StringVar(&opts.BodyFile, "body-file", "", "Read body from file")
+	cmd.Flags().StringVar(&opts.HTMLFile, "html", "", "Read an HTML body from `file`")
+	cmd.Flags().StringVar(&opts.Snippet, "snippet", "", "Insert the snippet `name` as the body, or after --body")
 	cmd.Flags().StringVar(&opts.From, "from", "", "Sender email (default: primary identity)")
 
 	_ = cmd.MarkFlagRequired("to")
@@ -79,17 +105,56 @@ func runNew(f *cmdutil.Factory, opts *newOptions) error {
 		body = string(content)
 	}
 
+	body, err := withSnippet(f, opts.Snippet, body)
+	if err != nil {
+		return err
+	}
+
+	// With --html, the text body is used as the plain-text alternative, or
+	// generated from the HTML if none was given
+	var htmlBody string
+	if opts.HTMLFile != "" {
+		content, err := os.ReadFile(opts.HTMLFile)
+		if err != nil {
+			return fmt.Errorf("failed to read HTML file: %w", err)
+		}
+		htmlBody = string(content)
+		if body == "" {
+			body = cmdutil.HTMLToText(htmlBody)
+		}
+	}
+
 	client, err := f.JMAPClient()
 	if err != nil {
 		return err
 	}
 
+	resolver := newRecipientResolver(f, client)
+	to, err := resolver.resolveRecipients(opts.To)
+	if err != nil {
+		return err
+	}
+	cc, err := resolver.resolveRecipients(opts.CC)
+	if err != nil {
+		return err
+	}
+	bcc, err := resolver.resolveRecipients(opts.BCC)
+	if err != nil {
+		return err
+	}
+	replyTo, err := resolver.resolveRecipients(opts.ReplyTo)
+	if err != nil {
+		return err
+	}
+
 	draftID, err := client.SaveDraft(jmap.DraftEmail{
-		To:       opts.To,
-		CC:       opts.CC,
-		BCC:      opts.BCC,
+		To:       to,
+		CC:       cc,
+		BCC:      bcc,
+		ReplyTo:  replyTo,
 		Subject:  opts.Subject,
 		TextBody: body,
+		HTMLBody: htmlBody,
 		From:     opts.From,
 	})
 	if err != nil {
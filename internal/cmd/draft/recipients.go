@@ -0,0 +1,101 @@
+package draft
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/marckohlbrugge/fastmail-cli/internal/cmdutil"
+	"github.com/marckohlbrugge/fastmail-cli/internal/jmap"
+)
+
+// recipientResolver turns contact names into email addresses.
+// Contacts are fetched once, on the first name that needs resolving.
+type recipientResolver struct {
+	f        *cmdutil.Factory
+	client   *jmap.Client
+	contacts []jmap.Contact
+	loaded   bool
+}
+
+func newRecipientResolver(f *cmdutil.Factory, client *jmap.Client) *recipientResolver {
+	return &recipientResolver{f: f, client: client}
+}
+
+// resolveRecipients resolves every entry that doesn't look like an email
+// address, leaving addresses untouched.
+func (r *recipientResolver) resolveRecipients(recipients []string) ([]string, error) {
+	resolved := make([]string, 0, len(recipients))
+	for _, recipient := range recipients {
+		addr, err := r.resolve(recipient)
+		if err != nil {
+			return nil, err
+		}
+		resolved = append(resolved, addr)
+	}
+	return resolved, nil
+}
+
+func (r *recipientResolver) resolve(recipient string) (string, error) {
+	if strings.Contains(recipient, "@") {
+		return recipient, nil
+	}
+
+	if !r.loaded {
+		contacts, err := r.client.GetContacts()
+		if errors.Is(err, jmap.ErrContactsUnavailable) {
+			return "", fmt.Errorf("cannot look up %q: %w; use an email address instead", recipient, err)
+		}
+		if err != nil {
+			return "", err
+		}
+		r.contacts = contacts
+		r.loaded = true
+	}
+
+	matches := cmdutil.MatchContacts(r.contacts, recipient)
+	switch len(matches) {
+	case 0:
+		return "", fmt.Errorf("no contact matches %q; use an email address instead", recipient)
+	case 1:
+		if !r.f.Quiet {
+			fmt.Fprintf(r.f.IOStreams.ErrOut, "Resolved %q to %s\n", recipient, matches[0].PrimaryEmail())
+		}
+		return matches[0].PrimaryEmail(), nil
+	}
+
+	if !r.f.IOStreams.IsInteractive() || r.f.Quiet {
+		var candidates []string
+		for _, c := range matches {
+			candidates = append(candidates, fmt.Sprintf("%s <%s>", c.FullName(), c.PrimaryEmail()))
+		}
+		return "", fmt.Errorf("%q matches multiple contacts: %s\n\nUse an email address instead.", recipient, strings.Join(candidates, ", "))
+	}
+
+	return r.choose(recipient, matches)
+}
+
+// choose prompts the user to pick one of several matching contacts.
+func (r *recipientResolver) choose(recipient string, matches []jmap.Contact) (string, error) {
+	errOut := r.f.IOStreams.ErrOut
+
+	fmt.Fprintf(errOut, "Multiple contacts match %q:\n", recipient)
+	for i, c := range matches {
+		fmt.Fprintf(errOut, "  %d. %s <%s>\n", i+1, c.FullName(), c.PrimaryEmail())
+	}
+	fmt.Fprintf(errOut, "Choose a contact [1-%d]: ", len(matches))
+
+	scanner := bufio.NewScanner(r.f.IOStreams.In)
+	response := ""
+	if scanner.Scan() {
+		response = strings.TrimSpace(scanner.Text())
+	}
+
+	n, err := strconv.Atoi(response)
+	if err != nil || n < 1 || n > len(matches) {
+		return "", cmdutil.CancelError
+	}
+	return matches[n-1].PrimaryEmail(), nil
+}
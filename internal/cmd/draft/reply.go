@@ -5,13 +5,17 @@ import (
 	"os"
 
 	"github.com/marckohlbrugge/fastmail-cli/internal/cmdutil"
+	"github.com/marckohlbrugge/fastmail-cli/internal/jmap"
 	"github.com/spf13/cobra"
 )
 
 type replyOptions struct {
-	Body     string
-	BodyFile string
-	All      bool
+	Body            string
+	BodyFile        string
+	Snippet         string
+	All             bool
+	KeepAttachments bool
+	KeepSignature   bool
 }
 
 // NewCmdReply creates the draft reply command.
@@ -24,15 +28,25 @@ func NewCmdReply(f *cmdutil.Factory) *cobra.Command {
 		Long: `Create a draft reply to an email.
 
 Automatically sets the recipient, subject (with Re: prefix), and threading
-headers for proper conversation grouping.`,
+headers for proper conversation grouping.
+
+Attachments on the original email are dropped unless --keep-attachments
+is given. The quoted original is cut at its signature delimiter ("-- " on a
+line of its own) unless --no-strip-signature is given.`,
 		Example: `  # Reply with body text
   fm draft reply M1234567890 --body "Thanks for your email!"
 
   # Reply with body from file
   fm draft reply M1234567890 --body-file response.txt
 
+  # Reply with a saved snippet
+  fm draft reply M1234567890 --snippet thanks
+
   # Reply-all to include all recipients
-  fm draft reply M1234567890 --all --body "Thanks everyone!"`,
+  fm draft reply M1234567890 --all --body "Thanks everyone!"
+
+  # Reply and keep the original attachments
+  fm draft reply M1234567890 --keep-attachments --body "Signed copy attached."`,
 		Args: cmdutil.ExactArgs(1, "email ID required\n\nUsage: fm draft reply <email-id>"),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return runReply(f, opts, args[0])
@@ -41,7 +55,10 @@ headers for proper conversation grouping.`,
 
 	cmd.Flags().StringVar(&opts.Body, "body", "", "Reply body text")
 	cmd.Flags().StringVar(&opts.BodyFile, "body-file", "", "Read body from file")
+	cmd.Flags().StringVar(&opts.Snippet, "snippet", "", "Insert the snippet `name` as the body, or after --body")
 	cmd.Flags().BoolVar(&opts.All, "all", false, "Reply to all recipients")
+	cmd.Flags().BoolVar(&opts.KeepAttachments, "keep-attachments", false, "Attach the original email's attachments")
+	cmd.Flags().BoolVar(&opts.KeepSignature, "no-strip-signature", false, "Quote the original's signature too")
 
 	return cmd
 }
@@ -57,8 +74,13 @@ func runReply(f *cmdutil.Factory, opts *replyOptions, emailID string) error {
 		body = string(content)
 	}
 
+	body, err := withSnippet(f, opts.Snippet, body)
+	if err != nil {
+		return err
+	}
+
 	if body == "" {
-		return cmdutil.FlagErrorf("--body or --body-file required")
+		return cmdutil.FlagErrorf("--body, --body-file, or --snippet required")
 	}
 
 	client, err := f.JMAPClient()
@@ -66,7 +88,13 @@ func runReply(f *cmdutil.Factory, opts *replyOptions, emailID string) error {
 		return err
 	}
 
-	draftID, err := client.CreateReplyDraft(emailID, body, opts.All)
+	draftID, err := client.CreateReplyDraft(jmap.ReplyOptions{
+		EmailID:         emailID,
+		Body:            body,
+		ReplyAll:        opts.All,
+		KeepAttachments: opts.KeepAttachments,
+		KeepSignature:   opts.KeepSignature,
+	})
 	if err != nil {
 		return err
 	}
@@ -1,9 +1,7 @@
 package draft
 
 import (
-	"bufio"
 	"fmt"
-	"strings"
 
 	"github.com/marckohlbrugge/fastmail-cli/internal/cmdutil"
 	"github.com/marckohlbrugge/fastmail-cli/internal/jmap"
@@ -60,6 +58,10 @@ func runSend(f *cmdutil.Factory, opts *sendOptions, draftID string) error {
 		return err
 	}
 
+	if err := client.RequireCapability(jmap.SubmissionCapability); err != nil {
+		return err
+	}
+
 	// Get draft info for confirmation
 	draft, err := client.GetEmailByID(draftID)
 	if err != nil {
@@ -73,19 +75,15 @@ func runSend(f *cmdutil.Factory, opts *sendOptions, draftID string) error {
 
 	// Require confirmation unless --yes
 	if !opts.Yes {
+		if f.Quiet {
+			return cmdutil.QuietConfirmError
+		}
+
 		showSendConfirmation(f, draft)
 
 		if f.IOStreams.IsInteractive() {
-			fmt.Fprintf(f.IOStreams.ErrOut, "Send this email? [y/N] ")
-
-			scanner := bufio.NewScanner(f.IOStreams.In)
-			response := ""
-			if scanner.Scan() {
-				response = scanner.Text()
-			}
-
-			if !strings.HasPrefix(strings.ToLower(strings.TrimSpace(response)), "y") {
-				return cmdutil.CancelError
+			if ok, err := cmdutil.Confirm(f.IOStreams, "Send this email?"); !ok {
+				return err
 			}
 		} else {
 			// Non-interactive but --unsafe was provided, still need --yes
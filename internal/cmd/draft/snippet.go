@@ -0,0 +1,29 @@
+package draft
+
+import (
+	"github.com/marckohlbrugge/fastmail-cli/internal/cmdutil"
+)
+
+// withSnippet returns body with the named snippet added: the snippet is the
+// whole body if body is empty, and is appended after a blank line otherwise.
+// An empty name returns body unchanged.
+func withSnippet(f *cmdutil.Factory, name, body string) (string, error) {
+	if name == "" {
+		return body, nil
+	}
+
+	store, err := f.Snippets()
+	if err != nil {
+		return "", err
+	}
+
+	text, err := store.Get(name)
+	if err != nil {
+		return "", err
+	}
+
+	if body == "" {
+		return text, nil
+	}
+	return body + "\n\n" + text, nil
+}
@@ -0,0 +1,212 @@
+package email
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/marckohlbrugge/fastmail-cli/internal/cmdutil"
+	"github.com/marckohlbrugge/fastmail-cli/internal/jmap"
+	"github.com/spf13/cobra"
+)
+
+// applyActions are the actions fm email apply can run over a query.
+var applyActions = []string{"archive", "delete", "move", "mark-read", "mark-unread"}
+
+type applyOptions struct {
+	Query  string
+	Action string
+	To     string
+	Limit  int
+	DryRun bool
+	Yes    bool
+	Unsafe bool
+}
+
+// NewCmdApply creates the email apply command.
+func NewCmdApply(f *cmdutil.Factory) *cobra.Command {
+	opts := &applyOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "apply --query <query> --action <action>",
+		Short: "Run an action on every email matching a query",
+		Long: `Run an action on every email matching a search query.
+
+The query uses the same syntax as 'fm search'. Matching emails are counted
+and, after confirmation, updated in a single batch. At most --limit emails
+are affected, newest first.
+
+Actions:
+  archive       - Move to Archive
+  delete        - Move to Trash
+  move          - Move to the folder given by --to
+  mark-read     - Mark as read
+  mark-unread   - Mark as unread
+
+Because it can touch many emails at once, this command is blocked in
+non-interactive mode (scripts, AI) unless --unsafe is specified. --dry-run
+lists the matching emails without changing anything, and is allowed
+without --unsafe.`,
+		Example: `  # Archive newsletters older than a month
+  fm email apply --query "from:newsletter older:30d" --action archive
+
+  # Move receipts into a folder
+  fm email apply --query "subject:receipt" --action move --to Receipts
+
+  # See which emails in a folder a query would delete (in: takes a folder
+  # ID, as listed by 'fm folders')
+  fm email apply --query "older:365d in:abc123def456" --action delete --dry-run
+
+  # Mark everything from a noisy list as read without prompting
+  fm email apply --query "from:alerts@example.com" --action mark-read --yes`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runApply(f, opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.Query, "query", "", "Search `query` selecting the emails (required)")
+	cmd.Flags().StringVar(&opts.Action, "action", "", "Action to run: "+strings.Join(applyActions, ", ")+" (required)")
+	cmd.Flags().StringVar(&opts.To, "to", "", "Destination `folder` for --action move")
+	cmd.Flags().IntVar(&opts.Limit, "limit", 50, "Maximum emails to affect (max 500)")
+	cmd.Flags().BoolVar(&opts.DryRun, "dry-run", false, "List the matching emails without changing them")
+	cmd.Flags().BoolVar(&opts.Yes, "yes", false, "Skip confirmation prompt")
+	cmd.Flags().BoolVar(&opts.Unsafe, "unsafe", false, "Allow in non-interactive mode")
+	_ = cmd.MarkFlagRequired("query")
+	_ = cmd.MarkFlagRequired("action")
+	_ = cmd.RegisterFlagCompletionFunc("action", cobra.FixedCompletions(applyActions, cobra.ShellCompDirectiveNoFileComp))
+	_ = cmd.RegisterFlagCompletionFunc("to", cmdutil.CompleteFolders(f))
+
+	return cmd
+}
+
+func runApply(f *cmdutil.Factory, opts *applyOptions) error {
+	// An empty filter would match every email
+	if strings.TrimSpace(opts.Query) == "" {
+		return cmdutil.FlagErrorf("--query cannot be empty")
+	}
+	if err := cmdutil.ValidateBulkLimit(opts.Limit); err != nil {
+		return err
+	}
+	if !slices.Contains(applyActions, opts.Action) {
+		return cmdutil.FlagErrorf("invalid action %q: use one of %s", opts.Action, strings.Join(applyActions, ", "))
+	}
+	if opts.Action == "move" && opts.To == "" {
+		return cmdutil.FlagErrorf("--action move requires --to <folder>")
+	}
+	if opts.Action != "move" && opts.To != "" {
+		return cmdutil.FlagErrorf("--to can only be used with --action move")
+	}
+
+	// Check safe mode - bulk changes are critical, but a dry run changes nothing
+	if f.IOStreams.IsSafeMode() && !opts.Unsafe && !opts.DryRun {
+		return &cmdutil.SafeModeError{Command: "email apply"}
+	}
+
+	client, err := f.JMAPClient()
+	if err != nil {
+		return err
+	}
+
+	var mailbox *jmap.Mailbox
+	if opts.Action == "move" {
+		if mailbox, err = cmdutil.ResolveMailbox(f, client, opts.To); err != nil {
+			return err
+		}
+	}
+
+	ids, total, err := client.QueryEmailIDs(jmap.SearchFilters{Query: opts.Query, Limit: opts.Limit})
+	if err != nil {
+		return err
+	}
+
+	// List the same emails the real run would change
+	if opts.DryRun {
+		var emails []jmap.Email
+		if len(ids) > 0 {
+			if emails, err = client.GetEmailSummaries(ids); err != nil {
+				return err
+			}
+		}
+		printDryRun(f, emails, applyOutcome(opts.Action, mailbox))
+		return nil
+	}
+
+	if len(ids) == 0 {
+		fmt.Fprintf(f.IOStreams.Out, "No emails found matching: %s\n", opts.Query)
+		return nil
+	}
+
+	errOut := f.IOStreams.ErrOut
+	fmt.Fprintf(errOut, "%d emails match: %s\n", total, opts.Query)
+	if total > len(ids) {
+		fmt.Fprintf(errOut, "Only the newest %d will be affected; raise --limit to include more.\n", len(ids))
+	}
+
+	// Require confirmation unless --yes
+	if !opts.Yes {
+		if f.Quiet {
+			return cmdutil.QuietConfirmError
+		}
+		if !f.IOStreams.IsInteractive() {
+			return cmdutil.FlagErrorf("non-interactive mode requires --yes flag")
+		}
+
+		if ok, err := cmdutil.Confirm(f.IOStreams, fmt.Sprintf("Run %s on %d emails?", opts.Action, len(ids))); !ok {
+			return err
+		}
+	}
+
+	var updated int
+	var failed []string
+	var summary string
+
+	switch opts.Action {
+	case "archive":
+		updated, failed, err = client.ArchiveEmails(ids, false)
+		summary = fmt.Sprintf("Archived %d emails.", updated)
+	case "delete":
+		updated, failed, err = client.DeleteEmails(ids)
+		summary = fmt.Sprintf("Moved %d emails to Trash.", updated)
+	case "move":
+		updated, failed, err = client.MoveEmails(ids, mailbox.ID)
+		summary = fmt.Sprintf("Moved %d emails to %s.", updated, mailbox.Name)
+	case "mark-read":
+		updated, failed, err = client.SetKeyword(ids, "$seen", true)
+		summary = fmt.Sprintf("Marked %d emails as read.", updated)
+	case "mark-unread":
+		updated, failed, err = client.SetKeyword(ids, "$seen", false)
+		summary = fmt.Sprintf("Marked %d emails as unread.", updated)
+	}
+	if err != nil {
+		return err
+	}
+
+	out := f.IOStreams.Out
+	if len(failed) > 0 {
+		fmt.Fprintf(out, "%s Failed: %d\n", summary, len(failed))
+		for _, id := range failed {
+			fmt.Fprintf(errOut, "  Failed: %s\n", id)
+		}
+		return nil
+	}
+
+	fmt.Fprintln(out, summary)
+	return nil
+}
+
+// applyOutcome describes what an action does to an email, for --dry-run.
+func applyOutcome(action string, mailbox *jmap.Mailbox) string {
+	switch action {
+	case "archive":
+		return "archived"
+	case "delete":
+		return "moved to Trash"
+	case "move":
+		return "moved to " + mailbox.Name
+	case "mark-read":
+		return "marked read"
+	default:
+		return "marked unread"
+	}
+}
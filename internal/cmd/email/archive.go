@@ -2,63 +2,256 @@ package email
 
 import (
 	"fmt"
+	"sort"
+	"strings"
 
 	"github.com/marckohlbrugge/fastmail-cli/internal/cmdutil"
+	"github.com/marckohlbrugge/fastmail-cli/internal/jmap"
 	"github.com/spf13/cobra"
 )
 
+type archiveOptions struct {
+	Query    string
+	Limit    int
+	MarkRead bool
+	DryRun   bool
+	Yes      bool
+	Unsafe   bool
+}
+
 // NewCmdArchive creates the email archive command.
 func NewCmdArchive(f *cmdutil.Factory) *cobra.Command {
+	opts := &archiveOptions{}
+
 	cmd := &cobra.Command{
 		Use:   "archive <email-id>...",
 		Short: "Move emails to archive",
 		Long: `Move one or more emails to the Archive folder.
 
+If no IDs are given and stdin is a pipe, IDs are read from stdin.
+
+With --mark-read the emails are also marked read in the same update.
+
+With --query, every email matching a search query is archived instead. The
+query uses the same syntax as 'fm search'; at most --limit matches are
+archived, newest first. A summary of the matches and their senders is
+shown before asking for confirmation. In non-interactive mode (scripts,
+AI), --query requires both --yes and --unsafe.
+
+--dry-run lists the emails that would be archived without changing
+anything. It needs neither --yes nor --unsafe.
+
 This is a reversible action - emails can be moved back from Archive.`,
 		Example: `  # Archive a single email
   fm email archive M1234567890
 
   # Archive multiple emails
-  fm email archive M1234567890 M0987654321`,
-		Args: cmdutil.MinimumArgs(1, "at least one email ID required\n\nUsage: fm email archive <email-id>..."),
+  fm email archive M1234567890 M0987654321
+
+  # Archive and mark read in one step
+  fm email archive M1234567890 --mark-read
+
+  # Archive everything matching a query
+  fm email archive --query "from:newsletter older:30d"
+
+  # Preview what a query would archive
+  fm email archive --query "from:newsletter older:30d" --dry-run
+
+  # Archive search results, reading IDs from stdin
+  fm search "from:newsletter" --json id | jq -r '.[].id' | fm email archive`,
+		Args: cobra.ArbitraryArgs,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runArchive(f, args)
+			if cmd.Flags().Changed("query") {
+				if len(args) > 0 {
+					return cmdutil.FlagErrorf("cannot combine email IDs with --query")
+				}
+				return runArchiveQuery(f, opts)
+			}
+
+			ids, err := cmdutil.IDsOrStdin(f.IOStreams, args, "at least one email ID required\n\nUsage: fm email archive <email-id>...")
+			if err != nil {
+				return err
+			}
+			return runArchive(f, opts, ids)
 		},
 	}
 
+	cmd.Flags().StringVar(&opts.Query, "query", "", "Archive every email matching a search `query`")
+	cmd.Flags().IntVar(&opts.Limit, "limit", 50, "Maximum emails to archive with --query (max 500)")
+	cmd.Flags().BoolVar(&opts.MarkRead, "mark-read", false, "Also mark the emails as read")
+	cmd.Flags().BoolVar(&opts.DryRun, "dry-run", false, "List the emails that would be archived without archiving them")
+	cmd.Flags().BoolVar(&opts.Yes, "yes", false, "Skip confirmation prompt for --query")
+	cmd.Flags().BoolVar(&opts.Unsafe, "unsafe", false, "Allow --query in non-interactive mode")
+
 	return cmd
 }
 
-func runArchive(f *cmdutil.Factory, emailIDs []string) error {
+func runArchive(f *cmdutil.Factory, opts *archiveOptions, emailIDs []string) error {
 	client, err := f.JMAPClient()
 	if err != nil {
 		return err
 	}
 
-	out := f.IOStreams.Out
+	if opts.DryRun {
+		emails, err := client.GetEmailSummaries(emailIDs)
+		if err != nil {
+			return err
+		}
+		printDryRun(f, emails, archiveOutcome(opts))
+		return nil
+	}
 
-	if len(emailIDs) == 1 {
+	if len(emailIDs) == 1 && !opts.MarkRead {
 		if err := client.ArchiveEmail(emailIDs[0]); err != nil {
 			return err
 		}
-		fmt.Fprintln(out, "Moved to Archive.")
+		fmt.Fprintln(f.IOStreams.Out, "Moved to Archive.")
+		return nil
+	}
+
+	return archiveEmails(f, client, opts, emailIDs)
+}
+
+func runArchiveQuery(f *cmdutil.Factory, opts *archiveOptions) error {
+	// An empty filter would match every email
+	if strings.TrimSpace(opts.Query) == "" {
+		return cmdutil.FlagErrorf("--query cannot be empty")
+	}
+	if err := cmdutil.ValidateBulkLimit(opts.Limit); err != nil {
+		return err
+	}
+
+	// Check safe mode - archiving a whole query touches many emails, but a
+	// dry run changes nothing
+	if f.IOStreams.IsSafeMode() && !opts.Unsafe && !opts.DryRun {
+		return &cmdutil.SafeModeError{Command: "email archive --query"}
+	}
+
+	client, err := f.JMAPClient()
+	if err != nil {
+		return err
+	}
+
+	emails, err := client.Search(jmap.SearchFilters{Query: opts.Query, Limit: opts.Limit})
+	if err != nil {
+		return err
+	}
+
+	if len(emails) == 0 {
+		fmt.Fprintf(f.IOStreams.Out, "No emails found matching: %s\n", opts.Query)
 		return nil
 	}
 
-	// Bulk archive
-	archived, failed, err := client.ArchiveEmails(emailIDs)
+	if opts.DryRun {
+		printDryRun(f, emails, archiveOutcome(opts))
+		return nil
+	}
+
+	// --limit may leave matches out, so say how many there are in all
+	total, err := client.CountEmails(jmap.SearchFilters{Query: opts.Query})
+	if err != nil {
+		return err
+	}
+
+	errOut := f.IOStreams.ErrOut
+	senders := countSenders(emails)
+	noun := "senders"
+	if len(senders) == 1 {
+		noun = "sender"
+	}
+	if total > len(emails) {
+		fmt.Fprintf(errOut, "About to archive %d of %d matching emails from %d %s:\n", len(emails), total, len(senders), noun)
+	} else {
+		fmt.Fprintf(errOut, "About to archive %d emails from %d %s:\n", len(emails), len(senders), noun)
+	}
+	for i, s := range senders {
+		if i == 5 {
+			fmt.Fprintf(errOut, "  ... and %d more\n", len(senders)-i)
+			break
+		}
+		fmt.Fprintf(errOut, "  %4d  %s\n", s.count, s.address)
+	}
+
+	// Require confirmation unless --yes
+	if !opts.Yes {
+		if f.Quiet {
+			return cmdutil.QuietConfirmError
+		}
+		if !f.IOStreams.IsInteractive() {
+			return cmdutil.FlagErrorf("non-interactive mode requires --yes flag")
+		}
+
+		if ok, err := cmdutil.Confirm(f.IOStreams, "Archive these emails?"); !ok {
+			return err
+		}
+	}
+
+	ids := make([]string, len(emails))
+	for i, email := range emails {
+		ids[i] = email.ID
+	}
+
+	return archiveEmails(f, client, opts, ids)
+}
+
+func archiveEmails(f *cmdutil.Factory, client *jmap.Client, opts *archiveOptions, emailIDs []string) error {
+	archived, failed, err := client.ArchiveEmails(emailIDs, opts.MarkRead)
 	if err != nil {
 		return err
 	}
 
+	summary := fmt.Sprintf("Archived %d emails", archived)
+	if opts.MarkRead {
+		summary += " and marked them read"
+	}
+	summary += "."
+
+	out := f.IOStreams.Out
 	if len(failed) > 0 {
-		fmt.Fprintf(out, "Archived %d emails. Failed: %d\n", archived, len(failed))
+		fmt.Fprintf(out, "%s Failed: %d\n", summary, len(failed))
 		for _, id := range failed {
 			fmt.Fprintf(f.IOStreams.ErrOut, "  Failed: %s\n", id)
 		}
 		return nil
 	}
 
-	fmt.Fprintf(out, "Archived %d emails.\n", archived)
+	fmt.Fprintln(out, summary)
 	return nil
 }
+
+// archiveOutcome describes what archiving does to an email, for --dry-run.
+func archiveOutcome(opts *archiveOptions) string {
+	if opts.MarkRead {
+		return "archived and marked read"
+	}
+	return "archived"
+}
+
+type senderCount struct {
+	address string
+	count   int
+}
+
+// countSenders tallies emails by sender address, most frequent first.
+func countSenders(emails []jmap.Email) []senderCount {
+	counts := make(map[string]int)
+	for _, email := range emails {
+		address := "(unknown)"
+		if len(email.From) > 0 {
+			address = strings.ToLower(email.From[0].Email)
+		}
+		counts[address]++
+	}
+
+	senders := make([]senderCount, 0, len(counts))
+	for address, count := range counts {
+		senders = append(senders, senderCount{address: address, count: count})
+	}
+	sort.Slice(senders, func(i, j int) bool {
+		if senders[i].count != senders[j].count {
+			return senders[i].count > senders[j].count
+		}
+		return senders[i].address < senders[j].address
+	})
+	return senders
+}
@@ -0,0 +1,157 @@
+package email
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode"
+
+	"github.com/marckohlbrugge/fastmail-cli/internal/cmdutil"
+	"github.com/marckohlbrugge/fastmail-cli/internal/jmap"
+	"github.com/spf13/cobra"
+)
+
+type attachmentsOptions struct {
+	JSON bool
+}
+
+// NewCmdAttachments creates the email attachments command.
+func NewCmdAttachments(f *cmdutil.Factory) *cobra.Command {
+	opts := &attachmentsOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "attachments <email-id>",
+		Short: "List an email's attachments",
+		Long: `List an email's attachments with their name, type, size, and blob ID,
+without printing the rest of the email.
+
+The blob IDs identify each attachment's content, so scripts can pick out
+specific files to download.`,
+		Example: `  # Check whether the invoice PDF is attached
+  fm email attachments M1234567890
+
+  # List blob IDs of PDF attachments
+  fm email attachments M1234567890 --json | jq -r '.[] | select(.type == "application/pdf") | .blobId'`,
+		Args: cmdutil.ExactArgs(1, "email ID required\n\nUsage: fm email attachments <email-id>"),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runAttachments(f, opts, args[0])
+		},
+	}
+
+	cmd.Flags().BoolVar(&opts.JSON, "json", false, "Output in JSON format")
+
+	return cmd
+}
+
+func runAttachments(f *cmdutil.Factory, opts *attachmentsOptions, emailID string) error {
+	client, err := f.JMAPClient()
+	if err != nil {
+		return err
+	}
+
+	email, err := client.GetEmailByID(emailID)
+	if err != nil {
+		return err
+	}
+
+	attachments := email.Attachments
+	if opts.JSON {
+		if attachments == nil {
+			attachments = []jmap.Attachment{}
+		}
+		return cmdutil.WriteJSON(f.IOStreams.Out, attachments)
+	}
+
+	out := f.IOStreams.Out
+	if len(attachments) == 0 {
+		fmt.Fprintln(out, "No attachments.")
+		return nil
+	}
+
+	for _, att := range attachments {
+		name := att.Name
+		if name == "" {
+			name = att.PartID
+		}
+		fmt.Fprintf(out, "%-32s  %-24s  %10s  %s\n", name, att.Type, cmdutil.FormatBytes(att.Size), att.BlobID)
+	}
+	return nil
+}
+
+// saveAttachments downloads every attachment of email into dir, creating it if
+// needed, and returns the paths written. Existing files are never overwritten.
+func saveAttachments(client *jmap.Client, email *jmap.Email, dir string) ([]string, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create %s: %w", dir, err)
+	}
+
+	var paths []string
+	for _, att := range email.Attachments {
+		name := attachmentFileName(att)
+
+		data, err := client.DownloadBlob(att.BlobID, name, att.Type)
+		if err != nil {
+			return paths, err
+		}
+
+		path, err := writeNewFile(dir, name, data)
+		if err != nil {
+			return paths, err
+		}
+		paths = append(paths, path)
+	}
+
+	return paths, nil
+}
+
+// attachmentFileName returns a safe file name for an attachment. Directory
+// parts and control characters are stripped so a crafted name cannot escape
+// the download directory.
+func attachmentFileName(att jmap.Attachment) string {
+	name := strings.ReplaceAll(att.Name, "\\", "/")
+	name = filepath.Base(name)
+	name = strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) {
+			return -1
+		}
+		return r
+	}, name)
+	name = strings.Trim(name, " .")
+
+	if name == "" || name == "/" {
+		return "attachment-" + att.PartID
+	}
+	return name
+}
+
+// writeNewFile writes data to name in dir, adding " (1)", " (2)", ... before
+// the extension if the file already exists. It returns the path written.
+func writeNewFile(dir, name string, data []byte) (string, error) {
+	ext := filepath.Ext(name)
+	base := strings.TrimSuffix(name, ext)
+
+	for i := 0; ; i++ {
+		candidate := name
+		if i > 0 {
+			candidate = fmt.Sprintf("%s (%d)%s", base, i, ext)
+		}
+		path := filepath.Join(dir, candidate)
+
+		file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+		if errors.Is(err, fs.ErrExist) {
+			continue
+		}
+		if err != nil {
+			return "", err
+		}
+
+		if _, err := file.Write(data); err != nil {
+			file.Close()
+			return "", err
+		}
+		return path, file.Close()
+	}
+}
@@ -0,0 +1,61 @@
+package email
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/marckohlbrugge/fastmail-cli/internal/cmdutil"
+	"github.com/spf13/cobra"
+)
+
+// NewCmdCopy creates the email copy command.
+func NewCmdCopy(f *cmdutil.Factory) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "copy <email-id> <folder>...",
+		Short: "Copy an email into one or more folders",
+		Long: `Add an email to one or more folders, keeping it where it is.
+
+Fastmail treats folders like labels: the email is not duplicated, it simply
+appears in every folder it belongs to. All folders are looked up first, so
+nothing changes if any of them does not exist.
+
+Folders can be specified by ID, name, or role (inbox, archive, etc.).`,
+		Example: `  # Copy an email into a folder
+  fm email copy M1234567890 "Work Projects"
+
+  # Apply several folders at once
+  fm email copy M1234567890 Receipts Taxes`,
+		Args:              cmdutil.MinimumArgs(2, "email ID and folder required\n\nUsage: fm email copy <email-id> <folder>..."),
+		ValidArgsFunction: cmdutil.CompleteFoldersAfter(f, 1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runCopy(f, args[0], args[1:])
+		},
+	}
+
+	return cmd
+}
+
+func runCopy(f *cmdutil.Factory, emailID string, folderRefs []string) error {
+	client, err := f.JMAPClient()
+	if err != nil {
+		return err
+	}
+
+	// Resolve every folder before changing anything
+	var mailboxIDs, names []string
+	for _, ref := range folderRefs {
+		mailbox, err := cmdutil.ResolveMailbox(f, client, ref)
+		if err != nil {
+			return err
+		}
+		mailboxIDs = append(mailboxIDs, mailbox.ID)
+		names = append(names, mailbox.Name)
+	}
+
+	if err := client.CopyEmail(emailID, mailboxIDs); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(f.IOStreams.Out, "Copied to %s.\n", strings.Join(names, ", "))
+	return nil
+}
@@ -0,0 +1,91 @@
+package email
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/marckohlbrugge/fastmail-cli/internal/cmdutil"
+	"github.com/marckohlbrugge/fastmail-cli/internal/jmap"
+	"github.com/spf13/cobra"
+)
+
+type countOptions struct {
+	Folder string
+	Unread bool
+	Since  string
+}
+
+// NewCmdCount creates the email count command.
+func NewCmdCount(f *cmdutil.Factory) *cobra.Command {
+	opts := &countOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "count [query]",
+		Short: "Print the number of matching emails",
+		Long: `Print the number of emails matching a search query, and nothing else.
+
+The query uses the same syntax as 'fm search' and is optional. Only the
+count is requested from the server; no emails are fetched, so this is cheap
+enough for shell prompts and status bars.`,
+		Example: `  # Unread emails in the inbox
+  fm email count --folder inbox --unread
+
+  # Everything from a sender in the last week
+  fm email count "from:alice" --since 7d
+
+  # Show it in a prompt
+  echo "unread: $(fm email count --folder inbox --unread)"`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			query := ""
+			if len(args) > 0 {
+				query = args[0]
+			}
+			return runCount(f, opts, query)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.Folder, "folder", "", "Only count emails in this folder ID or name")
+	cmd.Flags().BoolVar(&opts.Unread, "unread", false, "Only count unread emails")
+	cmd.Flags().StringVar(&opts.Since, "since", "", "Only count emails from the last `duration` (e.g. 24h, 7d, 2w)")
+	_ = cmd.RegisterFlagCompletionFunc("folder", cmdutil.CompleteFolders(f))
+
+	return cmd
+}
+
+func runCount(f *cmdutil.Factory, opts *countOptions, query string) error {
+	filters := jmap.SearchFilters{Query: query}
+
+	if opts.Since != "" {
+		d, err := jmap.ParseRelativeDuration(opts.Since)
+		if err != nil {
+			return cmdutil.FlagErrorWrap(err)
+		}
+		filters.After = jmap.UTCDate(time.Now().Add(-d))
+	}
+	if opts.Unread {
+		unread := true
+		filters.IsUnread = &unread
+	}
+
+	client, err := f.JMAPClient()
+	if err != nil {
+		return err
+	}
+
+	if opts.Folder != "" {
+		mailbox, err := cmdutil.ResolveMailbox(f, client, opts.Folder)
+		if err != nil {
+			return err
+		}
+		filters.MailboxID = mailbox.ID
+	}
+
+	count, err := client.CountEmails(filters)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintln(f.IOStreams.Out, count)
+	return nil
+}
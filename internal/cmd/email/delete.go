@@ -1,17 +1,16 @@
 package email
 
 import (
-	"bufio"
 	"fmt"
-	"strings"
 
 	"github.com/marckohlbrugge/fastmail-cli/internal/cmdutil"
 	"github.com/spf13/cobra"
 )
 
 type deleteOptions struct {
-	Yes    bool
-	Unsafe bool
+	Yes       bool
+	Unsafe    bool
+	FromStdin bool
 }
 
 // NewCmdDelete creates the email delete command.
@@ -19,20 +18,30 @@ func NewCmdDelete(f *cmdutil.Factory) *cobra.Command {
 	opts := &deleteOptions{}
 
 	cmd := &cobra.Command{
-		Use:   "delete <email-id>",
-		Short: "Move an email to trash",
-		Long: `Move an email to the Trash folder.
+		Use:   "delete <email-id>...",
+		Short: "Move emails to trash",
+		Long: `Move one or more emails to the Trash folder.
 
 This action requires confirmation unless --yes is provided.
-In non-interactive mode (scripts, AI), this command is blocked unless --unsafe is specified.`,
+In non-interactive mode (scripts, AI), this command is blocked unless --unsafe is specified.
+If no IDs are given and stdin is a pipe, IDs are read from stdin; as stdin
+then can't answer a prompt, --unsafe and --yes are required.`,
 		Example: `  # Delete with confirmation prompt
   fm email delete M1234567890
 
   # Delete without confirmation
-  fm email delete M1234567890 --yes`,
-		Args: cmdutil.ExactArgs(1, "email ID required\n\nUsage: fm email delete <email-id>"),
+  fm email delete M1234567890 --yes
+
+  # Delete search results, reading IDs from stdin
+  fm search "from:spammer" --json id | jq -r '.[].id' | fm email delete --unsafe --yes`,
+		Args: cobra.ArbitraryArgs,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runDelete(f, opts, args[0])
+			ids, err := cmdutil.IDsOrStdin(f.IOStreams, args, "email ID required\n\nUsage: fm email delete <email-id>...")
+			if err != nil {
+				return err
+			}
+			opts.FromStdin = len(args) == 0
+			return runDelete(f, opts, ids)
 		},
 	}
 
@@ -42,12 +51,17 @@ In non-interactive mode (scripts, AI), this command is blocked unless --unsafe i
 	return cmd
 }
 
-func runDelete(f *cmdutil.Factory, opts *deleteOptions, emailID string) error {
+func runDelete(f *cmdutil.Factory, opts *deleteOptions, emailIDs []string) error {
 	// Check safe mode
 	if f.IOStreams.IsSafeMode() && !opts.Unsafe {
 		return &cmdutil.SafeModeError{Command: "email delete"}
 	}
 
+	// Stdin was read to the end for the IDs, so it can't answer a prompt
+	if opts.FromStdin && !opts.Yes {
+		return cmdutil.FlagErrorf("--yes required when email IDs are read from stdin")
+	}
+
 	client, err := f.JMAPClient()
 	if err != nil {
 		return err
@@ -55,35 +69,55 @@ func runDelete(f *cmdutil.Factory, opts *deleteOptions, emailID string) error {
 
 	// Require confirmation unless --yes
 	if !opts.Yes && f.IOStreams.IsInteractive() {
-		// Get email info for confirmation
-		email, err := client.GetEmailByID(emailID)
-		if err != nil {
-			return err
+		if f.Quiet {
+			return cmdutil.QuietConfirmError
 		}
 
-		subject := email.Subject
-		if subject == "" {
-			subject = "(no subject)"
+		prompt := fmt.Sprintf("Delete these %d emails?", len(emailIDs))
+		if len(emailIDs) == 1 {
+			// Get email info for confirmation
+			email, err := client.GetEmailByID(emailIDs[0])
+			if err != nil {
+				return err
+			}
+
+			subject := email.Subject
+			if subject == "" {
+				subject = "(no subject)"
+			}
+
+			fmt.Fprintf(f.IOStreams.ErrOut, "Subject: %s\n", subject)
+			prompt = "Delete this email?"
 		}
 
-		fmt.Fprintf(f.IOStreams.ErrOut, "Subject: %s\n", subject)
-		fmt.Fprintf(f.IOStreams.ErrOut, "Delete this email? [y/N] ")
-
-		scanner := bufio.NewScanner(f.IOStreams.In)
-		response := ""
-		if scanner.Scan() {
-			response = scanner.Text()
+		if ok, err := cmdutil.Confirm(f.IOStreams, prompt); !ok {
+			return err
 		}
+	}
 
-		if !strings.HasPrefix(strings.ToLower(strings.TrimSpace(response)), "y") {
-			return cmdutil.CancelError
+	out := f.IOStreams.Out
+
+	if len(emailIDs) == 1 {
+		if err := client.DeleteEmail(emailIDs[0]); err != nil {
+			return err
 		}
+		fmt.Fprintln(out, "Moved to Trash.")
+		return nil
 	}
 
-	if err := client.DeleteEmail(emailID); err != nil {
+	deleted, failed, err := client.DeleteEmails(emailIDs)
+	if err != nil {
 		return err
 	}
 
-	fmt.Fprintln(f.IOStreams.Out, "Moved to Trash.")
+	if len(failed) > 0 {
+		fmt.Fprintf(out, "Moved %d emails to Trash. Failed: %d\n", deleted, len(failed))
+		for _, id := range failed {
+			fmt.Fprintf(f.IOStreams.ErrOut, "  Failed: %s\n", id)
+		}
+		return nil
+	}
+
+	fmt.Fprintf(out, "Moved %d emails to Trash.\n", deleted)
 	return nil
 }
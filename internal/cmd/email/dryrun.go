@@ -0,0 +1,23 @@
+package email
+
+import (
+	"fmt"
+
+	"github.com/marckohlbrugge/fastmail-cli/internal/cmdutil"
+	"github.com/marckohlbrugge/fastmail-cli/internal/jmap"
+)
+
+// printDryRun lists the emails a --dry-run would have changed. The list goes
+// to stdout like 'fm search' output; the summary goes to stderr.
+func printDryRun(f *cmdutil.Factory, emails []jmap.Email, outcome string) {
+	if len(emails) == 0 {
+		fmt.Fprintln(f.IOStreams.Out, "No emails would be affected.")
+		return
+	}
+
+	cmdutil.PrintEmailList(f.IOStreams.Out, f.EmailListStyle(), emails, cmdutil.DefaultEmailFields)
+
+	if !f.Quiet {
+		fmt.Fprintf(f.IOStreams.ErrOut, "\nDry run: %d emails would be %s. Nothing was changed.\n", len(emails), outcome)
+	}
+}
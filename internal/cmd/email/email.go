@@ -10,19 +10,38 @@ func NewCmdEmail(f *cmdutil.Factory) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "email <command>",
 		Short: "Manage emails",
-		Long:  "Read, archive, move, and delete emails.",
+		Long:  "Read, archive, move, delete, reply to, resend, and redirect emails.",
 		Example: `  $ fm email read M1234567890
   $ fm email thread M1234567890
+  $ fm email headers M1234567890 Authentication-Results
   $ fm email archive M1234567890
-  $ fm email move M1234567890 inbox`,
+  $ fm email move M1234567890 inbox
+  $ fm email junk M1234567890`,
 		GroupID: "email",
 	}
 
 	cmd.AddCommand(NewCmdRead(f))
+	cmd.AddCommand(NewCmdPreview(f))
+	cmd.AddCommand(NewCmdInfo(f))
+	cmd.AddCommand(NewCmdAttachments(f))
+	cmd.AddCommand(NewCmdCount(f))
+	cmd.AddCommand(NewCmdFrom(f))
 	cmd.AddCommand(NewCmdThread(f))
+	cmd.AddCommand(NewCmdHeaders(f))
+	cmd.AddCommand(NewCmdSource(f))
+	cmd.AddCommand(NewCmdMarkThreadRead(f))
+	cmd.AddCommand(NewCmdFlag(f))
+	cmd.AddCommand(NewCmdTagList(f))
+	cmd.AddCommand(NewCmdApply(f))
 	cmd.AddCommand(NewCmdArchive(f))
 	cmd.AddCommand(NewCmdMove(f))
+	cmd.AddCommand(NewCmdCopy(f))
+	cmd.AddCommand(NewCmdMoveToJunk(f))
+	cmd.AddCommand(NewCmdSnooze(f))
 	cmd.AddCommand(NewCmdDelete(f))
+	cmd.AddCommand(NewCmdReply(f))
+	cmd.AddCommand(NewCmdResend(f))
+	cmd.AddCommand(NewCmdRedirect(f))
 
 	return cmd
 }
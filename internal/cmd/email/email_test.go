@@ -3,7 +3,12 @@ package email
 import (
 	"bytes"
 	"encoding/json"
+	"fmt"
+	"io"
 	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
@@ -28,6 +33,11 @@ func setupTest(t *testing.T) (*cmdutil.Factory, *bytes.Buffer, *bytes.Buffer) {
 			"accounts": map[string]interface{}{
 				"account-1": map[string]interface{}{},
 			},
+			"capabilities": map[string]interface{}{
+				jmap.CoreCapability:       map[string]interface{}{},
+				jmap.MailCapability:       map[string]interface{}{},
+				jmap.SubmissionCapability: map[string]interface{}{},
+			},
 		}))
 
 	// Create test client
@@ -168,6 +178,59 @@ func TestReadCommand(t *testing.T) {
 		assert.Contains(t, output, "application/pdf")
 	})
 
+	t.Run("downloads attachments to a directory", func(t *testing.T) {
+		f, stdout, stderr := setupTest(t)
+		dir := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "report.pdf"), []byte("existing"), 0o644))
+
+		httpmock.RegisterResponder("GET", "https://api.test.com/jmap/session",
+			httpmock.NewJsonResponderOrPanic(200, map[string]interface{}{
+				"apiUrl":      "https://api.test.com/jmap/api",
+				"downloadUrl": "https://api.test.com/jmap/download/{accountId}/{blobId}/{name}?type={type}",
+				"accounts": map[string]interface{}{
+					"account-1": map[string]interface{}{},
+				},
+			}))
+		httpmock.RegisterResponder("POST", "https://api.test.com/jmap/api",
+			mockEmailGetResponse(map[string]interface{}{
+				"id":         "email-1",
+				"subject":    "Reports",
+				"receivedAt": time.Now().Format(time.RFC3339),
+				"attachments": []map[string]interface{}{
+					{"partId": "2", "blobId": "blob-1", "name": "report.pdf", "type": "application/pdf"},
+					{"partId": "3", "blobId": "blob-2", "name": "../../notes.txt", "type": "text/plain"},
+				},
+			}))
+		httpmock.RegisterResponder("GET", `=~^https://api\.test\.com/jmap/download/account-1/`,
+			func(req *http.Request) (*http.Response, error) {
+				assert.Equal(t, "Bearer test-token", req.Header.Get("Authorization"))
+				blobID := strings.Split(req.URL.Path, "/")[4]
+				return httpmock.NewStringResponse(200, "contents of "+blobID), nil
+			})
+
+		cmd := NewCmdRead(f)
+		cmd.SetArgs([]string{"email-1", "--download-attachments", dir})
+		cmd.SetOut(stdout)
+		cmd.SetErr(&bytes.Buffer{})
+
+		err := cmd.Execute()
+
+		require.NoError(t, err)
+		assert.Contains(t, stdout.String(), "Reports")
+		assert.NotContains(t, stdout.String(), "Saved")
+
+		renamed := filepath.Join(dir, "report (1).pdf")
+		notes := filepath.Join(dir, "notes.txt")
+		assert.Equal(t, "Saved "+renamed+"\nSaved "+notes+"\n", stderr.String())
+
+		data, err := os.ReadFile(renamed)
+		require.NoError(t, err)
+		assert.Equal(t, "contents of blob-1", string(data))
+		data, err = os.ReadFile(filepath.Join(dir, "report.pdf"))
+		require.NoError(t, err)
+		assert.Equal(t, "existing", string(data), "existing files must not be overwritten")
+	})
+
 	t.Run("requires email ID argument", func(t *testing.T) {
 		f := &cmdutil.Factory{}
 		cmd := NewCmdRead(f)
@@ -182,67 +245,55 @@ func TestReadCommand(t *testing.T) {
 	})
 }
 
-// Thread command tests
-
-func TestThreadCommand(t *testing.T) {
-	t.Run("displays thread emails", func(t *testing.T) {
-		f, stdout, _ := setupTest(t)
-
-		httpmock.RegisterResponder("POST", "https://api.test.com/jmap/api",
-			func(req *http.Request) (*http.Response, error) {
-				var jmapReq jmap.Request
-				json.NewDecoder(req.Body).Decode(&jmapReq)
-
-				method := jmapReq.MethodCalls[0][0].(string)
-
-				switch method {
-				case "Email/get":
-					// First call gets the email to find thread ID
-					return httpmock.NewJsonResponse(200, map[string]interface{}{
-						"methodResponses": [][]interface{}{
-							{"Email/get", map[string]interface{}{
-								"list": []map[string]interface{}{
-									{"id": "email-1", "threadId": "thread-1"},
-								},
-							}, "email"},
-						},
-					})
-				case "Thread/get":
-					return httpmock.NewJsonResponse(200, map[string]interface{}{
-						"methodResponses": [][]interface{}{
-							{"Thread/get", map[string]interface{}{
-								"list": []map[string]interface{}{
-									{"id": "thread-1", "emailIds": []string{"email-1", "email-2"}},
-								},
-							}, "getThread"},
-							{"Email/get", map[string]interface{}{
-								"list": []map[string]interface{}{
-									{
-										"id":         "email-1",
-										"threadId":   "thread-1",
-										"subject":    "Original message",
-										"from":       []map[string]string{{"name": "Alice", "email": "alice@example.com"}},
-										"receivedAt": time.Now().Add(-2 * time.Hour).Format(time.RFC3339),
-										"preview":    "First email content",
-									},
-									{
-										"id":         "email-2",
-										"threadId":   "thread-1",
-										"subject":    "Re: Original message",
-										"from":       []map[string]string{{"name": "Bob", "email": "bob@example.com"}},
-										"receivedAt": time.Now().Add(-1 * time.Hour).Format(time.RFC3339),
-										"preview":    "Reply content",
-									},
-								},
-							}, "emails"},
+// mockThreadContext serves an email and the thread it belongs to.
+func mockThreadContext() httpmock.Responder {
+	return func(req *http.Request) (*http.Response, error) {
+		var jmapReq jmap.Request
+		json.NewDecoder(req.Body).Decode(&jmapReq)
+
+		switch jmapReq.MethodCalls[0][0].(string) {
+		case "Email/get":
+			return mockEmailGetResponse(map[string]interface{}{
+				"id":       "email-3",
+				"threadId": "thread-1",
+				"subject":  "Re: Plans",
+			})(req)
+		case "Thread/get":
+			return httpmock.NewJsonResponse(200, map[string]interface{}{
+				"methodResponses": [][]interface{}{
+					{"Thread/get", map[string]interface{}{
+						"list": []map[string]interface{}{
+							{"id": "thread-1", "emailIds": []string{"email-1", "email-2", "email-3", "email-4", "email-5"}},
 						},
-					})
-				default:
-					return httpmock.NewStringResponse(400, "unexpected"), nil
-				}
+					}, "getThread"},
+				},
 			})
+		default:
+			return httpmock.NewStringResponse(400, "unexpected"), nil
+		}
+	}
+}
 
-		cmd := NewCmdThread(f)
+func TestPreviewCommand(t *testing.T) {
+	var body []string
+	for i := 1; i <= 15; i++ {
+		body = append(body, fmt.Sprintf("Line %d of a body long enough to count as substantial text.", i))
+	}
+	email := map[string]interface{}{
+		"id":         "email-1",
+		"threadId":   "thread-1",
+		"subject":    "Long read",
+		"from":       []map[string]string{{"name": "Alice", "email": "alice@example.com"}},
+		"receivedAt": "2024-01-15T10:30:00Z",
+		"textBody":   []map[string]string{{"partId": "1"}},
+		"bodyValues": map[string]map[string]string{"1": {"value": strings.Join(body, "\n")}},
+	}
+
+	t.Run("shows the headers and first 10 lines", func(t *testing.T) {
+		f, stdout, _ := setupTest(t)
+		httpmock.RegisterResponder("POST", "https://api.test.com/jmap/api", mockEmailGetResponse(email))
+
+		cmd := NewCmdPreview(f)
 		cmd.SetArgs([]string{"email-1"})
 		cmd.SetOut(stdout)
 		cmd.SetErr(&bytes.Buffer{})
@@ -251,403 +302,3341 @@ func TestThreadCommand(t *testing.T) {
 
 		require.NoError(t, err)
 		output := stdout.String()
-		assert.Contains(t, output, "Thread with 2 emails")
-		assert.Contains(t, output, "Alice")
-		assert.Contains(t, output, "Bob")
-		assert.Contains(t, output, "Original message")
-		assert.Contains(t, output, "Re: Original message")
+		assert.Contains(t, output, "Subject: Long read")
+		assert.Contains(t, output, "Line 10 of")
+		assert.NotContains(t, output, "Line 11 of")
+		assert.Contains(t, output, "... 5 more lines (fm email read email-1)")
 	})
 
-	t.Run("outputs JSON format", func(t *testing.T) {
+	t.Run("honors --lines", func(t *testing.T) {
 		f, stdout, _ := setupTest(t)
+		httpmock.RegisterResponder("POST", "https://api.test.com/jmap/api", mockEmailGetResponse(email))
 
-		httpmock.RegisterResponder("POST", "https://api.test.com/jmap/api",
-			func(req *http.Request) (*http.Response, error) {
-				var jmapReq jmap.Request
-				json.NewDecoder(req.Body).Decode(&jmapReq)
-
-				method := jmapReq.MethodCalls[0][0].(string)
-
-				if method == "Email/get" {
-					return httpmock.NewJsonResponse(200, map[string]interface{}{
-						"methodResponses": [][]interface{}{
-							{"Email/get", map[string]interface{}{
-								"list": []map[string]interface{}{
-									{"id": "email-1", "threadId": "thread-1"},
-								},
-							}, "email"},
-						},
-					})
-				}
-
-				return httpmock.NewJsonResponse(200, map[string]interface{}{
-					"methodResponses": [][]interface{}{
-						{"Thread/get", map[string]interface{}{
-							"list": []map[string]interface{}{
-								{"id": "thread-1", "emailIds": []string{"email-1"}},
-							},
-						}, "getThread"},
-						{"Email/get", map[string]interface{}{
-							"list": []map[string]interface{}{
-								{
-									"id":         "email-1",
-									"subject":    "Test",
-									"receivedAt": "2024-01-15T10:30:00Z",
-								},
-							},
-						}, "emails"},
-					},
-				})
-			})
-
-		cmd := NewCmdThread(f)
-		cmd.SetArgs([]string{"email-1", "--json"})
+		cmd := NewCmdPreview(f)
+		cmd.SetArgs([]string{"email-1", "--lines", "20"})
 		cmd.SetOut(stdout)
 		cmd.SetErr(&bytes.Buffer{})
 
 		err := cmd.Execute()
 
 		require.NoError(t, err)
-
-		var result []map[string]interface{}
-		err = json.Unmarshal(stdout.Bytes(), &result)
-		require.NoError(t, err)
-		assert.Len(t, result, 1)
+		assert.Contains(t, stdout.String(), "Line 15 of")
+		assert.NotContains(t, stdout.String(), "more lines")
 	})
-}
 
-// Archive command tests
+	t.Run("rejects --lines below 1", func(t *testing.T) {
+		f, _, _ := setupTest(t)
 
-func TestArchiveCommand(t *testing.T) {
-	t.Run("archives single email", func(t *testing.T) {
-		f, stdout, _ := setupTest(t)
+		cmd := NewCmdPreview(f)
+		cmd.SetArgs([]string{"email-1", "--lines", "0"})
+		cmd.SetOut(&bytes.Buffer{})
+		cmd.SetErr(&bytes.Buffer{})
 
-		httpmock.RegisterResponder("POST", "https://api.test.com/jmap/api",
-			func(req *http.Request) (*http.Response, error) {
-				var jmapReq jmap.Request
-				json.NewDecoder(req.Body).Decode(&jmapReq)
+		err := cmd.Execute()
 
-				method := jmapReq.MethodCalls[0][0].(string)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "--lines must be at least 1")
+	})
+}
 
-				switch method {
-				case "Mailbox/get":
-					return mockMailboxResponse([]map[string]interface{}{
-						{"id": "archive-1", "name": "Archive", "role": "archive"},
-					})(req)
-				case "Email/set":
-					return mockEmailSetResponse(map[string]interface{}{
-						"email-1": nil,
-					})(req)
-				default:
-					return httpmock.NewStringResponse(400, "unexpected: "+method), nil
-				}
-			})
+func TestReadCommandThreadContext(t *testing.T) {
+	t.Run("shows the position in the thread", func(t *testing.T) {
+		f, stdout, _ := setupTest(t)
+		httpmock.RegisterResponder("POST", "https://api.test.com/jmap/api", mockThreadContext())
 
-		cmd := NewCmdArchive(f)
-		cmd.SetArgs([]string{"email-1"})
+		cmd := NewCmdRead(f)
+		cmd.SetArgs([]string{"email-3", "--thread-context"})
 		cmd.SetOut(stdout)
 		cmd.SetErr(&bytes.Buffer{})
 
 		err := cmd.Execute()
 
 		require.NoError(t, err)
-		assert.Contains(t, stdout.String(), "Moved to Archive")
+		assert.Contains(t, stdout.String(), "Thread:  thread-1 (message 3 of 5)")
 	})
 
-	t.Run("archives multiple emails", func(t *testing.T) {
+	t.Run("includes the position in JSON output", func(t *testing.T) {
 		f, stdout, _ := setupTest(t)
+		httpmock.RegisterResponder("POST", "https://api.test.com/jmap/api", mockThreadContext())
 
-		httpmock.RegisterResponder("POST", "https://api.test.com/jmap/api",
-			func(req *http.Request) (*http.Response, error) {
-				var jmapReq jmap.Request
-				json.NewDecoder(req.Body).Decode(&jmapReq)
-
-				method := jmapReq.MethodCalls[0][0].(string)
-
-				switch method {
-				case "Mailbox/get":
-					return mockMailboxResponse([]map[string]interface{}{
-						{"id": "archive-1", "name": "Archive", "role": "archive"},
-					})(req)
-				case "Email/set":
-					return httpmock.NewJsonResponse(200, map[string]interface{}{
-						"methodResponses": [][]interface{}{
-							{"Email/set", map[string]interface{}{
-								"updated": map[string]interface{}{
-									"email-1": nil,
-									"email-2": nil,
-									"email-3": nil,
-								},
-							}, "bulkArchive"},
-						},
-					})
-				default:
-					return httpmock.NewStringResponse(400, "unexpected"), nil
-				}
-			})
-
-		cmd := NewCmdArchive(f)
-		cmd.SetArgs([]string{"email-1", "email-2", "email-3"})
+		cmd := NewCmdRead(f)
+		cmd.SetArgs([]string{"email-3", "--thread-context", "--json"})
 		cmd.SetOut(stdout)
 		cmd.SetErr(&bytes.Buffer{})
 
 		err := cmd.Execute()
 
 		require.NoError(t, err)
-		assert.Contains(t, stdout.String(), "Archived 3 emails")
+		var result map[string]interface{}
+		require.NoError(t, json.Unmarshal(stdout.Bytes(), &result))
+		assert.Equal(t, "email-3", result["id"])
+		assert.Equal(t, float64(3), result["threadPosition"])
+		assert.Equal(t, float64(5), result["threadSize"])
 	})
 
-	t.Run("requires at least one email ID", func(t *testing.T) {
-		f := &cmdutil.Factory{}
-		cmd := NewCmdArchive(f)
-		cmd.SetArgs([]string{})
-		cmd.SetOut(&bytes.Buffer{})
+	t.Run("skips the thread lookup by default", func(t *testing.T) {
+		f, stdout, _ := setupTest(t)
+		httpmock.RegisterResponder("POST", "https://api.test.com/jmap/api", mockThreadContext())
+
+		cmd := NewCmdRead(f)
+		cmd.SetArgs([]string{"email-3", "--json"})
+		cmd.SetOut(stdout)
 		cmd.SetErr(&bytes.Buffer{})
 
 		err := cmd.Execute()
 
-		require.Error(t, err)
-		assert.Contains(t, err.Error(), "at least one email ID required")
+		require.NoError(t, err)
+		assert.NotContains(t, stdout.String(), "threadPosition")
+		assert.Equal(t, 1, httpmock.GetCallCountInfo()["POST https://api.test.com/jmap/api"])
 	})
 }
+func TestAttachmentFileName(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"report.pdf", "report.pdf"},
+		{"../../etc/passwd", "passwd"},
+		{`C:\Users\evil.exe`, "evil.exe"},
+		{"bad\x00name\n.txt", "badname.txt"},
+		{"..", "attachment-2"},
+		{"", "attachment-2"},
+	}
 
-// Move command tests
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, attachmentFileName(jmap.Attachment{PartID: "2", Name: tt.name}))
+		})
+	}
+}
 
-func TestMoveCommand(t *testing.T) {
-	t.Run("moves email to folder by name", func(t *testing.T) {
+// Headers command tests
+
+func TestHeadersCommand(t *testing.T) {
+	t.Run("prints a single header", func(t *testing.T) {
 		f, stdout, _ := setupTest(t)
 
+		var properties []interface{}
 		httpmock.RegisterResponder("POST", "https://api.test.com/jmap/api",
 			func(req *http.Request) (*http.Response, error) {
 				var jmapReq jmap.Request
 				json.NewDecoder(req.Body).Decode(&jmapReq)
+				properties = jmapReq.MethodCalls[0][1].(map[string]interface{})["properties"].([]interface{})
 
-				method := jmapReq.MethodCalls[0][0].(string)
-
-				switch method {
-				case "Mailbox/get":
-					return mockMailboxResponse([]map[string]interface{}{
-						{"id": "inbox-1", "name": "Inbox", "role": "inbox"},
-						{"id": "work-1", "name": "Work", "role": ""},
-					})(req)
-				case "Email/set":
-					return mockEmailSetResponse(map[string]interface{}{
-						"email-1": nil,
-					})(req)
-				default:
-					return httpmock.NewStringResponse(400, "unexpected"), nil
-				}
+				return mockEmailGetResponse(map[string]interface{}{
+					"id": "email-1",
+					"header:Authentication-Results:asText:all": []string{"mx.example.com; spf=pass"},
+				})(req)
 			})
 
-		cmd := NewCmdMove(f)
-		cmd.SetArgs([]string{"email-1", "Work"})
+		cmd := NewCmdHeaders(f)
+		cmd.SetArgs([]string{"email-1", "Authentication-Results"})
 		cmd.SetOut(stdout)
 		cmd.SetErr(&bytes.Buffer{})
 
 		err := cmd.Execute()
 
 		require.NoError(t, err)
-		assert.Contains(t, stdout.String(), "Moved to Work")
+		assert.Equal(t, []interface{}{"header:Authentication-Results:asText:all"}, properties)
+		assert.Equal(t, "mx.example.com; spf=pass\n", stdout.String())
 	})
 
-	t.Run("moves email to folder by role", func(t *testing.T) {
+	t.Run("errors when the header is missing", func(t *testing.T) {
+		f, _, _ := setupTest(t)
+
+		httpmock.RegisterResponder("POST", "https://api.test.com/jmap/api",
+			mockEmailGetResponse(map[string]interface{}{
+				"id":                          "email-1",
+				"header:X-Missing:asText:all": []string{},
+			}))
+
+		cmd := NewCmdHeaders(f)
+		cmd.SetArgs([]string{"email-1", "X-Missing"})
+		cmd.SetOut(&bytes.Buffer{})
+		cmd.SetErr(&bytes.Buffer{})
+
+		err := cmd.Execute()
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "has no X-Missing header")
+	})
+
+	t.Run("summarizes authentication results with --auth", func(t *testing.T) {
+		f, stdout, _ := setupTest(t)
+
+		httpmock.RegisterResponder("POST", "https://api.test.com/jmap/api",
+			mockEmailGetResponse(map[string]interface{}{
+				"id": "email-1",
+				"header:Authentication-Results:asText:all": []string{
+					"mx.messagingengine.com; dkim=pass (2048-bit rsa key sha256) header.d=example.com; dmarc=fail (p=reject,d=none) header.from=example.com; spf=softfail smtp.mailfrom=bounce@example.com",
+					"upstream.example.net; spf=pass",
+				},
+			}))
+
+		cmd := NewCmdHeaders(f)
+		cmd.SetArgs([]string{"email-1", "--auth"})
+		cmd.SetOut(stdout)
+		cmd.SetErr(&bytes.Buffer{})
+
+		err := cmd.Execute()
+
+		require.NoError(t, err)
+		assert.Equal(t, "dkim=pass header.d=example.com\ndmarc=fail header.from=example.com\nspf=softfail smtp.mailfrom=bounce@example.com\n", stdout.String())
+	})
+
+	t.Run("prints the raw header when --auth can't parse it", func(t *testing.T) {
+		f, stdout, _ := setupTest(t)
+
+		httpmock.RegisterResponder("POST", "https://api.test.com/jmap/api",
+			mockEmailGetResponse(map[string]interface{}{
+				"id": "email-1",
+				"header:Authentication-Results:asText:all": []string{"mx.example.com; none"},
+			}))
+
+		cmd := NewCmdHeaders(f)
+		cmd.SetArgs([]string{"email-1", "--auth"})
+		cmd.SetOut(stdout)
+		cmd.SetErr(&bytes.Buffer{})
+
+		err := cmd.Execute()
+
+		require.NoError(t, err)
+		assert.Equal(t, "mx.example.com; none\n", stdout.String())
+	})
+
+	t.Run("dumps all headers", func(t *testing.T) {
+		f, stdout, _ := setupTest(t)
+
+		httpmock.RegisterResponder("POST", "https://api.test.com/jmap/api",
+			mockEmailGetResponse(map[string]interface{}{
+				"id": "email-1",
+				"headers": []map[string]string{
+					{"name": "From", "value": " Alice <alice@example.com>"},
+					{"name": "Subject", "value": " Hello"},
+				},
+			}))
+
+		cmd := NewCmdHeaders(f)
+		cmd.SetArgs([]string{"email-1", "--all"})
+		cmd.SetOut(stdout)
+		cmd.SetErr(&bytes.Buffer{})
+
+		err := cmd.Execute()
+
+		require.NoError(t, err)
+		assert.Equal(t, "From: Alice <alice@example.com>\nSubject: Hello\n", stdout.String())
+	})
+
+	t.Run("outputs JSON format", func(t *testing.T) {
+		f, stdout, _ := setupTest(t)
+
+		httpmock.RegisterResponder("POST", "https://api.test.com/jmap/api",
+			mockEmailGetResponse(map[string]interface{}{
+				"id":                         "email-1",
+				"header:Received:asText:all": []string{"from a", "from b"},
+			}))
+
+		cmd := NewCmdHeaders(f)
+		cmd.SetArgs([]string{"email-1", "Received", "--json"})
+		cmd.SetOut(stdout)
+		cmd.SetErr(&bytes.Buffer{})
+
+		err := cmd.Execute()
+
+		require.NoError(t, err)
+		var headers []jmap.EmailHeader
+		require.NoError(t, json.Unmarshal(stdout.Bytes(), &headers))
+		assert.Equal(t, []jmap.EmailHeader{
+			{Name: "Received", Value: "from a"},
+			{Name: "Received", Value: "from b"},
+		}, headers)
+	})
+
+	t.Run("requires a header name or --all", func(t *testing.T) {
+		f := &cmdutil.Factory{}
+		cmd := NewCmdHeaders(f)
+		cmd.SetArgs([]string{"email-1"})
+		cmd.SetOut(&bytes.Buffer{})
+		cmd.SetErr(&bytes.Buffer{})
+
+		err := cmd.Execute()
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "header name required")
+	})
+}
+
+func TestInfoCommand(t *testing.T) {
+	setup := func(t *testing.T) (*cmdutil.Factory, *bytes.Buffer, *map[string]interface{}) {
 		f, stdout, _ := setupTest(t)
 
+		var args map[string]interface{}
 		httpmock.RegisterResponder("POST", "https://api.test.com/jmap/api",
 			func(req *http.Request) (*http.Response, error) {
 				var jmapReq jmap.Request
 				json.NewDecoder(req.Body).Decode(&jmapReq)
 
-				method := jmapReq.MethodCalls[0][0].(string)
-
-				switch method {
+				switch jmapReq.MethodCalls[0][0].(string) {
+				case "Email/get":
+					args = jmapReq.MethodCalls[0][1].(map[string]interface{})
+					return mockEmailGetResponse(map[string]interface{}{
+						"id":         "email-1",
+						"threadId":   "thread-1",
+						"subject":    "Invoice",
+						"from":       []map[string]string{{"name": "Alice", "email": "alice@example.com"}},
+						"to":         []map[string]string{{"email": "me@example.com"}},
+						"receivedAt": "2024-01-15T10:30:00Z",
+						"size":       2048,
+						"mailboxIds": map[string]bool{"inbox-1": true},
+						"keywords":   map[string]bool{"$seen": true, "$flagged": true},
+					})(req)
 				case "Mailbox/get":
 					return mockMailboxResponse([]map[string]interface{}{
 						{"id": "inbox-1", "name": "Inbox", "role": "inbox"},
 					})(req)
-				case "Email/set":
-					return mockEmailSetResponse(map[string]interface{}{
-						"email-1": nil,
-					})(req)
-				default:
-					return httpmock.NewStringResponse(400, "unexpected"), nil
 				}
+				return httpmock.NewStringResponse(400, "unexpected"), nil
 			})
 
-		cmd := NewCmdMove(f)
-		cmd.SetArgs([]string{"email-1", "inbox"})
+		return f, stdout, &args
+	}
+
+	t.Run("prints metadata without fetching the body", func(t *testing.T) {
+		f, stdout, args := setup(t)
+
+		cmd := NewCmdInfo(f)
+		cmd.SetArgs([]string{"email-1"})
 		cmd.SetOut(stdout)
 		cmd.SetErr(&bytes.Buffer{})
 
 		err := cmd.Execute()
 
 		require.NoError(t, err)
-		assert.Contains(t, stdout.String(), "Moved to Inbox")
+		assert.NotContains(t, *args, "fetchTextBodyValues")
+		assert.NotContains(t, (*args)["properties"], "bodyValues")
+		output := stdout.String()
+		assert.Contains(t, output, "Subject: Invoice")
+		assert.Contains(t, output, "From:    Alice <alice@example.com>")
+		assert.Contains(t, output, "Size:    2.0 KB")
+		assert.Contains(t, output, "Folder:  Inbox")
+		assert.Contains(t, output, "Flags:   read, flagged")
 	})
 
-	t.Run("requires email ID and folder arguments", func(t *testing.T) {
-		f := &cmdutil.Factory{}
-		cmd := NewCmdMove(f)
-		cmd.SetArgs([]string{"email-1"})
-		cmd.SetOut(&bytes.Buffer{})
+	t.Run("outputs JSON", func(t *testing.T) {
+		f, stdout, _ := setup(t)
+
+		cmd := NewCmdInfo(f)
+		cmd.SetArgs([]string{"email-1", "--json"})
+		cmd.SetOut(stdout)
 		cmd.SetErr(&bytes.Buffer{})
 
 		err := cmd.Execute()
 
-		require.Error(t, err)
-		assert.Contains(t, err.Error(), "email ID and folder required")
+		require.NoError(t, err)
+		var result map[string]interface{}
+		require.NoError(t, json.Unmarshal(stdout.Bytes(), &result))
+		assert.Equal(t, "Invoice", result["subject"])
+		assert.Equal(t, float64(2048), result["size"])
+		assert.Equal(t, []interface{}{"Inbox"}, result["folders"])
+		assert.Equal(t, false, result["isUnread"])
+		assert.Equal(t, true, result["isFlagged"])
+		assert.Equal(t, false, result["isDraft"])
+		assert.Equal(t, []interface{}{"$flagged", "$seen"}, result["keywords"])
 	})
 }
 
-// Delete command tests
+func TestAttachmentsCommand(t *testing.T) {
+	t.Run("lists attachments with blob IDs", func(t *testing.T) {
+		f, stdout, _ := setupTest(t)
 
-func TestDeleteCommand(t *testing.T) {
-	t.Run("deletes email with --yes and --unsafe flags", func(t *testing.T) {
+		httpmock.RegisterResponder("POST", "https://api.test.com/jmap/api",
+			mockEmailGetResponse(map[string]interface{}{
+				"id":      "email-1",
+				"subject": "Invoice",
+				"attachments": []map[string]interface{}{
+					{"partId": "2", "blobId": "blob-pdf", "type": "application/pdf", "size": 2048, "name": "invoice.pdf"},
+					{"partId": "3", "blobId": "blob-img", "type": "image/png", "size": 512},
+				},
+			}))
+
+		cmd := NewCmdAttachments(f)
+		cmd.SetArgs([]string{"email-1"})
+		cmd.SetOut(stdout)
+		cmd.SetErr(&bytes.Buffer{})
+
+		err := cmd.Execute()
+
+		require.NoError(t, err)
+		output := stdout.String()
+		assert.Contains(t, output, "invoice.pdf")
+		assert.Contains(t, output, "application/pdf")
+		assert.Contains(t, output, "2.0 KB")
+		assert.Contains(t, output, "blob-pdf")
+		assert.Contains(t, output, "blob-img")
+		assert.NotContains(t, output, "Subject")
+	})
+
+	t.Run("outputs JSON", func(t *testing.T) {
+		f, stdout, _ := setupTest(t)
+
+		httpmock.RegisterResponder("POST", "https://api.test.com/jmap/api",
+			mockEmailGetResponse(map[string]interface{}{
+				"id": "email-1",
+				"attachments": []map[string]interface{}{
+					{"partId": "2", "blobId": "blob-pdf", "type": "application/pdf", "size": 2048, "name": "invoice.pdf"},
+				},
+			}))
+
+		cmd := NewCmdAttachments(f)
+		cmd.SetArgs([]string{"email-1", "--json"})
+		cmd.SetOut(stdout)
+		cmd.SetErr(&bytes.Buffer{})
+
+		err := cmd.Execute()
+
+		require.NoError(t, err)
+		var result []map[string]interface{}
+		require.NoError(t, json.Unmarshal(stdout.Bytes(), &result))
+		require.Len(t, result, 1)
+		assert.Equal(t, "blob-pdf", result[0]["blobId"])
+		assert.Equal(t, "invoice.pdf", result[0]["name"])
+	})
+
+	t.Run("reports emails without attachments", func(t *testing.T) {
+		f, stdout, _ := setupTest(t)
+
+		httpmock.RegisterResponder("POST", "https://api.test.com/jmap/api",
+			mockEmailGetResponse(map[string]interface{}{"id": "email-1"}))
+
+		cmd := NewCmdAttachments(f)
+		cmd.SetArgs([]string{"email-1", "--json"})
+		cmd.SetOut(stdout)
+		cmd.SetErr(&bytes.Buffer{})
+
+		err := cmd.Execute()
+
+		require.NoError(t, err)
+		assert.Equal(t, "[]\n", stdout.String())
+	})
+}
+
+func TestCountCommand(t *testing.T) {
+	t.Run("prints only the total", func(t *testing.T) {
 		f, stdout, _ := setupTest(t)
 
+		var args map[string]interface{}
+		var methods []string
 		httpmock.RegisterResponder("POST", "https://api.test.com/jmap/api",
 			func(req *http.Request) (*http.Response, error) {
 				var jmapReq jmap.Request
 				json.NewDecoder(req.Body).Decode(&jmapReq)
 
 				method := jmapReq.MethodCalls[0][0].(string)
-
 				switch method {
 				case "Mailbox/get":
 					return mockMailboxResponse([]map[string]interface{}{
-						{"id": "trash-1", "name": "Trash", "role": "trash"},
-					})(req)
-				case "Email/set":
-					return mockEmailSetResponse(map[string]interface{}{
-						"email-1": nil,
+						{"id": "inbox-1", "name": "Inbox", "role": "inbox"},
 					})(req)
-				default:
-					return httpmock.NewStringResponse(400, "unexpected"), nil
+				case "Email/query":
+					for _, call := range jmapReq.MethodCalls {
+						methods = append(methods, call[0].(string))
+					}
+					args = jmapReq.MethodCalls[0][1].(map[string]interface{})
+					return httpmock.NewJsonResponse(200, map[string]interface{}{
+						"methodResponses": [][]interface{}{
+							{"Email/query", map[string]interface{}{"ids": []string{}, "total": 7}, "query"},
+						},
+					})
 				}
+				return httpmock.NewStringResponse(400, "unexpected"), nil
 			})
 
-		cmd := NewCmdDelete(f)
-		// In test environment (non-TTY), both --yes and --unsafe are needed
-		cmd.SetArgs([]string{"email-1", "--yes", "--unsafe"})
+		cmd := NewCmdCount(f)
+		cmd.SetArgs([]string{"--folder", "inbox", "--unread"})
 		cmd.SetOut(stdout)
 		cmd.SetErr(&bytes.Buffer{})
 
 		err := cmd.Execute()
 
 		require.NoError(t, err)
-		assert.Contains(t, stdout.String(), "Moved to Trash")
+		assert.Equal(t, "7\n", stdout.String())
+		assert.Equal(t, []string{"Email/query"}, methods)
+		assert.Equal(t, true, args["calculateTotal"])
+		assert.Equal(t, float64(0), args["limit"])
+
+		filter := args["filter"].(map[string]interface{})
+		assert.Equal(t, "AND", filter["operator"])
+		assert.ElementsMatch(t, []interface{}{
+			map[string]interface{}{"notKeyword": "$seen"},
+			map[string]interface{}{"inMailbox": "inbox-1"},
+		}, filter["conditions"])
 	})
 
-	t.Run("blocks in safe mode without --unsafe", func(t *testing.T) {
-		// Test streams have stdinIsTTY=false, which triggers safe mode
+	t.Run("rejects an invalid --since", func(t *testing.T) {
 		f, _, _ := setupTest(t)
 
-		cmd := NewCmdDelete(f)
-		cmd.SetArgs([]string{"email-1", "--yes"})
+		cmd := NewCmdCount(f)
+		cmd.SetArgs([]string{"--since", "soon"})
 		cmd.SetOut(&bytes.Buffer{})
 		cmd.SetErr(&bytes.Buffer{})
 
 		err := cmd.Execute()
 
 		require.Error(t, err)
-		var safeModeErr *cmdutil.SafeModeError
-		assert.ErrorAs(t, err, &safeModeErr)
+		var flagErr *cmdutil.FlagError
+		assert.ErrorAs(t, err, &flagErr)
 	})
+}
 
-	t.Run("allows delete in safe mode with --unsafe", func(t *testing.T) {
-		// Test streams have stdinIsTTY=false (safe mode), but --unsafe overrides
+func TestSourceCommand(t *testing.T) {
+	t.Run("streams the raw message to stdout", func(t *testing.T) {
 		f, stdout, _ := setupTest(t)
 
+		httpmock.RegisterResponder("GET", "https://api.test.com/jmap/session",
+			httpmock.NewJsonResponderOrPanic(200, map[string]interface{}{
+				"apiUrl":      "https://api.test.com/jmap/api",
+				"downloadUrl": "https://api.test.com/jmap/download/{accountId}/{blobId}/{name}?type={type}",
+				"accounts": map[string]interface{}{
+					"account-1": map[string]interface{}{},
+				},
+			}))
+
+		var properties []interface{}
 		httpmock.RegisterResponder("POST", "https://api.test.com/jmap/api",
 			func(req *http.Request) (*http.Response, error) {
 				var jmapReq jmap.Request
 				json.NewDecoder(req.Body).Decode(&jmapReq)
+				properties = jmapReq.MethodCalls[0][1].(map[string]interface{})["properties"].([]interface{})
+				return mockEmailGetResponse(map[string]interface{}{"id": "email-1", "blobId": "blob-msg"})(req)
+			})
 
-				method := jmapReq.MethodCalls[0][0].(string)
-
-				switch method {
-				case "Mailbox/get":
-					return mockMailboxResponse([]map[string]interface{}{
-						{"id": "trash-1", "name": "Trash", "role": "trash"},
-					})(req)
-				case "Email/set":
-					return mockEmailSetResponse(map[string]interface{}{
-						"email-1": nil,
-					})(req)
-				default:
-					return httpmock.NewStringResponse(400, "unexpected"), nil
-				}
+		raw := "Received: from mx.example.com\r\nSubject: Hi\r\n\r\nHello\r\n"
+		var contentType string
+		httpmock.RegisterResponder("GET", `=~^https://api\.test\.com/jmap/download/account-1/blob-msg/`,
+			func(req *http.Request) (*http.Response, error) {
+				contentType = req.URL.Query().Get("type")
+				return httpmock.NewStringResponse(200, raw), nil
 			})
 
-		cmd := NewCmdDelete(f)
-		cmd.SetArgs([]string{"email-1", "--yes", "--unsafe"})
+		cmd := NewCmdSource(f)
+		cmd.SetArgs([]string{"email-1"})
 		cmd.SetOut(stdout)
 		cmd.SetErr(&bytes.Buffer{})
 
 		err := cmd.Execute()
 
 		require.NoError(t, err)
-		assert.Contains(t, stdout.String(), "Moved to Trash")
+		assert.Equal(t, raw, stdout.String())
+		assert.Equal(t, []interface{}{"blobId"}, properties)
+		assert.Equal(t, "message/rfc822", contentType)
 	})
 
-	t.Run("requires email ID argument", func(t *testing.T) {
-		f := &cmdutil.Factory{}
-		cmd := NewCmdDelete(f)
-		cmd.SetArgs([]string{})
+	t.Run("reports a failed download", func(t *testing.T) {
+		f, _, _ := setupTest(t)
+
+		httpmock.RegisterResponder("GET", "https://api.test.com/jmap/session",
+			httpmock.NewJsonResponderOrPanic(200, map[string]interface{}{
+				"apiUrl":      "https://api.test.com/jmap/api",
+				"downloadUrl": "https://api.test.com/jmap/download/{accountId}/{blobId}/{name}?type={type}",
+				"accounts": map[string]interface{}{
+					"account-1": map[string]interface{}{},
+				},
+			}))
+		httpmock.RegisterResponder("POST", "https://api.test.com/jmap/api",
+			mockEmailGetResponse(map[string]interface{}{"id": "email-1", "blobId": "blob-msg"}))
+		httpmock.RegisterResponder("GET", `=~^https://api\.test\.com/jmap/download/`,
+			httpmock.NewStringResponder(404, "not found"))
+
+		cmd := NewCmdSource(f)
+		cmd.SetArgs([]string{"email-1"})
 		cmd.SetOut(&bytes.Buffer{})
 		cmd.SetErr(&bytes.Buffer{})
 
 		err := cmd.Execute()
 
 		require.Error(t, err)
-		assert.Contains(t, err.Error(), "email ID required")
+		assert.Contains(t, err.Error(), "failed to download email-1.eml: 404")
 	})
 }
 
-// HTML to text conversion tests
+// Thread command tests
 
-func TestHtmlToText(t *testing.T) {
-	tests := []struct {
-		name     string
-		html     string
-		expected string
-	}{
-		{
-			name:     "simple text",
-			html:     "<p>Hello world</p>",
-			expected: "Hello world",
-		},
-		{
-			name:     "line breaks",
-			html:     "Line 1<br>Line 2<br/>Line 3",
-			expected: "Line 1\nLine 2\nLine 3",
-		},
-		{
-			name:     "paragraphs",
-			html:     "<p>First paragraph</p><p>Second paragraph</p>",
-			expected: "First paragraph\n\nSecond paragraph",
-		},
-		{
-			name:     "HTML entities",
-			html:     "Tom &amp; Jerry &lt;3 &quot;movies&quot;",
-			expected: "Tom & Jerry <3 \"movies\"",
-		},
-		{
-			name:     "strips style tags",
-			html:     "<style>body { color: red; }</style><p>Content</p>",
-			expected: "Content",
-		},
-		{
-			name:     "strips script tags",
-			html:     "<script>alert('hi');</script><p>Content</p>",
-			expected: "Content",
-		},
-	}
+func TestThreadCommand(t *testing.T) {
+	t.Run("displays thread emails", func(t *testing.T) {
+		f, stdout, _ := setupTest(t)
 
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			result := htmlToText(tt.html)
-			assert.Equal(t, tt.expected, result)
-		})
-	}
+		httpmock.RegisterResponder("POST", "https://api.test.com/jmap/api",
+			func(req *http.Request) (*http.Response, error) {
+				var jmapReq jmap.Request
+				json.NewDecoder(req.Body).Decode(&jmapReq)
+
+				method := jmapReq.MethodCalls[0][0].(string)
+
+				switch method {
+				case "Email/get":
+					// First call gets the email to find thread ID
+					return httpmock.NewJsonResponse(200, map[string]interface{}{
+						"methodResponses": [][]interface{}{
+							{"Email/get", map[string]interface{}{
+								"list": []map[string]interface{}{
+									{"id": "email-1", "threadId": "thread-1"},
+								},
+							}, "email"},
+						},
+					})
+				case "Thread/get":
+					return httpmock.NewJsonResponse(200, map[string]interface{}{
+						"methodResponses": [][]interface{}{
+							{"Thread/get", map[string]interface{}{
+								"list": []map[string]interface{}{
+									{"id": "thread-1", "emailIds": []string{"email-1", "email-2"}},
+								},
+							}, "getThread"},
+							{"Email/get", map[string]interface{}{
+								"list": []map[string]interface{}{
+									{
+										"id":         "email-1",
+										"threadId":   "thread-1",
+										"subject":    "Original message",
+										"from":       []map[string]string{{"name": "Alice", "email": "alice@example.com"}},
+										"receivedAt": time.Now().Add(-2 * time.Hour).Format(time.RFC3339),
+										"preview":    "First email content",
+									},
+									{
+										"id":         "email-2",
+										"threadId":   "thread-1",
+										"subject":    "Re: Original message",
+										"from":       []map[string]string{{"name": "Bob", "email": "bob@example.com"}},
+										"receivedAt": time.Now().Add(-1 * time.Hour).Format(time.RFC3339),
+										"preview":    "Reply content",
+									},
+								},
+							}, "emails"},
+						},
+					})
+				default:
+					return httpmock.NewStringResponse(400, "unexpected"), nil
+				}
+			})
+
+		cmd := NewCmdThread(f)
+		cmd.SetArgs([]string{"email-1"})
+		cmd.SetOut(stdout)
+		cmd.SetErr(&bytes.Buffer{})
+
+		err := cmd.Execute()
+
+		require.NoError(t, err)
+		output := stdout.String()
+		assert.Contains(t, output, "Thread with 2 emails")
+		assert.Contains(t, output, "Alice")
+		assert.Contains(t, output, "Bob")
+		assert.Contains(t, output, "Original message")
+		assert.Contains(t, output, "Re: Original message")
+	})
+
+	t.Run("outputs JSON format", func(t *testing.T) {
+		f, stdout, _ := setupTest(t)
+
+		httpmock.RegisterResponder("POST", "https://api.test.com/jmap/api",
+			func(req *http.Request) (*http.Response, error) {
+				var jmapReq jmap.Request
+				json.NewDecoder(req.Body).Decode(&jmapReq)
+
+				method := jmapReq.MethodCalls[0][0].(string)
+
+				if method == "Email/get" {
+					return httpmock.NewJsonResponse(200, map[string]interface{}{
+						"methodResponses": [][]interface{}{
+							{"Email/get", map[string]interface{}{
+								"list": []map[string]interface{}{
+									{"id": "email-1", "threadId": "thread-1"},
+								},
+							}, "email"},
+						},
+					})
+				}
+
+				return httpmock.NewJsonResponse(200, map[string]interface{}{
+					"methodResponses": [][]interface{}{
+						{"Thread/get", map[string]interface{}{
+							"list": []map[string]interface{}{
+								{"id": "thread-1", "emailIds": []string{"email-1"}},
+							},
+						}, "getThread"},
+						{"Email/get", map[string]interface{}{
+							"list": []map[string]interface{}{
+								{
+									"id":         "email-1",
+									"subject":    "Test",
+									"receivedAt": "2024-01-15T10:30:00Z",
+								},
+							},
+						}, "emails"},
+					},
+				})
+			})
+
+		cmd := NewCmdThread(f)
+		cmd.SetArgs([]string{"email-1", "--json"})
+		cmd.SetOut(stdout)
+		cmd.SetErr(&bytes.Buffer{})
+
+		err := cmd.Execute()
+
+		require.NoError(t, err)
+
+		var result []map[string]interface{}
+		err = json.Unmarshal(stdout.Bytes(), &result)
+		require.NoError(t, err)
+		assert.Len(t, result, 1)
+	})
+}
+
+// Archive command tests
+
+func TestArchiveCommand(t *testing.T) {
+	t.Run("archives single email", func(t *testing.T) {
+		f, stdout, _ := setupTest(t)
+
+		httpmock.RegisterResponder("POST", "https://api.test.com/jmap/api",
+			func(req *http.Request) (*http.Response, error) {
+				var jmapReq jmap.Request
+				json.NewDecoder(req.Body).Decode(&jmapReq)
+
+				method := jmapReq.MethodCalls[0][0].(string)
+
+				switch method {
+				case "Mailbox/get":
+					return mockMailboxResponse([]map[string]interface{}{
+						{"id": "archive-1", "name": "Archive", "role": "archive"},
+					})(req)
+				case "Email/set":
+					return mockEmailSetResponse(map[string]interface{}{
+						"email-1": nil,
+					})(req)
+				default:
+					return httpmock.NewStringResponse(400, "unexpected: "+method), nil
+				}
+			})
+
+		cmd := NewCmdArchive(f)
+		cmd.SetArgs([]string{"email-1"})
+		cmd.SetOut(stdout)
+		cmd.SetErr(&bytes.Buffer{})
+
+		err := cmd.Execute()
+
+		require.NoError(t, err)
+		assert.Contains(t, stdout.String(), "Moved to Archive")
+	})
+
+	t.Run("archives multiple emails", func(t *testing.T) {
+		f, stdout, _ := setupTest(t)
+
+		httpmock.RegisterResponder("POST", "https://api.test.com/jmap/api",
+			func(req *http.Request) (*http.Response, error) {
+				var jmapReq jmap.Request
+				json.NewDecoder(req.Body).Decode(&jmapReq)
+
+				method := jmapReq.MethodCalls[0][0].(string)
+
+				switch method {
+				case "Mailbox/get":
+					return mockMailboxResponse([]map[string]interface{}{
+						{"id": "archive-1", "name": "Archive", "role": "archive"},
+					})(req)
+				case "Email/set":
+					return httpmock.NewJsonResponse(200, map[string]interface{}{
+						"methodResponses": [][]interface{}{
+							{"Email/set", map[string]interface{}{
+								"updated": map[string]interface{}{
+									"email-1": nil,
+									"email-2": nil,
+									"email-3": nil,
+								},
+							}, "bulkArchive"},
+						},
+					})
+				default:
+					return httpmock.NewStringResponse(400, "unexpected"), nil
+				}
+			})
+
+		cmd := NewCmdArchive(f)
+		cmd.SetArgs([]string{"email-1", "email-2", "email-3"})
+		cmd.SetOut(stdout)
+		cmd.SetErr(&bytes.Buffer{})
+
+		err := cmd.Execute()
+
+		require.NoError(t, err)
+		assert.Contains(t, stdout.String(), "Archived 3 emails")
+	})
+
+	t.Run("marks archived emails read in the same update", func(t *testing.T) {
+		f, stdout, _ := setupTest(t)
+
+		var updated map[string]interface{}
+		httpmock.RegisterResponder("POST", "https://api.test.com/jmap/api", mockMoveResponse([]map[string]interface{}{
+			{"id": "archive-1", "name": "Archive", "role": "archive"},
+		}, &updated))
+
+		cmd := NewCmdArchive(f)
+		cmd.SetArgs([]string{"email-1", "--mark-read"})
+		cmd.SetOut(stdout)
+		cmd.SetErr(&bytes.Buffer{})
+
+		err := cmd.Execute()
+
+		require.NoError(t, err)
+		assert.Equal(t, "Archived 1 emails and marked them read.\n", stdout.String())
+		assert.Equal(t, map[string]interface{}{
+			"email-1": map[string]interface{}{
+				"mailboxIds":     map[string]interface{}{"archive-1": true},
+				"keywords/$seen": true,
+			},
+		}, updated)
+		assert.Equal(t, 2, httpmock.GetCallCountInfo()["POST https://api.test.com/jmap/api"], "one Mailbox/get and one Email/set")
+	})
+
+	t.Run("requires at least one email ID", func(t *testing.T) {
+		f := &cmdutil.Factory{}
+		cmd := NewCmdArchive(f)
+		cmd.SetArgs([]string{})
+		cmd.SetOut(&bytes.Buffer{})
+		cmd.SetErr(&bytes.Buffer{})
+
+		err := cmd.Execute()
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "at least one email ID required")
+	})
+}
+
+func TestArchiveQueryCommand(t *testing.T) {
+	mockArchiveQuery := func(update *map[string]interface{}) httpmock.Responder {
+		return func(req *http.Request) (*http.Response, error) {
+			var jmapReq jmap.Request
+			json.NewDecoder(req.Body).Decode(&jmapReq)
+
+			method := jmapReq.MethodCalls[0][0].(string)
+			args := jmapReq.MethodCalls[0][1].(map[string]interface{})
+
+			switch method {
+			case "Mailbox/get":
+				return mockMailboxResponse([]map[string]interface{}{
+					{"id": "archive-1", "name": "Archive", "role": "archive"},
+				})(req)
+			case "Email/query":
+				list := []map[string]interface{}{
+					{"id": "email-1", "from": []map[string]string{{"email": "news@example.com"}}},
+					{"id": "email-2", "from": []map[string]string{{"email": "News@Example.com"}}},
+					{"id": "email-3", "from": []map[string]string{{"email": "deals@shop.example"}}},
+				}
+				if limit := int(args["limit"].(float64)); limit < len(list) {
+					list = list[:limit]
+				}
+				return httpmock.NewJsonResponse(200, map[string]interface{}{
+					"methodResponses": [][]interface{}{
+						{"Email/query", map[string]interface{}{"ids": []string{}, "total": 3}, "query"},
+						{"Email/get", map[string]interface{}{"list": list}, "emails"},
+					},
+				})
+			case "Email/set":
+				*update = args["update"].(map[string]interface{})
+				return mockEmailSetResponse(*update)(req)
+			default:
+				return httpmock.NewStringResponse(400, "unexpected: "+method), nil
+			}
+		}
+	}
+
+	t.Run("archives matches after showing a summary", func(t *testing.T) {
+		f, stdout, stderr := setupTest(t)
+		f.IOStreams.SetStdinTTY(true)
+		f.IOStreams.SetStdoutTTY(true)
+		f.IOStreams.In = strings.NewReader("y\n")
+
+		var update map[string]interface{}
+		httpmock.RegisterResponder("POST", "https://api.test.com/jmap/api", mockArchiveQuery(&update))
+
+		cmd := NewCmdArchive(f)
+		cmd.SetArgs([]string{"--query", "from:example"})
+		cmd.SetOut(stdout)
+		cmd.SetErr(&bytes.Buffer{})
+
+		err := cmd.Execute()
+
+		require.NoError(t, err)
+		assert.Contains(t, stderr.String(), "About to archive 3 emails from 2 senders:")
+		assert.Contains(t, stderr.String(), "     2  news@example.com\n")
+		assert.Contains(t, stderr.String(), "     1  deals@shop.example\n")
+		assert.Equal(t, "Archived 3 emails.\n", stdout.String())
+		assert.Len(t, update, 3)
+	})
+
+	t.Run("reports matches beyond --limit", func(t *testing.T) {
+		f, stdout, stderr := setupTest(t)
+		f.IOStreams.SetStdinTTY(true)
+		f.IOStreams.SetStdoutTTY(true)
+		f.IOStreams.In = strings.NewReader("y\n")
+
+		var update map[string]interface{}
+		httpmock.RegisterResponder("POST", "https://api.test.com/jmap/api", mockArchiveQuery(&update))
+
+		cmd := NewCmdArchive(f)
+		cmd.SetArgs([]string{"--query", "from:example", "--limit", "2"})
+		cmd.SetOut(stdout)
+		cmd.SetErr(&bytes.Buffer{})
+
+		err := cmd.Execute()
+
+		require.NoError(t, err)
+		assert.Contains(t, stderr.String(), "About to archive 2 of 3 matching emails from 1 sender:")
+		assert.Len(t, update, 2)
+	})
+
+	t.Run("cancels when not confirmed", func(t *testing.T) {
+		f, _, _ := setupTest(t)
+		f.IOStreams.SetStdinTTY(true)
+		f.IOStreams.SetStdoutTTY(true)
+		f.IOStreams.In = strings.NewReader("n\n")
+
+		var update map[string]interface{}
+		httpmock.RegisterResponder("POST", "https://api.test.com/jmap/api", mockArchiveQuery(&update))
+
+		cmd := NewCmdArchive(f)
+		cmd.SetArgs([]string{"--query", "from:example"})
+		cmd.SetOut(&bytes.Buffer{})
+		cmd.SetErr(&bytes.Buffer{})
+
+		err := cmd.Execute()
+
+		assert.ErrorIs(t, err, cmdutil.CancelError)
+		assert.Nil(t, update)
+	})
+
+	t.Run("blocks in safe mode without --unsafe", func(t *testing.T) {
+		f, _, _ := setupTest(t)
+
+		cmd := NewCmdArchive(f)
+		cmd.SetArgs([]string{"--query", "from:example", "--yes"})
+		cmd.SetOut(&bytes.Buffer{})
+		cmd.SetErr(&bytes.Buffer{})
+
+		err := cmd.Execute()
+
+		require.Error(t, err)
+		var safeModeErr *cmdutil.SafeModeError
+		assert.ErrorAs(t, err, &safeModeErr)
+	})
+
+	t.Run("requires --yes in non-interactive mode", func(t *testing.T) {
+		f, _, _ := setupTest(t)
+
+		var update map[string]interface{}
+		httpmock.RegisterResponder("POST", "https://api.test.com/jmap/api", mockArchiveQuery(&update))
+
+		cmd := NewCmdArchive(f)
+		cmd.SetArgs([]string{"--query", "from:example", "--unsafe"})
+		cmd.SetOut(&bytes.Buffer{})
+		cmd.SetErr(&bytes.Buffer{})
+
+		err := cmd.Execute()
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "requires --yes")
+		assert.Nil(t, update)
+	})
+
+	t.Run("lists matches with --dry-run, even in safe mode", func(t *testing.T) {
+		f, stdout, stderr := setupTest(t)
+
+		var update map[string]interface{}
+		httpmock.RegisterResponder("POST", "https://api.test.com/jmap/api", mockArchiveQuery(&update))
+
+		cmd := NewCmdArchive(f)
+		cmd.SetArgs([]string{"--query", "from:example", "--dry-run"})
+		cmd.SetOut(stdout)
+		cmd.SetErr(&bytes.Buffer{})
+
+		err := cmd.Execute()
+
+		require.NoError(t, err)
+		assert.Contains(t, stdout.String(), "email-1")
+		assert.Contains(t, stdout.String(), "deals")
+		assert.Contains(t, stderr.String(), "Dry run: 3 emails would be archived. Nothing was changed.")
+		assert.Nil(t, update)
+	})
+
+	t.Run("rejects --limit below 1", func(t *testing.T) {
+		for _, limit := range []string{"0", "-1"} {
+			f, _, _ := setupTest(t)
+
+			cmd := NewCmdArchive(f)
+			cmd.SetArgs([]string{"--query", "from:example", "--unsafe", "--yes", "--limit", limit})
+			cmd.SetOut(&bytes.Buffer{})
+			cmd.SetErr(&bytes.Buffer{})
+
+			err := cmd.Execute()
+
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), "invalid --limit "+limit)
+			assert.Zero(t, httpmock.GetTotalCallCount())
+		}
+	})
+
+	t.Run("rejects a blank query", func(t *testing.T) {
+		for _, query := range []string{"", "   "} {
+			f, _, _ := setupTest(t)
+
+			cmd := NewCmdArchive(f)
+			cmd.SetArgs([]string{"--query", query, "--unsafe", "--yes"})
+			cmd.SetOut(&bytes.Buffer{})
+			cmd.SetErr(&bytes.Buffer{})
+
+			err := cmd.Execute()
+
+			require.Error(t, err)
+			var flagErr *cmdutil.FlagError
+			assert.ErrorAs(t, err, &flagErr)
+			assert.Contains(t, err.Error(), "--query cannot be empty")
+			assert.Zero(t, httpmock.GetTotalCallCount())
+		}
+	})
+
+	t.Run("rejects IDs with --query", func(t *testing.T) {
+		f, _, _ := setupTest(t)
+
+		cmd := NewCmdArchive(f)
+		cmd.SetArgs([]string{"email-1", "--query", "from:example"})
+		cmd.SetOut(&bytes.Buffer{})
+		cmd.SetErr(&bytes.Buffer{})
+
+		err := cmd.Execute()
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "cannot combine email IDs with --query")
+	})
+}
+
+// Move command tests
+
+func TestMoveCommand(t *testing.T) {
+	t.Run("moves email to folder by name", func(t *testing.T) {
+		f, stdout, _ := setupTest(t)
+
+		httpmock.RegisterResponder("POST", "https://api.test.com/jmap/api",
+			func(req *http.Request) (*http.Response, error) {
+				var jmapReq jmap.Request
+				json.NewDecoder(req.Body).Decode(&jmapReq)
+
+				method := jmapReq.MethodCalls[0][0].(string)
+
+				switch method {
+				case "Mailbox/get":
+					return mockMailboxResponse([]map[string]interface{}{
+						{"id": "inbox-1", "name": "Inbox", "role": "inbox"},
+						{"id": "work-1", "name": "Work", "role": ""},
+					})(req)
+				case "Email/set":
+					return mockEmailSetResponse(map[string]interface{}{
+						"email-1": nil,
+					})(req)
+				default:
+					return httpmock.NewStringResponse(400, "unexpected"), nil
+				}
+			})
+
+		cmd := NewCmdMove(f)
+		cmd.SetArgs([]string{"email-1", "Work"})
+		cmd.SetOut(stdout)
+		cmd.SetErr(&bytes.Buffer{})
+
+		err := cmd.Execute()
+
+		require.NoError(t, err)
+		assert.Contains(t, stdout.String(), "Moved to Work")
+	})
+
+	t.Run("moves email to folder by role", func(t *testing.T) {
+		f, stdout, _ := setupTest(t)
+
+		httpmock.RegisterResponder("POST", "https://api.test.com/jmap/api",
+			func(req *http.Request) (*http.Response, error) {
+				var jmapReq jmap.Request
+				json.NewDecoder(req.Body).Decode(&jmapReq)
+
+				method := jmapReq.MethodCalls[0][0].(string)
+
+				switch method {
+				case "Mailbox/get":
+					return mockMailboxResponse([]map[string]interface{}{
+						{"id": "inbox-1", "name": "Inbox", "role": "inbox"},
+					})(req)
+				case "Email/set":
+					return mockEmailSetResponse(map[string]interface{}{
+						"email-1": nil,
+					})(req)
+				default:
+					return httpmock.NewStringResponse(400, "unexpected"), nil
+				}
+			})
+
+		cmd := NewCmdMove(f)
+		cmd.SetArgs([]string{"email-1", "inbox"})
+		cmd.SetOut(stdout)
+		cmd.SetErr(&bytes.Buffer{})
+
+		err := cmd.Execute()
+
+		require.NoError(t, err)
+		assert.Contains(t, stdout.String(), "Moved to Inbox")
+	})
+
+	t.Run("requires email ID and folder arguments", func(t *testing.T) {
+		f := &cmdutil.Factory{}
+		cmd := NewCmdMove(f)
+		cmd.SetArgs([]string{"email-1"})
+		cmd.SetOut(&bytes.Buffer{})
+		cmd.SetErr(&bytes.Buffer{})
+
+		err := cmd.Execute()
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "email ID and folder required")
+	})
+}
+
+// fuzzyMailboxes are folders with overlapping names for partial matching tests.
+var fuzzyMailboxes = []map[string]interface{}{
+	{"id": "inbox-1", "name": "Inbox", "role": "inbox"},
+	{"id": "proj-1", "name": "Projects 2024"},
+	{"id": "proj-2", "name": "Projects 2025"},
+	{"id": "rcpt-1", "name": "Receipts and Invoices"},
+}
+
+func TestMoveCommandKeywords(t *testing.T) {
+	t.Run("only replaces mailboxIds", func(t *testing.T) {
+		f, stdout, _ := setupTest(t)
+
+		var updated map[string]interface{}
+		httpmock.RegisterResponder("POST", "https://api.test.com/jmap/api", mockMoveResponse(fuzzyMailboxes, &updated))
+
+		cmd := NewCmdMove(f)
+		cmd.SetArgs([]string{"email-1", "Inbox"})
+		cmd.SetOut(stdout)
+		cmd.SetErr(&bytes.Buffer{})
+
+		err := cmd.Execute()
+
+		require.NoError(t, err)
+		// Keywords such as $seen must not be touched by a move
+		assert.Equal(t, map[string]interface{}{
+			"email-1": map[string]interface{}{"mailboxIds": map[string]interface{}{"inbox-1": true}},
+		}, updated)
+	})
+
+	t.Run("marks read in the same update with --mark-read", func(t *testing.T) {
+		f, stdout, _ := setupTest(t)
+
+		var updated map[string]interface{}
+		httpmock.RegisterResponder("POST", "https://api.test.com/jmap/api", mockMoveResponse(fuzzyMailboxes, &updated))
+
+		cmd := NewCmdMove(f)
+		cmd.SetArgs([]string{"email-1", "email-2", "Inbox", "--mark-read"})
+		cmd.SetOut(stdout)
+		cmd.SetErr(&bytes.Buffer{})
+
+		err := cmd.Execute()
+
+		require.NoError(t, err)
+		patch := map[string]interface{}{
+			"mailboxIds":     map[string]interface{}{"inbox-1": true},
+			"keywords/$seen": true,
+		}
+		assert.Equal(t, map[string]interface{}{"email-1": patch, "email-2": patch}, updated)
+		assert.Equal(t, "Moved 2 emails to Inbox and marked them read.\n", stdout.String())
+		// Mailbox/get and a single Email/set
+		assert.Equal(t, 2, httpmock.GetCallCountInfo()["POST https://api.test.com/jmap/api"])
+	})
+}
+
+func TestMoveCommandFuzzyFolder(t *testing.T) {
+	t.Run("uses the only folder containing the input", func(t *testing.T) {
+		f, stdout, stderr := setupTest(t)
+
+		var updated map[string]interface{}
+		httpmock.RegisterResponder("POST", "https://api.test.com/jmap/api", mockMoveResponse(fuzzyMailboxes, &updated))
+
+		cmd := NewCmdMove(f)
+		cmd.SetArgs([]string{"email-1", "receipts"})
+		cmd.SetOut(stdout)
+		cmd.SetErr(&bytes.Buffer{})
+
+		err := cmd.Execute()
+
+		require.NoError(t, err)
+		assert.Equal(t, map[string]interface{}{
+			"email-1": map[string]interface{}{"mailboxIds": map[string]interface{}{"rcpt-1": true}},
+		}, updated)
+		assert.Contains(t, stdout.String(), "Moved to Receipts and Invoices")
+		assert.Contains(t, stderr.String(), `Resolved "receipts" to folder Receipts and Invoices`)
+	})
+
+	t.Run("lists matches when several folders match", func(t *testing.T) {
+		f, _, _ := setupTest(t)
+
+		var updated map[string]interface{}
+		httpmock.RegisterResponder("POST", "https://api.test.com/jmap/api", mockMoveResponse(fuzzyMailboxes, &updated))
+
+		cmd := NewCmdMove(f)
+		cmd.SetArgs([]string{"email-1", "projects"})
+		cmd.SetOut(&bytes.Buffer{})
+		cmd.SetErr(&bytes.Buffer{})
+
+		err := cmd.Execute()
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `"projects" matches multiple folders: Projects 2024, Projects 2025`)
+		assert.Nil(t, updated)
+	})
+
+	t.Run("prompts when several folders match and interactive", func(t *testing.T) {
+		f, _, stderr := setupTest(t)
+		f.IOStreams.SetStdinTTY(true)
+		f.IOStreams.SetStdoutTTY(true)
+		f.IOStreams.In = strings.NewReader("2\n")
+
+		var updated map[string]interface{}
+		httpmock.RegisterResponder("POST", "https://api.test.com/jmap/api", mockMoveResponse(fuzzyMailboxes, &updated))
+
+		cmd := NewCmdMove(f)
+		cmd.SetArgs([]string{"email-1", "projects"})
+		cmd.SetOut(&bytes.Buffer{})
+		cmd.SetErr(&bytes.Buffer{})
+
+		err := cmd.Execute()
+
+		require.NoError(t, err)
+		assert.Contains(t, stderr.String(), `Multiple folders match "projects"`)
+		assert.Equal(t, map[string]interface{}{
+			"email-1": map[string]interface{}{"mailboxIds": map[string]interface{}{"proj-2": true}},
+		}, updated)
+	})
+
+	t.Run("errors when nothing matches", func(t *testing.T) {
+		f, _, _ := setupTest(t)
+
+		var updated map[string]interface{}
+		httpmock.RegisterResponder("POST", "https://api.test.com/jmap/api", mockMoveResponse(fuzzyMailboxes, &updated))
+
+		cmd := NewCmdMove(f)
+		cmd.SetArgs([]string{"email-1", "Taxes"})
+		cmd.SetOut(&bytes.Buffer{})
+		cmd.SetErr(&bytes.Buffer{})
+
+		err := cmd.Execute()
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "folder not found: Taxes")
+	})
+}
+
+// mockMoveResponse serves mailboxes and records the Email/set update map.
+func mockMoveResponse(mailboxes []map[string]interface{}, updated *map[string]interface{}) httpmock.Responder {
+	return func(req *http.Request) (*http.Response, error) {
+		var jmapReq jmap.Request
+		json.NewDecoder(req.Body).Decode(&jmapReq)
+
+		switch jmapReq.MethodCalls[0][0].(string) {
+		case "Mailbox/get":
+			return mockMailboxResponse(mailboxes)(req)
+		case "Email/set":
+			args := jmapReq.MethodCalls[0][1].(map[string]interface{})
+			*updated = args["update"].(map[string]interface{})
+			result := make(map[string]interface{})
+			for id := range *updated {
+				result[id] = nil
+			}
+			return mockEmailSetResponse(result)(req)
+		default:
+			return httpmock.NewStringResponse(400, "unexpected"), nil
+		}
+	}
+}
+
+func TestMoveCommandCreateFolder(t *testing.T) {
+	// mockCreate serves Clients and Old Projects folders, records created
+	// folders, and records the Email/set update map.
+	mockCreate := func(created *[]map[string]interface{}, updated *map[string]interface{}) httpmock.Responder {
+		move := mockMoveResponse([]map[string]interface{}{
+			{"id": "inbox-1", "name": "Inbox", "role": "inbox"},
+			{"id": "clients-1", "name": "Clients"},
+			{"id": "old-projects-1", "name": "Old Projects"},
+		}, updated)
+		return func(req *http.Request) (*http.Response, error) {
+			var jmapReq jmap.Request
+			body, _ := io.ReadAll(req.Body)
+			json.Unmarshal(body, &jmapReq)
+			req.Body = io.NopCloser(bytes.NewReader(body))
+
+			if jmapReq.MethodCalls[0][0].(string) != "Mailbox/set" {
+				return move(req)
+			}
+			args := jmapReq.MethodCalls[0][1].(map[string]interface{})
+			mailbox := args["create"].(map[string]interface{})["newMailbox"].(map[string]interface{})
+			*created = append(*created, mailbox)
+			return httpmock.NewJsonResponse(200, map[string]interface{}{
+				"methodResponses": [][]interface{}{
+					{"Mailbox/set", map[string]interface{}{
+						"created": map[string]interface{}{
+							"newMailbox": map[string]interface{}{"id": fmt.Sprintf("new-%d", len(*created))},
+						},
+					}, "createMailbox"},
+				},
+			})
+		}
+	}
+
+	t.Run("creates missing folders along the path", func(t *testing.T) {
+		f, stdout, _ := setupTest(t)
+
+		var created []map[string]interface{}
+		var updated map[string]interface{}
+		httpmock.RegisterResponder("POST", "https://api.test.com/jmap/api", mockCreate(&created, &updated))
+
+		cmd := NewCmdMove(f)
+		cmd.SetArgs([]string{"email-1", "email-2", "Clients/Acme/2024", "--create-folder"})
+		cmd.SetOut(stdout)
+		cmd.SetErr(&bytes.Buffer{})
+
+		err := cmd.Execute()
+
+		require.NoError(t, err)
+		assert.Equal(t, []map[string]interface{}{
+			{"name": "Acme", "parentId": "clients-1"},
+			{"name": "2024", "parentId": "new-1"},
+		}, created)
+		assert.Equal(t, map[string]interface{}{"new-2": true}, updated["email-1"].(map[string]interface{})["mailboxIds"])
+		assert.Equal(t, "Created folder Clients/Acme/2024 and moved 2 emails.\n", stdout.String())
+	})
+
+	t.Run("creates the folder rather than using a similar name", func(t *testing.T) {
+		f, stdout, _ := setupTest(t)
+
+		var created []map[string]interface{}
+		var updated map[string]interface{}
+		httpmock.RegisterResponder("POST", "https://api.test.com/jmap/api", mockCreate(&created, &updated))
+
+		cmd := NewCmdMove(f)
+		cmd.SetArgs([]string{"email-1", "email-2", "Projects", "--create-folder"})
+		cmd.SetOut(stdout)
+		cmd.SetErr(&bytes.Buffer{})
+
+		err := cmd.Execute()
+
+		require.NoError(t, err)
+		assert.Equal(t, []map[string]interface{}{{"name": "Projects"}}, created)
+		assert.Equal(t, map[string]interface{}{"new-1": true}, updated["email-1"].(map[string]interface{})["mailboxIds"])
+		assert.Equal(t, "Created folder Projects and moved 2 emails.\n", stdout.String())
+	})
+
+	t.Run("requires --create-folder when not interactive", func(t *testing.T) {
+		f, _, _ := setupTest(t)
+
+		var created []map[string]interface{}
+		var updated map[string]interface{}
+		httpmock.RegisterResponder("POST", "https://api.test.com/jmap/api", mockCreate(&created, &updated))
+
+		cmd := NewCmdMove(f)
+		cmd.SetArgs([]string{"email-1", "Receipts"})
+		cmd.SetOut(&bytes.Buffer{})
+		cmd.SetErr(&bytes.Buffer{})
+
+		err := cmd.Execute()
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "folder not found: Receipts")
+		assert.Contains(t, err.Error(), "--create-folder")
+		assert.Empty(t, created)
+		assert.Nil(t, updated)
+	})
+
+	t.Run("asks before creating when interactive", func(t *testing.T) {
+		f, stdout, stderr := setupTest(t)
+		f.IOStreams.SetStdinTTY(true)
+		f.IOStreams.SetStdoutTTY(true)
+		f.IOStreams.In = strings.NewReader("y\n")
+
+		var created []map[string]interface{}
+		var updated map[string]interface{}
+		httpmock.RegisterResponder("POST", "https://api.test.com/jmap/api", mockCreate(&created, &updated))
+
+		cmd := NewCmdMove(f)
+		cmd.SetArgs([]string{"email-1", "Receipts"})
+		cmd.SetOut(stdout)
+		cmd.SetErr(&bytes.Buffer{})
+
+		err := cmd.Execute()
+
+		require.NoError(t, err)
+		assert.Contains(t, stderr.String(), `Folder "Receipts" doesn't exist. Create it?`)
+		assert.Equal(t, []map[string]interface{}{{"name": "Receipts"}}, created)
+		assert.Contains(t, stdout.String(), "Created folder Receipts and moved 1 emails.")
+	})
+
+	t.Run("moves to an existing nested folder without asking", func(t *testing.T) {
+		f, stdout, stderr := setupTest(t)
+		f.IOStreams.SetStdinTTY(true)
+		f.IOStreams.SetStdoutTTY(true)
+
+		var updated map[string]interface{}
+		httpmock.RegisterResponder("POST", "https://api.test.com/jmap/api", mockMoveResponse([]map[string]interface{}{
+			{"id": "clients-1", "name": "Clients"},
+			{"id": "acme-1", "name": "Acme", "parentId": "clients-1"},
+		}, &updated))
+
+		cmd := NewCmdMove(f)
+		cmd.SetArgs([]string{"email-1", "email-2", "Clients/Acme"})
+		cmd.SetOut(stdout)
+		cmd.SetErr(&bytes.Buffer{})
+
+		err := cmd.Execute()
+
+		require.NoError(t, err)
+		assert.NotContains(t, stderr.String(), "Create it?")
+		assert.Equal(t, map[string]interface{}{"mailboxIds": map[string]interface{}{"acme-1": true}}, updated["email-1"])
+		assert.Equal(t, "Moved 2 emails to Acme.\n", stdout.String())
+	})
+
+	t.Run("cancels when the prompt is declined", func(t *testing.T) {
+		f, _, _ := setupTest(t)
+		f.IOStreams.SetStdinTTY(true)
+		f.IOStreams.SetStdoutTTY(true)
+		f.IOStreams.In = strings.NewReader("n\n")
+
+		var created []map[string]interface{}
+		var updated map[string]interface{}
+		httpmock.RegisterResponder("POST", "https://api.test.com/jmap/api", mockCreate(&created, &updated))
+
+		cmd := NewCmdMove(f)
+		cmd.SetArgs([]string{"email-1", "Receipts"})
+		cmd.SetOut(&bytes.Buffer{})
+		cmd.SetErr(&bytes.Buffer{})
+
+		err := cmd.Execute()
+
+		assert.ErrorIs(t, err, cmdutil.CancelError)
+		assert.Empty(t, created)
+		assert.Nil(t, updated)
+	})
+}
+
+func TestMoveCommandDryRun(t *testing.T) {
+	// mockDryRun serves a Work folder and email summaries, and fails any
+	// request that would change something.
+	mockDryRun := func(req *http.Request) (*http.Response, error) {
+		var jmapReq jmap.Request
+		json.NewDecoder(req.Body).Decode(&jmapReq)
+
+		switch jmapReq.MethodCalls[0][0].(string) {
+		case "Mailbox/get":
+			return mockMailboxResponse([]map[string]interface{}{
+				{"id": "work-1", "name": "Work"},
+				{"id": "old-projects-1", "name": "Old Projects"},
+			})(req)
+		case "Email/get":
+			return httpmock.NewJsonResponse(200, map[string]interface{}{
+				"methodResponses": [][]interface{}{
+					{"Email/get", map[string]interface{}{
+						"list": []map[string]interface{}{
+							{"id": "email-1", "subject": "Quarterly report"},
+							{"id": "email-2", "subject": "Budget"},
+						},
+					}, "email"},
+				},
+			})
+		default:
+			return httpmock.NewStringResponse(400, "unexpected"), nil
+		}
+	}
+
+	t.Run("lists the emails without moving them", func(t *testing.T) {
+		f, stdout, stderr := setupTest(t)
+		httpmock.RegisterResponder("POST", "https://api.test.com/jmap/api", mockDryRun)
+
+		cmd := NewCmdMove(f)
+		cmd.SetArgs([]string{"email-1", "email-2", "Work", "--mark-read", "--dry-run"})
+		cmd.SetOut(stdout)
+		cmd.SetErr(&bytes.Buffer{})
+
+		err := cmd.Execute()
+
+		require.NoError(t, err)
+		assert.Contains(t, stdout.String(), "Quarterly report")
+		assert.Contains(t, stdout.String(), "Budget")
+		assert.Contains(t, stderr.String(), "Dry run: 2 emails would be moved to Work and marked read.")
+	})
+
+	t.Run("doesn't create a missing folder", func(t *testing.T) {
+		f, _, stderr := setupTest(t)
+		httpmock.RegisterResponder("POST", "https://api.test.com/jmap/api", mockDryRun)
+
+		cmd := NewCmdMove(f)
+		cmd.SetArgs([]string{"email-1", "email-2", "Clients/Acme", "--create-folder", "--dry-run"})
+		cmd.SetOut(&bytes.Buffer{})
+		cmd.SetErr(&bytes.Buffer{})
+
+		err := cmd.Execute()
+
+		require.NoError(t, err)
+		assert.Contains(t, stderr.String(), "would be moved to new folder Clients/Acme.")
+	})
+
+	t.Run("reports a new folder rather than a similar name", func(t *testing.T) {
+		f, _, stderr := setupTest(t)
+		httpmock.RegisterResponder("POST", "https://api.test.com/jmap/api", mockDryRun)
+
+		cmd := NewCmdMove(f)
+		cmd.SetArgs([]string{"email-1", "email-2", "Projects", "--create-folder", "--dry-run"})
+		cmd.SetOut(&bytes.Buffer{})
+		cmd.SetErr(&bytes.Buffer{})
+
+		err := cmd.Execute()
+
+		require.NoError(t, err)
+		assert.Contains(t, stderr.String(), "would be moved to new folder Projects.")
+	})
+}
+
+// Move-to-junk command tests
+
+func TestMoveToJunkCommand(t *testing.T) {
+	t.Run("moves email to the junk role mailbox", func(t *testing.T) {
+		f, stdout, _ := setupTest(t)
+
+		var updated map[string]interface{}
+		httpmock.RegisterResponder("POST", "https://api.test.com/jmap/api",
+			mockMoveResponse([]map[string]interface{}{
+				{"id": "inbox-1", "name": "Inbox", "role": "inbox"},
+				{"id": "spam-1", "name": "Spam", "role": "junk"},
+			}, &updated))
+
+		cmd := NewCmdMoveToJunk(f)
+		cmd.SetArgs([]string{"email-1"})
+		cmd.SetOut(stdout)
+		cmd.SetErr(&bytes.Buffer{})
+
+		err := cmd.Execute()
+
+		require.NoError(t, err)
+		assert.Equal(t, map[string]interface{}{
+			"email-1": map[string]interface{}{"mailboxIds": map[string]interface{}{"spam-1": true}},
+		}, updated)
+		assert.Contains(t, stdout.String(), "Moved to Spam.")
+	})
+
+	t.Run("falls back to a folder named Junk", func(t *testing.T) {
+		f, stdout, _ := setupTest(t)
+
+		var updated map[string]interface{}
+		httpmock.RegisterResponder("POST", "https://api.test.com/jmap/api",
+			mockMoveResponse([]map[string]interface{}{
+				{"id": "inbox-1", "name": "Inbox", "role": "inbox"},
+				{"id": "junk-1", "name": "Junk"},
+			}, &updated))
+
+		cmd := NewCmdMoveToJunk(f)
+		cmd.SetArgs([]string{"email-1", "email-2"})
+		cmd.SetOut(stdout)
+		cmd.SetErr(&bytes.Buffer{})
+
+		err := cmd.Execute()
+
+		require.NoError(t, err)
+		assert.Len(t, updated, 2)
+		assert.Contains(t, stdout.String(), "Moved 2 emails to Junk.")
+	})
+
+	t.Run("errors when there is no junk mailbox", func(t *testing.T) {
+		f, _, _ := setupTest(t)
+
+		var updated map[string]interface{}
+		httpmock.RegisterResponder("POST", "https://api.test.com/jmap/api",
+			mockMoveResponse([]map[string]interface{}{
+				{"id": "inbox-1", "name": "Inbox", "role": "inbox"},
+			}, &updated))
+
+		cmd := NewCmdMoveToJunk(f)
+		cmd.SetArgs([]string{"email-1"})
+		cmd.SetOut(&bytes.Buffer{})
+		cmd.SetErr(&bytes.Buffer{})
+
+		err := cmd.Execute()
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "no junk mailbox found")
+		assert.Nil(t, updated)
+	})
+
+	t.Run("is available as junk", func(t *testing.T) {
+		cmd := NewCmdEmail(&cmdutil.Factory{})
+
+		found, _, err := cmd.Find([]string{"junk"})
+
+		require.NoError(t, err)
+		assert.Equal(t, "move-to-junk", found.Name())
+	})
+}
+
+func registerSnoozeSession() {
+	httpmock.RegisterResponder("GET", "https://api.test.com/jmap/session",
+		httpmock.NewJsonResponderOrPanic(200, map[string]interface{}{
+			"apiUrl": "https://api.test.com/jmap/api",
+			"accounts": map[string]interface{}{
+				"account-1": map[string]interface{}{},
+			},
+			"capabilities": map[string]interface{}{
+				jmap.CoreCapability:   map[string]interface{}{},
+				jmap.MailCapability:   map[string]interface{}{},
+				jmap.SnoozeCapability: map[string]interface{}{},
+			},
+		}))
+}
+
+func TestSnoozeCommand(t *testing.T) {
+	t.Run("moves to Snoozed and sets the wake time", func(t *testing.T) {
+		f, stdout, _ := setupTest(t)
+		registerSnoozeSession()
+
+		var using []string
+		var patch map[string]interface{}
+		httpmock.RegisterResponder("POST", "https://api.test.com/jmap/api",
+			func(req *http.Request) (*http.Response, error) {
+				var jmapReq jmap.Request
+				json.NewDecoder(req.Body).Decode(&jmapReq)
+
+				switch jmapReq.MethodCalls[0][0].(string) {
+				case "Mailbox/get":
+					return mockMailboxResponse([]map[string]interface{}{
+						{"id": "inbox-1", "name": "Inbox", "role": "inbox"},
+						{"id": "snoozed-1", "name": "Snoozed", "role": "snoozed"},
+					})(req)
+				case "Email/set":
+					using = jmapReq.Using
+					args := jmapReq.MethodCalls[0][1].(map[string]interface{})
+					patch = args["update"].(map[string]interface{})["email-1"].(map[string]interface{})
+					return mockEmailSetResponse(map[string]interface{}{"email-1": nil})(req)
+				}
+				return httpmock.NewStringResponse(400, "unexpected"), nil
+			})
+
+		before := time.Now()
+		cmd := NewCmdSnooze(f)
+		cmd.SetArgs([]string{"email-1", "--until", "+3h"})
+		cmd.SetOut(stdout)
+		cmd.SetErr(&bytes.Buffer{})
+
+		err := cmd.Execute()
+
+		require.NoError(t, err)
+		assert.Contains(t, using, jmap.SnoozeCapability)
+		assert.Equal(t, map[string]interface{}{"snoozed-1": true}, patch["mailboxIds"])
+		until, err := time.Parse(time.RFC3339, patch["snoozed"].(map[string]interface{})["until"].(string))
+		require.NoError(t, err)
+		assert.WithinDuration(t, before.Add(3*time.Hour), until, time.Minute)
+		assert.Contains(t, stdout.String(), "Snoozed until")
+	})
+
+	t.Run("reports when snooze is not supported", func(t *testing.T) {
+		f, _, _ := setupTest(t)
+
+		cmd := NewCmdSnooze(f)
+		cmd.SetArgs([]string{"email-1", "--until", "+3h"})
+		cmd.SetOut(&bytes.Buffer{})
+		cmd.SetErr(&bytes.Buffer{})
+
+		err := cmd.Execute()
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "doesn't support snoozing email")
+		assert.Zero(t, httpmock.GetCallCountInfo()["POST https://api.test.com/jmap/api"])
+	})
+
+	t.Run("rejects times in the past", func(t *testing.T) {
+		f, _, _ := setupTest(t)
+
+		cmd := NewCmdSnooze(f)
+		cmd.SetArgs([]string{"email-1", "--until", "2020-01-01"})
+		cmd.SetOut(&bytes.Buffer{})
+		cmd.SetErr(&bytes.Buffer{})
+
+		err := cmd.Execute()
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "must be in the future")
+	})
+
+	t.Run("rejects unparseable times", func(t *testing.T) {
+		f, _, _ := setupTest(t)
+
+		cmd := NewCmdSnooze(f)
+		cmd.SetArgs([]string{"email-1", "--until", "tomorrow"})
+		cmd.SetOut(&bytes.Buffer{})
+		cmd.SetErr(&bytes.Buffer{})
+
+		err := cmd.Execute()
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid time")
+	})
+}
+
+// Delete command tests
+
+func TestDeleteCommand(t *testing.T) {
+	t.Run("deletes email with --yes and --unsafe flags", func(t *testing.T) {
+		f, stdout, _ := setupTest(t)
+
+		httpmock.RegisterResponder("POST", "https://api.test.com/jmap/api",
+			func(req *http.Request) (*http.Response, error) {
+				var jmapReq jmap.Request
+				json.NewDecoder(req.Body).Decode(&jmapReq)
+
+				method := jmapReq.MethodCalls[0][0].(string)
+
+				switch method {
+				case "Mailbox/get":
+					return mockMailboxResponse([]map[string]interface{}{
+						{"id": "trash-1", "name": "Trash", "role": "trash"},
+					})(req)
+				case "Email/set":
+					return mockEmailSetResponse(map[string]interface{}{
+						"email-1": nil,
+					})(req)
+				default:
+					return httpmock.NewStringResponse(400, "unexpected"), nil
+				}
+			})
+
+		cmd := NewCmdDelete(f)
+		// In test environment (non-TTY), both --yes and --unsafe are needed
+		cmd.SetArgs([]string{"email-1", "--yes", "--unsafe"})
+		cmd.SetOut(stdout)
+		cmd.SetErr(&bytes.Buffer{})
+
+		err := cmd.Execute()
+
+		require.NoError(t, err)
+		assert.Contains(t, stdout.String(), "Moved to Trash")
+	})
+
+	t.Run("blocks in safe mode without --unsafe", func(t *testing.T) {
+		// Test streams have stdinIsTTY=false, which triggers safe mode
+		f, _, _ := setupTest(t)
+
+		cmd := NewCmdDelete(f)
+		cmd.SetArgs([]string{"email-1", "--yes"})
+		cmd.SetOut(&bytes.Buffer{})
+		cmd.SetErr(&bytes.Buffer{})
+
+		err := cmd.Execute()
+
+		require.Error(t, err)
+		var safeModeErr *cmdutil.SafeModeError
+		assert.ErrorAs(t, err, &safeModeErr)
+	})
+
+	t.Run("allows delete in safe mode with --unsafe", func(t *testing.T) {
+		// Test streams have stdinIsTTY=false (safe mode), but --unsafe overrides
+		f, stdout, _ := setupTest(t)
+
+		httpmock.RegisterResponder("POST", "https://api.test.com/jmap/api",
+			func(req *http.Request) (*http.Response, error) {
+				var jmapReq jmap.Request
+				json.NewDecoder(req.Body).Decode(&jmapReq)
+
+				method := jmapReq.MethodCalls[0][0].(string)
+
+				switch method {
+				case "Mailbox/get":
+					return mockMailboxResponse([]map[string]interface{}{
+						{"id": "trash-1", "name": "Trash", "role": "trash"},
+					})(req)
+				case "Email/set":
+					return mockEmailSetResponse(map[string]interface{}{
+						"email-1": nil,
+					})(req)
+				default:
+					return httpmock.NewStringResponse(400, "unexpected"), nil
+				}
+			})
+
+		cmd := NewCmdDelete(f)
+		cmd.SetArgs([]string{"email-1", "--yes", "--unsafe"})
+		cmd.SetOut(stdout)
+		cmd.SetErr(&bytes.Buffer{})
+
+		err := cmd.Execute()
+
+		require.NoError(t, err)
+		assert.Contains(t, stdout.String(), "Moved to Trash")
+	})
+
+	t.Run("requires --yes instead of prompting when quiet", func(t *testing.T) {
+		f, _, _ := setupTest(t)
+		f.IOStreams.SetStdinTTY(true)
+		f.IOStreams.SetStdoutTTY(true)
+		f.Quiet = true
+
+		cmd := NewCmdDelete(f)
+		cmd.SetArgs([]string{"email-1"})
+		cmd.SetOut(&bytes.Buffer{})
+		cmd.SetErr(&bytes.Buffer{})
+
+		err := cmd.Execute()
+
+		require.Error(t, err)
+		assert.Equal(t, cmdutil.QuietConfirmError, err)
+	})
+
+	t.Run("requires email ID argument", func(t *testing.T) {
+		f := &cmdutil.Factory{}
+		cmd := NewCmdDelete(f)
+		cmd.SetArgs([]string{})
+		cmd.SetOut(&bytes.Buffer{})
+		cmd.SetErr(&bytes.Buffer{})
+
+		err := cmd.Execute()
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "email ID required")
+	})
+}
+
+func TestActionCommandsReadStdin(t *testing.T) {
+	mailboxes := []map[string]interface{}{
+		{"id": "archive-1", "name": "Archive", "role": "archive"},
+		{"id": "trash-1", "name": "Trash", "role": "trash"},
+		{"id": "work-1", "name": "Work"},
+	}
+
+	t.Run("archive reads IDs from stdin", func(t *testing.T) {
+		f, stdout, _ := setupTest(t)
+		f.IOStreams.In = strings.NewReader("email-1\nemail-2 email-3\n")
+
+		var updated map[string]interface{}
+		httpmock.RegisterResponder("POST", "https://api.test.com/jmap/api", mockMoveResponse(mailboxes, &updated))
+
+		cmd := NewCmdArchive(f)
+		cmd.SetArgs([]string{})
+		cmd.SetOut(stdout)
+		cmd.SetErr(&bytes.Buffer{})
+
+		err := cmd.Execute()
+
+		require.NoError(t, err)
+		assert.Len(t, updated, 3)
+		assert.Contains(t, stdout.String(), "Archived 3 emails.")
+	})
+
+	t.Run("archive requires IDs when stdin is empty", func(t *testing.T) {
+		f, _, _ := setupTest(t)
+
+		cmd := NewCmdArchive(f)
+		cmd.SetArgs([]string{})
+		cmd.SetOut(&bytes.Buffer{})
+		cmd.SetErr(&bytes.Buffer{})
+
+		err := cmd.Execute()
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "at least one email ID required")
+	})
+
+	t.Run("move takes only the folder when IDs come from stdin", func(t *testing.T) {
+		f, stdout, _ := setupTest(t)
+		f.IOStreams.In = strings.NewReader("email-1\nemail-2\n")
+
+		var updated map[string]interface{}
+		httpmock.RegisterResponder("POST", "https://api.test.com/jmap/api", mockMoveResponse(mailboxes, &updated))
+
+		cmd := NewCmdMove(f)
+		cmd.SetArgs([]string{"Work"})
+		cmd.SetOut(stdout)
+		cmd.SetErr(&bytes.Buffer{})
+
+		err := cmd.Execute()
+
+		require.NoError(t, err)
+		assert.Equal(t, map[string]interface{}{
+			"email-1": map[string]interface{}{"mailboxIds": map[string]interface{}{"work-1": true}},
+			"email-2": map[string]interface{}{"mailboxIds": map[string]interface{}{"work-1": true}},
+		}, updated)
+		assert.Contains(t, stdout.String(), "Moved 2 emails to Work.")
+	})
+
+	t.Run("delete from stdin still requires --unsafe", func(t *testing.T) {
+		f, _, _ := setupTest(t)
+		f.IOStreams.In = strings.NewReader("email-1\nemail-2\n")
+
+		cmd := NewCmdDelete(f)
+		cmd.SetArgs([]string{"--yes"})
+		cmd.SetOut(&bytes.Buffer{})
+		cmd.SetErr(&bytes.Buffer{})
+
+		err := cmd.Execute()
+
+		require.Error(t, err)
+		var safeModeErr *cmdutil.SafeModeError
+		assert.ErrorAs(t, err, &safeModeErr)
+	})
+
+	t.Run("delete from stdin requires --yes", func(t *testing.T) {
+		f, _, _ := setupTest(t)
+		f.IOStreams.In = strings.NewReader("email-1\nemail-2\n")
+
+		cmd := NewCmdDelete(f)
+		cmd.SetArgs([]string{"--unsafe"})
+		cmd.SetOut(&bytes.Buffer{})
+		cmd.SetErr(&bytes.Buffer{})
+
+		err := cmd.Execute()
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "--yes required when email IDs are read from stdin")
+		assert.Zero(t, httpmock.GetTotalCallCount())
+	})
+
+	t.Run("delete reads IDs from stdin with --unsafe", func(t *testing.T) {
+		f, stdout, _ := setupTest(t)
+		f.IOStreams.In = strings.NewReader("email-1\nemail-2\n")
+
+		var updated map[string]interface{}
+		httpmock.RegisterResponder("POST", "https://api.test.com/jmap/api", mockMoveResponse(mailboxes, &updated))
+
+		cmd := NewCmdDelete(f)
+		cmd.SetArgs([]string{"--unsafe", "--yes"})
+		cmd.SetOut(stdout)
+		cmd.SetErr(&bytes.Buffer{})
+
+		err := cmd.Execute()
+
+		require.NoError(t, err)
+		assert.Len(t, updated, 2)
+		assert.Contains(t, stdout.String(), "Moved 2 emails to Trash.")
+	})
+}
+
+// Resend command tests
+
+func TestResendCommand(t *testing.T) {
+	t.Run("blocks in safe mode without --unsafe", func(t *testing.T) {
+		f, _, _ := setupTest(t)
+
+		cmd := NewCmdResend(f)
+		cmd.SetArgs([]string{"email-1", "--yes"})
+		cmd.SetOut(&bytes.Buffer{})
+		cmd.SetErr(&bytes.Buffer{})
+
+		err := cmd.Execute()
+
+		require.Error(t, err)
+		var safeModeErr *cmdutil.SafeModeError
+		assert.ErrorAs(t, err, &safeModeErr)
+	})
+
+	t.Run("refuses to resend a draft", func(t *testing.T) {
+		f, _, _ := setupTest(t)
+
+		httpmock.RegisterResponder("POST", "https://api.test.com/jmap/api",
+			mockEmailGetResponse(map[string]interface{}{
+				"id":       "draft-1",
+				"keywords": map[string]bool{"$draft": true},
+			}))
+
+		cmd := NewCmdResend(f)
+		cmd.SetArgs([]string{"draft-1", "--unsafe", "--yes"})
+		cmd.SetOut(&bytes.Buffer{})
+		cmd.SetErr(&bytes.Buffer{})
+
+		err := cmd.Execute()
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "is a draft")
+	})
+
+	t.Run("copies the email into a new draft and sends it", func(t *testing.T) {
+		f, stdout, _ := setupTest(t)
+
+		var created map[string]interface{}
+		var submitted string
+		httpmock.RegisterResponder("POST", "https://api.test.com/jmap/api",
+			func(req *http.Request) (*http.Response, error) {
+				var jmapReq jmap.Request
+				json.NewDecoder(req.Body).Decode(&jmapReq)
+
+				method := jmapReq.MethodCalls[0][0].(string)
+				args := jmapReq.MethodCalls[0][1].(map[string]interface{})
+
+				switch method {
+				case "Email/get":
+					return mockEmailGetResponse(map[string]interface{}{
+						"id":         "email-1",
+						"subject":    "Invoice",
+						"from":       []map[string]string{{"email": "me@example.com"}},
+						"to":         []map[string]string{{"name": "Bob Smith", "email": "bob@example.com"}},
+						"cc":         []map[string]string{{"email": "carol@example.com"}},
+						"bcc":        []map[string]string{{"name": "Dana, Finance", "email": "dana@example.com"}},
+						"receivedAt": "2024-01-15T10:30:00Z",
+						"textBody":   []map[string]string{{"partId": "1", "type": "text/plain"}},
+						"htmlBody":   []map[string]string{{"partId": "1", "type": "text/plain"}},
+						"bodyValues": map[string]map[string]string{"1": {"value": "Please find attached."}},
+						"attachments": []map[string]interface{}{
+							{"partId": "2", "blobId": "blob-1", "type": "application/pdf", "name": "invoice.pdf", "size": 1024},
+						},
+					})(req)
+				case "Mailbox/get":
+					return mockMailboxResponse([]map[string]interface{}{
+						{"id": "drafts-1", "name": "Drafts", "role": "drafts"},
+						{"id": "sent-1", "name": "Sent", "role": "sent"},
+					})(req)
+				case "Identity/get":
+					return httpmock.NewJsonResponse(200, map[string]interface{}{
+						"methodResponses": [][]interface{}{
+							{"Identity/get", map[string]interface{}{
+								"list": []map[string]interface{}{
+									{"id": "id-1", "email": "me@example.com"},
+								},
+							}, "identities"},
+						},
+					})
+				case "Email/set":
+					created = args["create"].(map[string]interface{})["draft"].(map[string]interface{})
+					return httpmock.NewJsonResponse(200, map[string]interface{}{
+						"methodResponses": [][]interface{}{
+							{"Email/set", map[string]interface{}{
+								"created": map[string]interface{}{"draft": map[string]interface{}{"id": "draft-2"}},
+							}, "createDraft"},
+						},
+					})
+				case "EmailSubmission/set":
+					submission := args["create"].(map[string]interface{})["submission"].(map[string]interface{})
+					submitted = submission["emailId"].(string)
+					return httpmock.NewJsonResponse(200, map[string]interface{}{
+						"methodResponses": [][]interface{}{
+							{"EmailSubmission/set", map[string]interface{}{
+								"created": map[string]interface{}{
+									"submission": map[string]interface{}{"id": "sub-1"},
+								},
+							}, "sendEmail"},
+						},
+					})
+				default:
+					return httpmock.NewStringResponse(400, "unexpected: "+method), nil
+				}
+			})
+
+		cmd := NewCmdResend(f)
+		cmd.SetArgs([]string{"email-1", "--unsafe", "--yes"})
+		cmd.SetOut(stdout)
+		cmd.SetErr(&bytes.Buffer{})
+
+		err := cmd.Execute()
+
+		require.NoError(t, err)
+		assert.Contains(t, stdout.String(), "Email resent successfully")
+		assert.Equal(t, "draft-2", submitted)
+
+		require.NotNil(t, created)
+		assert.Equal(t, "Invoice", created["subject"])
+		assert.Equal(t, []interface{}{map[string]interface{}{"name": "Bob Smith", "email": "bob@example.com"}}, created["to"])
+		assert.Equal(t, []interface{}{map[string]interface{}{"email": "carol@example.com"}}, created["cc"])
+		assert.Equal(t, []interface{}{map[string]interface{}{"name": "Dana, Finance", "email": "dana@example.com"}}, created["bcc"])
+		assert.Equal(t, []interface{}{
+			map[string]interface{}{"blobId": "blob-1", "type": "application/pdf", "name": "invoice.pdf"},
+		}, created["attachments"])
+		assert.NotContains(t, created, "htmlBody", "plain-text emails should not gain an HTML part")
+	})
+}
+
+// Redirect command tests
+
+// mockReply serves an original email from alice, creates the reply draft,
+// and records whether it was submitted.
+func mockReply(submitted *bool) httpmock.Responder {
+	return func(req *http.Request) (*http.Response, error) {
+		var jmapReq jmap.Request
+		json.NewDecoder(req.Body).Decode(&jmapReq)
+
+		method := jmapReq.MethodCalls[0][0].(string)
+
+		switch method {
+		case "Email/get":
+			return mockEmailGetResponse(map[string]interface{}{
+				"id":        "email-1",
+				"subject":   "Lunch?",
+				"from":      []map[string]string{{"email": "alice@example.com"}},
+				"to":        []map[string]string{{"email": "alice@example.com"}},
+				"messageId": []string{"<msg-1@example.com>"},
+			})(req)
+		case "Mailbox/get":
+			return mockMailboxResponse([]map[string]interface{}{
+				{"id": "drafts-1", "name": "Drafts", "role": "drafts"},
+				{"id": "sent-1", "name": "Sent", "role": "sent"},
+			})(req)
+		case "Identity/get":
+			return httpmock.NewJsonResponse(200, map[string]interface{}{
+				"methodResponses": [][]interface{}{
+					{"Identity/get", map[string]interface{}{
+						"list": []map[string]interface{}{
+							{"id": "id-1", "email": "me@example.com"},
+						},
+					}, "identities"},
+				},
+			})
+		case "Email/set":
+			return httpmock.NewJsonResponse(200, map[string]interface{}{
+				"methodResponses": [][]interface{}{
+					{"Email/set", map[string]interface{}{
+						"created": map[string]interface{}{
+							"draft": map[string]interface{}{"id": "reply-1"},
+						},
+					}, "createDraft"},
+				},
+			})
+		case "EmailSubmission/set":
+			*submitted = true
+			return httpmock.NewJsonResponse(200, map[string]interface{}{
+				"methodResponses": [][]interface{}{
+					{"EmailSubmission/set", map[string]interface{}{
+						"created": map[string]interface{}{
+							"submission": map[string]interface{}{"id": "sub-1"},
+						},
+					}, "sendEmail"},
+				},
+			})
+		default:
+			return httpmock.NewStringResponse(400, "unexpected: "+method), nil
+		}
+	}
+}
+
+func TestReplyCommand(t *testing.T) {
+	t.Run("creates a draft without --send", func(t *testing.T) {
+		f, stdout, _ := setupTest(t)
+
+		var submitted bool
+		httpmock.RegisterResponder("POST", "https://api.test.com/jmap/api", mockReply(&submitted))
+
+		cmd := NewCmdReply(f)
+		cmd.SetArgs([]string{"email-1", "--body", "Sure!"})
+		cmd.SetOut(stdout)
+		cmd.SetErr(&bytes.Buffer{})
+
+		err := cmd.Execute()
+
+		require.NoError(t, err)
+		assert.Equal(t, "Reply draft created: reply-1\n", stdout.String())
+		assert.False(t, submitted)
+	})
+
+	t.Run("creates and sends with --send", func(t *testing.T) {
+		f, stdout, _ := setupTest(t)
+
+		var submitted bool
+		httpmock.RegisterResponder("POST", "https://api.test.com/jmap/api", mockReply(&submitted))
+
+		cmd := NewCmdReply(f)
+		cmd.SetArgs([]string{"email-1", "--body", "Sure!", "--send", "--unsafe", "--yes"})
+		cmd.SetOut(stdout)
+		cmd.SetErr(&bytes.Buffer{})
+
+		err := cmd.Execute()
+
+		require.NoError(t, err)
+		assert.Equal(t, "Reply sent.\n", stdout.String())
+		assert.True(t, submitted)
+	})
+
+	t.Run("keeps the draft when sending is declined", func(t *testing.T) {
+		f, _, stderr := setupTest(t)
+		f.IOStreams.SetStdinTTY(true)
+		f.IOStreams.SetStdoutTTY(true)
+		f.IOStreams.In = strings.NewReader("n\n")
+
+		var submitted bool
+		httpmock.RegisterResponder("POST", "https://api.test.com/jmap/api", mockReply(&submitted))
+
+		cmd := NewCmdReply(f)
+		cmd.SetArgs([]string{"email-1", "--body", "Sure!", "--send"})
+		cmd.SetOut(&bytes.Buffer{})
+		cmd.SetErr(&bytes.Buffer{})
+
+		err := cmd.Execute()
+
+		assert.ErrorIs(t, err, cmdutil.CancelError)
+		assert.Contains(t, stderr.String(), "To:      alice@example.com")
+		assert.Contains(t, stderr.String(), "Reply draft kept: reply-1")
+		assert.False(t, submitted)
+	})
+
+	t.Run("blocks --send in safe mode without --unsafe", func(t *testing.T) {
+		f, _, _ := setupTest(t)
+
+		cmd := NewCmdReply(f)
+		cmd.SetArgs([]string{"email-1", "--body", "Sure!", "--send", "--yes"})
+		cmd.SetOut(&bytes.Buffer{})
+		cmd.SetErr(&bytes.Buffer{})
+
+		err := cmd.Execute()
+
+		require.Error(t, err)
+		var safeModeErr *cmdutil.SafeModeError
+		assert.ErrorAs(t, err, &safeModeErr)
+		assert.Zero(t, httpmock.GetTotalCallCount(), "no draft should be created")
+	})
+
+	t.Run("requires a body", func(t *testing.T) {
+		f := &cmdutil.Factory{}
+		cmd := NewCmdReply(f)
+		cmd.SetArgs([]string{"email-1"})
+		cmd.SetOut(&bytes.Buffer{})
+		cmd.SetErr(&bytes.Buffer{})
+
+		err := cmd.Execute()
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "--body or --body-file required")
+	})
+}
+
+func TestRedirectCommand(t *testing.T) {
+	t.Run("blocks in safe mode without --unsafe", func(t *testing.T) {
+		f, _, _ := setupTest(t)
+
+		cmd := NewCmdRedirect(f)
+		cmd.SetArgs([]string{"email-1", "--to", "alice@example.com", "--yes"})
+		cmd.SetOut(&bytes.Buffer{})
+		cmd.SetErr(&bytes.Buffer{})
+
+		err := cmd.Execute()
+
+		require.Error(t, err)
+		var safeModeErr *cmdutil.SafeModeError
+		assert.ErrorAs(t, err, &safeModeErr)
+	})
+
+	t.Run("submits the original email with an overridden envelope", func(t *testing.T) {
+		f, stdout, _ := setupTest(t)
+
+		var submission map[string]interface{}
+		httpmock.RegisterResponder("POST", "https://api.test.com/jmap/api",
+			func(req *http.Request) (*http.Response, error) {
+				var jmapReq jmap.Request
+				json.NewDecoder(req.Body).Decode(&jmapReq)
+
+				method := jmapReq.MethodCalls[0][0].(string)
+				args := jmapReq.MethodCalls[0][1].(map[string]interface{})
+
+				switch method {
+				case "Identity/get":
+					return httpmock.NewJsonResponse(200, map[string]interface{}{
+						"methodResponses": [][]interface{}{
+							{"Identity/get", map[string]interface{}{
+								"list": []map[string]interface{}{
+									{"id": "id-1", "email": "me@example.com"},
+								},
+							}, "identities"},
+						},
+					})
+				case "EmailSubmission/set":
+					submission = args["create"].(map[string]interface{})["submission"].(map[string]interface{})
+					assert.NotContains(t, args, "onSuccessUpdateEmail", "the original email should not be moved")
+					return httpmock.NewJsonResponse(200, map[string]interface{}{
+						"methodResponses": [][]interface{}{
+							{"EmailSubmission/set", map[string]interface{}{
+								"created": map[string]interface{}{
+									"submission": map[string]interface{}{"id": "sub-1"},
+								},
+							}, "redirectEmail"},
+						},
+					})
+				default:
+					return httpmock.NewStringResponse(400, "unexpected: "+method), nil
+				}
+			})
+
+		cmd := NewCmdRedirect(f)
+		cmd.SetArgs([]string{"email-1", "--to", "alice@example.com,bob@example.com", "--unsafe", "--yes"})
+		cmd.SetOut(stdout)
+		cmd.SetErr(&bytes.Buffer{})
+
+		err := cmd.Execute()
+
+		require.NoError(t, err)
+		assert.Contains(t, stdout.String(), "Email redirected to alice@example.com, bob@example.com")
+
+		require.NotNil(t, submission)
+		assert.Equal(t, "email-1", submission["emailId"])
+		assert.Equal(t, map[string]interface{}{
+			"mailFrom": map[string]interface{}{"email": "me@example.com"},
+			"rcptTo": []interface{}{
+				map[string]interface{}{"email": "alice@example.com"},
+				map[string]interface{}{"email": "bob@example.com"},
+			},
+		}, submission["envelope"])
+	})
+
+	t.Run("requires --to", func(t *testing.T) {
+		f := &cmdutil.Factory{}
+		cmd := NewCmdRedirect(f)
+		cmd.SetArgs([]string{"email-1"})
+		cmd.SetOut(&bytes.Buffer{})
+		cmd.SetErr(&bytes.Buffer{})
+
+		err := cmd.Execute()
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "to")
+	})
+
+	t.Run("rejects invalid addresses before sending", func(t *testing.T) {
+		for _, addr := range []string{"@", "a@", "foo@bar@baz", "alice"} {
+			f, _, _ := setupTest(t)
+
+			cmd := NewCmdRedirect(f)
+			cmd.SetArgs([]string{"email-1", "--to", addr, "--unsafe", "--yes"})
+			cmd.SetOut(&bytes.Buffer{})
+			cmd.SetErr(&bytes.Buffer{})
+
+			err := cmd.Execute()
+
+			require.Error(t, err, addr)
+			var flagErr *cmdutil.FlagError
+			assert.ErrorAs(t, err, &flagErr)
+			assert.Contains(t, err.Error(), "invalid email address")
+			assert.Zero(t, httpmock.GetTotalCallCount())
+		}
+	})
+}
+
+// Mark-thread-read command tests
+
+// mockThreadMarkRead serves a two-email thread and records the Email/set update.
+func mockThreadMarkRead(update *map[string]interface{}) httpmock.Responder {
+	return func(req *http.Request) (*http.Response, error) {
+		var jmapReq jmap.Request
+		json.NewDecoder(req.Body).Decode(&jmapReq)
+
+		method := jmapReq.MethodCalls[0][0].(string)
+
+		switch method {
+		case "Email/get":
+			return mockEmailGetResponse(map[string]interface{}{"id": "email-1", "threadId": "thread-1"})(req)
+		case "Thread/get":
+			return httpmock.NewJsonResponse(200, map[string]interface{}{
+				"methodResponses": [][]interface{}{
+					{"Thread/get", map[string]interface{}{
+						"list": []map[string]interface{}{
+							{"id": "thread-1", "emailIds": []string{"email-1", "email-2"}},
+						},
+					}, "getThread"},
+					{"Email/get", map[string]interface{}{
+						"list": []map[string]interface{}{
+							{"id": "email-1", "threadId": "thread-1"},
+							{"id": "email-2", "threadId": "thread-1"},
+						},
+					}, "emails"},
+				},
+			})
+		case "Email/set":
+			*update = jmapReq.MethodCalls[0][1].(map[string]interface{})["update"].(map[string]interface{})
+			return mockEmailSetResponse(*update)(req)
+		default:
+			return httpmock.NewStringResponse(400, "unexpected: "+method), nil
+		}
+	}
+}
+
+func TestMarkThreadReadCommand(t *testing.T) {
+
+	t.Run("marks every email in the thread as read", func(t *testing.T) {
+		f, stdout, _ := setupTest(t)
+
+		var update map[string]interface{}
+		httpmock.RegisterResponder("POST", "https://api.test.com/jmap/api", mockThreadMarkRead(&update))
+
+		cmd := NewCmdMarkThreadRead(f)
+		cmd.SetArgs([]string{"email-1"})
+		cmd.SetOut(stdout)
+		cmd.SetErr(&bytes.Buffer{})
+
+		err := cmd.Execute()
+
+		require.NoError(t, err)
+		assert.Equal(t, "Marked 2 emails as read.\n", stdout.String())
+		assert.Equal(t, map[string]interface{}{
+			"email-1": map[string]interface{}{"keywords/$seen": true},
+			"email-2": map[string]interface{}{"keywords/$seen": true},
+		}, update)
+	})
+
+	t.Run("marks the thread unread with --unread", func(t *testing.T) {
+		f, stdout, _ := setupTest(t)
+
+		var update map[string]interface{}
+		httpmock.RegisterResponder("POST", "https://api.test.com/jmap/api", mockThreadMarkRead(&update))
+
+		cmd := NewCmdMarkThreadRead(f)
+		cmd.SetArgs([]string{"email-1", "--unread"})
+		cmd.SetOut(stdout)
+		cmd.SetErr(&bytes.Buffer{})
+
+		err := cmd.Execute()
+
+		require.NoError(t, err)
+		assert.Equal(t, "Marked 2 emails as unread.\n", stdout.String())
+		assert.Equal(t, map[string]interface{}{"keywords/$seen": nil}, update["email-2"])
+	})
+}
+
+func TestThreadCommandMarkRead(t *testing.T) {
+	t.Run("marks the thread read after showing it", func(t *testing.T) {
+		f, stdout, _ := setupTest(t)
+		f.IOStreams.SetStdinTTY(true)
+
+		var update map[string]interface{}
+		httpmock.RegisterResponder("POST", "https://api.test.com/jmap/api", mockThreadMarkRead(&update))
+
+		cmd := NewCmdThread(f)
+		cmd.SetArgs([]string{"email-1", "--mark-read"})
+		cmd.SetOut(stdout)
+		cmd.SetErr(&bytes.Buffer{})
+
+		err := cmd.Execute()
+
+		require.NoError(t, err)
+		assert.Contains(t, stdout.String(), "Thread with 2 emails")
+		assert.True(t, strings.HasSuffix(stdout.String(), "Marked 2 emails as read.\n"))
+		assert.Equal(t, map[string]interface{}{
+			"email-1": map[string]interface{}{"keywords/$seen": true},
+			"email-2": map[string]interface{}{"keywords/$seen": true},
+		}, update)
+	})
+
+	t.Run("keeps stdout valid JSON with --json", func(t *testing.T) {
+		f, stdout, stderr := setupTest(t)
+
+		var update map[string]interface{}
+		httpmock.RegisterResponder("POST", "https://api.test.com/jmap/api", mockThreadMarkRead(&update))
+
+		cmd := NewCmdThread(f)
+		cmd.SetArgs([]string{"email-1", "--mark-read", "--json", "--unsafe"})
+		cmd.SetOut(stdout)
+
+		err := cmd.Execute()
+
+		require.NoError(t, err)
+		var emails []jmap.Email
+		require.NoError(t, json.Unmarshal(stdout.Bytes(), &emails))
+		assert.Len(t, emails, 2)
+		assert.Equal(t, "Marked 2 emails as read.\n", stderr.String())
+		assert.Len(t, update, 2)
+	})
+
+	t.Run("blocks in safe mode without --unsafe", func(t *testing.T) {
+		f, _, _ := setupTest(t)
+
+		cmd := NewCmdThread(f)
+		cmd.SetArgs([]string{"email-1", "--mark-read"})
+		cmd.SetOut(&bytes.Buffer{})
+		cmd.SetErr(&bytes.Buffer{})
+
+		err := cmd.Execute()
+
+		require.Error(t, err)
+		var safeModeErr *cmdutil.SafeModeError
+		assert.ErrorAs(t, err, &safeModeErr)
+		assert.Equal(t, 0, httpmock.GetCallCountInfo()["POST https://api.test.com/jmap/api"])
+	})
+}
+
+func TestThreadCommandOrder(t *testing.T) {
+	// The server returns the thread's emails out of date order
+	mockThread := func(req *http.Request) (*http.Response, error) {
+		var jmapReq jmap.Request
+		json.NewDecoder(req.Body).Decode(&jmapReq)
+
+		switch jmapReq.MethodCalls[0][0].(string) {
+		case "Email/get":
+			return mockEmailGetResponse(map[string]interface{}{"id": "email-1", "threadId": "thread-1"})(req)
+		case "Thread/get":
+			return httpmock.NewJsonResponse(200, map[string]interface{}{
+				"methodResponses": [][]interface{}{
+					{"Thread/get", map[string]interface{}{
+						"list": []map[string]interface{}{
+							{"id": "thread-1", "emailIds": []string{"email-1", "email-2", "email-3"}},
+						},
+					}, "getThread"},
+					{"Email/get", map[string]interface{}{
+						"list": []map[string]interface{}{
+							{"id": "email-2", "threadId": "thread-1", "receivedAt": "2024-01-02T10:00:00Z"},
+							{"id": "email-3", "threadId": "thread-1", "receivedAt": "2024-01-03T10:00:00Z"},
+							{"id": "email-1", "threadId": "thread-1", "receivedAt": "2024-01-01T10:00:00Z"},
+						},
+					}, "emails"},
+				},
+			})
+		}
+		return httpmock.NewStringResponse(400, "unexpected"), nil
+	}
+
+	tests := []struct {
+		name string
+		args []string
+		want []string
+	}{
+		{"oldest first by default", []string{"email-1", "--json"}, []string{"email-1", "email-2", "email-3"}},
+		{"newest first with --reverse", []string{"email-1", "--json", "--reverse"}, []string{"email-3", "email-2", "email-1"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, stdout, _ := setupTest(t)
+			httpmock.RegisterResponder("POST", "https://api.test.com/jmap/api", mockThread)
+
+			cmd := NewCmdThread(f)
+			cmd.SetArgs(tt.args)
+			cmd.SetOut(stdout)
+			cmd.SetErr(&bytes.Buffer{})
+
+			err := cmd.Execute()
+
+			require.NoError(t, err)
+			var emails []jmap.Email
+			require.NoError(t, json.Unmarshal(stdout.Bytes(), &emails))
+			ids := make([]string, len(emails))
+			for i, email := range emails {
+				ids[i] = email.ID
+			}
+			assert.Equal(t, tt.want, ids)
+		})
+	}
+}
+
+func TestThreadCommandIncludeBody(t *testing.T) {
+	setup := func(t *testing.T) (*cmdutil.Factory, *map[string]interface{}) {
+		f, _, _ := setupTest(t)
+
+		var getArgs map[string]interface{}
+		httpmock.RegisterResponder("POST", "https://api.test.com/jmap/api",
+			func(req *http.Request) (*http.Response, error) {
+				var jmapReq jmap.Request
+				json.NewDecoder(req.Body).Decode(&jmapReq)
+
+				switch jmapReq.MethodCalls[0][0].(string) {
+				case "Email/get":
+					return mockEmailGetResponse(map[string]interface{}{"id": "email-1", "threadId": "thread-1"})(req)
+				case "Thread/get":
+					getArgs = jmapReq.MethodCalls[1][1].(map[string]interface{})
+					return httpmock.NewJsonResponse(200, map[string]interface{}{
+						"methodResponses": [][]interface{}{
+							{"Thread/get", map[string]interface{}{
+								"list": []map[string]interface{}{
+									{"id": "thread-1", "emailIds": []string{"email-1"}},
+								},
+							}, "getThread"},
+							{"Email/get", map[string]interface{}{
+								"list": []map[string]interface{}{
+									{"id": "email-1", "threadId": "thread-1", "subject": "Plans", "receivedAt": "2024-01-01T10:00:00Z"},
+								},
+							}, "emails"},
+						},
+					})
+				}
+				return httpmock.NewStringResponse(400, "unexpected"), nil
+			})
+
+		return f, &getArgs
+	}
+
+	t.Run("fetches bodies by default", func(t *testing.T) {
+		f, getArgs := setup(t)
+
+		cmd := NewCmdThread(f)
+		cmd.SetArgs([]string{"email-1", "--json"})
+		cmd.SetOut(&bytes.Buffer{})
+		cmd.SetErr(&bytes.Buffer{})
+
+		require.NoError(t, cmd.Execute())
+		assert.Equal(t, true, (*getArgs)["fetchTextBodyValues"])
+		assert.Contains(t, (*getArgs)["properties"], "bodyValues")
+	})
+
+	t.Run("fetches only metadata with --include-body=false", func(t *testing.T) {
+		f, getArgs := setup(t)
+
+		cmd := NewCmdThread(f)
+		cmd.SetArgs([]string{"email-1", "--json", "--include-body=false"})
+		cmd.SetOut(&bytes.Buffer{})
+		cmd.SetErr(&bytes.Buffer{})
+
+		require.NoError(t, cmd.Execute())
+		assert.NotContains(t, *getArgs, "fetchTextBodyValues")
+		assert.NotContains(t, (*getArgs)["properties"], "bodyValues")
+		assert.Contains(t, (*getArgs)["properties"], "preview")
+	})
+}
+
+// Flag command tests
+
+func TestFlagCommand(t *testing.T) {
+	t.Run("flags emails by ID", func(t *testing.T) {
+		f, stdout, _ := setupTest(t)
+
+		var update map[string]interface{}
+		httpmock.RegisterResponder("POST", "https://api.test.com/jmap/api",
+			func(req *http.Request) (*http.Response, error) {
+				var jmapReq jmap.Request
+				json.NewDecoder(req.Body).Decode(&jmapReq)
+				update = jmapReq.MethodCalls[0][1].(map[string]interface{})["update"].(map[string]interface{})
+				return mockEmailSetResponse(update)(req)
+			})
+
+		cmd := NewCmdFlag(f)
+		cmd.SetArgs([]string{"email-1", "email-2"})
+		cmd.SetOut(stdout)
+		cmd.SetErr(&bytes.Buffer{})
+
+		err := cmd.Execute()
+
+		require.NoError(t, err)
+		assert.Equal(t, "Flagged 2 emails.\n", stdout.String())
+		assert.Equal(t, map[string]interface{}{"keywords/$flagged": true}, update["email-1"])
+	})
+
+	mockFlagQuery := func(queryArgs, update *map[string]interface{}) httpmock.Responder {
+		return func(req *http.Request) (*http.Response, error) {
+			var jmapReq jmap.Request
+			json.NewDecoder(req.Body).Decode(&jmapReq)
+
+			method := jmapReq.MethodCalls[0][0].(string)
+			args := jmapReq.MethodCalls[0][1].(map[string]interface{})
+
+			switch method {
+			case "Email/query":
+				*queryArgs = args
+				return httpmock.NewJsonResponse(200, map[string]interface{}{
+					"methodResponses": [][]interface{}{
+						{"Email/query", map[string]interface{}{"ids": []string{"email-1", "email-2"}, "total": 5}, "query"},
+					},
+				})
+			case "Email/set":
+				*update = args["update"].(map[string]interface{})
+				return mockEmailSetResponse(*update)(req)
+			default:
+				return httpmock.NewStringResponse(400, "unexpected: "+method), nil
+			}
+		}
+	}
+
+	t.Run("flags every match of --query and warns when truncated", func(t *testing.T) {
+		f, stdout, stderr := setupTest(t)
+
+		var queryArgs, update map[string]interface{}
+		httpmock.RegisterResponder("POST", "https://api.test.com/jmap/api", mockFlagQuery(&queryArgs, &update))
+
+		cmd := NewCmdFlag(f)
+		cmd.SetArgs([]string{"--query", "from:boss is:unread", "--limit", "2", "--yes"})
+		cmd.SetOut(stdout)
+		cmd.SetErr(&bytes.Buffer{})
+
+		err := cmd.Execute()
+
+		require.NoError(t, err)
+		assert.Equal(t, "Flagged 2 emails.\n", stdout.String())
+		assert.Contains(t, stderr.String(), "5 emails match: from:boss is:unread")
+		assert.Contains(t, stderr.String(), "Only the newest 2 will be flagged")
+		assert.Equal(t, float64(2), queryArgs["limit"])
+		assert.Equal(t, true, queryArgs["calculateTotal"])
+		assert.Equal(t, "AND", queryArgs["filter"].(map[string]interface{})["operator"])
+		assert.Len(t, update, 2)
+	})
+
+	t.Run("asks before flagging --query matches", func(t *testing.T) {
+		f, stdout, stderr := setupTest(t)
+		f.IOStreams.SetStdinTTY(true)
+		f.IOStreams.SetStdoutTTY(true)
+		f.IOStreams.In = strings.NewReader("y\n")
+
+		var queryArgs, update map[string]interface{}
+		httpmock.RegisterResponder("POST", "https://api.test.com/jmap/api", mockFlagQuery(&queryArgs, &update))
+
+		cmd := NewCmdFlag(f)
+		cmd.SetArgs([]string{"--query", "from:boss", "--unflag"})
+		cmd.SetOut(stdout)
+		cmd.SetErr(&bytes.Buffer{})
+
+		err := cmd.Execute()
+
+		require.NoError(t, err)
+		assert.Contains(t, stderr.String(), "Unflag 2 emails? [y/N]")
+		assert.Equal(t, "Unflagged 2 emails.\n", stdout.String())
+		assert.Len(t, update, 2)
+	})
+
+	t.Run("cancels --query when not confirmed", func(t *testing.T) {
+		f, _, _ := setupTest(t)
+		f.IOStreams.SetStdinTTY(true)
+		f.IOStreams.SetStdoutTTY(true)
+		f.IOStreams.In = strings.NewReader("n\n")
+
+		var queryArgs, update map[string]interface{}
+		httpmock.RegisterResponder("POST", "https://api.test.com/jmap/api", mockFlagQuery(&queryArgs, &update))
+
+		cmd := NewCmdFlag(f)
+		cmd.SetArgs([]string{"--query", "from:boss"})
+		cmd.SetOut(&bytes.Buffer{})
+		cmd.SetErr(&bytes.Buffer{})
+
+		err := cmd.Execute()
+
+		assert.ErrorIs(t, err, cmdutil.CancelError)
+		assert.Nil(t, update)
+	})
+
+	t.Run("requires --yes for --query in non-interactive mode", func(t *testing.T) {
+		f, _, _ := setupTest(t)
+
+		var queryArgs, update map[string]interface{}
+		httpmock.RegisterResponder("POST", "https://api.test.com/jmap/api", mockFlagQuery(&queryArgs, &update))
+
+		cmd := NewCmdFlag(f)
+		cmd.SetArgs([]string{"--query", "from:boss"})
+		cmd.SetOut(&bytes.Buffer{})
+		cmd.SetErr(&bytes.Buffer{})
+
+		err := cmd.Execute()
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "requires --yes")
+		assert.Nil(t, update)
+	})
+
+	t.Run("rejects --limit below 1", func(t *testing.T) {
+		for _, limit := range []string{"0", "-1"} {
+			f, _, _ := setupTest(t)
+
+			cmd := NewCmdFlag(f)
+			cmd.SetArgs([]string{"--query", "from:boss", "--yes", "--limit", limit})
+			cmd.SetOut(&bytes.Buffer{})
+			cmd.SetErr(&bytes.Buffer{})
+
+			err := cmd.Execute()
+
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), "invalid --limit "+limit)
+			assert.Zero(t, httpmock.GetTotalCallCount())
+		}
+	})
+
+	t.Run("rejects a blank query", func(t *testing.T) {
+		for _, query := range []string{"", "   "} {
+			f, _, _ := setupTest(t)
+
+			cmd := NewCmdFlag(f)
+			cmd.SetArgs([]string{"--query", query, "--yes"})
+			cmd.SetOut(&bytes.Buffer{})
+			cmd.SetErr(&bytes.Buffer{})
+
+			err := cmd.Execute()
+
+			require.Error(t, err)
+			var flagErr *cmdutil.FlagError
+			assert.ErrorAs(t, err, &flagErr)
+			assert.Contains(t, err.Error(), "--query cannot be empty")
+			assert.Zero(t, httpmock.GetTotalCallCount())
+		}
+	})
+
+	t.Run("removes the flag with --unflag", func(t *testing.T) {
+		f, stdout, _ := setupTest(t)
+
+		var update map[string]interface{}
+		httpmock.RegisterResponder("POST", "https://api.test.com/jmap/api",
+			func(req *http.Request) (*http.Response, error) {
+				var jmapReq jmap.Request
+				json.NewDecoder(req.Body).Decode(&jmapReq)
+				update = jmapReq.MethodCalls[0][1].(map[string]interface{})["update"].(map[string]interface{})
+				return mockEmailSetResponse(update)(req)
+			})
+
+		cmd := NewCmdFlag(f)
+		cmd.SetArgs([]string{"email-1", "--unflag"})
+		cmd.SetOut(stdout)
+		cmd.SetErr(&bytes.Buffer{})
+
+		err := cmd.Execute()
+
+		require.NoError(t, err)
+		assert.Equal(t, "Unflagged 1 emails.\n", stdout.String())
+		assert.Equal(t, map[string]interface{}{"keywords/$flagged": nil}, update["email-1"])
+	})
+
+	t.Run("rejects IDs combined with --query", func(t *testing.T) {
+		f := &cmdutil.Factory{}
+		cmd := NewCmdFlag(f)
+		cmd.SetArgs([]string{"email-1", "--query", "from:boss"})
+		cmd.SetOut(&bytes.Buffer{})
+		cmd.SetErr(&bytes.Buffer{})
+
+		err := cmd.Execute()
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "cannot combine")
+	})
+}
+
+// Apply command tests
+
+func TestTagListCommand(t *testing.T) {
+	mockTagged := httpmock.NewJsonResponderOrPanic(200, map[string]interface{}{
+		"methodResponses": [][]interface{}{
+			{"Email/query", map[string]interface{}{"ids": []string{"email-1", "email-2", "email-3"}}, "query"},
+			{"Email/get", map[string]interface{}{
+				"list": []map[string]interface{}{
+					{"id": "email-1", "keywords": map[string]bool{"$seen": true, "receipts": true, "work": true}},
+					{"id": "email-2", "keywords": map[string]bool{"$flagged": true, "work": true}},
+					{"id": "email-3", "keywords": map[string]bool{"$seen": true}},
+				},
+			}, "emails"},
+		},
+	})
+
+	t.Run("counts user keywords, most used first", func(t *testing.T) {
+		f, stdout, stderr := setupTest(t)
+		httpmock.RegisterResponder("POST", "https://api.test.com/jmap/api", mockTagged)
+
+		cmd := NewCmdTagList(f)
+		cmd.SetArgs([]string{})
+		cmd.SetOut(stdout)
+		cmd.SetErr(&bytes.Buffer{})
+
+		err := cmd.Execute()
+
+		require.NoError(t, err)
+		assert.Equal(t, "     2  work\n     1  receipts\n", stdout.String())
+		assert.Contains(t, stderr.String(), "Sampled the 3 most recent emails.")
+	})
+
+	t.Run("outputs JSON", func(t *testing.T) {
+		f, stdout, _ := setupTest(t)
+		httpmock.RegisterResponder("POST", "https://api.test.com/jmap/api", mockTagged)
+
+		cmd := NewCmdTagList(f)
+		cmd.SetArgs([]string{"--json"})
+		cmd.SetOut(stdout)
+		cmd.SetErr(&bytes.Buffer{})
+
+		err := cmd.Execute()
+
+		require.NoError(t, err)
+		var result []map[string]interface{}
+		require.NoError(t, json.Unmarshal(stdout.Bytes(), &result))
+		assert.Equal(t, []map[string]interface{}{
+			{"keyword": "work", "count": float64(2)},
+			{"keyword": "receipts", "count": float64(1)},
+		}, result)
+	})
+}
+
+func TestFromCommand(t *testing.T) {
+	// mockFrom serves contacts and search results, recording the query filter.
+	mockFrom := func(filter *map[string]interface{}) httpmock.Responder {
+		return func(req *http.Request) (*http.Response, error) {
+			var jmapReq jmap.Request
+			json.NewDecoder(req.Body).Decode(&jmapReq)
+
+			switch jmapReq.MethodCalls[0][0].(string) {
+			case "Mailbox/get":
+				return httpmock.NewJsonResponse(200, map[string]interface{}{
+					"methodResponses": [][]interface{}{
+						{"Mailbox/get", map[string]interface{}{
+							"list": []map[string]interface{}{
+								{"id": "inbox-1", "name": "Inbox", "role": "inbox"},
+							},
+						}, "mailboxes"},
+					},
+				})
+			case "ContactCard/get":
+				return httpmock.NewJsonResponse(200, map[string]interface{}{
+					"methodResponses": [][]interface{}{
+						{"ContactCard/get", map[string]interface{}{
+							"list": []map[string]interface{}{
+								{"id": "c-1", "name": map[string]interface{}{"full": "Alice Smith"},
+									"emails": map[string]interface{}{"e": map[string]interface{}{"address": "alice@example.com"}}},
+							},
+						}, "contacts"},
+					},
+				})
+			}
+
+			args := jmapReq.MethodCalls[0][1].(map[string]interface{})
+			*filter = args["filter"].(map[string]interface{})
+			return httpmock.NewJsonResponse(200, map[string]interface{}{
+				"methodResponses": [][]interface{}{
+					{"Email/query", map[string]interface{}{"ids": []string{"email-1"}}, "query"},
+					{"Email/get", map[string]interface{}{
+						"list": []map[string]interface{}{
+							{"id": "email-1", "subject": "Lunch?", "receivedAt": "2024-01-15T10:30:00Z",
+								"from":       []map[string]string{{"name": "Alice", "email": "alice@example.com"}},
+								"mailboxIds": map[string]bool{"inbox-1": true}},
+						},
+					}, "emails"},
+				},
+			})
+		}
+	}
+
+	t.Run("searches for an address", func(t *testing.T) {
+		f, stdout, _ := setupTest(t)
+		var filter map[string]interface{}
+		httpmock.RegisterResponder("POST", "https://api.test.com/jmap/api", mockFrom(&filter))
+
+		cmd := NewCmdFrom(f)
+		cmd.SetArgs([]string{"alice@example.com", "--unread"})
+		cmd.SetOut(stdout)
+		cmd.SetErr(&bytes.Buffer{})
+
+		err := cmd.Execute()
+
+		require.NoError(t, err)
+		assert.Equal(t, map[string]interface{}{
+			"operator": "AND",
+			"conditions": []interface{}{
+				map[string]interface{}{"from": "alice@example.com"},
+				map[string]interface{}{"notKeyword": "$seen"},
+			},
+		}, filter)
+		assert.Contains(t, stdout.String(), "Lunch?")
+		assert.Contains(t, stdout.String(), "1 results")
+	})
+
+	t.Run("resolves a contact name", func(t *testing.T) {
+		f, stdout, stderr := setupTest(t)
+		httpmock.RegisterResponder("GET", "https://api.test.com/jmap/session",
+			httpmock.NewJsonResponderOrPanic(200, map[string]interface{}{
+				"apiUrl": "https://api.test.com/jmap/api",
+				"accounts": map[string]interface{}{
+					"account-1": map[string]interface{}{},
+				},
+				"capabilities": map[string]interface{}{
+					jmap.CoreCapability:     map[string]interface{}{},
+					jmap.MailCapability:     map[string]interface{}{},
+					jmap.ContactsCapability: map[string]interface{}{},
+				},
+			}))
+		var filter map[string]interface{}
+		httpmock.RegisterResponder("POST", "https://api.test.com/jmap/api", mockFrom(&filter))
+
+		cmd := NewCmdFrom(f)
+		cmd.SetArgs([]string{"alice smith"})
+		cmd.SetOut(stdout)
+		cmd.SetErr(&bytes.Buffer{})
+
+		err := cmd.Execute()
+
+		require.NoError(t, err)
+		assert.Equal(t, "alice@example.com", filter["from"])
+		assert.Contains(t, stderr.String(), `Resolved "alice smith" to alice@example.com`)
+	})
+
+	t.Run("fills in folder names in JSON output", func(t *testing.T) {
+		f, stdout, _ := setupTest(t)
+		var filter map[string]interface{}
+		httpmock.RegisterResponder("POST", "https://api.test.com/jmap/api", mockFrom(&filter))
+
+		cmd := NewCmdFrom(f)
+		cmd.SetArgs([]string{"alice@example.com", "--json", "id,folder"})
+		cmd.SetOut(stdout)
+		cmd.SetErr(&bytes.Buffer{})
+
+		err := cmd.Execute()
+
+		require.NoError(t, err)
+		var result []map[string]interface{}
+		require.NoError(t, json.Unmarshal(stdout.Bytes(), &result))
+		require.Len(t, result, 1)
+		assert.Equal(t, []interface{}{"Inbox"}, result[0]["folder"])
+	})
+
+	t.Run("searches the name as-is without contacts", func(t *testing.T) {
+		f, stdout, _ := setupTest(t)
+		var filter map[string]interface{}
+		httpmock.RegisterResponder("POST", "https://api.test.com/jmap/api", mockFrom(&filter))
+
+		cmd := NewCmdFrom(f)
+		cmd.SetArgs([]string{"Alice"})
+		cmd.SetOut(stdout)
+		cmd.SetErr(&bytes.Buffer{})
+
+		err := cmd.Execute()
+
+		require.NoError(t, err)
+		assert.Equal(t, "Alice", filter["from"])
+	})
+}
+
+func TestApplyCommand(t *testing.T) {
+	mockApply := func(update *map[string]interface{}) httpmock.Responder {
+		return func(req *http.Request) (*http.Response, error) {
+			var jmapReq jmap.Request
+			json.NewDecoder(req.Body).Decode(&jmapReq)
+
+			method := jmapReq.MethodCalls[0][0].(string)
+			args := jmapReq.MethodCalls[0][1].(map[string]interface{})
+
+			switch method {
+			case "Mailbox/get":
+				return mockMailboxResponse([]map[string]interface{}{
+					{"id": "archive-1", "name": "Archive", "role": "archive"},
+					{"id": "receipts-1", "name": "Receipts"},
+				})(req)
+			case "Email/query":
+				return httpmock.NewJsonResponse(200, map[string]interface{}{
+					"methodResponses": [][]interface{}{
+						{"Email/query", map[string]interface{}{"ids": []string{"email-1", "email-2"}, "total": 2}, "query"},
+						{"Email/get", map[string]interface{}{
+							"list": []map[string]interface{}{
+								{"id": "email-1", "subject": "Your receipt"},
+								{"id": "email-2", "subject": "Another receipt"},
+							},
+						}, "emails"},
+					},
+				})
+			case "Email/get":
+				return httpmock.NewJsonResponse(200, map[string]interface{}{
+					"methodResponses": [][]interface{}{
+						{"Email/get", map[string]interface{}{
+							"list": []map[string]interface{}{
+								{"id": "email-1", "subject": "Your receipt"},
+								{"id": "email-2", "subject": "Another receipt"},
+							},
+						}, "email"},
+					},
+				})
+			case "Email/set":
+				*update = args["update"].(map[string]interface{})
+				return mockEmailSetResponse(*update)(req)
+			default:
+				return httpmock.NewStringResponse(400, "unexpected: "+method), nil
+			}
+		}
+	}
+
+	t.Run("blocks in safe mode without --unsafe", func(t *testing.T) {
+		f, _, _ := setupTest(t)
+
+		cmd := NewCmdApply(f)
+		cmd.SetArgs([]string{"--query", "from:newsletter", "--action", "archive", "--yes"})
+		cmd.SetOut(&bytes.Buffer{})
+		cmd.SetErr(&bytes.Buffer{})
+
+		err := cmd.Execute()
+
+		require.Error(t, err)
+		var safeModeErr *cmdutil.SafeModeError
+		assert.ErrorAs(t, err, &safeModeErr)
+	})
+
+	t.Run("archives every match", func(t *testing.T) {
+		f, stdout, stderr := setupTest(t)
+
+		var update map[string]interface{}
+		httpmock.RegisterResponder("POST", "https://api.test.com/jmap/api", mockApply(&update))
+
+		cmd := NewCmdApply(f)
+		cmd.SetArgs([]string{"--query", "from:newsletter older:30d", "--action", "archive", "--unsafe", "--yes"})
+		cmd.SetOut(stdout)
+		cmd.SetErr(&bytes.Buffer{})
+
+		err := cmd.Execute()
+
+		require.NoError(t, err)
+		assert.Contains(t, stderr.String(), "2 emails match: from:newsletter older:30d")
+		assert.Equal(t, "Archived 2 emails.\n", stdout.String())
+		assert.Equal(t, map[string]interface{}{
+			"email-1": map[string]interface{}{"mailboxIds": map[string]interface{}{"archive-1": true}},
+			"email-2": map[string]interface{}{"mailboxIds": map[string]interface{}{"archive-1": true}},
+		}, update)
+	})
+
+	t.Run("moves matches to the --to folder", func(t *testing.T) {
+		f, stdout, _ := setupTest(t)
+
+		var update map[string]interface{}
+		httpmock.RegisterResponder("POST", "https://api.test.com/jmap/api", mockApply(&update))
+
+		cmd := NewCmdApply(f)
+		cmd.SetArgs([]string{"--query", "subject:receipt", "--action", "move", "--to", "Receipts", "--unsafe", "--yes"})
+		cmd.SetOut(stdout)
+		cmd.SetErr(&bytes.Buffer{})
+
+		err := cmd.Execute()
+
+		require.NoError(t, err)
+		assert.Equal(t, "Moved 2 emails to Receipts.\n", stdout.String())
+		assert.Equal(t, map[string]interface{}{"mailboxIds": map[string]interface{}{"receipts-1": true}}, update["email-1"])
+	})
+
+	t.Run("marks matches as read", func(t *testing.T) {
+		f, stdout, _ := setupTest(t)
+
+		var update map[string]interface{}
+		httpmock.RegisterResponder("POST", "https://api.test.com/jmap/api", mockApply(&update))
+
+		cmd := NewCmdApply(f)
+		cmd.SetArgs([]string{"--query", "from:alerts", "--action", "mark-read", "--unsafe", "--yes"})
+		cmd.SetOut(stdout)
+		cmd.SetErr(&bytes.Buffer{})
+
+		err := cmd.Execute()
+
+		require.NoError(t, err)
+		assert.Equal(t, "Marked 2 emails as read.\n", stdout.String())
+		assert.Equal(t, map[string]interface{}{"keywords/$seen": true}, update["email-2"])
+	})
+
+	t.Run("requires --yes in non-interactive mode", func(t *testing.T) {
+		f, _, _ := setupTest(t)
+
+		var update map[string]interface{}
+		httpmock.RegisterResponder("POST", "https://api.test.com/jmap/api", mockApply(&update))
+
+		cmd := NewCmdApply(f)
+		cmd.SetArgs([]string{"--query", "from:newsletter", "--action", "delete", "--unsafe"})
+		cmd.SetOut(&bytes.Buffer{})
+		cmd.SetErr(&bytes.Buffer{})
+
+		err := cmd.Execute()
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "requires --yes")
+		assert.Nil(t, update, "nothing should change without confirmation")
+	})
+
+	t.Run("lists matches with --dry-run, even in safe mode", func(t *testing.T) {
+		f, stdout, stderr := setupTest(t)
+
+		var update map[string]interface{}
+		httpmock.RegisterResponder("POST", "https://api.test.com/jmap/api", mockApply(&update))
+
+		cmd := NewCmdApply(f)
+		cmd.SetArgs([]string{"--query", "subject:receipt", "--action", "move", "--to", "Receipts", "--dry-run"})
+		cmd.SetOut(stdout)
+		cmd.SetErr(&bytes.Buffer{})
+
+		err := cmd.Execute()
+
+		require.NoError(t, err)
+		assert.Contains(t, stdout.String(), "Your receipt")
+		assert.Contains(t, stdout.String(), "Another receipt")
+		assert.Contains(t, stderr.String(), "Dry run: 2 emails would be moved to Receipts.")
+		assert.Nil(t, update)
+	})
+
+	t.Run("runs the documented folder example", func(t *testing.T) {
+		f, stdout, stderr := setupTest(t)
+
+		var update, queryArgs map[string]interface{}
+		apply := mockApply(&update)
+		httpmock.RegisterResponder("POST", "https://api.test.com/jmap/api",
+			func(req *http.Request) (*http.Response, error) {
+				var jmapReq jmap.Request
+				body, _ := io.ReadAll(req.Body)
+				json.Unmarshal(body, &jmapReq)
+				req.Body = io.NopCloser(bytes.NewReader(body))
+
+				if jmapReq.MethodCalls[0][0].(string) == "Email/query" {
+					queryArgs = jmapReq.MethodCalls[0][1].(map[string]interface{})
+				}
+				return apply(req)
+			})
+
+		cmd := NewCmdApply(f)
+		cmd.SetArgs([]string{"--query", "older:365d in:abc123def456", "--action", "delete", "--dry-run"})
+		cmd.SetOut(stdout)
+		cmd.SetErr(&bytes.Buffer{})
+
+		err := cmd.Execute()
+
+		require.NoError(t, err)
+		assert.Contains(t, stderr.String(), "Dry run: 2 emails would be moved to Trash.")
+		filter := queryArgs["filter"].(map[string]interface{})
+		assert.Equal(t, "AND", filter["operator"])
+		assert.Contains(t, filter["conditions"], map[string]interface{}{"inMailbox": "abc123def456"})
+		conditions := filter["conditions"].([]interface{})
+		require.Len(t, conditions, 2)
+		assert.Contains(t, conditions[0], "before")
+		assert.Nil(t, update)
+	})
+
+	t.Run("dry run queries the same emails as the real run", func(t *testing.T) {
+		f, _, _ := setupTest(t)
+
+		var update map[string]interface{}
+		var queries []map[string]interface{}
+		apply := mockApply(&update)
+		httpmock.RegisterResponder("POST", "https://api.test.com/jmap/api",
+			func(req *http.Request) (*http.Response, error) {
+				var jmapReq jmap.Request
+				body, _ := io.ReadAll(req.Body)
+				json.Unmarshal(body, &jmapReq)
+				req.Body = io.NopCloser(bytes.NewReader(body))
+
+				if jmapReq.MethodCalls[0][0].(string) == "Email/query" {
+					queries = append(queries, jmapReq.MethodCalls[0][1].(map[string]interface{}))
+				}
+				return apply(req)
+			})
+
+		for _, extra := range []string{"--dry-run", "--yes"} {
+			cmd := NewCmdApply(f)
+			cmd.SetArgs([]string{"--query", "subject:receipt", "--action", "archive", "--limit", "2", "--unsafe", extra})
+			cmd.SetOut(&bytes.Buffer{})
+			cmd.SetErr(&bytes.Buffer{})
+			require.NoError(t, cmd.Execute())
+		}
+
+		require.Len(t, queries, 2)
+		assert.Equal(t, queries[1], queries[0])
+		assert.Equal(t, float64(2), queries[0]["limit"])
+	})
+
+	t.Run("rejects --limit below 1", func(t *testing.T) {
+		for _, limit := range []string{"0", "-1"} {
+			f, _, _ := setupTest(t)
+
+			cmd := NewCmdApply(f)
+			cmd.SetArgs([]string{"--query", "from:newsletter", "--action", "archive", "--dry-run", "--limit", limit})
+			cmd.SetOut(&bytes.Buffer{})
+			cmd.SetErr(&bytes.Buffer{})
+
+			err := cmd.Execute()
+
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), "invalid --limit "+limit)
+			assert.Zero(t, httpmock.GetTotalCallCount())
+		}
+	})
+
+	t.Run("rejects a blank query", func(t *testing.T) {
+		for _, query := range []string{"", "   "} {
+			f, _, _ := setupTest(t)
+
+			cmd := NewCmdApply(f)
+			cmd.SetArgs([]string{"--query", query, "--action", "archive", "--unsafe", "--yes"})
+			cmd.SetOut(&bytes.Buffer{})
+			cmd.SetErr(&bytes.Buffer{})
+
+			err := cmd.Execute()
+
+			require.Error(t, err)
+			var flagErr *cmdutil.FlagError
+			assert.ErrorAs(t, err, &flagErr)
+			assert.Contains(t, err.Error(), "--query cannot be empty")
+			assert.Zero(t, httpmock.GetTotalCallCount())
+		}
+	})
+
+	t.Run("validates the action", func(t *testing.T) {
+		tests := []struct {
+			args []string
+			want string
+		}{
+			{[]string{"--query", "x", "--action", "explode"}, "invalid action"},
+			{[]string{"--query", "x", "--action", "move"}, "requires --to"},
+			{[]string{"--query", "x", "--action", "archive", "--to", "Receipts"}, "--to can only be used"},
+		}
+
+		for _, tt := range tests {
+			f := &cmdutil.Factory{}
+			cmd := NewCmdApply(f)
+			cmd.SetArgs(tt.args)
+			cmd.SetOut(&bytes.Buffer{})
+			cmd.SetErr(&bytes.Buffer{})
+
+			err := cmd.Execute()
+
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.want)
+		}
+	})
+}
+
+// Copy command tests
+
+func TestCopyCommand(t *testing.T) {
+	mailboxes := []map[string]interface{}{
+		{"id": "inbox-1", "name": "Inbox", "role": "inbox"},
+		{"id": "receipts-1", "name": "Receipts"},
+		{"id": "taxes-1", "name": "Taxes"},
+	}
+
+	t.Run("adds the email to every folder in one update", func(t *testing.T) {
+		f, stdout, _ := setupTest(t)
+
+		var updated map[string]interface{}
+		httpmock.RegisterResponder("POST", "https://api.test.com/jmap/api", mockMoveResponse(mailboxes, &updated))
+
+		cmd := NewCmdCopy(f)
+		cmd.SetArgs([]string{"email-1", "Receipts", "Taxes"})
+		cmd.SetOut(stdout)
+		cmd.SetErr(&bytes.Buffer{})
+
+		err := cmd.Execute()
+
+		require.NoError(t, err)
+		assert.Equal(t, "Copied to Receipts, Taxes.\n", stdout.String())
+		assert.Equal(t, map[string]interface{}{
+			"email-1": map[string]interface{}{
+				"mailboxIds/receipts-1": true,
+				"mailboxIds/taxes-1":    true,
+			},
+		}, updated)
+	})
+
+	t.Run("changes nothing if a folder does not exist", func(t *testing.T) {
+		f, _, _ := setupTest(t)
+
+		var updated map[string]interface{}
+		httpmock.RegisterResponder("POST", "https://api.test.com/jmap/api", mockMoveResponse(mailboxes, &updated))
+
+		cmd := NewCmdCopy(f)
+		cmd.SetArgs([]string{"email-1", "Receipts", "Nope"})
+		cmd.SetOut(&bytes.Buffer{})
+		cmd.SetErr(&bytes.Buffer{})
+
+		err := cmd.Execute()
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "folder not found: Nope")
+		assert.Nil(t, updated)
+	})
+
+	t.Run("requires email ID and folder arguments", func(t *testing.T) {
+		f := &cmdutil.Factory{}
+		cmd := NewCmdCopy(f)
+		cmd.SetArgs([]string{"email-1"})
+		cmd.SetOut(&bytes.Buffer{})
+		cmd.SetErr(&bytes.Buffer{})
+
+		err := cmd.Execute()
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "email ID and folder required")
+	})
 }
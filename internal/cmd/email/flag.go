@@ -0,0 +1,153 @@
+package email
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/marckohlbrugge/fastmail-cli/internal/cmdutil"
+	"github.com/marckohlbrugge/fastmail-cli/internal/jmap"
+	"github.com/spf13/cobra"
+)
+
+type flagOptions struct {
+	Query  string
+	Limit  int
+	Unflag bool
+	Yes    bool
+}
+
+// NewCmdFlag creates the email flag command.
+func NewCmdFlag(f *cmdutil.Factory) *cobra.Command {
+	opts := &flagOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "flag [<email-id>...]",
+		Short: "Flag emails",
+		Long: `Flag (star) one or more emails, or every email matching a search query.
+
+With --query, the query uses the same syntax as 'fm search' and at most
+--limit matches are flagged, newest first. The number of matches is shown
+before asking for confirmation; --yes skips it, and is required in
+non-interactive mode.
+
+If no IDs or query are given and stdin is a pipe, IDs are read from stdin.`,
+		Example: `  # Flag an email
+  fm email flag M1234567890
+
+  # Flag everything unread from your boss
+  fm email flag --query "from:boss is:unread"
+
+  # Flag matches without prompting
+  fm email flag --query "subject:invoice" --yes
+
+  # Remove the flag
+  fm email flag M1234567890 --unflag`,
+		Args: cobra.ArbitraryArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if cmd.Flags().Changed("query") {
+				if len(args) > 0 {
+					return cmdutil.FlagErrorf("cannot combine email IDs with --query")
+				}
+				return runFlagQuery(f, opts)
+			}
+
+			ids, err := cmdutil.IDsOrStdin(f.IOStreams, args, "email IDs or --query required\n\nUsage: fm email flag <email-id>...\n       fm email flag --query <query>")
+			if err != nil {
+				return err
+			}
+			return runFlag(f, opts, ids)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.Query, "query", "", "Flag every email matching a search `query`")
+	cmd.Flags().IntVar(&opts.Limit, "limit", 50, "Maximum emails to flag with --query (max 500)")
+	cmd.Flags().BoolVar(&opts.Unflag, "unflag", false, "Remove the flag instead")
+	cmd.Flags().BoolVar(&opts.Yes, "yes", false, "Skip confirmation prompt for --query")
+
+	return cmd
+}
+
+func runFlagQuery(f *cmdutil.Factory, opts *flagOptions) error {
+	// An empty filter would match every email
+	if strings.TrimSpace(opts.Query) == "" {
+		return cmdutil.FlagErrorf("--query cannot be empty")
+	}
+	if err := cmdutil.ValidateBulkLimit(opts.Limit); err != nil {
+		return err
+	}
+
+	client, err := f.JMAPClient()
+	if err != nil {
+		return err
+	}
+
+	ids, total, err := client.QueryEmailIDs(jmap.SearchFilters{Query: opts.Query, Limit: opts.Limit})
+	if err != nil {
+		return err
+	}
+
+	if len(ids) == 0 {
+		fmt.Fprintf(f.IOStreams.Out, "No emails found matching: %s\n", opts.Query)
+		return nil
+	}
+
+	verb, done := "Flag", "flagged"
+	if opts.Unflag {
+		verb, done = "Unflag", "unflagged"
+	}
+
+	errOut := f.IOStreams.ErrOut
+	fmt.Fprintf(errOut, "%d emails match: %s\n", total, opts.Query)
+	if total > len(ids) {
+		fmt.Fprintf(errOut, "Only the newest %d will be %s; raise --limit to include more.\n", len(ids), done)
+	}
+
+	// Require confirmation unless --yes
+	if !opts.Yes {
+		if f.Quiet {
+			return cmdutil.QuietConfirmError
+		}
+		if !f.IOStreams.IsInteractive() {
+			return cmdutil.FlagErrorf("non-interactive mode requires --yes flag")
+		}
+
+		if ok, err := cmdutil.Confirm(f.IOStreams, fmt.Sprintf("%s %d emails?", verb, len(ids))); !ok {
+			return err
+		}
+	}
+
+	return flagEmails(f, client, opts, ids)
+}
+
+func runFlag(f *cmdutil.Factory, opts *flagOptions, emailIDs []string) error {
+	client, err := f.JMAPClient()
+	if err != nil {
+		return err
+	}
+
+	return flagEmails(f, client, opts, emailIDs)
+}
+
+func flagEmails(f *cmdutil.Factory, client *jmap.Client, opts *flagOptions, emailIDs []string) error {
+	updated, failed, err := client.SetKeyword(emailIDs, "$flagged", !opts.Unflag)
+	if err != nil {
+		return err
+	}
+
+	verb := "Flagged"
+	if opts.Unflag {
+		verb = "Unflagged"
+	}
+
+	out := f.IOStreams.Out
+	if len(failed) > 0 {
+		fmt.Fprintf(out, "%s %d emails. Failed: %d\n", verb, updated, len(failed))
+		for _, id := range failed {
+			fmt.Fprintf(f.IOStreams.ErrOut, "  Failed: %s\n", id)
+		}
+		return nil
+	}
+
+	fmt.Fprintf(out, "%s %d emails.\n", verb, updated)
+	return nil
+}
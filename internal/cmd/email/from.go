@@ -0,0 +1,129 @@
+package email
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/marckohlbrugge/fastmail-cli/internal/cmd/search"
+	"github.com/marckohlbrugge/fastmail-cli/internal/cmdutil"
+	"github.com/marckohlbrugge/fastmail-cli/internal/jmap"
+	"github.com/spf13/cobra"
+)
+
+type fromOptions struct {
+	Limit      int
+	Unread     bool
+	JSONFields []string
+}
+
+// NewCmdFrom creates the email from command.
+func NewCmdFrom(f *cmdutil.Factory) *cobra.Command {
+	opts := &fromOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "from <address-or-name>",
+		Short: "List emails from a sender",
+		Long: `List emails from a sender, newest first. A shortcut for
+'fm search "from:<address>"', with the same output formats.
+
+An argument without an @ is looked up in your contacts, like draft
+recipients are. If it matches several contacts, emails from any of them
+are listed. When contacts aren't available or nothing matches, the name is
+searched as-is, which matches the sender's display name.`,
+		Example: `  # Emails from an address
+  fm email from alice@example.com
+
+  # Unread emails from a contact
+  fm email from "Alice Smith" --unread
+
+  # Output as JSON
+  fm email from alice@example.com --json id,subject,date`,
+		Args: cmdutil.ExactArgs(1, "sender required\n\nUsage: fm email from <address-or-name>"),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if !cmd.Flags().Changed("limit") {
+				opts.Limit = f.DefaultLimit(opts.Limit)
+			}
+			limit, err := cmdutil.ParseLimit(opts.Limit)
+			if err != nil {
+				return err
+			}
+			opts.Limit = limit
+			return runFrom(f, opts, args[0])
+		},
+	}
+
+	cmd.Flags().IntVar(&opts.Limit, "limit", 50, fmt.Sprintf("Maximum results (max 500, or 0 for every match; fails if more than %d match)", jmap.MaxAllEmails))
+	cmd.Flags().BoolVar(&opts.Unread, "unread", false, "Only show unread emails")
+	cmd.Flags().StringSliceVar(&opts.JSONFields, "json", nil, "Output JSON with specified `fields` ("+strings.Join(cmdutil.AvailableEmailFields, ",")+")")
+
+	return cmd
+}
+
+func runFrom(f *cmdutil.Factory, opts *fromOptions, sender string) error {
+	if err := cmdutil.ValidateFields(opts.JSONFields); err != nil {
+		return err
+	}
+
+	client, err := f.JMAPClient()
+	if err != nil {
+		return err
+	}
+
+	addresses, err := resolveSender(f, client, sender)
+	if err != nil {
+		return err
+	}
+
+	return search.RunQuery(f, fromQuery(addresses, opts.Unread), opts.Limit, opts.JSONFields)
+}
+
+// fromQuery builds the search query for emails from any of addresses.
+func fromQuery(addresses []string, unread bool) string {
+	terms := make([]string, len(addresses))
+	for i, addr := range addresses {
+		if strings.ContainsAny(addr, " \t") {
+			addr = `"` + addr + `"`
+		}
+		terms[i] = "from:" + addr
+	}
+
+	query := strings.Join(terms, " OR ")
+	if unread {
+		if len(terms) > 1 {
+			query = "(" + query + ")"
+		}
+		query += " is:unread"
+	}
+	return query
+}
+
+// resolveSender returns the addresses to search for. Names are resolved
+// through contacts; a name that can't be resolved is returned unchanged.
+func resolveSender(f *cmdutil.Factory, client *jmap.Client, sender string) ([]string, error) {
+	if strings.Contains(sender, "@") {
+		return []string{sender}, nil
+	}
+
+	contacts, err := client.GetContacts()
+	if errors.Is(err, jmap.ErrContactsUnavailable) {
+		return []string{sender}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	matches := cmdutil.MatchContacts(contacts, sender)
+	if len(matches) == 0 {
+		return []string{sender}, nil
+	}
+
+	addresses := make([]string, len(matches))
+	for i, c := range matches {
+		addresses[i] = c.PrimaryEmail()
+	}
+	if !f.Quiet {
+		fmt.Fprintf(f.IOStreams.ErrOut, "Resolved %q to %s\n", sender, strings.Join(addresses, ", "))
+	}
+	return addresses, nil
+}
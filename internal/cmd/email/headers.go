@@ -0,0 +1,242 @@
+package email
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/marckohlbrugge/fastmail-cli/internal/cmdutil"
+	"github.com/marckohlbrugge/fastmail-cli/internal/jmap"
+	"github.com/spf13/cobra"
+)
+
+const headersUsage = "Usage: fm email headers <email-id> <header-name>\n       fm email headers <email-id> --all\n       fm email headers <email-id> --auth"
+
+type headersOptions struct {
+	All  bool
+	Auth bool
+	JSON bool
+}
+
+// NewCmdHeaders creates the email headers command.
+func NewCmdHeaders(f *cmdutil.Factory) *cobra.Command {
+	opts := &headersOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "headers <email-id> [<header-name>]",
+		Short: "Print email headers",
+		Long: `Print the value of a single email header, or every header with --all.
+
+Header names are case-insensitive. If a header appears more than once
+(such as Received), each value is printed on its own line. The command
+fails if the email does not have the header.
+
+--auth summarizes the Authentication-Results header added by Fastmail's
+servers into one line per check (spf, dkim, dmarc, ...), colored by
+outcome. If the header can't be parsed, its raw value is printed instead.`,
+		Example: `  # Check how a message was authenticated
+  fm email headers M1234567890 Authentication-Results
+
+  # Summarize SPF, DKIM, and DMARC results
+  fm email headers M1234567890 --auth
+
+  # Dump all headers
+  fm email headers M1234567890 --all
+
+  # Output as JSON
+  fm email headers M1234567890 Received --json`,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if len(args) == 0 {
+				return cmdutil.FlagErrorf("email ID required\n\n%s", headersUsage)
+			}
+			if opts.All && opts.Auth {
+				return cmdutil.FlagErrorf("cannot combine --all with --auth\n\n%s", headersUsage)
+			}
+			if opts.All && len(args) > 1 {
+				return cmdutil.FlagErrorf("cannot combine a header name with --all\n\n%s", headersUsage)
+			}
+			if opts.Auth && len(args) > 1 {
+				return cmdutil.FlagErrorf("cannot combine a header name with --auth\n\n%s", headersUsage)
+			}
+			if !opts.All && !opts.Auth && len(args) != 2 {
+				return cmdutil.FlagErrorf("header name required\n\n%s", headersUsage)
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if opts.All {
+				return runAllHeaders(f, opts, args[0])
+			}
+			if opts.Auth {
+				return runAuthHeader(f, opts, args[0])
+			}
+			return runHeader(f, opts, args[0], args[1])
+		},
+	}
+
+	cmd.Flags().BoolVar(&opts.All, "all", false, "Print every header")
+	cmd.Flags().BoolVar(&opts.Auth, "auth", false, "Summarize SPF, DKIM, and DMARC results")
+	cmd.Flags().BoolVar(&opts.JSON, "json", false, "Output in JSON format")
+
+	return cmd
+}
+
+func runHeader(f *cmdutil.Factory, opts *headersOptions, emailID, name string) error {
+	if name == "" || strings.ContainsAny(name, ": \t") {
+		return cmdutil.FlagErrorf("invalid header name %q", name)
+	}
+
+	client, err := f.JMAPClient()
+	if err != nil {
+		return err
+	}
+
+	values, err := client.GetEmailHeader(emailID, name)
+	if err != nil {
+		return err
+	}
+
+	if len(values) == 0 {
+		return fmt.Errorf("email %s has no %s header", emailID, name)
+	}
+
+	return printHeaderValues(f, opts, name, values)
+}
+
+// printHeaderValues prints each value of a header on its own line, or as
+// JSON name/value pairs.
+func printHeaderValues(f *cmdutil.Factory, opts *headersOptions, name string, values []string) error {
+	if opts.JSON {
+		headers := make([]jmap.EmailHeader, len(values))
+		for i, value := range values {
+			headers[i] = jmap.EmailHeader{Name: name, Value: value}
+		}
+		return cmdutil.WriteJSON(f.IOStreams.Out, headers)
+	}
+
+	for _, value := range values {
+		fmt.Fprintln(f.IOStreams.Out, value)
+	}
+	return nil
+}
+
+func runAllHeaders(f *cmdutil.Factory, opts *headersOptions, emailID string) error {
+	client, err := f.JMAPClient()
+	if err != nil {
+		return err
+	}
+
+	headers, err := client.GetEmailHeaders(emailID)
+	if err != nil {
+		return err
+	}
+
+	// Raw values keep the space after the colon and any folding
+	for i := range headers {
+		headers[i].Value = strings.TrimSpace(headers[i].Value)
+	}
+
+	if opts.JSON {
+		return cmdutil.WriteJSON(f.IOStreams.Out, headers)
+	}
+
+	for _, h := range headers {
+		fmt.Fprintf(f.IOStreams.Out, "%s: %s\n", h.Name, h.Value)
+	}
+	return nil
+}
+
+// authResult is one check from an Authentication-Results header, such as
+// "dkim=pass header.d=example.com".
+type authResult struct {
+	Method     string `json:"method"`
+	Result     string `json:"result"`
+	Properties string `json:"properties,omitempty"`
+}
+
+func runAuthHeader(f *cmdutil.Factory, opts *headersOptions, emailID string) error {
+	client, err := f.JMAPClient()
+	if err != nil {
+		return err
+	}
+
+	values, err := client.GetEmailHeader(emailID, "Authentication-Results")
+	if err != nil {
+		return err
+	}
+
+	if len(values) == 0 {
+		return fmt.Errorf("email %s has no Authentication-Results header", emailID)
+	}
+
+	// Only the topmost header was added by Fastmail; any below it came from
+	// earlier hops and could have been written by the sender.
+	results := parseAuthResults(values[0])
+	if len(results) == 0 {
+		return printHeaderValues(f, opts, "Authentication-Results", values)
+	}
+
+	if opts.JSON {
+		return cmdutil.WriteJSON(f.IOStreams.Out, results)
+	}
+
+	cs := f.IOStreams.ColorScheme()
+	for _, r := range results {
+		result := r.Result
+		switch r.Result {
+		case "pass":
+			result = cs.Green(result)
+		case "fail", "softfail", "permerror":
+			result = cs.Red(result)
+		default:
+			result = cs.Yellow(result)
+		}
+		line := fmt.Sprintf("%s=%s", r.Method, result)
+		if r.Properties != "" {
+			line += " " + cs.Dim(r.Properties)
+		}
+		fmt.Fprintln(f.IOStreams.Out, line)
+	}
+	return nil
+}
+
+// parseAuthResults parses an Authentication-Results header value (RFC 8601):
+// an authserv-id followed by semicolon-separated "method=result property..."
+// checks. Comments in parentheses are dropped. It returns nil if the value
+// contains no checks.
+func parseAuthResults(value string) []authResult {
+	parts := strings.Split(stripComments(value), ";")
+	var results []authResult
+	for _, part := range parts[1:] {
+		fields := strings.Fields(part)
+		if len(fields) == 0 {
+			continue
+		}
+		method, result, ok := strings.Cut(fields[0], "=")
+		if !ok || method == "" || result == "" {
+			continue
+		}
+		results = append(results, authResult{
+			Method:     strings.ToLower(method),
+			Result:     strings.ToLower(result),
+			Properties: strings.Join(fields[1:], " "),
+		})
+	}
+	return results
+}
+
+// stripComments removes RFC 5322 parenthesized comments, which may nest.
+func stripComments(s string) string {
+	var b strings.Builder
+	depth := 0
+	for _, r := range s {
+		switch {
+		case r == '(':
+			depth++
+		case r == ')' && depth > 0:
+			depth--
+		case depth == 0:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,125 @@
+package email
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/marckohlbrugge/fastmail-cli/internal/cmdutil"
+	"github.com/marckohlbrugge/fastmail-cli/internal/jmap"
+	"github.com/spf13/cobra"
+)
+
+type infoOptions struct {
+	JSON bool
+}
+
+// infoJSON is the --json output of info.
+type infoJSON struct {
+	ID         string              `json:"id"`
+	ThreadID   string              `json:"threadId"`
+	Subject    string              `json:"subject"`
+	From       []jmap.EmailAddress `json:"from"`
+	To         []jmap.EmailAddress `json:"to"`
+	ReceivedAt time.Time           `json:"receivedAt"`
+	Size       int64               `json:"size"`
+	Folders    []string            `json:"folders"`
+	IsUnread   bool                `json:"isUnread"`
+	IsFlagged  bool                `json:"isFlagged"`
+	IsDraft    bool                `json:"isDraft"`
+	Keywords   []string            `json:"keywords"`
+}
+
+// NewCmdInfo creates the email info command.
+func NewCmdInfo(f *cmdutil.Factory) *cobra.Command {
+	opts := &infoOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "info <email-id>",
+		Short: "Show an email's metadata without its body",
+		Long: `Show an email's subject, sender, recipients, date, size, folder, and
+flags without fetching its body.
+
+This is faster than 'fm email read' and is meant for scripts that only need
+to check an email's state.`,
+		Example: `  # Show an email's metadata
+  fm email info M1234567890
+
+  # Check whether an email has been read
+  fm email info M1234567890 --json | jq .isUnread`,
+		Args: cmdutil.ExactArgs(1, "email ID required\n\nUsage: fm email info <email-id>"),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runInfo(f, opts, args[0])
+		},
+	}
+
+	cmd.Flags().BoolVar(&opts.JSON, "json", false, "Output in JSON format")
+
+	return cmd
+}
+
+func runInfo(f *cmdutil.Factory, opts *infoOptions, emailID string) error {
+	client, err := f.JMAPClient()
+	if err != nil {
+		return err
+	}
+
+	email, err := client.GetEmailSummary(emailID)
+	if err != nil {
+		return err
+	}
+
+	emails := []jmap.Email{*email}
+	if err := client.ResolveMailboxNames(emails); err != nil {
+		return fmt.Errorf("failed to resolve folders: %w", err)
+	}
+	email = &emails[0]
+
+	if opts.JSON {
+		return cmdutil.WriteJSON(f.IOStreams.Out, infoJSON{
+			ID:         email.ID,
+			ThreadID:   email.ThreadID,
+			Subject:    email.Subject,
+			From:       email.From,
+			To:         email.To,
+			ReceivedAt: email.ReceivedAt,
+			Size:       email.Size,
+			Folders:    email.MailboxNames,
+			IsUnread:   email.IsUnread(),
+			IsFlagged:  email.Keywords["$flagged"],
+			IsDraft:    email.IsDraft(),
+			Keywords:   email.KeywordList(),
+		})
+	}
+
+	subject := email.Subject
+	if subject == "" {
+		subject = "(no subject)"
+	}
+
+	out := f.IOStreams.Out
+	fmt.Fprintf(out, "ID:      %s\n", email.ID)
+	fmt.Fprintf(out, "Subject: %s\n", subject)
+	fmt.Fprintf(out, "From:    %s\n", jmap.FormatAddresses(email.From))
+	fmt.Fprintf(out, "To:      %s\n", jmap.FormatAddresses(email.To))
+	fmt.Fprintf(out, "Date:    %s\n", f.FormatDate(email.ReceivedAt, dateLayout))
+	fmt.Fprintf(out, "Size:    %s\n", cmdutil.FormatBytes(email.Size))
+	fmt.Fprintf(out, "Folder:  %s\n", strings.Join(email.MailboxNames, ", "))
+	fmt.Fprintf(out, "Flags:   %s\n", infoFlags(email))
+	return nil
+}
+
+// infoFlags describes an email's read, flagged, and draft state.
+func infoFlags(email *jmap.Email) string {
+	flags := []string{"read"}
+	if email.IsUnread() {
+		flags[0] = "unread"
+	}
+	if email.Keywords["$flagged"] {
+		flags = append(flags, "flagged")
+	}
+	if email.IsDraft() {
+		flags = append(flags, "draft")
+	}
+	return strings.Join(flags, ", ")
+}
@@ -0,0 +1,77 @@
+package email
+
+import (
+	"fmt"
+
+	"github.com/marckohlbrugge/fastmail-cli/internal/cmdutil"
+	"github.com/spf13/cobra"
+)
+
+// NewCmdMoveToJunk creates the email move-to-junk command.
+func NewCmdMoveToJunk(f *cmdutil.Factory) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "move-to-junk <email-id>...",
+		Aliases: []string{"junk"},
+		Short:   "Move emails to the junk folder",
+		Long: `Move one or more emails to the Junk folder.
+
+The junk folder is the mailbox with the junk role, or else a folder named
+Junk or Spam. This only files the emails; it does not report them as spam.
+This is a reversible action - emails can be moved back from Junk.
+
+If no IDs are given and stdin is a pipe, IDs are read from stdin.`,
+		Example: `  # Move an email to junk
+  fm email move-to-junk M1234567890
+
+  # Move several emails using the short alias
+  fm email junk M1234567890 M0987654321`,
+		Args: cobra.ArbitraryArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ids, err := cmdutil.IDsOrStdin(f.IOStreams, args, "at least one email ID required\n\nUsage: fm email move-to-junk <email-id>...")
+			if err != nil {
+				return err
+			}
+			return runMoveToJunk(f, ids)
+		},
+	}
+
+	return cmd
+}
+
+func runMoveToJunk(f *cmdutil.Factory, emailIDs []string) error {
+	client, err := f.JMAPClient()
+	if err != nil {
+		return err
+	}
+
+	junk, err := client.GetJunkMailbox()
+	if err != nil {
+		return err
+	}
+
+	out := f.IOStreams.Out
+
+	if len(emailIDs) == 1 {
+		if err := client.MoveEmail(emailIDs[0], junk.ID); err != nil {
+			return err
+		}
+		fmt.Fprintf(out, "Moved to %s.\n", junk.Name)
+		return nil
+	}
+
+	moved, failed, err := client.MoveEmails(emailIDs, junk.ID)
+	if err != nil {
+		return err
+	}
+
+	if len(failed) > 0 {
+		fmt.Fprintf(out, "Moved %d emails to %s. Failed: %d\n", moved, junk.Name, len(failed))
+		for _, id := range failed {
+			fmt.Fprintf(f.IOStreams.ErrOut, "  Failed: %s\n", id)
+		}
+		return nil
+	}
+
+	fmt.Fprintf(out, "Moved %d emails to %s.\n", moved, junk.Name)
+	return nil
+}
@@ -0,0 +1,82 @@
+package email
+
+import (
+	"fmt"
+
+	"github.com/marckohlbrugge/fastmail-cli/internal/cmdutil"
+	"github.com/spf13/cobra"
+)
+
+type markThreadReadOptions struct {
+	Unread bool
+}
+
+// NewCmdMarkThreadRead creates the email mark-thread-read command.
+func NewCmdMarkThreadRead(f *cmdutil.Factory) *cobra.Command {
+	opts := &markThreadReadOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "mark-thread-read <email-id>",
+		Short: "Mark every email in a thread as read",
+		Long: `Mark every email in the conversation containing an email as read.
+
+Accepts an email ID or a thread ID. Use --unread to mark the whole thread
+unread instead.`,
+		Example: `  # Mark a noisy thread as read
+  fm email mark-thread-read M1234567890
+
+  # Mark it unread again
+  fm email mark-thread-read M1234567890 --unread`,
+		Args: cmdutil.ExactArgs(1, "email ID required\n\nUsage: fm email mark-thread-read <email-id>"),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runMarkThreadRead(f, opts, args[0])
+		},
+	}
+
+	cmd.Flags().BoolVar(&opts.Unread, "unread", false, "Mark the thread as unread instead")
+
+	return cmd
+}
+
+func runMarkThreadRead(f *cmdutil.Factory, opts *markThreadReadOptions, emailID string) error {
+	client, err := f.JMAPClient()
+	if err != nil {
+		return err
+	}
+
+	emails, err := client.GetThread(emailID)
+	if err != nil {
+		return err
+	}
+
+	if len(emails) == 0 {
+		return fmt.Errorf("no emails found in thread")
+	}
+
+	ids := make([]string, len(emails))
+	for i, email := range emails {
+		ids[i] = email.ID
+	}
+
+	updated, failed, err := client.SetKeyword(ids, "$seen", !opts.Unread)
+	if err != nil {
+		return err
+	}
+
+	state := "read"
+	if opts.Unread {
+		state = "unread"
+	}
+
+	out := f.IOStreams.Out
+	if len(failed) > 0 {
+		fmt.Fprintf(out, "Marked %d emails as %s. Failed: %d\n", updated, state, len(failed))
+		for _, id := range failed {
+			fmt.Fprintf(f.IOStreams.ErrOut, "  Failed: %s\n", id)
+		}
+		return nil
+	}
+
+	fmt.Fprintf(out, "Marked %d emails as %s.\n", updated, state)
+	return nil
+}
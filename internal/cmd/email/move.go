@@ -1,21 +1,48 @@
 package email
 
 import (
+	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/marckohlbrugge/fastmail-cli/internal/cmdutil"
 	"github.com/marckohlbrugge/fastmail-cli/internal/jmap"
 	"github.com/spf13/cobra"
 )
 
+const moveUsage = "email ID and folder required\n\nUsage: fm email move <email-id>... <folder>"
+
+type moveOptions struct {
+	MarkRead     bool
+	CreateFolder bool
+	DryRun       bool
+}
+
 // NewCmdMove creates the email move command.
 func NewCmdMove(f *cmdutil.Factory) *cobra.Command {
+	opts := &moveOptions{}
+
 	cmd := &cobra.Command{
-		Use:   "move <email-id> <folder>",
-		Short: "Move an email to a folder",
-		Long: `Move an email to a different folder.
+		Use:   "move <email-id>... <folder>",
+		Short: "Move emails to a folder",
+		Long: `Move one or more emails to a different folder.
+
+The folder can be specified by ID, name, or role (inbox, archive, trash, etc.).
+Part of a name also works when only one folder contains it; if several do,
+you are asked to choose (or, in non-interactive mode, shown the matches).
+If only the folder is given and stdin is a pipe, IDs are read from stdin.
+
+Moving leaves an email's keywords, such as read state, untouched. With
+--mark-read the emails are also marked read in the same update.
 
-The folder can be specified by ID, name, or role (inbox, archive, trash, etc.).`,
+If the folder doesn't exist, --create-folder creates it first; a path like
+"Clients/Acme" creates any missing parent folders too. With --create-folder
+part of a name doesn't count, so "Clients" is created even when "Old
+Clients" exists. Without the flag you are asked whether to create it, or in
+non-interactive mode the move fails.
+
+--dry-run lists the emails that would be moved without changing anything,
+including not creating any folder.`,
 		Example: `  # Move by folder ID
   fm email move M1234567890 abc123def456
 
@@ -23,49 +50,180 @@ The folder can be specified by ID, name, or role (inbox, archive, trash, etc.).`
   fm email move M1234567890 "Work Projects"
 
   # Move by role
-  fm email move M1234567890 inbox`,
-		Args: cmdutil.ExactArgs(2, "email ID and folder required\n\nUsage: fm email move <email-id> <folder>"),
+  fm email move M1234567890 inbox
+
+  # File an email and mark it read in one step
+  fm email move M1234567890 archive --mark-read
+
+  # File into a new nested folder
+  fm email move M1234567890 "Clients/Acme" --create-folder
+
+  # Check what would be moved first
+  fm email move M1234567890 M0987654321 Work --dry-run
+
+  # Move search results, reading IDs from stdin
+  fm search "from:alice" --json id | jq -r '.[].id' | fm email move Work`,
+		Args:              cmdutil.MinimumArgs(1, moveUsage),
+		ValidArgsFunction: cmdutil.CompleteFoldersAfter(f, 1),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runMove(f, args[0], args[1])
+			folder := args[len(args)-1]
+			ids, err := cmdutil.IDsOrStdin(f.IOStreams, args[:len(args)-1], moveUsage)
+			if err != nil {
+				return err
+			}
+			return runMove(f, opts, ids, folder)
 		},
 	}
 
+	cmd.Flags().BoolVar(&opts.MarkRead, "mark-read", false, "Also mark the emails as read")
+	cmd.Flags().BoolVar(&opts.CreateFolder, "create-folder", false, "Create the folder (and any missing parents) if it doesn't exist")
+	cmd.Flags().BoolVar(&opts.DryRun, "dry-run", false, "List the emails that would be moved without moving them")
+
 	return cmd
 }
 
-func runMove(f *cmdutil.Factory, emailID, folderRef string) error {
+func runMove(f *cmdutil.Factory, opts *moveOptions, emailIDs []string, folderRef string) error {
 	client, err := f.JMAPClient()
 	if err != nil {
 		return err
 	}
 
-	// Resolve folder
-	mailbox, err := resolveMailbox(client, folderRef)
+	if opts.DryRun {
+		return dryRunMove(f, client, opts, emailIDs, folderRef)
+	}
+
+	// Resolve folder, creating it if it doesn't exist and that is allowed
+	var created []string
+	mailbox, err := resolveDestination(f, client, opts, folderRef)
+	if errors.Is(err, cmdutil.ErrFolderNotFound) {
+		if !opts.CreateFolder {
+			if f.Quiet || !f.IOStreams.IsInteractive() {
+				return fmt.Errorf("%w\n\nUse --create-folder to create it.", err)
+			}
+			if ok, err := cmdutil.Confirm(f.IOStreams, fmt.Sprintf("Folder %q doesn't exist. Create it?", folderRef)); !ok {
+				return err
+			}
+		}
+		mailbox, created, err = createFolderPath(client, folderRef)
+	}
 	if err != nil {
-		return fmt.Errorf("folder not found: %s", folderRef)
+		return err
 	}
 
-	if err := client.MoveEmail(emailID, mailbox.ID); err != nil {
+	var moved int
+	var failed []string
+	switch {
+	case opts.MarkRead:
+		moved, failed, err = client.MoveEmailsAndMarkRead(emailIDs, mailbox.ID)
+	case len(emailIDs) == 1:
+		if err = client.MoveEmail(emailIDs[0], mailbox.ID); err == nil {
+			moved = 1
+		}
+	default:
+		moved, failed, err = client.MoveEmails(emailIDs, mailbox.ID)
+	}
+	if err != nil {
 		return err
 	}
 
-	fmt.Fprintf(f.IOStreams.Out, "Moved to %s.\n", mailbox.Name)
+	var summary string
+	switch {
+	case len(created) > 0:
+		summary = fmt.Sprintf("Created folder %s and moved %d emails", created[len(created)-1], moved)
+	case len(emailIDs) == 1 && !opts.MarkRead:
+		summary = "Moved"
+	default:
+		summary = fmt.Sprintf("Moved %d emails", moved)
+	}
+	if len(created) == 0 {
+		summary += " to " + mailbox.Name
+	}
+	if opts.MarkRead {
+		summary += " and marked them read"
+	}
+	summary += "."
+
+	out := f.IOStreams.Out
+	if len(failed) > 0 {
+		fmt.Fprintf(out, "%s Failed: %d\n", summary, len(failed))
+		for _, id := range failed {
+			fmt.Fprintf(f.IOStreams.ErrOut, "  Failed: %s\n", id)
+		}
+		return nil
+	}
+
+	fmt.Fprintln(out, summary)
+	return nil
+}
+
+// dryRunMove resolves the destination and lists the emails runMove would
+// move, without creating folders or moving anything.
+func dryRunMove(f *cmdutil.Factory, client *jmap.Client, opts *moveOptions, emailIDs []string, folderRef string) error {
+	outcome := "moved to "
+	mailbox, err := resolveDestination(f, client, opts, folderRef)
+	switch {
+	case errors.Is(err, cmdutil.ErrFolderNotFound) && opts.CreateFolder:
+		outcome += "new folder " + folderRef
+	case err != nil:
+		return err
+	default:
+		outcome += mailbox.Name
+	}
+	if opts.MarkRead {
+		outcome += " and marked read"
+	}
+
+	emails, err := client.GetEmailSummaries(emailIDs)
+	if err != nil {
+		return err
+	}
+
+	printDryRun(f, emails, outcome)
 	return nil
 }
 
-func resolveMailbox(client *jmap.Client, folderRef string) (*jmap.Mailbox, error) {
-	// Try by ID first
-	mailbox, err := client.GetMailboxByID(folderRef)
-	if err == nil {
-		return mailbox, nil
+// resolveDestination finds the folder to move to. With --create-folder only
+// an exact match counts, so a similarly named folder isn't used in place of
+// the one to create.
+func resolveDestination(f *cmdutil.Factory, client *jmap.Client, opts *moveOptions, folderRef string) (*jmap.Mailbox, error) {
+	if opts.CreateFolder {
+		return cmdutil.ResolveMailboxExact(client, folderRef)
+	}
+	return cmdutil.ResolveMailbox(f, client, folderRef)
+}
+
+// createFolderPath creates the folder named by a "Parent/Child" path,
+// reusing any folders along it that already exist. It returns the last
+// folder and the paths of the folders it created.
+func createFolderPath(client *jmap.Client, path string) (*jmap.Mailbox, []string, error) {
+	names := strings.Split(path, "/")
+	for i, name := range names {
+		names[i] = strings.TrimSpace(name)
+		if names[i] == "" {
+			return nil, nil, cmdutil.FlagErrorf("invalid folder path %q", path)
+		}
+	}
+
+	mailboxes, err := client.GetMailboxes()
+	if err != nil {
+		return nil, nil, err
 	}
 
-	// Try by name
-	mailbox, err = client.GetMailboxByName(folderRef)
-	if err == nil {
-		return mailbox, nil
+	var created []string
+	var folder *jmap.Mailbox
+	parentID := ""
+	for i, name := range names {
+		folder = cmdutil.FindChildFolder(mailboxes, parentID, name)
+		if folder == nil {
+			id, err := client.CreateMailbox(name, parentID, "")
+			if err != nil {
+				return nil, created, err
+			}
+			folder = &jmap.Mailbox{ID: id, Name: name, ParentID: parentID}
+			created = append(created, strings.Join(names[:i+1], "/"))
+		}
+		parentID = folder.ID
 	}
 
-	// Try by role
-	return client.GetMailboxByRole(folderRef)
+	return folder, created, nil
 }
@@ -0,0 +1,77 @@
+package email
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/marckohlbrugge/fastmail-cli/internal/cmdutil"
+	"github.com/spf13/cobra"
+)
+
+type previewOptions struct {
+	Lines int
+}
+
+// NewCmdPreview creates the email preview command.
+func NewCmdPreview(f *cmdutil.Factory) *cobra.Command {
+	opts := &previewOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "preview <email-id>",
+		Short: "Show the headers and first lines of an email",
+		Long: `Show an email's headers and the first lines of its body.
+
+This is more than the one-line preview in 'fm inbox' and 'fm search' but
+less than the full 'fm email read' view. Attachments are not listed.`,
+		Example: `  # Show the first 10 lines
+  fm email preview M1234567890
+
+  # Show a little more
+  fm email preview M1234567890 --lines 25`,
+		Args: cmdutil.ExactArgs(1, "email ID required\n\nUsage: fm email preview <email-id>"),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runPreview(f, opts, args[0])
+		},
+	}
+
+	cmd.Flags().IntVar(&opts.Lines, "lines", 10, "Number of body lines to show")
+
+	return cmd
+}
+
+func runPreview(f *cmdutil.Factory, opts *previewOptions, emailID string) error {
+	if opts.Lines < 1 {
+		return cmdutil.FlagErrorf("--lines must be at least 1")
+	}
+
+	client, err := f.JMAPClient()
+	if err != nil {
+		return err
+	}
+
+	email, err := client.GetEmailByID(emailID)
+	if err != nil {
+		return err
+	}
+
+	printEmailHeaders(f, email, nil)
+
+	out := f.IOStreams.Out
+	body := strings.TrimSpace(getBodyText(email))
+	if body == "" {
+		fmt.Fprintln(out, "(no body)")
+		return nil
+	}
+
+	lines := strings.Split(strings.ReplaceAll(body, "\r\n", "\n"), "\n")
+	if len(lines) <= opts.Lines {
+		fmt.Fprintln(out, strings.Join(lines, "\n"))
+		return nil
+	}
+
+	fmt.Fprintln(out, strings.Join(lines[:opts.Lines], "\n"))
+	if !f.Quiet {
+		fmt.Fprintf(out, "\n... %d more lines (fm email read %s)\n", len(lines)-opts.Lines, email.ID)
+	}
+	return nil
+}
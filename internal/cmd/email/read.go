@@ -3,7 +3,6 @@ package email
 import (
 	"encoding/json"
 	"fmt"
-	"regexp"
 	"strings"
 
 	"github.com/marckohlbrugge/fastmail-cli/internal/cmdutil"
@@ -11,8 +10,27 @@ import (
 	"github.com/spf13/cobra"
 )
 
+// dateLayout is how read and thread show dates unless --date-format is given.
+const dateLayout = "Mon, Jan 2, 2006 at 3:04 PM"
+
 type readOptions struct {
-	JSON bool
+	JSON                bool
+	ThreadContext       bool
+	DownloadAttachments string
+}
+
+// threadPosition is where an email sits in its thread, counting from 1.
+type threadPosition struct {
+	Position int
+	Size     int
+}
+
+// readJSON is the --json output of read, with the thread position added
+// when --thread-context is given.
+type readJSON struct {
+	*jmap.Email
+	ThreadPosition int `json:"threadPosition,omitempty"`
+	ThreadSize     int `json:"threadSize,omitempty"`
 }
 
 // NewCmdRead creates the email read command.
@@ -24,12 +42,21 @@ func NewCmdRead(f *cmdutil.Factory) *cobra.Command {
 		Short: "Display the full content of an email",
 		Long: `Display the full content of an email including headers, body, and attachments.
 
-The email-id can be obtained from 'fm inbox' or 'fm search' output.`,
+The email-id can be obtained from 'fm inbox' or 'fm search' output.
+
+With --thread-context, the email's position in its conversation (such as
+"message 3 of 5") is shown too, at the cost of one extra request.`,
 		Example: `  # Read an email
   fm email read M1234567890
 
   # Output as JSON
-  fm email read M1234567890 --json`,
+  fm email read M1234567890 --json
+
+  # Show where the email sits in its conversation
+  fm email read M1234567890 --thread-context
+
+  # Read an email and save its attachments
+  fm email read M1234567890 --download-attachments ~/Downloads`,
 		Args: cmdutil.ExactArgs(1, "email ID required\n\nUsage: fm email read <email-id>"),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return runRead(f, opts, args[0])
@@ -37,6 +64,8 @@ The email-id can be obtained from 'fm inbox' or 'fm search' output.`,
 	}
 
 	cmd.Flags().BoolVar(&opts.JSON, "json", false, "Output in JSON format")
+	cmd.Flags().BoolVar(&opts.ThreadContext, "thread-context", false, "Show the email's position in its thread")
+	cmd.Flags().StringVar(&opts.DownloadAttachments, "download-attachments", "", "Save all attachments into `dir`")
 
 	return cmd
 }
@@ -52,35 +81,57 @@ func runRead(f *cmdutil.Factory, opts *readOptions, emailID string) error {
 		return err
 	}
 
+	var position *threadPosition
+	if opts.ThreadContext && email.ThreadID != "" {
+		if position, err = getThreadPosition(client, email); err != nil {
+			return err
+		}
+	}
+
 	if opts.JSON {
+		output := readJSON{Email: email}
+		if position != nil {
+			output.ThreadPosition = position.Position
+			output.ThreadSize = position.Size
+		}
 		encoder := json.NewEncoder(f.IOStreams.Out)
 		encoder.SetIndent("", "  ")
-		return encoder.Encode(email)
+		err = encoder.Encode(output)
+	} else {
+		err = printEmail(f, email, position)
+	}
+	if err != nil || opts.DownloadAttachments == "" {
+		return err
 	}
 
-	return printEmail(f, email)
+	// Saved paths go to stderr so stdout stays clean for the email itself
+	paths, err := saveAttachments(client, email, opts.DownloadAttachments)
+	for _, path := range paths {
+		fmt.Fprintf(f.IOStreams.ErrOut, "Saved %s\n", path)
+	}
+	return err
 }
 
-func printEmail(f *cmdutil.Factory, email *jmap.Email) error {
-	out := f.IOStreams.Out
-	sep := strings.Repeat("─", 72)
-
-	fmt.Fprintln(out, sep)
-	fmt.Fprintf(out, "ID:      %s\n", email.ID)
-	fmt.Fprintf(out, "Thread:  %s\n", email.ThreadID)
-	fmt.Fprintf(out, "From:    %s\n", jmap.FormatAddresses(email.From))
-	fmt.Fprintf(out, "To:      %s\n", jmap.FormatAddresses(email.To))
-	if len(email.CC) > 0 {
-		fmt.Fprintf(out, "Cc:      %s\n", jmap.FormatAddresses(email.CC))
+// getThreadPosition fetches the email's thread and finds the email in it.
+func getThreadPosition(client *jmap.Client, email *jmap.Email) (*threadPosition, error) {
+	ids, err := client.GetThreadEmailIDs(email.ThreadID)
+	if err != nil {
+		return nil, err
 	}
-	fmt.Fprintf(out, "Date:    %s\n", email.ReceivedAt.Format("Mon, Jan 2, 2006 at 3:04 PM"))
 
-	subject := email.Subject
-	if subject == "" {
-		subject = "(no subject)"
+	for i, id := range ids {
+		if id == email.ID {
+			return &threadPosition{Position: i + 1, Size: len(ids)}, nil
+		}
 	}
-	fmt.Fprintf(out, "Subject: %s\n", subject)
-	fmt.Fprintln(out, sep)
+	return nil, fmt.Errorf("email %s not found in thread %s", email.ID, email.ThreadID)
+}
+
+func printEmail(f *cmdutil.Factory, email *jmap.Email, position *threadPosition) error {
+	out := f.IOStreams.Out
+	sep := strings.Repeat("─", 72)
+
+	printEmailHeaders(f, email, position)
 
 	// Get body content
 	body := getBodyText(email)
@@ -106,6 +157,33 @@ func printEmail(f *cmdutil.Factory, email *jmap.Email) error {
 	return nil
 }
 
+// printEmailHeaders prints the header block shown above an email's body.
+func printEmailHeaders(f *cmdutil.Factory, email *jmap.Email, position *threadPosition) {
+	out := f.IOStreams.Out
+	sep := strings.Repeat("─", 72)
+
+	fmt.Fprintln(out, sep)
+	fmt.Fprintf(out, "ID:      %s\n", email.ID)
+	if position != nil {
+		fmt.Fprintf(out, "Thread:  %s (message %d of %d)\n", email.ThreadID, position.Position, position.Size)
+	} else {
+		fmt.Fprintf(out, "Thread:  %s\n", email.ThreadID)
+	}
+	fmt.Fprintf(out, "From:    %s\n", jmap.FormatAddresses(email.From))
+	fmt.Fprintf(out, "To:      %s\n", jmap.FormatAddresses(email.To))
+	if len(email.CC) > 0 {
+		fmt.Fprintf(out, "Cc:      %s\n", jmap.FormatAddresses(email.CC))
+	}
+	fmt.Fprintf(out, "Date:    %s\n", f.FormatDate(email.ReceivedAt, dateLayout))
+
+	subject := email.Subject
+	if subject == "" {
+		subject = "(no subject)"
+	}
+	fmt.Fprintf(out, "Subject: %s\n", subject)
+	fmt.Fprintln(out, sep)
+}
+
 func getBodyText(email *jmap.Email) string {
 	if email.BodyValues == nil {
 		return ""
@@ -124,7 +202,7 @@ func getBodyText(email *jmap.Email) string {
 	// Fall back to HTML body
 	for _, part := range email.HTMLBody {
 		if bv, ok := email.BodyValues[part.PartID]; ok && bv.Value != "" {
-			return htmlToText(bv.Value)
+			return cmdutil.HTMLToText(bv.Value)
 		}
 	}
 
@@ -137,63 +215,3 @@ func getBodyText(email *jmap.Email) string {
 
 	return ""
 }
-
-func htmlToText(html string) string {
-	// Add newlines for block elements
-	replacements := []struct {
-		pattern string
-		replace string
-	}{
-		{`<br\s*/?>`, "\n"},
-		{`</p>`, "\n\n"},
-		{`</div>`, "\n"},
-		{`</tr>`, "\n"},
-		{`</li>`, "\n"},
-		{`<hr\s*/?>`, "\n───\n"},
-	}
-
-	text := html
-
-	// Apply replacements
-	for _, r := range replacements {
-		re := regexp.MustCompile("(?i)" + r.pattern)
-		text = re.ReplaceAllString(text, r.replace)
-	}
-
-	// Remove style and script content
-	styleRe := regexp.MustCompile(`(?is)<style[^>]*>.*?</style>`)
-	scriptRe := regexp.MustCompile(`(?is)<script[^>]*>.*?</script>`)
-	text = styleRe.ReplaceAllString(text, "")
-	text = scriptRe.ReplaceAllString(text, "")
-
-	// Remove remaining tags
-	tagRe := regexp.MustCompile(`<[^>]+>`)
-	text = tagRe.ReplaceAllString(text, "")
-
-	// Decode common HTML entities
-	entities := map[string]string{
-		"&nbsp;":  " ",
-		"&amp;":   "&",
-		"&lt;":    "<",
-		"&gt;":    ">",
-		"&quot;":  `"`,
-		"&#39;":   "'",
-		"&rsquo;": "'",
-		"&lsquo;": "'",
-		"&rdquo;": `"`,
-		"&ldquo;": `"`,
-		"&ndash;": "–",
-		"&mdash;": "—",
-	}
-	for entity, char := range entities {
-		text = strings.ReplaceAll(text, entity, char)
-	}
-
-	// Clean up whitespace
-	text = regexp.MustCompile(`[ \t]+`).ReplaceAllString(text, " ")
-	text = regexp.MustCompile(`\n `).ReplaceAllString(text, "\n")
-	text = regexp.MustCompile(` \n`).ReplaceAllString(text, "\n")
-	text = regexp.MustCompile(`\n{3,}`).ReplaceAllString(text, "\n\n")
-
-	return strings.TrimSpace(text)
-}
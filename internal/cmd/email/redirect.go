@@ -0,0 +1,107 @@
+package email
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/marckohlbrugge/fastmail-cli/internal/cmdutil"
+	"github.com/marckohlbrugge/fastmail-cli/internal/jmap"
+	"github.com/spf13/cobra"
+)
+
+type redirectOptions struct {
+	To     []string
+	Yes    bool
+	Unsafe bool
+}
+
+// NewCmdRedirect creates the email redirect command.
+func NewCmdRedirect(f *cmdutil.Factory) *cobra.Command {
+	opts := &redirectOptions{}
+
+	cmd := &cobra.Command{
+		Use:     "redirect <email-id> --to <address>",
+		Aliases: []string{"bounce"},
+		Short:   "Re-send an email to new recipients unchanged",
+		Long: `Re-send an email, exactly as it was received, to new recipients.
+
+Unlike 'fm draft forward', which wraps the message in a new email from you,
+redirect hands the original message to the new recipients untouched: it
+keeps its original From, To, Subject, and body, and replies go to the
+original sender. Only the delivery envelope is addressed to --to.
+
+This is a critical action and requires confirmation. In non-interactive
+mode (scripts, AI), this command is blocked unless --unsafe is specified.`,
+		Example: `  # Redirect an email to a colleague
+  fm email redirect M1234567890 --to alice@example.com
+
+  # Redirect in script/AI mode (requires explicit unsafe flag)
+  fm email redirect M1234567890 --to alice@example.com --unsafe --yes`,
+		Args: cmdutil.ExactArgs(1, "email ID required\n\nUsage: fm email redirect <email-id> --to <address>"),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runRedirect(f, opts, args[0])
+		},
+	}
+
+	cmd.Flags().StringSliceVar(&opts.To, "to", nil, "Recipient `addresses` (required)")
+	cmd.Flags().BoolVar(&opts.Yes, "yes", false, "Skip confirmation prompt")
+	cmd.Flags().BoolVar(&opts.Unsafe, "unsafe", false, "Allow in non-interactive mode")
+	_ = cmd.MarkFlagRequired("to")
+
+	return cmd
+}
+
+func runRedirect(f *cmdutil.Factory, opts *redirectOptions, emailID string) error {
+	// Check safe mode - sending is critical
+	if f.IOStreams.IsSafeMode() && !opts.Unsafe {
+		return &cmdutil.SafeModeError{Command: "email redirect"}
+	}
+
+	for _, addr := range opts.To {
+		if err := cmdutil.ValidateEmail(addr); err != nil {
+			return cmdutil.FlagErrorWrap(err)
+		}
+	}
+
+	client, err := f.JMAPClient()
+	if err != nil {
+		return err
+	}
+
+	if err := client.RequireCapability(jmap.SubmissionCapability); err != nil {
+		return err
+	}
+
+	// Require confirmation unless --yes
+	if !opts.Yes {
+		if f.Quiet {
+			return cmdutil.QuietConfirmError
+		}
+		if !f.IOStreams.IsInteractive() {
+			return cmdutil.FlagErrorf("non-interactive mode requires --yes flag")
+		}
+
+		email, err := client.GetEmailByID(emailID)
+		if err != nil {
+			return err
+		}
+
+		subject := email.Subject
+		if subject == "" {
+			subject = "(no subject)"
+		}
+
+		fmt.Fprintf(f.IOStreams.ErrOut, "Subject: %s\n", subject)
+		fmt.Fprintf(f.IOStreams.ErrOut, "Redirect to: %s\n\n", strings.Join(opts.To, ", "))
+		if ok, err := cmdutil.Confirm(f.IOStreams, "Redirect this email?"); !ok {
+			return err
+		}
+	}
+
+	if err := client.RedirectEmail(emailID, opts.To); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(f.IOStreams.Out, "Email redirected to %s.\n", strings.Join(opts.To, ", "))
+	return nil
+}
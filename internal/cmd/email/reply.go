@@ -0,0 +1,149 @@
+package email
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/marckohlbrugge/fastmail-cli/internal/cmdutil"
+	"github.com/marckohlbrugge/fastmail-cli/internal/jmap"
+	"github.com/spf13/cobra"
+)
+
+type replyOptions struct {
+	Body          string
+	BodyFile      string
+	All           bool
+	KeepSignature bool
+	Send          bool
+	Yes           bool
+	Unsafe        bool
+}
+
+// NewCmdReply creates the email reply command.
+func NewCmdReply(f *cmdutil.Factory) *cobra.Command {
+	opts := &replyOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "reply <email-id>",
+		Short: "Reply to an email",
+		Long: `Reply to an email, optionally sending the reply right away.
+
+Without --send this creates a reply draft, like 'fm draft reply'. With
+--send the draft is created and then sent in one step; the recipients and
+subject are shown for confirmation first. If you decline, the draft is
+kept so you can edit and send it later.
+
+The quoted original is cut at its signature delimiter ("-- " on a line of
+its own) unless --no-strip-signature is given.
+
+Sending is a critical action. In non-interactive mode (scripts, AI), --send
+is blocked unless --unsafe is specified.`,
+		Example: `  # Create a reply draft
+  fm email reply M1234567890 --body "Thanks, will do."
+
+  # Reply and send immediately
+  fm email reply M1234567890 --body "Thanks, will do." --send
+
+  # Reply-all and send without confirmation
+  fm email reply M1234567890 --all --body-file answer.txt --send --yes`,
+		Args: cmdutil.ExactArgs(1, "email ID required\n\nUsage: fm email reply <email-id>"),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runReply(f, opts, args[0])
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.Body, "body", "", "Reply body text")
+	cmd.Flags().StringVar(&opts.BodyFile, "body-file", "", "Read body from file")
+	cmd.Flags().BoolVar(&opts.All, "all", false, "Reply to all recipients")
+	cmd.Flags().BoolVar(&opts.KeepSignature, "no-strip-signature", false, "Quote the original's signature too")
+	cmd.Flags().BoolVar(&opts.Send, "send", false, "Send the reply instead of leaving a draft")
+	cmd.Flags().BoolVar(&opts.Yes, "yes", false, "Skip confirmation prompt for --send")
+	cmd.Flags().BoolVar(&opts.Unsafe, "unsafe", false, "Allow --send in non-interactive mode")
+
+	return cmd
+}
+
+func runReply(f *cmdutil.Factory, opts *replyOptions, emailID string) error {
+	if opts.Send {
+		// Check safe mode - sending is critical
+		if f.IOStreams.IsSafeMode() && !opts.Unsafe {
+			return &cmdutil.SafeModeError{Command: "email reply --send"}
+		}
+
+		// Fail before creating a draft that could never be confirmed
+		if !opts.Yes {
+			if f.Quiet {
+				return cmdutil.QuietConfirmError
+			}
+			if !f.IOStreams.IsInteractive() {
+				return cmdutil.FlagErrorf("non-interactive mode requires --yes flag")
+			}
+		}
+	}
+
+	body := opts.Body
+	if opts.BodyFile != "" {
+		content, err := os.ReadFile(opts.BodyFile)
+		if err != nil {
+			return fmt.Errorf("failed to read body file: %w", err)
+		}
+		body = string(content)
+	}
+
+	if body == "" {
+		return cmdutil.FlagErrorf("--body or --body-file required")
+	}
+
+	client, err := f.JMAPClient()
+	if err != nil {
+		return err
+	}
+
+	if opts.Send {
+		if err := client.RequireCapability(jmap.SubmissionCapability); err != nil {
+			return err
+		}
+	}
+
+	draftID, err := client.CreateReplyDraft(jmap.ReplyOptions{
+		EmailID:       emailID,
+		Body:          body,
+		ReplyAll:      opts.All,
+		KeepSignature: opts.KeepSignature,
+	})
+	if err != nil {
+		return err
+	}
+
+	if !opts.Send {
+		fmt.Fprintf(f.IOStreams.Out, "Reply draft created: %s\n", draftID)
+		return nil
+	}
+
+	// Require confirmation unless --yes
+	if !opts.Yes {
+		draft, err := client.GetEmailForSending(draftID)
+		if err != nil {
+			return err
+		}
+
+		errOut := f.IOStreams.ErrOut
+		fmt.Fprintf(errOut, "To:      %s\n", jmap.FormatAddresses(draft.To))
+		if len(draft.CC) > 0 {
+			fmt.Fprintf(errOut, "Cc:      %s\n", jmap.FormatAddresses(draft.CC))
+		}
+		fmt.Fprintf(errOut, "Subject: %s\n\n", draft.Subject)
+
+		if ok, err := cmdutil.Confirm(f.IOStreams, "Send this reply?"); !ok {
+			fmt.Fprintf(errOut, "Reply draft kept: %s\n", draftID)
+			return err
+		}
+	}
+
+	if err := client.SendEmail(draftID); err != nil {
+		return fmt.Errorf("reply draft %s was created but not sent: %w", draftID, err)
+	}
+
+	fmt.Fprintln(f.IOStreams.Out, "Reply sent.")
+	return nil
+}
@@ -0,0 +1,106 @@
+package email
+
+import (
+	"fmt"
+
+	"github.com/marckohlbrugge/fastmail-cli/internal/cmdutil"
+	"github.com/marckohlbrugge/fastmail-cli/internal/jmap"
+	"github.com/spf13/cobra"
+)
+
+type resendOptions struct {
+	Yes    bool
+	Unsafe bool
+}
+
+// NewCmdResend creates the email resend command.
+func NewCmdResend(f *cmdutil.Factory) *cobra.Command {
+	opts := &resendOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "resend <email-id>",
+		Short: "Send a copy of a sent email again",
+		Long: `Send a new copy of an already-sent email, for example after a transient bounce.
+
+A new draft is created with the same recipients, subject, body, and
+attachments, and then sent. The original email is left untouched.
+
+This is a critical action and requires confirmation. In non-interactive
+mode (scripts, AI), this command is blocked unless --unsafe is specified.`,
+		Example: `  # Resend with confirmation prompt
+  fm email resend M1234567890
+
+  # Resend in script/AI mode (requires explicit unsafe flag)
+  fm email resend M1234567890 --unsafe --yes`,
+		Args: cmdutil.ExactArgs(1, "email ID required\n\nUsage: fm email resend <email-id>"),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runResend(f, opts, args[0])
+		},
+	}
+
+	cmd.Flags().BoolVar(&opts.Yes, "yes", false, "Skip confirmation prompt")
+	cmd.Flags().BoolVar(&opts.Unsafe, "unsafe", false, "Allow in non-interactive mode")
+
+	return cmd
+}
+
+func runResend(f *cmdutil.Factory, opts *resendOptions, emailID string) error {
+	// Check safe mode - sending is critical
+	if f.IOStreams.IsSafeMode() && !opts.Unsafe {
+		return &cmdutil.SafeModeError{Command: "email resend"}
+	}
+
+	client, err := f.JMAPClient()
+	if err != nil {
+		return err
+	}
+
+	if err := client.RequireCapability(jmap.SubmissionCapability); err != nil {
+		return err
+	}
+
+	original, err := client.GetEmailByID(emailID)
+	if err != nil {
+		return err
+	}
+
+	if original.IsDraft() {
+		return fmt.Errorf("email %s is a draft; use 'fm draft send' instead", emailID)
+	}
+
+	// Require confirmation unless --yes
+	if !opts.Yes {
+		if f.Quiet {
+			return cmdutil.QuietConfirmError
+		}
+		if !f.IOStreams.IsInteractive() {
+			return cmdutil.FlagErrorf("non-interactive mode requires --yes flag")
+		}
+
+		subject := original.Subject
+		if subject == "" {
+			subject = "(no subject)"
+		}
+
+		fmt.Fprintf(f.IOStreams.ErrOut, "To:      %s\n", jmap.FormatAddresses(original.To))
+		if len(original.CC) > 0 {
+			fmt.Fprintf(f.IOStreams.ErrOut, "Cc:      %s\n", jmap.FormatAddresses(original.CC))
+		}
+		fmt.Fprintf(f.IOStreams.ErrOut, "Subject: %s\n\n", subject)
+		if ok, err := cmdutil.Confirm(f.IOStreams, "Resend this email?"); !ok {
+			return err
+		}
+	}
+
+	draftID, err := client.CreateResendDraft(original)
+	if err != nil {
+		return err
+	}
+
+	if err := client.SendEmail(draftID); err != nil {
+		return fmt.Errorf("%w\n\nThe copy was saved as draft %s", err, draftID)
+	}
+
+	fmt.Fprintln(f.IOStreams.Out, "Email resent successfully.")
+	return nil
+}
@@ -0,0 +1,67 @@
+package email
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/marckohlbrugge/fastmail-cli/internal/cmdutil"
+	"github.com/spf13/cobra"
+)
+
+type snoozeOptions struct {
+	Until string
+}
+
+// NewCmdSnooze creates the email snooze command.
+func NewCmdSnooze(f *cmdutil.Factory) *cobra.Command {
+	opts := &snoozeOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "snooze <email-id> --until <time>",
+		Short: "Hide an email until later",
+		Long: `Move an email to the Snoozed folder until a given time, when Fastmail
+moves it back to your Inbox.
+
+--until takes a time relative to now, such as +3h or +2d, or an absolute
+date (YYYY-MM-DD, meaning midnight) or RFC 3339 timestamp.
+
+Snoozing needs the server's snooze extension; accounts that don't advertise
+it get an error and the email is left where it is.`,
+		Example: `  # Deal with this after lunch
+  fm email snooze M1234567890 --until +3h
+
+  # Bring it back on Monday morning
+  fm email snooze M1234567890 --until 2024-07-01T09:00:00+02:00`,
+		Args: cmdutil.ExactArgs(1, "email ID required\n\nUsage: fm email snooze <email-id> --until <time>"),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runSnooze(f, opts, args[0])
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.Until, "until", "", "When the email comes back, e.g. +3h, +2d, or 2024-07-01 (required)")
+	_ = cmd.MarkFlagRequired("until")
+
+	return cmd
+}
+
+func runSnooze(f *cmdutil.Factory, opts *snoozeOptions, emailID string) error {
+	until, err := cmdutil.ParseTime(opts.Until, time.Now())
+	if err != nil {
+		return cmdutil.FlagErrorf("--until: %s", err)
+	}
+	if !until.After(time.Now()) {
+		return cmdutil.FlagErrorf("--until must be in the future")
+	}
+
+	client, err := f.JMAPClient()
+	if err != nil {
+		return err
+	}
+
+	if err := client.SnoozeEmail(emailID, until); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(f.IOStreams.Out, "Snoozed until %s.\n", f.FormatDate(until, dateLayout))
+	return nil
+}
@@ -0,0 +1,52 @@
+package email
+
+import (
+	"io"
+
+	"github.com/marckohlbrugge/fastmail-cli/internal/cmdutil"
+	"github.com/spf13/cobra"
+)
+
+// NewCmdSource creates the email source command.
+func NewCmdSource(f *cmdutil.Factory) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "source <email-id>",
+		Short: "Print the raw message source",
+		Long: `Print an email's raw RFC 822 source, headers and all, to stdout.
+
+The message is streamed as it downloads, so even large emails can be piped
+straight into other tools.`,
+		Example: `  # Show every Received header
+  fm email source M1234567890 | grep Received
+
+  # Save the message as an .eml file
+  fm email source M1234567890 > message.eml`,
+		Args: cmdutil.ExactArgs(1, "email ID required\n\nUsage: fm email source <email-id>"),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runSource(f, args[0])
+		},
+	}
+
+	return cmd
+}
+
+func runSource(f *cmdutil.Factory, emailID string) error {
+	client, err := f.JMAPClient()
+	if err != nil {
+		return err
+	}
+
+	blobID, err := client.GetEmailBlobID(emailID)
+	if err != nil {
+		return err
+	}
+
+	body, err := client.OpenBlob(blobID, emailID+".eml", "message/rfc822")
+	if err != nil {
+		return err
+	}
+	defer body.Close()
+
+	_, err = io.Copy(f.IOStreams.Out, body)
+	return err
+}
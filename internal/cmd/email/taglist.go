@@ -0,0 +1,128 @@
+package email
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/marckohlbrugge/fastmail-cli/internal/cmdutil"
+	"github.com/marckohlbrugge/fastmail-cli/internal/jmap"
+	"github.com/spf13/cobra"
+)
+
+// systemKeywords are the standard IMAP and JMAP keywords, which tag-list
+// leaves out since they aren't labels anyone applied.
+var systemKeywords = map[string]bool{
+	"$seen":      true,
+	"$flagged":   true,
+	"$draft":     true,
+	"$answered":  true,
+	"$forwarded": true,
+	"$junk":      true,
+	"$notjunk":   true,
+	"$phishing":  true,
+	"$mdnsent":   true,
+}
+
+type tagListOptions struct {
+	Limit int
+	JSON  bool
+}
+
+type keywordCount struct {
+	Keyword string `json:"keyword"`
+	Count   int    `json:"count"`
+}
+
+// NewCmdTagList creates the email tag-list command.
+func NewCmdTagList(f *cmdutil.Factory) *cobra.Command {
+	opts := &tagListOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "tag-list",
+		Short: "List the keywords used on recent emails",
+		Long: `List the keywords (tags) set on your most recent emails, with how many
+emails carry each, most used first.
+
+JMAP has no way to ask the server for every keyword in use, so the newest
+--limit emails across all folders are sampled. Standard keywords such as
+$seen, $flagged, and $draft are left out.`,
+		Example: `  # Keywords on the 500 most recent emails
+  fm email tag-list
+
+  # Sample every email (fails if there are more than 10000)
+  fm email tag-list --limit 0
+
+  # Output as JSON
+  fm email tag-list --json`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			limit, err := cmdutil.ParseLimit(opts.Limit)
+			if err != nil {
+				return err
+			}
+			opts.Limit = limit
+			return runTagList(f, opts)
+		},
+	}
+
+	cmd.Flags().IntVar(&opts.Limit, "limit", 500, fmt.Sprintf("Number of recent emails to sample (max 500, or 0 for every email; fails above %d)", jmap.MaxAllEmails))
+	cmd.Flags().BoolVar(&opts.JSON, "json", false, "Output in JSON format")
+
+	return cmd
+}
+
+func runTagList(f *cmdutil.Factory, opts *tagListOptions) error {
+	client, err := f.JMAPClient()
+	if err != nil {
+		return err
+	}
+
+	emails, err := client.Search(jmap.SearchFilters{Limit: opts.Limit})
+	if err != nil {
+		return err
+	}
+
+	keywords := countKeywords(emails)
+
+	if opts.JSON {
+		return cmdutil.WriteJSON(f.IOStreams.Out, keywords)
+	}
+
+	out := f.IOStreams.Out
+	if len(keywords) == 0 {
+		fmt.Fprintf(out, "No keywords found on the %d most recent emails.\n", len(emails))
+		return nil
+	}
+
+	for _, k := range keywords {
+		fmt.Fprintf(out, "%6d  %s\n", k.Count, k.Keyword)
+	}
+	if !f.Quiet {
+		fmt.Fprintf(f.IOStreams.ErrOut, "\nSampled the %d most recent emails.\n", len(emails))
+	}
+	return nil
+}
+
+// countKeywords tallies the non-system keywords on emails, most used first.
+func countKeywords(emails []jmap.Email) []keywordCount {
+	counts := make(map[string]int)
+	for _, email := range emails {
+		for _, keyword := range email.KeywordList() {
+			if !systemKeywords[keyword] {
+				counts[keyword]++
+			}
+		}
+	}
+
+	keywords := make([]keywordCount, 0, len(counts))
+	for keyword, count := range counts {
+		keywords = append(keywords, keywordCount{Keyword: keyword, Count: count})
+	}
+	sort.Slice(keywords, func(i, j int) bool {
+		if keywords[i].Count != keywords[j].Count {
+			return keywords[i].Count > keywords[j].Count
+		}
+		return keywords[i].Keyword < keywords[j].Keyword
+	})
+	return keywords
+}
@@ -3,6 +3,7 @@ package email
 import (
 	"encoding/json"
 	"fmt"
+	"sort"
 	"strings"
 
 	"github.com/marckohlbrugge/fastmail-cli/internal/cmdutil"
@@ -11,7 +12,11 @@ import (
 )
 
 type threadOptions struct {
-	JSON bool
+	JSON        bool
+	Reverse     bool
+	MarkRead    bool
+	Unsafe      bool
+	IncludeBody bool
 }
 
 // NewCmdThread creates the email thread command.
@@ -24,12 +29,34 @@ func NewCmdThread(f *cmdutil.Factory) *cobra.Command {
 		Long: `Display all emails in a conversation thread.
 
 You can pass either an email ID or thread ID. If an email ID is provided,
-the thread containing that email will be displayed.`,
+the thread containing that email will be displayed.
+
+Emails are shown oldest first, in both human and JSON output. Use
+--reverse to show the newest first.
+
+JSON output includes each email's full headers and body. With
+--include-body=false only list fields are fetched (id, threadId,
+mailboxIds, subject, from, to, receivedAt, preview, hasAttachment,
+keywords, size, messageId), leaving out cc, bcc, replyTo, textBody,
+htmlBody, bodyValues, attachments, inReplyTo, and references.
+
+With --mark-read, every email in the thread is marked as read after it is
+shown. Because this changes the emails, it is blocked in non-interactive
+mode (scripts, AI) unless --unsafe is specified.`,
 		Example: `  # View a thread by email ID
   fm email thread M1234567890
 
+  # Show the latest reply first
+  fm email thread M1234567890 --reverse
+
+  # Read a thread and mark it read
+  fm email thread M1234567890 --mark-read
+
   # Output as JSON
-  fm email thread M1234567890 --json`,
+  fm email thread M1234567890 --json
+
+  # List who wrote what, without downloading the bodies
+  fm email thread M1234567890 --json --include-body=false | jq '.[] | {from, receivedAt}'`,
 		Args: cmdutil.ExactArgs(1, "email or thread ID required\n\nUsage: fm email thread <id>"),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return runThread(f, opts, args[0])
@@ -37,17 +64,31 @@ the thread containing that email will be displayed.`,
 	}
 
 	cmd.Flags().BoolVar(&opts.JSON, "json", false, "Output in JSON format")
+	cmd.Flags().BoolVar(&opts.IncludeBody, "include-body", true, "Fetch email bodies (false fetches only metadata)")
+	cmd.Flags().BoolVar(&opts.Reverse, "reverse", false, "Show the newest email first")
+	cmd.Flags().BoolVar(&opts.MarkRead, "mark-read", false, "Mark every email in the thread as read after showing it")
+	cmd.Flags().BoolVar(&opts.Unsafe, "unsafe", false, "Allow --mark-read in non-interactive mode")
 
 	return cmd
 }
 
 func runThread(f *cmdutil.Factory, opts *threadOptions, id string) error {
+	// Check safe mode - marking read changes the emails
+	if opts.MarkRead && f.IOStreams.IsSafeMode() && !opts.Unsafe {
+		return &cmdutil.SafeModeError{Command: "email thread --mark-read"}
+	}
+
 	client, err := f.JMAPClient()
 	if err != nil {
 		return err
 	}
 
-	emails, err := client.GetThread(id)
+	getThread := client.GetThread
+	if !opts.IncludeBody {
+		getThread = client.GetThreadSummaries
+	}
+
+	emails, err := getThread(id)
 	if err != nil {
 		return err
 	}
@@ -56,13 +97,56 @@ func runThread(f *cmdutil.Factory, opts *threadOptions, id string) error {
 		return fmt.Errorf("thread not found")
 	}
 
+	// The server returns emails in no guaranteed order
+	sort.SliceStable(emails, func(i, j int) bool {
+		if opts.Reverse {
+			return emails[i].ReceivedAt.After(emails[j].ReceivedAt)
+		}
+		return emails[i].ReceivedAt.Before(emails[j].ReceivedAt)
+	})
+
 	if opts.JSON {
 		encoder := json.NewEncoder(f.IOStreams.Out)
 		encoder.SetIndent("", "  ")
-		return encoder.Encode(emails)
+		err = encoder.Encode(emails)
+	} else {
+		err = printThread(f, emails)
+	}
+	if err != nil || !opts.MarkRead {
+		return err
+	}
+
+	return markThreadRead(f, client, emails, opts.JSON)
+}
+
+// markThreadRead marks the already-fetched thread emails as read. With JSON
+// output the summary goes to stderr so stdout stays valid JSON.
+func markThreadRead(f *cmdutil.Factory, client *jmap.Client, emails []jmap.Email, jsonOutput bool) error {
+	ids := make([]string, len(emails))
+	for i, email := range emails {
+		ids[i] = email.ID
+	}
+
+	updated, failed, err := client.SetKeyword(ids, "$seen", true)
+	if err != nil {
+		return err
 	}
 
-	return printThread(f, emails)
+	out := f.IOStreams.Out
+	if jsonOutput {
+		out = f.IOStreams.ErrOut
+	}
+
+	if len(failed) > 0 {
+		fmt.Fprintf(out, "Marked %d emails as read. Failed: %d\n", updated, len(failed))
+		for _, id := range failed {
+			fmt.Fprintf(f.IOStreams.ErrOut, "  Failed: %s\n", id)
+		}
+		return nil
+	}
+
+	fmt.Fprintf(out, "Marked %d emails as read.\n", updated)
+	return nil
 }
 
 func printThread(f *cmdutil.Factory, emails []jmap.Email) error {
@@ -76,7 +160,7 @@ func printThread(f *cmdutil.Factory, emails []jmap.Email) error {
 			from = jmap.FormatAddresses(email.From)
 		}
 
-		date := email.ReceivedAt.Format("Mon, Jan 2, 2006 at 3:04 PM")
+		date := f.FormatDate(email.ReceivedAt, dateLayout)
 		subject := email.Subject
 		if subject == "" {
 			subject = "(no subject)"
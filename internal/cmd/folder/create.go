@@ -2,13 +2,17 @@ package folder
 
 import (
 	"fmt"
+	"slices"
+	"strings"
 
 	"github.com/marckohlbrugge/fastmail-cli/internal/cmdutil"
+	"github.com/marckohlbrugge/fastmail-cli/internal/jmap"
 	"github.com/spf13/cobra"
 )
 
 type createOptions struct {
 	Parent string
+	Role   string
 }
 
 // NewCmdCreate creates the folder create command.
@@ -20,12 +24,19 @@ func NewCmdCreate(f *cmdutil.Factory) *cobra.Command {
 		Short: "Create a new folder",
 		Long: `Create a new folder (mailbox).
 
-You can optionally specify a parent folder to create a nested folder.`,
+You can optionally specify a parent folder to create a nested folder.
+
+With --role the folder is given a special-use role, such as snoozed, which
+mail clients use to find it. Each role can belong to only one folder, so
+creating a second folder with a role that is already taken fails.`,
 		Example: `  # Create a top-level folder
   fm folder create "Work Projects"
 
   # Create a nested folder
-  fm folder create "Q1 Reports" --parent abc123`,
+  fm folder create "Q1 Reports" --parent abc123
+
+  # Create a folder for snoozed mail
+  fm folder create "Snoozed" --role snoozed`,
 		Args: cmdutil.ExactArgs(1, "folder name required\n\nUsage: fm folder create <name>"),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return runCreate(f, opts, args[0])
@@ -33,17 +44,24 @@ You can optionally specify a parent folder to create a nested folder.`,
 	}
 
 	cmd.Flags().StringVar(&opts.Parent, "parent", "", "Parent folder ID for nested folder")
+	cmd.Flags().StringVar(&opts.Role, "role", "", "Special-use `role` for the folder ("+strings.Join(jmap.MailboxRoles, ", ")+")")
+	_ = cmd.RegisterFlagCompletionFunc("role", cobra.FixedCompletions(jmap.MailboxRoles, cobra.ShellCompDirectiveNoFileComp))
 
 	return cmd
 }
 
 func runCreate(f *cmdutil.Factory, opts *createOptions, name string) error {
+	role := strings.ToLower(opts.Role)
+	if role != "" && !slices.Contains(jmap.MailboxRoles, role) {
+		return cmdutil.FlagErrorf("invalid role %q: use one of %s", opts.Role, strings.Join(jmap.MailboxRoles, ", "))
+	}
+
 	client, err := f.JMAPClient()
 	if err != nil {
 		return err
 	}
 
-	folderID, err := client.CreateMailbox(name, opts.Parent)
+	folderID, err := client.CreateMailbox(name, opts.Parent, role)
 	if err != nil {
 		return err
 	}
@@ -10,16 +10,19 @@ func NewCmdFolder(f *cmdutil.Factory) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "folder <command>",
 		Short: "Manage folders",
-		Long:  "Create, rename, and delete folders (mailboxes).",
+		Long:  "Create, rename, delete, and subscribe to folders (mailboxes).",
 		Example: `  $ fm folder list
   $ fm folder create "Work Projects"
-  $ fm folder rename abc123 "New Name"`,
+  $ fm folder rename abc123 "New Name"
+  $ fm folder unsubscribe "Old Imports"`,
 		GroupID: "folder",
 	}
 
 	cmd.AddCommand(NewCmdList(f))
 	cmd.AddCommand(NewCmdCreate(f))
 	cmd.AddCommand(NewCmdRename(f))
+	cmd.AddCommand(NewCmdSubscribe(f))
+	cmd.AddCommand(NewCmdUnsubscribe(f))
 
 	return cmd
 }
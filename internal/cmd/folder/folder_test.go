@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"encoding/json"
 	"net/http"
+	"strings"
 	"testing"
 
 	"github.com/jarcoal/httpmock"
@@ -104,6 +105,35 @@ func TestListCommand(t *testing.T) {
 		assert.Len(t, result, 1)
 		assert.Equal(t, "inbox-1", result[0]["id"])
 	})
+
+	t.Run("sorts by name with --sort name", func(t *testing.T) {
+		f, stdout, _ := setupTest(t)
+
+		httpmock.RegisterResponder("POST", "https://api.test.com/jmap/api",
+			httpmock.NewJsonResponderOrPanic(200, map[string]interface{}{
+				"methodResponses": [][]interface{}{
+					{"Mailbox/get", map[string]interface{}{
+						"list": []map[string]interface{}{
+							{"id": "work-1", "name": "work", "sortOrder": 1},
+							{"id": "inbox-1", "name": "Inbox", "role": "inbox", "sortOrder": 2},
+						},
+					}, "mailboxes"},
+				},
+			}))
+
+		cmd := NewCmdList(f)
+		cmd.SetArgs([]string{"--sort", "name"})
+		cmd.SetOut(stdout)
+		cmd.SetErr(&bytes.Buffer{})
+
+		err := cmd.Execute()
+
+		require.NoError(t, err)
+		lines := strings.Split(strings.TrimSpace(stdout.String()), "\n")
+		require.Len(t, lines, 2)
+		assert.True(t, strings.HasPrefix(lines[0], "inbox-1"))
+		assert.True(t, strings.HasPrefix(lines[1], "work-1"))
+	})
 }
 
 // Create command tests
@@ -176,6 +206,84 @@ func TestCreateCommand(t *testing.T) {
 		assert.Equal(t, "parent-folder-id", capturedParent)
 	})
 
+	t.Run("sets a role on creation", func(t *testing.T) {
+		f, stdout, _ := setupTest(t)
+
+		var created map[string]interface{}
+		httpmock.RegisterResponder("POST", "https://api.test.com/jmap/api",
+			func(req *http.Request) (*http.Response, error) {
+				var jmapReq jmap.Request
+				json.NewDecoder(req.Body).Decode(&jmapReq)
+
+				args := jmapReq.MethodCalls[0][1].(map[string]interface{})
+				created = args["create"].(map[string]interface{})["newMailbox"].(map[string]interface{})
+
+				return httpmock.NewJsonResponse(200, map[string]interface{}{
+					"methodResponses": [][]interface{}{
+						{"Mailbox/set", map[string]interface{}{
+							"created": map[string]interface{}{
+								"newMailbox": map[string]interface{}{"id": "snoozed-1"},
+							},
+						}, "createMailbox"},
+					},
+				})
+			})
+
+		cmd := NewCmdCreate(f)
+		cmd.SetArgs([]string{"Snoozed", "--role", "Snoozed"})
+		cmd.SetOut(stdout)
+		cmd.SetErr(&bytes.Buffer{})
+
+		err := cmd.Execute()
+
+		require.NoError(t, err)
+		assert.Equal(t, map[string]interface{}{"name": "Snoozed", "role": "snoozed"}, created)
+		assert.Contains(t, stdout.String(), "Folder created: snoozed-1")
+	})
+
+	t.Run("surfaces the server error when a role is taken", func(t *testing.T) {
+		f, _, _ := setupTest(t)
+
+		httpmock.RegisterResponder("POST", "https://api.test.com/jmap/api",
+			httpmock.NewJsonResponderOrPanic(200, map[string]interface{}{
+				"methodResponses": [][]interface{}{
+					{"Mailbox/set", map[string]interface{}{
+						"notCreated": map[string]interface{}{
+							"newMailbox": map[string]interface{}{
+								"type":        "invalidProperties",
+								"description": "role: a mailbox with this role already exists",
+							},
+						},
+					}, "createMailbox"},
+				},
+			}))
+
+		cmd := NewCmdCreate(f)
+		cmd.SetArgs([]string{"Second Inbox", "--role", "inbox"})
+		cmd.SetOut(&bytes.Buffer{})
+		cmd.SetErr(&bytes.Buffer{})
+
+		err := cmd.Execute()
+
+		require.Error(t, err)
+		assert.Equal(t, "failed to create mailbox: role: a mailbox with this role already exists", err.Error())
+	})
+
+	t.Run("rejects an unknown role", func(t *testing.T) {
+		f, _, _ := setupTest(t)
+
+		cmd := NewCmdCreate(f)
+		cmd.SetArgs([]string{"Stuff", "--role", "stuff"})
+		cmd.SetOut(&bytes.Buffer{})
+		cmd.SetErr(&bytes.Buffer{})
+
+		err := cmd.Execute()
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `invalid role "stuff"`)
+		assert.Equal(t, 0, httpmock.GetCallCountInfo()["POST https://api.test.com/jmap/api"])
+	})
+
 	t.Run("requires folder name argument", func(t *testing.T) {
 		f := &cmdutil.Factory{}
 		cmd := NewCmdCreate(f)
@@ -192,75 +300,115 @@ func TestCreateCommand(t *testing.T) {
 
 // Rename command tests
 
-func TestRenameCommand(t *testing.T) {
-	t.Run("renames folder", func(t *testing.T) {
-		f, stdout, _ := setupTest(t)
+// mockRename serves Mailbox/get and records each Mailbox/set rename.
+func mockRename(renamed map[string]string) httpmock.Responder {
+	return func(req *http.Request) (*http.Response, error) {
+		var jmapReq jmap.Request
+		json.NewDecoder(req.Body).Decode(&jmapReq)
 
-		httpmock.RegisterResponder("POST", "https://api.test.com/jmap/api",
-			httpmock.NewJsonResponderOrPanic(200, map[string]interface{}{
+		method := jmapReq.MethodCalls[0][0].(string)
+
+		switch method {
+		case "Mailbox/get":
+			return httpmock.NewJsonResponse(200, map[string]interface{}{
 				"methodResponses": [][]interface{}{
-					{"Mailbox/set", map[string]interface{}{
-						"updated": map[string]interface{}{
-							"folder-1": nil,
+					{"Mailbox/get", map[string]interface{}{
+						"list": []map[string]interface{}{
+							{"id": "inbox-1", "name": "Inbox", "role": "inbox"},
+							{"id": "abc123", "name": "Projects"},
 						},
-					}, "renameMailbox"},
+					}, "mailboxes"},
 				},
-			}))
+			})
+		case "Mailbox/set":
+			args := jmapReq.MethodCalls[0][1].(map[string]interface{})
+			updated := map[string]interface{}{}
+			for id, patch := range args["update"].(map[string]interface{}) {
+				renamed[id] = patch.(map[string]interface{})["name"].(string)
+				updated[id] = nil
+			}
+			return httpmock.NewJsonResponse(200, map[string]interface{}{
+				"methodResponses": [][]interface{}{
+					{"Mailbox/set", map[string]interface{}{"updated": updated}, "renameMailbox"},
+				},
+			})
+		default:
+			return httpmock.NewStringResponse(400, "unexpected: "+method), nil
+		}
+	}
+}
+
+func TestRenameCommand(t *testing.T) {
+	t.Run("renames folder", func(t *testing.T) {
+		f, stdout, _ := setupTest(t)
+
+		renamed := map[string]string{}
+		httpmock.RegisterResponder("POST", "https://api.test.com/jmap/api", mockRename(renamed))
 
 		cmd := NewCmdRename(f)
-		cmd.SetArgs([]string{"folder-1", "New Name"})
+		cmd.SetArgs([]string{"abc123", "Renamed Folder"})
 		cmd.SetOut(stdout)
 		cmd.SetErr(&bytes.Buffer{})
 
 		err := cmd.Execute()
 
 		require.NoError(t, err)
+		assert.Equal(t, map[string]string{"abc123": "Renamed Folder"}, renamed)
 		assert.Contains(t, stdout.String(), "Folder renamed")
 	})
 
-	t.Run("sends correct rename request", func(t *testing.T) {
-		f, stdout, _ := setupTest(t)
+	t.Run("refuses a system folder without --force", func(t *testing.T) {
+		f, _, _ := setupTest(t)
 
-		var capturedFolderID, capturedNewName string
+		renamed := map[string]string{}
+		httpmock.RegisterResponder("POST", "https://api.test.com/jmap/api", mockRename(renamed))
 
-		httpmock.RegisterResponder("POST", "https://api.test.com/jmap/api",
-			func(req *http.Request) (*http.Response, error) {
-				var jmapReq jmap.Request
-				json.NewDecoder(req.Body).Decode(&jmapReq)
+		cmd := NewCmdRename(f)
+		cmd.SetArgs([]string{"inbox-1", "Incoming"})
+		cmd.SetOut(&bytes.Buffer{})
+		cmd.SetErr(&bytes.Buffer{})
 
-				args := jmapReq.MethodCalls[0][1].(map[string]interface{})
-				if update, ok := args["update"].(map[string]interface{}); ok {
-					for id, changes := range update {
-						capturedFolderID = id
-						if changeMap, ok := changes.(map[string]interface{}); ok {
-							if name, ok := changeMap["name"].(string); ok {
-								capturedNewName = name
-							}
-						}
-					}
-				}
+		err := cmd.Execute()
 
-				return httpmock.NewJsonResponse(200, map[string]interface{}{
-					"methodResponses": [][]interface{}{
-						{"Mailbox/set", map[string]interface{}{
-							"updated": map[string]interface{}{
-								capturedFolderID: nil,
-							},
-						}, "renameMailbox"},
-					},
-				})
-			})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `"Inbox" is the inbox system folder`)
+		assert.Contains(t, err.Error(), "--force")
+		assert.Empty(t, renamed)
+	})
+
+	t.Run("renames a system folder with --force", func(t *testing.T) {
+		f, stdout, _ := setupTest(t)
+
+		renamed := map[string]string{}
+		httpmock.RegisterResponder("POST", "https://api.test.com/jmap/api", mockRename(renamed))
 
 		cmd := NewCmdRename(f)
-		cmd.SetArgs([]string{"abc123", "Renamed Folder"})
+		cmd.SetArgs([]string{"inbox-1", "Incoming", "--force"})
 		cmd.SetOut(stdout)
 		cmd.SetErr(&bytes.Buffer{})
 
 		err := cmd.Execute()
 
 		require.NoError(t, err)
-		assert.Equal(t, "abc123", capturedFolderID)
-		assert.Equal(t, "Renamed Folder", capturedNewName)
+		assert.Equal(t, map[string]string{"inbox-1": "Incoming"}, renamed)
+	})
+
+	t.Run("errors on an unknown folder ID", func(t *testing.T) {
+		f, _, _ := setupTest(t)
+
+		renamed := map[string]string{}
+		httpmock.RegisterResponder("POST", "https://api.test.com/jmap/api", mockRename(renamed))
+
+		cmd := NewCmdRename(f)
+		cmd.SetArgs([]string{"missing", "New Name"})
+		cmd.SetOut(&bytes.Buffer{})
+		cmd.SetErr(&bytes.Buffer{})
+
+		err := cmd.Execute()
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "not found")
+		assert.Empty(t, renamed)
 	})
 
 	t.Run("requires folder ID and new name", func(t *testing.T) {
@@ -289,3 +437,122 @@ func TestRenameCommand(t *testing.T) {
 		}
 	})
 }
+
+// Subscribe command tests
+
+// mockSubscribe serves Mailbox/get and records the isSubscribed update for
+// each mailbox, failing updates to notUpdated IDs.
+func mockSubscribe(updates map[string]interface{}, notUpdated string) httpmock.Responder {
+	return func(req *http.Request) (*http.Response, error) {
+		var jmapReq jmap.Request
+		json.NewDecoder(req.Body).Decode(&jmapReq)
+
+		method := jmapReq.MethodCalls[0][0].(string)
+
+		switch method {
+		case "Mailbox/get":
+			return httpmock.NewJsonResponse(200, map[string]interface{}{
+				"methodResponses": [][]interface{}{
+					{"Mailbox/get", map[string]interface{}{
+						"list": []map[string]interface{}{
+							{"id": "inbox-1", "name": "Inbox", "role": "inbox", "isSubscribed": true},
+							{"id": "lists-1", "name": "Mailing Lists", "isSubscribed": false},
+						},
+					}, "mailboxes"},
+				},
+			})
+		case "Mailbox/set":
+			args := jmapReq.MethodCalls[0][1].(map[string]interface{})
+			result := map[string]interface{}{}
+			for id, patch := range args["update"].(map[string]interface{}) {
+				if id == notUpdated {
+					result["notUpdated"] = map[string]interface{}{
+						id: map[string]interface{}{"type": "forbidden", "description": "cannot change subscription"},
+					}
+					continue
+				}
+				updates[id] = patch.(map[string]interface{})["isSubscribed"]
+			}
+			return httpmock.NewJsonResponse(200, map[string]interface{}{
+				"methodResponses": [][]interface{}{
+					{"Mailbox/set", result, "subscribeMailbox"},
+				},
+			})
+		default:
+			return httpmock.NewStringResponse(400, "unexpected: "+method), nil
+		}
+	}
+}
+
+func TestSubscribeCommand(t *testing.T) {
+	t.Run("subscribes to a folder by name", func(t *testing.T) {
+		f, stdout, _ := setupTest(t)
+
+		updates := map[string]interface{}{}
+		httpmock.RegisterResponder("POST", "https://api.test.com/jmap/api", mockSubscribe(updates, ""))
+
+		cmd := NewCmdSubscribe(f)
+		cmd.SetArgs([]string{"mailing lists"})
+		cmd.SetOut(stdout)
+		cmd.SetErr(&bytes.Buffer{})
+
+		err := cmd.Execute()
+
+		require.NoError(t, err)
+		assert.Equal(t, map[string]interface{}{"lists-1": true}, updates)
+		assert.Equal(t, "Subscribed to Mailing Lists.\n", stdout.String())
+	})
+
+	t.Run("unsubscribes from a folder by ID", func(t *testing.T) {
+		f, stdout, _ := setupTest(t)
+
+		updates := map[string]interface{}{}
+		httpmock.RegisterResponder("POST", "https://api.test.com/jmap/api", mockSubscribe(updates, ""))
+
+		cmd := NewCmdUnsubscribe(f)
+		cmd.SetArgs([]string{"inbox-1"})
+		cmd.SetOut(stdout)
+		cmd.SetErr(&bytes.Buffer{})
+
+		err := cmd.Execute()
+
+		require.NoError(t, err)
+		assert.Equal(t, map[string]interface{}{"inbox-1": false}, updates)
+		assert.Equal(t, "Unsubscribed from Inbox.\n", stdout.String())
+	})
+
+	t.Run("reports notUpdated errors", func(t *testing.T) {
+		f, _, _ := setupTest(t)
+
+		updates := map[string]interface{}{}
+		httpmock.RegisterResponder("POST", "https://api.test.com/jmap/api", mockSubscribe(updates, "inbox-1"))
+
+		cmd := NewCmdUnsubscribe(f)
+		cmd.SetArgs([]string{"inbox"})
+		cmd.SetOut(&bytes.Buffer{})
+		cmd.SetErr(&bytes.Buffer{})
+
+		err := cmd.Execute()
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to update subscription: cannot change subscription")
+	})
+
+	t.Run("errors on unknown folder", func(t *testing.T) {
+		f, _, _ := setupTest(t)
+
+		updates := map[string]interface{}{}
+		httpmock.RegisterResponder("POST", "https://api.test.com/jmap/api", mockSubscribe(updates, ""))
+
+		cmd := NewCmdSubscribe(f)
+		cmd.SetArgs([]string{"Nope"})
+		cmd.SetOut(&bytes.Buffer{})
+		cmd.SetErr(&bytes.Buffer{})
+
+		err := cmd.Execute()
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "folder not found: Nope")
+		assert.Empty(t, updates)
+	})
+}
@@ -3,6 +3,7 @@ package folder
 import (
 	"encoding/json"
 	"fmt"
+	"strings"
 
 	"github.com/marckohlbrugge/fastmail-cli/internal/cmdutil"
 	"github.com/marckohlbrugge/fastmail-cli/internal/jmap"
@@ -11,6 +12,7 @@ import (
 
 type listOptions struct {
 	JSON bool
+	Sort string
 }
 
 // NewCmdList creates the folder list command.
@@ -30,6 +32,8 @@ This is an alias for 'fm folders'.`,
 	}
 
 	cmd.Flags().BoolVar(&opts.JSON, "json", false, "Output in JSON format")
+	cmd.Flags().StringVar(&opts.Sort, "sort", "order", "Sort by: "+strings.Join(cmdutil.MailboxSorts, ", "))
+	_ = cmd.RegisterFlagCompletionFunc("sort", cobra.FixedCompletions(cmdutil.MailboxSorts, cobra.ShellCompDirectiveNoFileComp))
 
 	return cmd
 }
@@ -45,6 +49,10 @@ func runList(f *cmdutil.Factory, opts *listOptions) error {
 		return err
 	}
 
+	if err := cmdutil.SortMailboxes(mailboxes, opts.Sort); err != nil {
+		return err
+	}
+
 	if opts.JSON {
 		encoder := json.NewEncoder(f.IOStreams.Out)
 		encoder.SetIndent("", "  ")
@@ -7,31 +7,55 @@ import (
 	"github.com/spf13/cobra"
 )
 
+type renameOptions struct {
+	Force bool
+}
+
 // NewCmdRename creates the folder rename command.
 func NewCmdRename(f *cmdutil.Factory) *cobra.Command {
+	opts := &renameOptions{}
+
 	cmd := &cobra.Command{
 		Use:   "rename <folder-id> <new-name>",
 		Short: "Rename a folder",
 		Long: `Rename an existing folder.
 
-Use 'fm folders' or 'fm folder list' to find the folder ID.`,
+Use 'fm folders' or 'fm folder list' to find the folder ID.
+
+System folders such as Inbox, Sent, or Trash are refused unless --force is
+given, since mail clients find them by role and a renamed one is easy to
+lose track of.`,
 		Example: `  # Rename a folder
-  fm folder rename abc123 "New Name"`,
+  fm folder rename abc123 "New Name"
+
+  # Rename a system folder anyway
+  fm folder rename def456 "Outbox" --force`,
 		Args: cmdutil.ExactArgs(2, "folder ID and new name required\n\nUsage: fm folder rename <folder-id> <new-name>"),
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runRename(f, args[0], args[1])
+			return runRename(f, opts, args[0], args[1])
 		},
 	}
 
+	cmd.Flags().BoolVar(&opts.Force, "force", false, "Rename even a system folder")
+
 	return cmd
 }
 
-func runRename(f *cmdutil.Factory, folderID, newName string) error {
+func runRename(f *cmdutil.Factory, opts *renameOptions, folderID, newName string) error {
 	client, err := f.JMAPClient()
 	if err != nil {
 		return err
 	}
 
+	mailbox, err := client.GetMailboxByID(folderID)
+	if err != nil {
+		return err
+	}
+
+	if mailbox.Role != "" && !opts.Force {
+		return fmt.Errorf("%q is the %s system folder: use --force to rename it anyway", mailbox.Name, mailbox.Role)
+	}
+
 	if err := client.RenameMailbox(folderID, newName); err != nil {
 		return err
 	}
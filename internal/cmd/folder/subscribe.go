@@ -0,0 +1,72 @@
+package folder
+
+import (
+	"fmt"
+
+	"github.com/marckohlbrugge/fastmail-cli/internal/cmdutil"
+	"github.com/spf13/cobra"
+)
+
+// NewCmdSubscribe creates the folder subscribe command.
+func NewCmdSubscribe(f *cmdutil.Factory) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "subscribe <folder>",
+		Short: "Subscribe to a folder",
+		Long: `Subscribe to a folder so mail clients show it.
+
+The folder can be given by ID, name, or role.`,
+		Example: `  # Subscribe to a folder
+  fm folder subscribe "Mailing Lists"`,
+		Args:              cmdutil.ExactArgs(1, "folder required\n\nUsage: fm folder subscribe <folder>"),
+		ValidArgsFunction: cmdutil.CompleteFolders(f),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runSubscribe(f, args[0], true)
+		},
+	}
+
+	return cmd
+}
+
+// NewCmdUnsubscribe creates the folder unsubscribe command.
+func NewCmdUnsubscribe(f *cmdutil.Factory) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "unsubscribe <folder>",
+		Short: "Unsubscribe from a folder",
+		Long: `Unsubscribe from a folder. Some mail clients hide unsubscribed folders;
+its emails are left untouched.
+
+The folder can be given by ID, name, or role.`,
+		Example: `  # Hide an imported folder in clients that honor subscriptions
+  fm folder unsubscribe "Old Imports"`,
+		Args:              cmdutil.ExactArgs(1, "folder required\n\nUsage: fm folder unsubscribe <folder>"),
+		ValidArgsFunction: cmdutil.CompleteFolders(f),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runSubscribe(f, args[0], false)
+		},
+	}
+
+	return cmd
+}
+
+func runSubscribe(f *cmdutil.Factory, folderRef string, subscribed bool) error {
+	client, err := f.JMAPClient()
+	if err != nil {
+		return err
+	}
+
+	mailbox, err := cmdutil.ResolveMailbox(f, client, folderRef)
+	if err != nil {
+		return err
+	}
+
+	if err := client.SetMailboxSubscribed(mailbox.ID, subscribed); err != nil {
+		return err
+	}
+
+	if subscribed {
+		fmt.Fprintf(f.IOStreams.Out, "Subscribed to %s.\n", mailbox.Name)
+	} else {
+		fmt.Fprintf(f.IOStreams.Out, "Unsubscribed from %s.\n", mailbox.Name)
+	}
+	return nil
+}
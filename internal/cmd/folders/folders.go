@@ -1,8 +1,9 @@
 package folders
 
 import (
-	"encoding/json"
 	"fmt"
+	"strconv"
+	"strings"
 
 	"github.com/marckohlbrugge/fastmail-cli/internal/cmdutil"
 	"github.com/marckohlbrugge/fastmail-cli/internal/jmap"
@@ -10,7 +11,9 @@ import (
 )
 
 type foldersOptions struct {
-	JSON bool
+	JSON       bool
+	Subscribed bool
+	Sort       string
 }
 
 // NewCmdFolders creates the folders command.
@@ -22,12 +25,27 @@ func NewCmdFolders(f *cmdutil.Factory) *cobra.Command {
 		Short: "List mailboxes",
 		Long: `List all mailboxes (folders) in your account.
 
-Displays folder ID, name, role (if any), and unread count.`,
+Displays folder ID, name, role (if any), and unread count. With
+--subscribed, unsubscribed folders are marked, and delimited output gains
+an isSubscribed column.
+
+Folders are listed in your account's folder order unless --sort is given:
+name, unread (most unread first), role (inbox, drafts, sent, ...), or
+order. JSON output uses the same order.`,
 		Example: `  # List all folders
   fm folders
 
   # Output as JSON
-  fm folders --json`,
+  fm folders --json
+
+  # Output as CSV
+  fm folders --output csv
+
+  # Include subscription status
+  fm folders --subscribed
+
+  # Folders with the most unread first
+  fm folders --sort unread`,
 		GroupID: "core",
 		Args:    cobra.NoArgs,
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -36,6 +54,9 @@ Displays folder ID, name, role (if any), and unread count.`,
 	}
 
 	cmd.Flags().BoolVar(&opts.JSON, "json", false, "Output in JSON format")
+	cmd.Flags().BoolVar(&opts.Subscribed, "subscribed", false, "Show whether each folder is subscribed")
+	cmd.Flags().StringVar(&opts.Sort, "sort", "order", "Sort by: "+strings.Join(cmdutil.MailboxSorts, ", "))
+	_ = cmd.RegisterFlagCompletionFunc("sort", cobra.FixedCompletions(cmdutil.MailboxSorts, cobra.ShellCompDirectiveNoFileComp))
 
 	return cmd
 }
@@ -51,20 +72,39 @@ func runFolders(f *cmdutil.Factory, opts *foldersOptions) error {
 		return err
 	}
 
-	if opts.JSON {
-		return outputJSON(f, mailboxes)
+	if err := cmdutil.SortMailboxes(mailboxes, opts.Sort); err != nil {
+		return err
+	}
+
+	format := f.OutputFormat(opts.JSON)
+	switch {
+	case format == cmdutil.OutputJSON:
+		return cmdutil.WriteJSON(f.IOStreams.Out, mailboxes)
+	case format == cmdutil.OutputJSONL:
+		return cmdutil.WriteJSONL(f.IOStreams.Out, mailboxes)
+	case cmdutil.IsDelimited(format):
+		return outputDelimited(f, opts, format, mailboxes)
 	}
 
-	return outputHuman(f, mailboxes)
+	return outputHuman(f, opts, mailboxes)
 }
 
-func outputJSON(f *cmdutil.Factory, mailboxes []jmap.Mailbox) error {
-	encoder := json.NewEncoder(f.IOStreams.Out)
-	encoder.SetIndent("", "  ")
-	return encoder.Encode(mailboxes)
+func outputDelimited(f *cmdutil.Factory, opts *foldersOptions, format string, mailboxes []jmap.Mailbox) error {
+	header := []string{"id", "name", "role", "unreadEmails", "totalEmails"}
+	if opts.Subscribed {
+		header = append(header, "isSubscribed")
+	}
+	rows := make([][]string, len(mailboxes))
+	for i, mb := range mailboxes {
+		rows[i] = []string{mb.ID, mb.Name, mb.Role, strconv.Itoa(mb.UnreadEmails), strconv.Itoa(mb.TotalEmails)}
+		if opts.Subscribed {
+			rows[i] = append(rows[i], strconv.FormatBool(mb.IsSubscribed))
+		}
+	}
+	return cmdutil.WriteDelimited(f.IOStreams.Out, format, header, rows)
 }
 
-func outputHuman(f *cmdutil.Factory, mailboxes []jmap.Mailbox) error {
+func outputHuman(f *cmdutil.Factory, opts *foldersOptions, mailboxes []jmap.Mailbox) error {
 	out := f.IOStreams.Out
 
 	if len(mailboxes) == 0 {
@@ -83,7 +123,12 @@ func outputHuman(f *cmdutil.Factory, mailboxes []jmap.Mailbox) error {
 			unread = fmt.Sprintf(" [%d unread]", mb.UnreadEmails)
 		}
 
-		fmt.Fprintf(out, "%-20s  %s%s%s\n", mb.ID, mb.Name, role, unread)
+		unsubscribed := ""
+		if opts.Subscribed && !mb.IsSubscribed {
+			unsubscribed = " [unsubscribed]"
+		}
+
+		fmt.Fprintf(out, "%-20s  %s%s%s%s\n", mb.ID, mb.Name, role, unread, unsubscribed)
 	}
 
 	return nil
@@ -3,6 +3,7 @@ package folders
 import (
 	"bytes"
 	"encoding/json"
+	"strings"
 	"testing"
 
 	"github.com/jarcoal/httpmock"
@@ -85,8 +86,8 @@ func TestFoldersCommand(t *testing.T) {
 
 		httpmock.RegisterResponder("POST", "https://api.test.com/jmap/api",
 			mockMailboxesResponse([]map[string]interface{}{
-				{"id": "inbox-1", "name": "Inbox", "role": "inbox", "unreadEmails": 3},
-				{"id": "archive-1", "name": "Archive", "role": "archive", "unreadEmails": 0},
+				{"id": "inbox-1", "name": "Inbox", "role": "inbox", "unreadEmails": 3, "sortOrder": 1},
+				{"id": "archive-1", "name": "Archive", "role": "archive", "unreadEmails": 0, "sortOrder": 4},
 			}))
 
 		cmd := NewCmdFolders(f)
@@ -107,6 +108,94 @@ func TestFoldersCommand(t *testing.T) {
 		assert.Equal(t, "inbox", result[0]["role"])
 	})
 
+	t.Run("honors --output tsv", func(t *testing.T) {
+		f, stdout, _ := setupTest(t)
+		require.NoError(t, f.SetOutputFormat(cmdutil.OutputTSV))
+
+		httpmock.RegisterResponder("POST", "https://api.test.com/jmap/api",
+			mockMailboxesResponse([]map[string]interface{}{
+				{"id": "inbox-1", "name": "Inbox", "role": "inbox", "unreadEmails": 3, "totalEmails": 10},
+			}))
+
+		cmd := NewCmdFolders(f)
+		cmd.SetArgs([]string{})
+		cmd.SetOut(stdout)
+		cmd.SetErr(&bytes.Buffer{})
+
+		err := cmd.Execute()
+
+		require.NoError(t, err)
+		assert.Equal(t, "id\tname\trole\tunreadEmails\ttotalEmails\ninbox-1\tInbox\tinbox\t3\t10\n", stdout.String())
+	})
+
+	t.Run("adds isSubscribed column with --subscribed", func(t *testing.T) {
+		f, stdout, _ := setupTest(t)
+		require.NoError(t, f.SetOutputFormat(cmdutil.OutputTSV))
+
+		httpmock.RegisterResponder("POST", "https://api.test.com/jmap/api",
+			mockMailboxesResponse([]map[string]interface{}{
+				{"id": "inbox-1", "name": "Inbox", "role": "inbox", "unreadEmails": 3, "totalEmails": 10, "isSubscribed": true},
+				{"id": "old-1", "name": "Old", "totalEmails": 2, "isSubscribed": false},
+			}))
+
+		cmd := NewCmdFolders(f)
+		cmd.SetArgs([]string{"--subscribed"})
+		cmd.SetOut(stdout)
+		cmd.SetErr(&bytes.Buffer{})
+
+		err := cmd.Execute()
+
+		require.NoError(t, err)
+		assert.Equal(t, "id\tname\trole\tunreadEmails\ttotalEmails\tisSubscribed\n"+
+			"inbox-1\tInbox\tinbox\t3\t10\ttrue\n"+
+			"old-1\tOld\t\t0\t2\tfalse\n", stdout.String())
+	})
+
+	t.Run("marks unsubscribed folders with --subscribed", func(t *testing.T) {
+		f, stdout, _ := setupTest(t)
+
+		httpmock.RegisterResponder("POST", "https://api.test.com/jmap/api",
+			mockMailboxesResponse([]map[string]interface{}{
+				{"id": "inbox-1", "name": "Inbox", "role": "inbox", "isSubscribed": true},
+				{"id": "old-1", "name": "Old", "isSubscribed": false},
+			}))
+
+		cmd := NewCmdFolders(f)
+		cmd.SetArgs([]string{"--subscribed"})
+		cmd.SetOut(stdout)
+		cmd.SetErr(&bytes.Buffer{})
+
+		err := cmd.Execute()
+
+		require.NoError(t, err)
+		lines := strings.Split(strings.TrimSpace(stdout.String()), "\n")
+		require.Len(t, lines, 2)
+		assert.NotContains(t, lines[0], "[unsubscribed]")
+		assert.Contains(t, lines[1], "Old [unsubscribed]")
+	})
+
+	t.Run("--json overrides --output", func(t *testing.T) {
+		f, stdout, _ := setupTest(t)
+		require.NoError(t, f.SetOutputFormat(cmdutil.OutputCSV))
+
+		httpmock.RegisterResponder("POST", "https://api.test.com/jmap/api",
+			mockMailboxesResponse([]map[string]interface{}{
+				{"id": "inbox-1", "name": "Inbox", "role": "inbox"},
+			}))
+
+		cmd := NewCmdFolders(f)
+		cmd.SetArgs([]string{"--json"})
+		cmd.SetOut(stdout)
+		cmd.SetErr(&bytes.Buffer{})
+
+		err := cmd.Execute()
+
+		require.NoError(t, err)
+		var result []map[string]interface{}
+		require.NoError(t, json.Unmarshal(stdout.Bytes(), &result))
+		assert.Len(t, result, 1)
+	})
+
 	t.Run("shows message when no mailboxes", func(t *testing.T) {
 		f, stdout, _ := setupTest(t)
 
@@ -165,4 +254,46 @@ func TestFoldersCommand(t *testing.T) {
 		assert.Contains(t, output, "My Folder")
 		assert.NotContains(t, output, "()")
 	})
+
+	t.Run("sorts JSON output with --sort", func(t *testing.T) {
+		f, stdout, _ := setupTest(t)
+
+		httpmock.RegisterResponder("POST", "https://api.test.com/jmap/api",
+			mockMailboxesResponse([]map[string]interface{}{
+				{"id": "inbox-1", "name": "Inbox", "role": "inbox", "unreadEmails": 3, "sortOrder": 1},
+				{"id": "work-1", "name": "Work", "unreadEmails": 9, "sortOrder": 10},
+				{"id": "archive-1", "name": "Archive", "role": "archive", "unreadEmails": 0, "sortOrder": 4},
+			}))
+
+		cmd := NewCmdFolders(f)
+		cmd.SetArgs([]string{"--json", "--sort", "unread"})
+		cmd.SetOut(stdout)
+		cmd.SetErr(&bytes.Buffer{})
+
+		err := cmd.Execute()
+
+		require.NoError(t, err)
+
+		var result []jmap.Mailbox
+		require.NoError(t, json.Unmarshal(stdout.Bytes(), &result))
+		require.Len(t, result, 3)
+		assert.Equal(t, []string{"work-1", "inbox-1", "archive-1"}, []string{result[0].ID, result[1].ID, result[2].ID})
+	})
+
+	t.Run("rejects an unknown --sort", func(t *testing.T) {
+		f, _, _ := setupTest(t)
+
+		httpmock.RegisterResponder("POST", "https://api.test.com/jmap/api",
+			mockMailboxesResponse([]map[string]interface{}{}))
+
+		cmd := NewCmdFolders(f)
+		cmd.SetArgs([]string{"--sort", "size"})
+		cmd.SetOut(&bytes.Buffer{})
+		cmd.SetErr(&bytes.Buffer{})
+
+		err := cmd.Execute()
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `invalid sort "size"`)
+	})
 }
@@ -25,6 +25,10 @@ func setupTest(t *testing.T) (*cmdutil.Factory, *bytes.Buffer, *bytes.Buffer) {
 			"accounts": map[string]interface{}{
 				"account-1": map[string]interface{}{},
 			},
+			"capabilities": map[string]interface{}{
+				jmap.CoreCapability:       map[string]interface{}{},
+				jmap.SubmissionCapability: map[string]interface{}{},
+			},
 		}))
 
 	client := jmap.NewClient("test-token")
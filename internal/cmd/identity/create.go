@@ -0,0 +1,67 @@
+package identity
+
+import (
+	"fmt"
+
+	"github.com/marckohlbrugge/fastmail-cli/internal/cmdutil"
+	"github.com/spf13/cobra"
+)
+
+type createOptions struct {
+	Email     string
+	Name      string
+	Signature string
+}
+
+// NewCmdCreate creates the identity create command.
+func NewCmdCreate(f *cmdutil.Factory) *cobra.Command {
+	opts := &createOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "create",
+		Short: "Create a new identity",
+		Long: `Create a new sender identity.
+
+The email must be an address you are allowed to send from, such as an
+alias or a domain you own. Once created, it can be used with --from.`,
+		Example: `  # Create an identity
+  fm identity create --email work@example.com --name "John at Work"
+
+  # Create with a signature
+  fm identity create --email work@example.com --name "John" --signature "-- John"`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runCreate(f, opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.Email, "email", "", "Email address for the identity")
+	cmd.Flags().StringVar(&opts.Name, "name", "", "Display name")
+	cmd.Flags().StringVar(&opts.Signature, "signature", "", "Plain text signature")
+
+	_ = cmd.MarkFlagRequired("email")
+
+	return cmd
+}
+
+func runCreate(f *cmdutil.Factory, opts *createOptions) error {
+	if opts.Email == "" {
+		return cmdutil.FlagErrorf("--email is required")
+	}
+	if err := cmdutil.ValidateEmail(opts.Email); err != nil {
+		return cmdutil.FlagErrorWrap(err)
+	}
+
+	client, err := f.JMAPClient()
+	if err != nil {
+		return err
+	}
+
+	identityID, err := client.CreateIdentity(opts.Email, opts.Name, opts.Signature)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintf(f.IOStreams.Out, "Identity created: %s\n", identityID)
+	return nil
+}
@@ -0,0 +1,96 @@
+package identity
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/marckohlbrugge/fastmail-cli/internal/cmdutil"
+	"github.com/marckohlbrugge/fastmail-cli/internal/jmap"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateCommand(t *testing.T) {
+	t.Run("creates identity", func(t *testing.T) {
+		f, stdout, _ := setupTest(t)
+
+		var capturedCreate map[string]interface{}
+		httpmock.RegisterResponder("POST", "https://api.test.com/jmap/api",
+			func(req *http.Request) (*http.Response, error) {
+				var jmapReq jmap.Request
+				json.NewDecoder(req.Body).Decode(&jmapReq)
+
+				args := jmapReq.MethodCalls[0][1].(map[string]interface{})
+				create := args["create"].(map[string]interface{})
+				capturedCreate = create["newIdentity"].(map[string]interface{})
+
+				return httpmock.NewJsonResponse(200, map[string]interface{}{
+					"methodResponses": [][]interface{}{
+						{"Identity/set", map[string]interface{}{
+							"created": map[string]interface{}{
+								"newIdentity": map[string]interface{}{"id": "identity-new"},
+							},
+						}, "createIdentity"},
+					},
+				})
+			})
+
+		cmd := NewCmdCreate(f)
+		cmd.SetArgs([]string{"--email", "work@example.com", "--name", "John at Work", "--signature", "-- John"})
+		cmd.SetOut(stdout)
+		cmd.SetErr(&bytes.Buffer{})
+
+		err := cmd.Execute()
+
+		require.NoError(t, err)
+		assert.Contains(t, stdout.String(), "Identity created: identity-new")
+		assert.Equal(t, "work@example.com", capturedCreate["email"])
+		assert.Equal(t, "John at Work", capturedCreate["name"])
+		assert.Equal(t, "-- John", capturedCreate["textSignature"])
+	})
+
+	t.Run("surfaces notCreated errors", func(t *testing.T) {
+		f, stdout, _ := setupTest(t)
+
+		httpmock.RegisterResponder("POST", "https://api.test.com/jmap/api",
+			httpmock.NewJsonResponderOrPanic(200, map[string]interface{}{
+				"methodResponses": [][]interface{}{
+					{"Identity/set", map[string]interface{}{
+						"notCreated": map[string]interface{}{
+							"newIdentity": map[string]interface{}{
+								"type":        "forbiddenFrom",
+								"description": "Address is not verified",
+							},
+						},
+					}, "createIdentity"},
+				},
+			}))
+
+		cmd := NewCmdCreate(f)
+		cmd.SetArgs([]string{"--email", "alias@example.com"})
+		cmd.SetOut(stdout)
+		cmd.SetErr(&bytes.Buffer{})
+
+		err := cmd.Execute()
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "forbiddenFrom")
+		assert.Contains(t, err.Error(), "Address is not verified")
+	})
+
+	t.Run("rejects invalid email", func(t *testing.T) {
+		f := &cmdutil.Factory{}
+		cmd := NewCmdCreate(f)
+		cmd.SetArgs([]string{"--email", "not-an-email"})
+		cmd.SetOut(&bytes.Buffer{})
+		cmd.SetErr(&bytes.Buffer{})
+
+		err := cmd.Execute()
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid email address")
+	})
+}
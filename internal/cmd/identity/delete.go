@@ -0,0 +1,84 @@
+package identity
+
+import (
+	"fmt"
+
+	"github.com/marckohlbrugge/fastmail-cli/internal/cmdutil"
+	"github.com/spf13/cobra"
+)
+
+type deleteOptions struct {
+	Yes    bool
+	Unsafe bool
+}
+
+// NewCmdDelete creates the identity delete command.
+func NewCmdDelete(f *cmdutil.Factory) *cobra.Command {
+	opts := &deleteOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "delete <identity-id>",
+		Short: "Delete an identity",
+		Long: `Delete a sender identity.
+
+The primary identity cannot be deleted. Use 'fm identity list --json' to
+find identity IDs.
+
+This action requires confirmation unless --yes is provided.
+In non-interactive mode (scripts, AI), this command is blocked unless --unsafe is specified.`,
+		Example: `  # Delete with confirmation prompt
+  fm identity delete I1234567890
+
+  # Delete without confirmation
+  fm identity delete I1234567890 --yes`,
+		Args: cmdutil.ExactArgs(1, "identity ID required\n\nUsage: fm identity delete <identity-id>"),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runDelete(f, opts, args[0])
+		},
+	}
+
+	cmd.Flags().BoolVar(&opts.Yes, "yes", false, "Skip confirmation prompt")
+	cmd.Flags().BoolVar(&opts.Unsafe, "unsafe", false, "Allow in non-interactive mode")
+
+	return cmd
+}
+
+func runDelete(f *cmdutil.Factory, opts *deleteOptions, identityID string) error {
+	// Check safe mode
+	if f.IOStreams.IsSafeMode() && !opts.Unsafe {
+		return &cmdutil.SafeModeError{Command: "identity delete"}
+	}
+
+	client, err := f.JMAPClient()
+	if err != nil {
+		return err
+	}
+
+	identity, err := client.GetIdentityByID(identityID)
+	if err != nil {
+		return err
+	}
+
+	if !identity.MayDelete {
+		return fmt.Errorf("identity %s (%s) is your primary identity and cannot be deleted", identity.ID, identity.Email)
+	}
+
+	// Require confirmation unless --yes
+	if !opts.Yes && f.IOStreams.IsInteractive() {
+		if f.Quiet {
+			return cmdutil.QuietConfirmError
+		}
+
+		fmt.Fprintf(f.IOStreams.ErrOut, "Identity: %s\n", identity.Email)
+		if ok, err := cmdutil.Confirm(f.IOStreams, "Delete this identity?"); !ok {
+			return err
+		}
+	}
+
+	if err := client.DeleteIdentity(identityID); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(f.IOStreams.Out, "Identity deleted: %s\n", identity.Email)
+	return nil
+}
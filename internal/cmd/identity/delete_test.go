@@ -0,0 +1,112 @@
+package identity
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/marckohlbrugge/fastmail-cli/internal/cmdutil"
+	"github.com/marckohlbrugge/fastmail-cli/internal/jmap"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func mockIdentityDeleteResponder(identities []map[string]interface{}, setResult map[string]interface{}) httpmock.Responder {
+	return func(req *http.Request) (*http.Response, error) {
+		var jmapReq jmap.Request
+		json.NewDecoder(req.Body).Decode(&jmapReq)
+
+		switch jmapReq.MethodCalls[0][0].(string) {
+		case "Identity/get":
+			return mockIdentitiesResponse(identities)(req)
+		case "Identity/set":
+			return httpmock.NewJsonResponse(200, map[string]interface{}{
+				"methodResponses": [][]interface{}{
+					{"Identity/set", setResult, "deleteIdentity"},
+				},
+			})
+		default:
+			return httpmock.NewStringResponse(400, "unexpected"), nil
+		}
+	}
+}
+
+func TestDeleteCommand(t *testing.T) {
+	identities := []map[string]interface{}{
+		{"id": "id-1", "email": "primary@example.com", "mayDelete": false},
+		{"id": "id-2", "email": "work@example.com", "mayDelete": true},
+	}
+
+	t.Run("deletes identity with --unsafe --yes", func(t *testing.T) {
+		f, stdout, _ := setupTest(t)
+
+		httpmock.RegisterResponder("POST", "https://api.test.com/jmap/api",
+			mockIdentityDeleteResponder(identities, map[string]interface{}{"destroyed": []string{"id-2"}}))
+
+		cmd := NewCmdDelete(f)
+		cmd.SetArgs([]string{"id-2", "--yes", "--unsafe"})
+		cmd.SetOut(stdout)
+		cmd.SetErr(&bytes.Buffer{})
+
+		err := cmd.Execute()
+
+		require.NoError(t, err)
+		assert.Contains(t, stdout.String(), "Identity deleted: work@example.com")
+	})
+
+	t.Run("refuses to delete primary identity", func(t *testing.T) {
+		f, _, _ := setupTest(t)
+
+		httpmock.RegisterResponder("POST", "https://api.test.com/jmap/api",
+			mockIdentityDeleteResponder(identities, nil))
+
+		cmd := NewCmdDelete(f)
+		cmd.SetArgs([]string{"id-1", "--yes", "--unsafe"})
+		cmd.SetOut(&bytes.Buffer{})
+		cmd.SetErr(&bytes.Buffer{})
+
+		err := cmd.Execute()
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "cannot be deleted")
+		assert.Equal(t, 1, httpmock.GetCallCountInfo()["POST https://api.test.com/jmap/api"], "should not send destroy")
+	})
+
+	t.Run("reports notDestroyed errors", func(t *testing.T) {
+		f, _, _ := setupTest(t)
+
+		httpmock.RegisterResponder("POST", "https://api.test.com/jmap/api",
+			mockIdentityDeleteResponder(identities, map[string]interface{}{
+				"notDestroyed": map[string]interface{}{
+					"id-2": map[string]interface{}{"type": "forbidden", "description": "Identity in use"},
+				},
+			}))
+
+		cmd := NewCmdDelete(f)
+		cmd.SetArgs([]string{"id-2", "--yes", "--unsafe"})
+		cmd.SetOut(&bytes.Buffer{})
+		cmd.SetErr(&bytes.Buffer{})
+
+		err := cmd.Execute()
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "Identity in use")
+	})
+
+	t.Run("blocks in safe mode without --unsafe", func(t *testing.T) {
+		f, _, _ := setupTest(t)
+
+		cmd := NewCmdDelete(f)
+		cmd.SetArgs([]string{"id-2", "--yes"})
+		cmd.SetOut(&bytes.Buffer{})
+		cmd.SetErr(&bytes.Buffer{})
+
+		err := cmd.Execute()
+
+		require.Error(t, err)
+		var safeModeErr *cmdutil.SafeModeError
+		assert.ErrorAs(t, err, &safeModeErr)
+	})
+}
@@ -10,12 +10,18 @@ func NewCmdIdentity(f *cmdutil.Factory) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:     "identity <command>",
 		Short:   "Manage identities",
-		Long:    "View sender identities (email addresses you can send from).",
+		Long:    "View and manage sender identities (email addresses you can send from).",
 		GroupID: "identity",
-		Example: `  $ fm identity list`,
+		Example: `  $ fm identity list
+  $ fm identity create --email work@example.com --name "John at Work"
+  $ fm identity update I1234567890 --signature "-- John"
+  $ fm identity delete I1234567890`,
 	}
 
 	cmd.AddCommand(NewCmdList(f))
+	cmd.AddCommand(NewCmdCreate(f))
+	cmd.AddCommand(NewCmdUpdate(f))
+	cmd.AddCommand(NewCmdDelete(f))
 
 	return cmd
 }
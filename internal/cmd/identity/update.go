@@ -0,0 +1,105 @@
+package identity
+
+import (
+	"fmt"
+
+	"github.com/marckohlbrugge/fastmail-cli/internal/cmdutil"
+	"github.com/marckohlbrugge/fastmail-cli/internal/jmap"
+	"github.com/spf13/cobra"
+)
+
+type updateOptions struct {
+	Name          string
+	Signature     string
+	HTMLSignature string
+	ReplyTo       []string
+}
+
+// NewCmdUpdate creates the identity update command.
+func NewCmdUpdate(f *cmdutil.Factory) *cobra.Command {
+	opts := &updateOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "update <identity-id>",
+		Short: "Update an identity",
+		Long: `Update the name, signature, or reply-to addresses of a sender identity.
+
+Only the flags you pass are changed; everything else is left as is.
+Pass an empty value to clear a field, e.g. --signature "".`,
+		Example: `  # Change the display name
+  fm identity update I1234567890 --name "John Doe"
+
+  # Set the signature appended to drafts
+  fm identity update I1234567890 --signature "-- John"
+
+  # Send replies to a different address
+  fm identity update I1234567890 --reply-to support@example.com`,
+		Args: cmdutil.ExactArgs(1, "identity ID required\n\nUsage: fm identity update <identity-id> [flags]"),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			updates, err := buildIdentityUpdates(cmd, opts)
+			if err != nil {
+				return err
+			}
+			return runUpdate(f, args[0], updates)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.Name, "name", "", "Display name")
+	cmd.Flags().StringVar(&opts.Signature, "signature", "", "Plain text signature")
+	cmd.Flags().StringVar(&opts.HTMLSignature, "html-signature", "", "HTML signature")
+	cmd.Flags().StringSliceVar(&opts.ReplyTo, "reply-to", nil, "Reply-to addresses (comma-separated)")
+
+	return cmd
+}
+
+// buildIdentityUpdates returns the patch for the flags that were set on the command line.
+func buildIdentityUpdates(cmd *cobra.Command, opts *updateOptions) (map[string]interface{}, error) {
+	updates := map[string]interface{}{}
+
+	if cmd.Flags().Changed("name") {
+		updates["name"] = opts.Name
+	}
+	if cmd.Flags().Changed("signature") {
+		updates["textSignature"] = opts.Signature
+	}
+	if cmd.Flags().Changed("html-signature") {
+		updates["htmlSignature"] = opts.HTMLSignature
+	}
+	if cmd.Flags().Changed("reply-to") {
+		var replyTo []jmap.EmailAddress
+		for _, addr := range opts.ReplyTo {
+			if addr == "" {
+				continue
+			}
+			if err := cmdutil.ValidateEmail(addr); err != nil {
+				return nil, cmdutil.FlagErrorWrap(err)
+			}
+			replyTo = append(replyTo, jmap.EmailAddress{Email: addr})
+		}
+		if len(replyTo) == 0 {
+			updates["replyTo"] = nil
+		} else {
+			updates["replyTo"] = replyTo
+		}
+	}
+
+	if len(updates) == 0 {
+		return nil, cmdutil.FlagErrorf("at least one of --name, --signature, --html-signature, or --reply-to is required")
+	}
+
+	return updates, nil
+}
+
+func runUpdate(f *cmdutil.Factory, identityID string, updates map[string]interface{}) error {
+	client, err := f.JMAPClient()
+	if err != nil {
+		return err
+	}
+
+	if err := client.UpdateIdentity(identityID, updates); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(f.IOStreams.Out, "Identity updated: %s\n", identityID)
+	return nil
+}
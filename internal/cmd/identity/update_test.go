@@ -0,0 +1,146 @@
+package identity
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/marckohlbrugge/fastmail-cli/internal/jmap"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func mockIdentityUpdateResponder(captured *map[string]interface{}, setResult map[string]interface{}) httpmock.Responder {
+	return func(req *http.Request) (*http.Response, error) {
+		var jmapReq jmap.Request
+		json.NewDecoder(req.Body).Decode(&jmapReq)
+
+		args := jmapReq.MethodCalls[0][1].(map[string]interface{})
+		update := args["update"].(map[string]interface{})
+		*captured = update["id-2"].(map[string]interface{})
+
+		return httpmock.NewJsonResponse(200, map[string]interface{}{
+			"methodResponses": [][]interface{}{
+				{"Identity/set", setResult, "updateIdentity"},
+			},
+		})
+	}
+}
+
+func TestUpdateCommand(t *testing.T) {
+	t.Run("sends only provided fields", func(t *testing.T) {
+		f, stdout, _ := setupTest(t)
+
+		var captured map[string]interface{}
+		httpmock.RegisterResponder("POST", "https://api.test.com/jmap/api",
+			mockIdentityUpdateResponder(&captured, map[string]interface{}{
+				"updated": map[string]interface{}{"id-2": nil},
+			}))
+
+		cmd := NewCmdUpdate(f)
+		cmd.SetArgs([]string{"id-2", "--signature", "-- John"})
+		cmd.SetOut(stdout)
+		cmd.SetErr(&bytes.Buffer{})
+
+		err := cmd.Execute()
+
+		require.NoError(t, err)
+		assert.Contains(t, stdout.String(), "Identity updated: id-2")
+		assert.Equal(t, map[string]interface{}{"textSignature": "-- John"}, captured)
+	})
+
+	t.Run("sets name and reply-to", func(t *testing.T) {
+		f, _, _ := setupTest(t)
+
+		var captured map[string]interface{}
+		httpmock.RegisterResponder("POST", "https://api.test.com/jmap/api",
+			mockIdentityUpdateResponder(&captured, map[string]interface{}{
+				"updated": map[string]interface{}{"id-2": nil},
+			}))
+
+		cmd := NewCmdUpdate(f)
+		cmd.SetArgs([]string{"id-2", "--name", "John", "--reply-to", "support@example.com"})
+		cmd.SetOut(&bytes.Buffer{})
+		cmd.SetErr(&bytes.Buffer{})
+
+		err := cmd.Execute()
+
+		require.NoError(t, err)
+		assert.Equal(t, "John", captured["name"])
+		assert.Equal(t, []interface{}{map[string]interface{}{"email": "support@example.com"}}, captured["replyTo"])
+		assert.NotContains(t, captured, "textSignature")
+		assert.NotContains(t, captured, "htmlSignature")
+	})
+
+	t.Run("clears reply-to with empty value", func(t *testing.T) {
+		f, _, _ := setupTest(t)
+
+		var captured map[string]interface{}
+		httpmock.RegisterResponder("POST", "https://api.test.com/jmap/api",
+			mockIdentityUpdateResponder(&captured, map[string]interface{}{
+				"updated": map[string]interface{}{"id-2": nil},
+			}))
+
+		cmd := NewCmdUpdate(f)
+		cmd.SetArgs([]string{"id-2", "--reply-to", ""})
+		cmd.SetOut(&bytes.Buffer{})
+		cmd.SetErr(&bytes.Buffer{})
+
+		err := cmd.Execute()
+
+		require.NoError(t, err)
+		assert.Contains(t, captured, "replyTo")
+		assert.Nil(t, captured["replyTo"])
+	})
+
+	t.Run("reports notUpdated errors", func(t *testing.T) {
+		f, _, _ := setupTest(t)
+
+		var captured map[string]interface{}
+		httpmock.RegisterResponder("POST", "https://api.test.com/jmap/api",
+			mockIdentityUpdateResponder(&captured, map[string]interface{}{
+				"notUpdated": map[string]interface{}{
+					"id-2": map[string]interface{}{"type": "invalidProperties", "description": "Bad signature"},
+				},
+			}))
+
+		cmd := NewCmdUpdate(f)
+		cmd.SetArgs([]string{"id-2", "--html-signature", "<p>"})
+		cmd.SetOut(&bytes.Buffer{})
+		cmd.SetErr(&bytes.Buffer{})
+
+		err := cmd.Execute()
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "Bad signature")
+	})
+
+	t.Run("requires at least one field", func(t *testing.T) {
+		f, _, _ := setupTest(t)
+
+		cmd := NewCmdUpdate(f)
+		cmd.SetArgs([]string{"id-2"})
+		cmd.SetOut(&bytes.Buffer{})
+		cmd.SetErr(&bytes.Buffer{})
+
+		err := cmd.Execute()
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "at least one of")
+	})
+
+	t.Run("rejects invalid reply-to address", func(t *testing.T) {
+		f, _, _ := setupTest(t)
+
+		cmd := NewCmdUpdate(f)
+		cmd.SetArgs([]string{"id-2", "--reply-to", "not-an-email"})
+		cmd.SetOut(&bytes.Buffer{})
+		cmd.SetErr(&bytes.Buffer{})
+
+		err := cmd.Execute()
+
+		require.Error(t, err)
+	})
+}
@@ -1,8 +1,11 @@
 package inbox
 
 import (
-	"encoding/json"
 	"fmt"
+	"slices"
+	"strings"
+	"text/template"
+	"time"
 
 	"github.com/marckohlbrugge/fastmail-cli/internal/cmdutil"
 	"github.com/marckohlbrugge/fastmail-cli/internal/jmap"
@@ -10,8 +13,18 @@ import (
 )
 
 type inboxOptions struct {
-	Limit      int
-	JSONFields []string
+	Limit       int
+	Folder      string
+	OldestFirst bool
+	Unread      bool
+	ByThread    bool
+	Since       string
+	SinceID     string
+	Fields      string
+	JSONFields  []string
+	JSONLFields []string
+	Template    string
+	HelpFields  bool
 }
 
 // NewCmdInbox creates the inbox command.
@@ -23,28 +36,91 @@ func NewCmdInbox(f *cmdutil.Factory) *cobra.Command {
 		Short: "List recent inbox emails",
 		Long: `List recent emails from your inbox.
 
+--folder lists another folder instead, by ID, name, or role. To make that
+the default, set inbox.default_folder in the config file.
+
 By default displays email ID, date, sender, and subject.
-Use --json with field names for machine-readable output.`,
+Use --json with field names, or --output json|jsonl|csv|tsv, for machine-readable output.
+
+--since-id shows only emails received after the given one, for polling
+loops: remember the newest ID you have seen and pass it next time. If more
+than --limit emails are newer, the ones right after it are shown, so the
+next poll carries on where this one stopped. When nothing is newer the
+table output is empty and the exit code is 0.`,
 		Example: `  # List recent inbox emails
   fm inbox
 
   # List last 10 emails
   fm inbox --limit 10
 
+  # List a filtered folder instead of the Inbox
+  fm inbox --folder Priority
+
+  # Show mail from the last day
+  fm inbox --since 24h
+
+  # Show only what still needs attention
+  fm inbox --unread
+
+  # Show what arrived since the last email you processed
+  fm inbox --since-id M1234567890
+
+  # Work through the backlog chronologically
+  fm inbox --oldest-first
+
+  # Choose which columns to show
+  fm inbox --fields unread,date,from,subject,size
+
   # Output as JSON with specific fields
   fm inbox --json id,subject,from
 
+  # Describe the fields --fields and --json accept
+  fm inbox --help-fields
+
   # Output all available JSON fields
-  fm inbox --json id,threadId,subject,from,to,cc,date,preview,unread,attachment`,
+  fm inbox --json id,threadId,subject,from,to,cc,date,preview,unread,attachment,size,folder,messageId,keywords
+
+  # Output as CSV
+  fm inbox --output csv
+
+  # Format each email with a template
+  fm inbox --template '{{.ID}}\t{{formatDate .ReceivedAt "Jan 2"}}\t{{addrs .From}}\t{{.Subject}}'`,
 		GroupID: "core",
 		Args:    cobra.NoArgs,
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if opts.HelpFields {
+				cmdutil.PrintFieldHelp(f.IOStreams.Out)
+				return nil
+			}
+			if !cmd.Flags().Changed("limit") {
+				opts.Limit = f.DefaultLimit(opts.Limit)
+			}
+			limit, err := cmdutil.ParseLimit(opts.Limit)
+			if err != nil {
+				return err
+			}
+			opts.Limit = limit
+			if !cmd.Flags().Changed("folder") && f.Config != nil {
+				opts.Folder = f.Config.Inbox.DefaultFolder
+			}
 			return runInbox(f, opts)
 		},
 	}
 
-	cmd.Flags().IntVar(&opts.Limit, "limit", 20, "Number of emails to show (max 50)")
-	cmd.Flags().StringSliceVar(&opts.JSONFields, "json", nil, "Output JSON with specified `fields` (id,threadId,subject,from,to,cc,date,preview,unread,attachment)")
+	cmd.Flags().IntVar(&opts.Limit, "limit", 20, fmt.Sprintf("Number of emails to show (max 500, or 0 for every email; fails above %d)", jmap.MaxAllEmails))
+	cmd.Flags().StringVar(&opts.Folder, "folder", "", "List this folder ID or name instead of the Inbox")
+	cmd.Flags().StringVar(&opts.Since, "since", "", "Only show emails from the last `duration` (e.g. 24h, 7d, 2w)")
+	cmd.Flags().StringVar(&opts.SinceID, "since-id", "", "Only show emails received after the email with this `id`")
+	cmd.Flags().BoolVar(&opts.Unread, "unread", false, "Only show unread emails")
+	cmd.Flags().BoolVar(&opts.OldestFirst, "oldest-first", false, "Show the oldest emails first")
+	cmd.Flags().BoolVar(&opts.ByThread, "group-by-thread", false, "Show only the latest email of each thread, with a message count")
+	cmd.Flags().StringVar(&opts.Fields, "fields", "", "Comma-separated `fields` to display (default id,date,from,subject)")
+	cmd.Flags().StringSliceVar(&opts.JSONFields, "json", nil, "Output JSON with specified `fields` ("+strings.Join(cmdutil.AvailableEmailFields, ",")+")")
+	cmd.Flags().StringSliceVar(&opts.JSONLFields, "jsonl", nil, "Output one compact JSON object per line with specified `fields`")
+	cmd.Flags().StringVar(&opts.Template, "template", "", "Format each email with a Go `template`")
+	cmd.Flags().BoolVar(&opts.HelpFields, "help-fields", false, "List the available fields with descriptions and exit")
+	cmd.MarkFlagsMutuallyExclusive("json", "jsonl", "template")
+	_ = cmd.RegisterFlagCompletionFunc("folder", cmdutil.CompleteFolders(f))
 
 	return cmd
 }
@@ -55,79 +131,128 @@ func runInbox(f *cmdutil.Factory, opts *inboxOptions) error {
 		return err
 	}
 
-	// Validate JSON fields if provided
-	if opts.JSONFields != nil {
-		if err := cmdutil.ValidateFields(opts.JSONFields); err != nil {
+	// Validate display and JSON fields if provided
+	displayFields := cmdutil.ParseFields(opts.Fields)
+	if err := cmdutil.ValidateFields(displayFields); err != nil {
+		return err
+	}
+	for _, fields := range [][]string{opts.JSONFields, opts.JSONLFields} {
+		if err := cmdutil.ValidateFields(fields); err != nil {
 			return err
 		}
 	}
 
-	// Get inbox mailbox
-	inbox, err := client.GetMailboxByRole("inbox")
-	if err != nil {
+	var tmpl *template.Template
+	if opts.Template != "" {
+		if tmpl, err = cmdutil.ParseEmailTemplate(opts.Template); err != nil {
+			return err
+		}
+	}
+
+	var after time.Time
+	if opts.Since != "" {
+		d, err := jmap.ParseRelativeDuration(opts.Since)
+		if err != nil {
+			return cmdutil.FlagErrorWrap(err)
+		}
+		after = time.Now().Add(-d)
+	}
+
+	if opts.SinceID != "" {
+		since, err := client.GetEmailSummary(opts.SinceID)
+		if err != nil {
+			return err
+		}
+		if since.ReceivedAt.After(after) {
+			after = since.ReceivedAt
+		}
+	}
+
+	// Get inbox mailbox, or the folder listed in its place
+	var inbox *jmap.Mailbox
+	if opts.Folder != "" {
+		if inbox, err = cmdutil.ResolveMailbox(f, client, opts.Folder); err != nil {
+			return err
+		}
+	} else if inbox, err = client.GetMailboxByRole("inbox"); err != nil {
 		return fmt.Errorf("could not find inbox: %w", err)
 	}
 
 	// Fetch recent emails
-	emails, err := client.GetRecentEmails(inbox.ID, opts.Limit)
+	emails, err := client.GetRecentEmails(inbox.ID, jmap.RecentEmailsOptions{
+		Limit:       opts.Limit,
+		OldestFirst: opts.OldestFirst,
+		After:       after,
+		AfterID:     opts.SinceID,
+		Unread:      opts.Unread,
+	})
 	if err != nil {
 		return err
 	}
 
-	if opts.JSONFields != nil {
-		return outputJSON(f, emails, opts.JSONFields)
+	// Grouping happens after --limit, so threads are counted within the
+	// fetched emails only.
+	if opts.ByThread {
+		emails = cmdutil.GroupByThread(emails)
 	}
 
-	return outputHuman(f, emails, cmdutil.DefaultEmailFields)
-}
+	if tmpl != nil {
+		return cmdutil.WriteEmailsTemplate(f.IOStreams.Out, tmpl, emails)
+	}
 
-func outputJSON(f *cmdutil.Factory, emails []jmap.Email, fields []string) error {
-	output := make([]map[string]interface{}, len(emails))
-
-	for i, e := range emails {
-		row := make(map[string]interface{})
-		for _, field := range fields {
-			switch field {
-			case "id":
-				row["id"] = e.ID
-			case "threadId":
-				row["threadId"] = e.ThreadID
-			case "subject":
-				row["subject"] = e.Subject
-			case "from":
-				row["from"] = e.From
-			case "to":
-				row["to"] = e.To
-			case "cc":
-				row["cc"] = e.CC
-			case "date":
-				row["receivedAt"] = e.ReceivedAt
-			case "preview":
-				row["preview"] = e.Preview
-			case "unread":
-				row["isUnread"] = e.IsUnread()
-			case "attachment":
-				row["hasAttachment"] = e.HasAttachment
-			}
+	format := f.OutputFormat(opts.JSONFields != nil)
+	fields := opts.JSONFields
+	if opts.JSONLFields != nil {
+		format = cmdutil.OutputJSONL
+		fields = opts.JSONLFields
+	}
+	if len(fields) == 0 {
+		fields = displayFields
+		if (format == cmdutil.OutputJSON || format == cmdutil.OutputJSONL) && opts.Fields == "" {
+			fields = cmdutil.AvailableEmailFields
 		}
-		output[i] = row
 	}
 
-	encoder := json.NewEncoder(f.IOStreams.Out)
-	encoder.SetIndent("", "  ")
-	return encoder.Encode(output)
+	if slices.Contains(fields, "folder") {
+		if err := client.ResolveMailboxNames(emails); err != nil {
+			return fmt.Errorf("failed to resolve folders: %w", err)
+		}
+	}
+
+	switch {
+	case format == cmdutil.OutputJSON:
+		return cmdutil.WriteJSON(f.IOStreams.Out, cmdutil.EmailsJSON(emails, fields))
+	case format == cmdutil.OutputJSONL:
+		return cmdutil.WriteEmailsJSONL(f.IOStreams.Out, emails, fields)
+	case cmdutil.IsDelimited(format):
+		return cmdutil.WriteEmailsDelimited(f.IOStreams.Out, format, emails, fields)
+	}
+
+	// Polling loops treat any output as new mail
+	if len(emails) == 0 && opts.SinceID != "" {
+		return nil
+	}
+
+	return outputHuman(f, emails, fields, opts.Unread)
 }
 
-func outputHuman(f *cmdutil.Factory, emails []jmap.Email, fields []string) error {
+func outputHuman(f *cmdutil.Factory, emails []jmap.Email, fields []string, unread bool) error {
 	out := f.IOStreams.Out
 
+	noun := "emails"
+	if unread {
+		noun = "unread emails"
+	}
+
 	if len(emails) == 0 {
-		fmt.Fprintln(out, "No emails found.")
+		fmt.Fprintf(out, "No %s found.\n", noun)
 		return nil
 	}
 
-	cmdutil.PrintEmailList(out, emails, fields)
+	cmdutil.PrintEmailList(out, f.EmailListStyle(), emails, fields)
 
-	fmt.Fprintf(out, "\n%d emails\n", len(emails))
+	if !f.Quiet {
+		fmt.Fprintf(out, "\n%d %s\n", len(emails), noun)
+	}
 	return nil
 }
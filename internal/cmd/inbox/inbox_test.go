@@ -3,12 +3,15 @@ package inbox
 import (
 	"bytes"
 	"encoding/json"
+	"fmt"
 	"net/http"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/jarcoal/httpmock"
 	"github.com/marckohlbrugge/fastmail-cli/internal/cmdutil"
+	"github.com/marckohlbrugge/fastmail-cli/internal/config"
 	"github.com/marckohlbrugge/fastmail-cli/internal/iostreams"
 	"github.com/marckohlbrugge/fastmail-cli/internal/jmap"
 	"github.com/stretchr/testify/assert"
@@ -183,6 +186,60 @@ func TestInboxCommand(t *testing.T) {
 		assert.Len(t, result[0], 3)
 	})
 
+	t.Run("honors --output csv", func(t *testing.T) {
+		f, stdout, _ := setupTest(t)
+		require.NoError(t, f.SetOutputFormat(cmdutil.OutputCSV))
+
+		httpmock.RegisterResponder("POST", "https://api.test.com/jmap/api",
+			func(req *http.Request) (*http.Response, error) {
+				var jmapReq jmap.Request
+				json.NewDecoder(req.Body).Decode(&jmapReq)
+
+				method := jmapReq.MethodCalls[0][0].(string)
+
+				switch method {
+				case "Mailbox/get":
+					return httpmock.NewJsonResponse(200, map[string]interface{}{
+						"methodResponses": [][]interface{}{
+							{"Mailbox/get", map[string]interface{}{
+								"list": []map[string]interface{}{
+									{"id": "inbox-1", "role": "inbox"},
+								},
+							}, "mailboxes"},
+						},
+					})
+				case "Email/query":
+					return httpmock.NewJsonResponse(200, map[string]interface{}{
+						"methodResponses": [][]interface{}{
+							{"Email/query", map[string]interface{}{"ids": []string{"email-1"}}, "query"},
+							{"Email/get", map[string]interface{}{
+								"list": []map[string]interface{}{
+									{
+										"id":         "email-1",
+										"subject":    "Test, with comma",
+										"from":       []map[string]string{{"email": "test@example.com"}},
+										"receivedAt": "2024-01-15T10:30:00Z",
+									},
+								},
+							}, "emails"},
+						},
+					})
+				default:
+					return httpmock.NewStringResponse(400, "unexpected"), nil
+				}
+			})
+
+		cmd := NewCmdInbox(f)
+		cmd.SetArgs([]string{})
+		cmd.SetOut(stdout)
+		cmd.SetErr(&bytes.Buffer{})
+
+		err := cmd.Execute()
+
+		require.NoError(t, err)
+		assert.Equal(t, "id,date,from,subject\nemail-1,2024-01-15T10:30:00Z,test@example.com,\"Test, with comma\"\n", stdout.String())
+	})
+
 	t.Run("shows empty message when no emails", func(t *testing.T) {
 		f, stdout, _ := setupTest(t)
 
@@ -278,6 +335,552 @@ func TestInboxCommand(t *testing.T) {
 		assert.Equal(t, 5, capturedLimit)
 	})
 
+	t.Run("pages past the server's result cap", func(t *testing.T) {
+		f, stdout, _ := setupTest(t)
+
+		httpmock.RegisterResponder("POST", "https://api.test.com/jmap/api",
+			func(req *http.Request) (*http.Response, error) {
+				var jmapReq jmap.Request
+				json.NewDecoder(req.Body).Decode(&jmapReq)
+
+				method := jmapReq.MethodCalls[0][0].(string)
+
+				switch method {
+				case "Mailbox/get":
+					return httpmock.NewJsonResponse(200, map[string]interface{}{
+						"methodResponses": [][]interface{}{
+							{"Mailbox/get", map[string]interface{}{
+								"list": []map[string]interface{}{
+									{"id": "inbox-1", "role": "inbox"},
+								},
+							}, "mailboxes"},
+						},
+					})
+				case "Email/query":
+					// Serve at most 50 of 120 emails per query, like Fastmail does
+					args := jmapReq.MethodCalls[0][1].(map[string]interface{})
+					position := int(args["position"].(float64))
+					limit := int(args["limit"].(float64))
+					if limit > 50 {
+						limit = 50
+					}
+
+					var ids []string
+					var list []map[string]interface{}
+					for i := position; i < position+limit && i < 120; i++ {
+						id := fmt.Sprintf("email-%d", i)
+						ids = append(ids, id)
+						list = append(list, map[string]interface{}{
+							"id":         id,
+							"subject":    "Message " + id,
+							"receivedAt": time.Now().Add(-time.Duration(i) * time.Minute).Format(time.RFC3339),
+						})
+					}
+
+					return httpmock.NewJsonResponse(200, map[string]interface{}{
+						"methodResponses": [][]interface{}{
+							{"Email/query", map[string]interface{}{"ids": ids, "position": position, "limit": limit}, "query"},
+							{"Email/get", map[string]interface{}{"list": list}, "emails"},
+						},
+					})
+				default:
+					return httpmock.NewStringResponse(400, "unexpected"), nil
+				}
+			})
+
+		cmd := NewCmdInbox(f)
+		cmd.SetArgs([]string{"--limit", "75", "--json", "id"})
+		cmd.SetOut(stdout)
+		cmd.SetErr(&bytes.Buffer{})
+
+		err := cmd.Execute()
+
+		require.NoError(t, err)
+		var result []map[string]interface{}
+		require.NoError(t, json.Unmarshal(stdout.Bytes(), &result))
+		require.Len(t, result, 75)
+		assert.Equal(t, "email-0", result[0]["id"])
+		assert.Equal(t, "email-74", result[74]["id"])
+		// Mailbox/get plus two Email/query pages
+		assert.Equal(t, 3, httpmock.GetCallCountInfo()["POST https://api.test.com/jmap/api"])
+	})
+
+	t.Run("shows only the requested --fields", func(t *testing.T) {
+		f, stdout, _ := setupTest(t)
+
+		httpmock.RegisterResponder("POST", "https://api.test.com/jmap/api",
+			func(req *http.Request) (*http.Response, error) {
+				var jmapReq jmap.Request
+				json.NewDecoder(req.Body).Decode(&jmapReq)
+
+				switch jmapReq.MethodCalls[0][0].(string) {
+				case "Mailbox/get":
+					return httpmock.NewJsonResponse(200, map[string]interface{}{
+						"methodResponses": [][]interface{}{
+							{"Mailbox/get", map[string]interface{}{
+								"list": []map[string]interface{}{{"id": "inbox-1", "name": "Inbox", "role": "inbox"}},
+							}, "mailboxes"},
+						},
+					})
+				default:
+					return httpmock.NewJsonResponse(200, map[string]interface{}{
+						"methodResponses": [][]interface{}{
+							{"Email/query", map[string]interface{}{"ids": []string{"email-1"}}, "query"},
+							{"Email/get", map[string]interface{}{
+								"list": []map[string]interface{}{
+									{
+										"id":            "email-1",
+										"subject":       "Hello World",
+										"from":          []map[string]string{{"name": "Alice", "email": "alice@example.com"}},
+										"receivedAt":    time.Now().Format(time.RFC3339),
+										"hasAttachment": true,
+									},
+								},
+							}, "emails"},
+						},
+					})
+				}
+			})
+
+		cmd := NewCmdInbox(f)
+		cmd.SetArgs([]string{"--fields", "attachment,id,subject"})
+		cmd.SetOut(stdout)
+		cmd.SetErr(&bytes.Buffer{})
+
+		err := cmd.Execute()
+
+		require.NoError(t, err)
+		output := stdout.String()
+		assert.True(t, strings.HasPrefix(output, "+  email-1"), "output: %q", output)
+		assert.Contains(t, output, "Hello World")
+		assert.NotContains(t, output, "Alice")
+	})
+
+	t.Run("validates display fields", func(t *testing.T) {
+		f, _, _ := setupTest(t)
+
+		cmd := NewCmdInbox(f)
+		cmd.SetArgs([]string{"--fields", "invalid_field"})
+		cmd.SetOut(&bytes.Buffer{})
+		cmd.SetErr(&bytes.Buffer{})
+
+		err := cmd.Execute()
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "unknown field")
+	})
+
+	t.Run("sorts oldest first", func(t *testing.T) {
+		f, stdout, _ := setupTest(t)
+
+		var sort []interface{}
+		var filter map[string]interface{}
+		httpmock.RegisterResponder("POST", "https://api.test.com/jmap/api",
+			func(req *http.Request) (*http.Response, error) {
+				var jmapReq jmap.Request
+				json.NewDecoder(req.Body).Decode(&jmapReq)
+
+				method := jmapReq.MethodCalls[0][0].(string)
+
+				switch method {
+				case "Mailbox/get":
+					return httpmock.NewJsonResponse(200, map[string]interface{}{
+						"methodResponses": [][]interface{}{
+							{"Mailbox/get", map[string]interface{}{
+								"list": []map[string]interface{}{
+									{"id": "inbox-1", "name": "Inbox", "role": "inbox"},
+								},
+							}, "mailboxes"},
+						},
+					})
+				case "Email/query":
+					args := jmapReq.MethodCalls[0][1].(map[string]interface{})
+					sort = args["sort"].([]interface{})
+					filter = args["filter"].(map[string]interface{})
+					return httpmock.NewJsonResponse(200, map[string]interface{}{
+						"methodResponses": [][]interface{}{
+							{"Email/query", map[string]interface{}{"ids": []string{}}, "query"},
+							{"Email/get", map[string]interface{}{"list": []interface{}{}}, "emails"},
+						},
+					})
+				default:
+					return httpmock.NewStringResponse(400, "unexpected"), nil
+				}
+			})
+
+		cmd := NewCmdInbox(f)
+		cmd.SetArgs([]string{"--oldest-first"})
+		cmd.SetOut(stdout)
+		cmd.SetErr(&bytes.Buffer{})
+
+		err := cmd.Execute()
+
+		require.NoError(t, err)
+		require.Len(t, sort, 1)
+		assert.Equal(t, true, sort[0].(map[string]interface{})["isAscending"])
+		assert.NotContains(t, filter, "after")
+	})
+
+	t.Run("filters by --since", func(t *testing.T) {
+		f, stdout, _ := setupTest(t)
+
+		var sort []interface{}
+		var filter map[string]interface{}
+		httpmock.RegisterResponder("POST", "https://api.test.com/jmap/api",
+			func(req *http.Request) (*http.Response, error) {
+				var jmapReq jmap.Request
+				json.NewDecoder(req.Body).Decode(&jmapReq)
+
+				method := jmapReq.MethodCalls[0][0].(string)
+
+				switch method {
+				case "Mailbox/get":
+					return httpmock.NewJsonResponse(200, map[string]interface{}{
+						"methodResponses": [][]interface{}{
+							{"Mailbox/get", map[string]interface{}{
+								"list": []map[string]interface{}{
+									{"id": "inbox-1", "name": "Inbox", "role": "inbox"},
+								},
+							}, "mailboxes"},
+						},
+					})
+				case "Email/query":
+					args := jmapReq.MethodCalls[0][1].(map[string]interface{})
+					sort = args["sort"].([]interface{})
+					filter = args["filter"].(map[string]interface{})
+					return httpmock.NewJsonResponse(200, map[string]interface{}{
+						"methodResponses": [][]interface{}{
+							{"Email/query", map[string]interface{}{"ids": []string{}}, "query"},
+							{"Email/get", map[string]interface{}{"list": []interface{}{}}, "emails"},
+						},
+					})
+				default:
+					return httpmock.NewStringResponse(400, "unexpected"), nil
+				}
+			})
+
+		cmd := NewCmdInbox(f)
+		cmd.SetArgs([]string{"--since", "7d"})
+		cmd.SetOut(stdout)
+		cmd.SetErr(&bytes.Buffer{})
+
+		err := cmd.Execute()
+
+		require.NoError(t, err)
+		assert.Equal(t, false, sort[0].(map[string]interface{})["isAscending"])
+		assert.Equal(t, "inbox-1", filter["inMailbox"])
+		after, err := time.Parse(time.RFC3339, filter["after"].(string))
+		require.NoError(t, err)
+		assert.WithinDuration(t, time.Now().Add(-7*24*time.Hour), after, time.Minute)
+	})
+
+	t.Run("filters by --unread", func(t *testing.T) {
+		f, stdout, _ := setupTest(t)
+
+		var filter map[string]interface{}
+		httpmock.RegisterResponder("POST", "https://api.test.com/jmap/api",
+			func(req *http.Request) (*http.Response, error) {
+				var jmapReq jmap.Request
+				json.NewDecoder(req.Body).Decode(&jmapReq)
+
+				method := jmapReq.MethodCalls[0][0].(string)
+
+				switch method {
+				case "Mailbox/get":
+					return httpmock.NewJsonResponse(200, map[string]interface{}{
+						"methodResponses": [][]interface{}{
+							{"Mailbox/get", map[string]interface{}{
+								"list": []map[string]interface{}{
+									{"id": "inbox-1", "name": "Inbox", "role": "inbox"},
+								},
+							}, "mailboxes"},
+						},
+					})
+				case "Email/query":
+					args := jmapReq.MethodCalls[0][1].(map[string]interface{})
+					filter = args["filter"].(map[string]interface{})
+					return httpmock.NewJsonResponse(200, map[string]interface{}{
+						"methodResponses": [][]interface{}{
+							{"Email/query", map[string]interface{}{"ids": []string{"email-1"}}, "query"},
+							{"Email/get", map[string]interface{}{
+								"list": []map[string]interface{}{
+									{
+										"id":         "email-1",
+										"subject":    "Needs attention",
+										"from":       []map[string]string{{"name": "Alice", "email": "alice@example.com"}},
+										"receivedAt": time.Now().Add(-1 * time.Hour).Format(time.RFC3339),
+										"keywords":   map[string]bool{},
+									},
+								},
+							}, "emails"},
+						},
+					})
+				default:
+					return httpmock.NewStringResponse(400, "unexpected"), nil
+				}
+			})
+
+		cmd := NewCmdInbox(f)
+		cmd.SetArgs([]string{"--unread", "--fields", "unread,id,subject"})
+		cmd.SetOut(stdout)
+		cmd.SetErr(&bytes.Buffer{})
+
+		err := cmd.Execute()
+
+		require.NoError(t, err)
+		assert.Equal(t, "inbox-1", filter["inMailbox"])
+		assert.Equal(t, "$seen", filter["notKeyword"])
+		output := stdout.String()
+		assert.Contains(t, output, "*")
+		assert.Contains(t, output, "Needs attention")
+		assert.Contains(t, output, "1 unread emails")
+	})
+
+	t.Run("lists another folder with --folder", func(t *testing.T) {
+		mockFolders := func(filter *map[string]interface{}) httpmock.Responder {
+			return func(req *http.Request) (*http.Response, error) {
+				var jmapReq jmap.Request
+				json.NewDecoder(req.Body).Decode(&jmapReq)
+
+				switch jmapReq.MethodCalls[0][0].(string) {
+				case "Mailbox/get":
+					return httpmock.NewJsonResponse(200, map[string]interface{}{
+						"methodResponses": [][]interface{}{
+							{"Mailbox/get", map[string]interface{}{
+								"list": []map[string]interface{}{
+									{"id": "inbox-1", "name": "Inbox", "role": "inbox"},
+									{"id": "priority-1", "name": "Priority"},
+								},
+							}, "mailboxes"},
+						},
+					})
+				case "Email/query":
+					args := jmapReq.MethodCalls[0][1].(map[string]interface{})
+					*filter = args["filter"].(map[string]interface{})
+					return httpmock.NewJsonResponse(200, map[string]interface{}{
+						"methodResponses": [][]interface{}{
+							{"Email/query", map[string]interface{}{"ids": []string{}}, "query"},
+							{"Email/get", map[string]interface{}{"list": []map[string]interface{}{}}, "emails"},
+						},
+					})
+				default:
+					return httpmock.NewStringResponse(400, "unexpected"), nil
+				}
+			}
+		}
+
+		t.Run("from the flag", func(t *testing.T) {
+			f, stdout, _ := setupTest(t)
+			var filter map[string]interface{}
+			httpmock.RegisterResponder("POST", "https://api.test.com/jmap/api", mockFolders(&filter))
+
+			cmd := NewCmdInbox(f)
+			cmd.SetArgs([]string{"--folder", "Priority"})
+			cmd.SetOut(stdout)
+			cmd.SetErr(&bytes.Buffer{})
+
+			err := cmd.Execute()
+
+			require.NoError(t, err)
+			assert.Equal(t, "priority-1", filter["inMailbox"])
+		})
+
+		t.Run("from the config file", func(t *testing.T) {
+			f, stdout, _ := setupTest(t)
+			f.Config = &config.Config{Inbox: config.InboxConfig{DefaultFolder: "Priority"}}
+			var filter map[string]interface{}
+			httpmock.RegisterResponder("POST", "https://api.test.com/jmap/api", mockFolders(&filter))
+
+			cmd := NewCmdInbox(f)
+			cmd.SetArgs([]string{})
+			cmd.SetOut(stdout)
+			cmd.SetErr(&bytes.Buffer{})
+
+			err := cmd.Execute()
+
+			require.NoError(t, err)
+			assert.Equal(t, "priority-1", filter["inMailbox"])
+		})
+
+		t.Run("flag overrides the config file", func(t *testing.T) {
+			f, stdout, _ := setupTest(t)
+			f.Config = &config.Config{Inbox: config.InboxConfig{DefaultFolder: "Priority"}}
+			var filter map[string]interface{}
+			httpmock.RegisterResponder("POST", "https://api.test.com/jmap/api", mockFolders(&filter))
+
+			cmd := NewCmdInbox(f)
+			cmd.SetArgs([]string{"--folder", "inbox"})
+			cmd.SetOut(stdout)
+			cmd.SetErr(&bytes.Buffer{})
+
+			err := cmd.Execute()
+
+			require.NoError(t, err)
+			assert.Equal(t, "inbox-1", filter["inMailbox"])
+		})
+	})
+
+	t.Run("collapses threads with --group-by-thread", func(t *testing.T) {
+		f, stdout, _ := setupTest(t)
+
+		now := time.Now()
+		httpmock.RegisterResponder("POST", "https://api.test.com/jmap/api",
+			func(req *http.Request) (*http.Response, error) {
+				var jmapReq jmap.Request
+				json.NewDecoder(req.Body).Decode(&jmapReq)
+
+				method := jmapReq.MethodCalls[0][0].(string)
+
+				switch method {
+				case "Mailbox/get":
+					return httpmock.NewJsonResponse(200, map[string]interface{}{
+						"methodResponses": [][]interface{}{
+							{"Mailbox/get", map[string]interface{}{
+								"list": []map[string]interface{}{
+									{"id": "inbox-1", "name": "Inbox", "role": "inbox"},
+								},
+							}, "mailboxes"},
+						},
+					})
+				case "Email/query":
+					return httpmock.NewJsonResponse(200, map[string]interface{}{
+						"methodResponses": [][]interface{}{
+							{"Email/query", map[string]interface{}{"ids": []string{"email-3", "email-2", "email-1"}}, "query"},
+							{"Email/get", map[string]interface{}{
+								"list": []map[string]interface{}{
+									{"id": "email-3", "threadId": "thread-a", "subject": "Re: Plans", "receivedAt": now.Add(-1 * time.Hour).Format(time.RFC3339)},
+									{"id": "email-2", "threadId": "thread-b", "subject": "Invoice", "receivedAt": now.Add(-2 * time.Hour).Format(time.RFC3339)},
+									{"id": "email-1", "threadId": "thread-a", "subject": "Plans", "receivedAt": now.Add(-3 * time.Hour).Format(time.RFC3339)},
+								},
+							}, "emails"},
+						},
+					})
+				default:
+					return httpmock.NewStringResponse(400, "unexpected"), nil
+				}
+			})
+
+		cmd := NewCmdInbox(f)
+		cmd.SetArgs([]string{"--group-by-thread", "--fields", "id,subject"})
+		cmd.SetOut(stdout)
+		cmd.SetErr(&bytes.Buffer{})
+
+		err := cmd.Execute()
+
+		require.NoError(t, err)
+		output := stdout.String()
+		assert.Contains(t, output, "(2) Re: Plans")
+		assert.Contains(t, output, "Invoice")
+		assert.NotContains(t, output, "email-1")
+		assert.Less(t, strings.Index(output, "email-3"), strings.Index(output, "email-2"))
+	})
+
+	t.Run("shows only newer emails with --since-id", func(t *testing.T) {
+		// mockSince serves the reference email and an inbox query returning
+		// the given IDs, recording the query arguments.
+		mockSince := func(ids []string, query *map[string]interface{}) httpmock.Responder {
+			return func(req *http.Request) (*http.Response, error) {
+				var jmapReq jmap.Request
+				json.NewDecoder(req.Body).Decode(&jmapReq)
+
+				switch jmapReq.MethodCalls[0][0].(string) {
+				case "Email/get":
+					return httpmock.NewJsonResponse(200, map[string]interface{}{
+						"methodResponses": [][]interface{}{
+							{"Email/get", map[string]interface{}{
+								"list": []map[string]interface{}{
+									{"id": "email-1", "subject": "Seen already", "receivedAt": "2024-01-15T10:00:00Z"},
+								},
+							}, "email"},
+						},
+					})
+				case "Mailbox/get":
+					return httpmock.NewJsonResponse(200, map[string]interface{}{
+						"methodResponses": [][]interface{}{
+							{"Mailbox/get", map[string]interface{}{
+								"list": []map[string]interface{}{
+									{"id": "inbox-1", "name": "Inbox", "role": "inbox"},
+								},
+							}, "mailboxes"},
+						},
+					})
+				case "Email/query":
+					*query = jmapReq.MethodCalls[0][1].(map[string]interface{})
+					var list []map[string]interface{}
+					for _, id := range ids {
+						list = append(list, map[string]interface{}{"id": id, "subject": "Subject of " + id, "receivedAt": "2024-01-15T11:00:00Z"})
+					}
+					return httpmock.NewJsonResponse(200, map[string]interface{}{
+						"methodResponses": [][]interface{}{
+							{"Email/query", map[string]interface{}{"ids": ids}, "query"},
+							{"Email/get", map[string]interface{}{"list": list}, "emails"},
+						},
+					})
+				}
+				return httpmock.NewStringResponse(400, "unexpected"), nil
+			}
+		}
+
+		t.Run("queries after the reference email", func(t *testing.T) {
+			f, stdout, _ := setupTest(t)
+
+			var query map[string]interface{}
+			httpmock.RegisterResponder("POST", "https://api.test.com/jmap/api", mockSince([]string{"email-2", "email-3"}, &query))
+
+			cmd := NewCmdInbox(f)
+			cmd.SetArgs([]string{"--since-id", "email-1", "--limit", "10", "--fields", "id,subject"})
+			cmd.SetOut(stdout)
+			cmd.SetErr(&bytes.Buffer{})
+
+			err := cmd.Execute()
+
+			require.NoError(t, err)
+			assert.Equal(t, "2024-01-15T10:00:00Z", query["filter"].(map[string]interface{})["after"])
+			assert.Equal(t, "email-1", query["anchor"])
+			assert.Equal(t, float64(1), query["anchorOffset"])
+			assert.Equal(t, float64(10), query["limit"])
+			assert.NotContains(t, query, "position")
+			output := stdout.String()
+			assert.NotContains(t, output, "email-1")
+			assert.Less(t, strings.Index(output, "email-3"), strings.Index(output, "email-2"), "newest first")
+			assert.Contains(t, output, "2 emails")
+		})
+
+		t.Run("prints nothing when nothing is newer", func(t *testing.T) {
+			f, stdout, _ := setupTest(t)
+
+			var query map[string]interface{}
+			httpmock.RegisterResponder("POST", "https://api.test.com/jmap/api", mockSince(nil, &query))
+
+			cmd := NewCmdInbox(f)
+			cmd.SetArgs([]string{"--since-id", "email-1"})
+			cmd.SetOut(stdout)
+			cmd.SetErr(&bytes.Buffer{})
+
+			err := cmd.Execute()
+
+			require.NoError(t, err)
+			assert.Empty(t, stdout.String())
+		})
+	})
+
+	t.Run("lists fields with --help-fields", func(t *testing.T) {
+		f, stdout, _ := setupTest(t)
+
+		cmd := NewCmdInbox(f)
+		cmd.SetArgs([]string{"--help-fields"})
+		cmd.SetOut(stdout)
+		cmd.SetErr(&bytes.Buffer{})
+
+		err := cmd.Execute()
+
+		require.NoError(t, err)
+		output := stdout.String()
+		assert.Contains(t, output, "threadId")
+		assert.Contains(t, output, "JSON key receivedAt")
+		assert.Zero(t, httpmock.GetCallCountInfo()["POST https://api.test.com/jmap/api"])
+	})
+
 	t.Run("validates JSON fields", func(t *testing.T) {
 		f, _, stderr := setupTest(t)
 
@@ -298,6 +901,7 @@ func TestInboxCommand_FlagParsing(t *testing.T) {
 		name           string
 		args           []string
 		wantLimit      int
+		wantFields     string
 		wantJSONFields []string
 	}{
 		{
@@ -310,6 +914,11 @@ func TestInboxCommand_FlagParsing(t *testing.T) {
 			args:      []string{"--limit", "10"},
 			wantLimit: 10,
 		},
+		{
+			name:       "display fields",
+			args:       []string{"--fields", "id,subject"},
+			wantFields: "id,subject",
+		},
 		{
 			name:           "json with fields",
 			args:           []string{"--json", "id,subject"},
@@ -337,6 +946,11 @@ func TestInboxCommand_FlagParsing(t *testing.T) {
 				assert.Equal(t, tt.wantLimit, limit)
 			}
 
+			if tt.wantFields != "" {
+				fields, _ := cmd.Flags().GetString("fields")
+				assert.Equal(t, tt.wantFields, fields)
+			}
+
 			if tt.wantJSONFields != nil {
 				jsonFields, _ := cmd.Flags().GetStringSlice("json")
 				assert.Equal(t, tt.wantJSONFields, jsonFields)
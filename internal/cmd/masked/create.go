@@ -0,0 +1,60 @@
+package masked
+
+import (
+	"fmt"
+
+	"github.com/marckohlbrugge/fastmail-cli/internal/cmdutil"
+	"github.com/spf13/cobra"
+)
+
+type createOptions struct {
+	Domain      string
+	Description string
+}
+
+// NewCmdCreate creates the masked create command.
+func NewCmdCreate(f *cmdutil.Factory) *cobra.Command {
+	opts := &createOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "create",
+		Short: "Create a masked email",
+		Long: `Create a new masked email address.
+
+Only the generated address is printed, so it can be piped into other
+commands. Use --domain and --description to keep track of where each
+address is used.`,
+		Example: `  # Create a masked email
+  fm masked create
+
+  # Record where it's used
+  fm masked create --domain example.com --description "Signup for X"
+
+  # Copy the new address to the clipboard (macOS)
+  fm masked create --domain example.com | pbcopy`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runCreate(f, opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.Domain, "domain", "", "Domain the address is for")
+	cmd.Flags().StringVar(&opts.Description, "description", "", "Description of where the address is used")
+
+	return cmd
+}
+
+func runCreate(f *cmdutil.Factory, opts *createOptions) error {
+	client, err := f.JMAPClient()
+	if err != nil {
+		return err
+	}
+
+	masked, err := client.CreateMaskedEmail(opts.Domain, opts.Description)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintln(f.IOStreams.Out, masked.Email)
+	return nil
+}
@@ -0,0 +1,80 @@
+package masked
+
+import (
+	"fmt"
+
+	"github.com/marckohlbrugge/fastmail-cli/internal/cmdutil"
+	"github.com/spf13/cobra"
+)
+
+type deleteOptions struct {
+	Yes    bool
+	Unsafe bool
+}
+
+// NewCmdDelete creates the masked delete command.
+func NewCmdDelete(f *cmdutil.Factory) *cobra.Command {
+	opts := &deleteOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "delete <address-or-id>",
+		Short: "Delete a masked email",
+		Long: `Delete a masked email.
+
+Mail sent to a deleted address bounces. Use 'fm masked disable' to drop
+it silently instead. The masked email can be given by address or by ID.
+
+This action requires confirmation unless --yes is provided.
+In non-interactive mode (scripts, AI), this command is blocked unless --unsafe is specified.`,
+		Example: `  # Delete with confirmation prompt
+  fm masked delete abc123@fastmail.com
+
+  # Delete without confirmation
+  fm masked delete abc123@fastmail.com --yes`,
+		Args: cmdutil.ExactArgs(1, "masked email address or ID required\n\nUsage: fm masked delete <address-or-id>"),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runDelete(f, opts, args[0])
+		},
+	}
+
+	cmd.Flags().BoolVar(&opts.Yes, "yes", false, "Skip confirmation prompt")
+	cmd.Flags().BoolVar(&opts.Unsafe, "unsafe", false, "Allow in non-interactive mode")
+
+	return cmd
+}
+
+func runDelete(f *cmdutil.Factory, opts *deleteOptions, idOrAddress string) error {
+	// Check safe mode
+	if f.IOStreams.IsSafeMode() && !opts.Unsafe {
+		return &cmdutil.SafeModeError{Command: "masked delete"}
+	}
+
+	client, err := f.JMAPClient()
+	if err != nil {
+		return err
+	}
+
+	masked, err := client.FindMaskedEmail(idOrAddress)
+	if err != nil {
+		return err
+	}
+
+	// Require confirmation unless --yes
+	if !opts.Yes && f.IOStreams.IsInteractive() {
+		if f.Quiet {
+			return cmdutil.QuietConfirmError
+		}
+
+		fmt.Fprintf(f.IOStreams.ErrOut, "Masked email: %s\n", masked.Email)
+		if ok, err := cmdutil.Confirm(f.IOStreams, "Delete this masked email? Mail sent to it will bounce."); !ok {
+			return err
+		}
+	}
+
+	if err := client.SetMaskedEmailState(masked.ID, "deleted"); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(f.IOStreams.Out, "Masked email deleted: %s\n", masked.Email)
+	return nil
+}
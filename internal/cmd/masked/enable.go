@@ -0,0 +1,64 @@
+package masked
+
+import (
+	"fmt"
+
+	"github.com/marckohlbrugge/fastmail-cli/internal/cmdutil"
+	"github.com/spf13/cobra"
+)
+
+// NewCmdEnable creates the masked enable command.
+func NewCmdEnable(f *cmdutil.Factory) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "enable <address-or-id>",
+		Short: "Enable a masked email",
+		Long: `Enable a masked email so that it delivers mail again.
+
+The masked email can be given by address or by ID.`,
+		Example: `  fm masked enable abc123@fastmail.com`,
+		Args:    cmdutil.ExactArgs(1, "masked email address or ID required\n\nUsage: fm masked enable <address-or-id>"),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runSetState(f, args[0], "enabled")
+		},
+	}
+
+	return cmd
+}
+
+// NewCmdDisable creates the masked disable command.
+func NewCmdDisable(f *cmdutil.Factory) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "disable <address-or-id>",
+		Short: "Disable a masked email",
+		Long: `Disable a masked email.
+
+Mail sent to a disabled address is silently dropped. Use 'fm masked delete'
+to make it bounce instead. The masked email can be given by address or by ID.`,
+		Example: `  fm masked disable abc123@fastmail.com`,
+		Args:    cmdutil.ExactArgs(1, "masked email address or ID required\n\nUsage: fm masked disable <address-or-id>"),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runSetState(f, args[0], "disabled")
+		},
+	}
+
+	return cmd
+}
+
+func runSetState(f *cmdutil.Factory, idOrAddress, state string) error {
+	client, err := f.JMAPClient()
+	if err != nil {
+		return err
+	}
+
+	masked, err := client.FindMaskedEmail(idOrAddress)
+	if err != nil {
+		return err
+	}
+
+	if err := client.SetMaskedEmailState(masked.ID, state); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(f.IOStreams.Out, "Masked email %s: %s\n", state, masked.Email)
+	return nil
+}
@@ -0,0 +1,96 @@
+package masked
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/marckohlbrugge/fastmail-cli/internal/cmdutil"
+	"github.com/marckohlbrugge/fastmail-cli/internal/jmap"
+	"github.com/spf13/cobra"
+)
+
+type listOptions struct {
+	JSON bool
+}
+
+// NewCmdList creates the masked list command.
+func NewCmdList(f *cmdutil.Factory) *cobra.Command {
+	opts := &listOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List masked emails",
+		Long: `List your masked email addresses.
+
+Each address is shown with its state (enabled, disabled, or pending),
+the domain it was created for, and its description. Deleted addresses
+are not shown.`,
+		Example: `  # List masked emails
+  fm masked list
+
+  # Output as JSON
+  fm masked list --json`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runList(f, opts)
+		},
+	}
+
+	cmd.Flags().BoolVar(&opts.JSON, "json", false, "Output in JSON format")
+
+	return cmd
+}
+
+func runList(f *cmdutil.Factory, opts *listOptions) error {
+	client, err := f.JMAPClient()
+	if err != nil {
+		return err
+	}
+
+	all, err := client.GetMaskedEmails()
+	if err != nil {
+		return err
+	}
+
+	var masked []jmap.MaskedEmail
+	for _, m := range all {
+		if m.State != "deleted" {
+			masked = append(masked, m)
+		}
+	}
+
+	if opts.JSON {
+		return outputJSON(f, masked)
+	}
+
+	return outputHuman(f, masked)
+}
+
+func outputJSON(f *cmdutil.Factory, masked []jmap.MaskedEmail) error {
+	if masked == nil {
+		masked = []jmap.MaskedEmail{}
+	}
+	encoder := json.NewEncoder(f.IOStreams.Out)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(masked)
+}
+
+func outputHuman(f *cmdutil.Factory, masked []jmap.MaskedEmail) error {
+	out := f.IOStreams.Out
+
+	if len(masked) == 0 {
+		fmt.Fprintln(out, "No masked emails found.")
+		return nil
+	}
+
+	for _, m := range masked {
+		domain := m.ForDomain
+		if domain == "" {
+			domain = "-"
+		}
+
+		fmt.Fprintf(out, "%-36s  %-8s  %-24s  %s\n", m.Email, m.State, domain, m.Description)
+	}
+
+	return nil
+}
@@ -0,0 +1,27 @@
+package masked
+
+import (
+	"github.com/marckohlbrugge/fastmail-cli/internal/cmdutil"
+	"github.com/spf13/cobra"
+)
+
+// NewCmdMasked creates the masked email command group.
+func NewCmdMasked(f *cmdutil.Factory) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "masked <command>",
+		Short:   "Manage masked emails",
+		Long:    "View and manage Fastmail masked email addresses.",
+		GroupID: "masked",
+		Example: `  $ fm masked list
+  $ fm masked create --domain example.com --description "Signup for X"
+  $ fm masked disable abc123@fastmail.com`,
+	}
+
+	cmd.AddCommand(NewCmdList(f))
+	cmd.AddCommand(NewCmdCreate(f))
+	cmd.AddCommand(NewCmdEnable(f))
+	cmd.AddCommand(NewCmdDisable(f))
+	cmd.AddCommand(NewCmdDelete(f))
+
+	return cmd
+}
@@ -0,0 +1,363 @@
+package masked
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/marckohlbrugge/fastmail-cli/internal/cmdutil"
+	"github.com/marckohlbrugge/fastmail-cli/internal/iostreams"
+	"github.com/marckohlbrugge/fastmail-cli/internal/jmap"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupTest(t *testing.T) (*cmdutil.Factory, *bytes.Buffer, *bytes.Buffer) {
+	t.Helper()
+
+	httpmock.Activate()
+	t.Cleanup(httpmock.DeactivateAndReset)
+
+	httpmock.RegisterResponder("GET", "https://api.test.com/jmap/session",
+		httpmock.NewJsonResponderOrPanic(200, map[string]interface{}{
+			"apiUrl": "https://api.test.com/jmap/api",
+			"accounts": map[string]interface{}{
+				"account-1": map[string]interface{}{},
+			},
+			"capabilities": map[string]interface{}{
+				jmap.CoreCapability:        map[string]interface{}{},
+				jmap.MaskedEmailCapability: map[string]interface{}{},
+			},
+		}))
+
+	client := jmap.NewClient("test-token")
+	client.SetBaseURL("https://api.test.com")
+
+	ios, _, stdout, stderr := iostreams.Test()
+	f := &cmdutil.Factory{
+		IOStreams: ios,
+	}
+	f.SetJMAPClient(client)
+
+	return f, stdout, stderr
+}
+
+func mockMaskedEmailsResponse(masked []map[string]interface{}) httpmock.Responder {
+	return httpmock.NewJsonResponderOrPanic(200, map[string]interface{}{
+		"methodResponses": [][]interface{}{
+			{"MaskedEmail/get", map[string]interface{}{
+				"list": masked,
+			}, "maskedEmails"},
+		},
+	})
+}
+
+var testMaskedEmails = []map[string]interface{}{
+	{"id": "me-1", "email": "abc123@fastmail.com", "state": "enabled", "forDomain": "shop.example.com", "description": "Shopping"},
+	{"id": "me-2", "email": "def456@fastmail.com", "state": "disabled", "description": "Old newsletter"},
+	{"id": "me-3", "email": "ghi789@fastmail.com", "state": "deleted"},
+}
+
+// List command tests
+
+func TestListCommand(t *testing.T) {
+	t.Run("lists masked emails in human format", func(t *testing.T) {
+		f, stdout, _ := setupTest(t)
+
+		httpmock.RegisterResponder("POST", "https://api.test.com/jmap/api",
+			mockMaskedEmailsResponse(testMaskedEmails))
+
+		cmd := NewCmdList(f)
+		cmd.SetArgs([]string{})
+		cmd.SetOut(stdout)
+		cmd.SetErr(&bytes.Buffer{})
+
+		err := cmd.Execute()
+
+		require.NoError(t, err)
+		output := stdout.String()
+		assert.Contains(t, output, "abc123@fastmail.com")
+		assert.Contains(t, output, "enabled")
+		assert.Contains(t, output, "shop.example.com")
+		assert.Contains(t, output, "Shopping")
+		assert.Contains(t, output, "def456@fastmail.com")
+		assert.Contains(t, output, "disabled")
+		assert.NotContains(t, output, "ghi789@fastmail.com")
+	})
+
+	t.Run("outputs JSON", func(t *testing.T) {
+		f, stdout, _ := setupTest(t)
+
+		httpmock.RegisterResponder("POST", "https://api.test.com/jmap/api",
+			mockMaskedEmailsResponse(testMaskedEmails))
+
+		cmd := NewCmdList(f)
+		cmd.SetArgs([]string{"--json"})
+		cmd.SetOut(stdout)
+		cmd.SetErr(&bytes.Buffer{})
+
+		err := cmd.Execute()
+
+		require.NoError(t, err)
+
+		var result []jmap.MaskedEmail
+		require.NoError(t, json.Unmarshal(stdout.Bytes(), &result))
+		require.Len(t, result, 2)
+		assert.Equal(t, "me-1", result[0].ID)
+		assert.Equal(t, "shop.example.com", result[0].ForDomain)
+	})
+
+	t.Run("declares the masked email capability", func(t *testing.T) {
+		f, stdout, _ := setupTest(t)
+
+		var using []string
+		httpmock.RegisterResponder("POST", "https://api.test.com/jmap/api",
+			func(req *http.Request) (*http.Response, error) {
+				var jmapReq jmap.Request
+				json.NewDecoder(req.Body).Decode(&jmapReq)
+				using = jmapReq.Using
+				return mockMaskedEmailsResponse(nil)(req)
+			})
+
+		cmd := NewCmdList(f)
+		cmd.SetArgs([]string{})
+		cmd.SetOut(stdout)
+		cmd.SetErr(&bytes.Buffer{})
+
+		err := cmd.Execute()
+
+		require.NoError(t, err)
+		assert.Contains(t, using, jmap.MaskedEmailCapability)
+		assert.Contains(t, stdout.String(), "No masked emails found.")
+	})
+}
+
+// Create command tests
+
+func TestCreateCommand(t *testing.T) {
+	t.Run("creates masked email and prints address", func(t *testing.T) {
+		f, stdout, _ := setupTest(t)
+
+		var using []string
+		var capturedCreate map[string]interface{}
+		httpmock.RegisterResponder("POST", "https://api.test.com/jmap/api",
+			func(req *http.Request) (*http.Response, error) {
+				var jmapReq jmap.Request
+				json.NewDecoder(req.Body).Decode(&jmapReq)
+				using = jmapReq.Using
+
+				args := jmapReq.MethodCalls[0][1].(map[string]interface{})
+				create := args["create"].(map[string]interface{})
+				capturedCreate = create["newMasked"].(map[string]interface{})
+
+				return httpmock.NewJsonResponse(200, map[string]interface{}{
+					"methodResponses": [][]interface{}{
+						{"MaskedEmail/set", map[string]interface{}{
+							"created": map[string]interface{}{
+								"newMasked": map[string]interface{}{
+									"id":    "me-new",
+									"email": "xyz987@fastmail.com",
+									"state": "enabled",
+								},
+							},
+						}, "createMasked"},
+					},
+				})
+			})
+
+		cmd := NewCmdCreate(f)
+		cmd.SetArgs([]string{"--domain", "example.com", "--description", "Signup for X"})
+		cmd.SetOut(stdout)
+		cmd.SetErr(&bytes.Buffer{})
+
+		err := cmd.Execute()
+
+		require.NoError(t, err)
+		assert.Equal(t, "xyz987@fastmail.com\n", stdout.String())
+		assert.Contains(t, using, jmap.MaskedEmailCapability)
+		assert.Equal(t, "enabled", capturedCreate["state"])
+		assert.Equal(t, "example.com", capturedCreate["forDomain"])
+		assert.Equal(t, "Signup for X", capturedCreate["description"])
+	})
+
+	t.Run("surfaces notCreated errors", func(t *testing.T) {
+		f, stdout, _ := setupTest(t)
+
+		httpmock.RegisterResponder("POST", "https://api.test.com/jmap/api",
+			httpmock.NewJsonResponderOrPanic(200, map[string]interface{}{
+				"methodResponses": [][]interface{}{
+					{"MaskedEmail/set", map[string]interface{}{
+						"notCreated": map[string]interface{}{
+							"newMasked": map[string]interface{}{
+								"type":        "rateLimit",
+								"description": "Too many masked emails created",
+							},
+						},
+					}, "createMasked"},
+				},
+			}))
+
+		cmd := NewCmdCreate(f)
+		cmd.SetArgs([]string{})
+		cmd.SetOut(&bytes.Buffer{})
+		cmd.SetErr(&bytes.Buffer{})
+
+		err := cmd.Execute()
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "Too many masked emails created")
+		assert.Empty(t, stdout.String())
+	})
+}
+
+// mockMaskedSetResponder serves MaskedEmail/get from testMaskedEmails and
+// records the update patch sent with MaskedEmail/set.
+func mockMaskedSetResponder(captured *map[string]interface{}, setResult map[string]interface{}) httpmock.Responder {
+	return func(req *http.Request) (*http.Response, error) {
+		var jmapReq jmap.Request
+		json.NewDecoder(req.Body).Decode(&jmapReq)
+
+		switch jmapReq.MethodCalls[0][0].(string) {
+		case "MaskedEmail/get":
+			return mockMaskedEmailsResponse(testMaskedEmails)(req)
+		case "MaskedEmail/set":
+			args := jmapReq.MethodCalls[0][1].(map[string]interface{})
+			*captured = args["update"].(map[string]interface{})
+			return httpmock.NewJsonResponse(200, map[string]interface{}{
+				"methodResponses": [][]interface{}{
+					{"MaskedEmail/set", setResult, "setMaskedState"},
+				},
+			})
+		default:
+			return httpmock.NewStringResponse(400, "unexpected"), nil
+		}
+	}
+}
+
+// Enable/disable command tests
+
+func TestEnableDisableCommands(t *testing.T) {
+	t.Run("disables by address", func(t *testing.T) {
+		f, stdout, _ := setupTest(t)
+
+		var captured map[string]interface{}
+		httpmock.RegisterResponder("POST", "https://api.test.com/jmap/api",
+			mockMaskedSetResponder(&captured, map[string]interface{}{
+				"updated": map[string]interface{}{"me-1": nil},
+			}))
+
+		cmd := NewCmdDisable(f)
+		cmd.SetArgs([]string{"ABC123@fastmail.com"})
+		cmd.SetOut(stdout)
+		cmd.SetErr(&bytes.Buffer{})
+
+		err := cmd.Execute()
+
+		require.NoError(t, err)
+		assert.Equal(t, map[string]interface{}{"me-1": map[string]interface{}{"state": "disabled"}}, captured)
+		assert.Contains(t, stdout.String(), "Masked email disabled: abc123@fastmail.com")
+	})
+
+	t.Run("enables by ID", func(t *testing.T) {
+		f, stdout, _ := setupTest(t)
+
+		var captured map[string]interface{}
+		httpmock.RegisterResponder("POST", "https://api.test.com/jmap/api",
+			mockMaskedSetResponder(&captured, map[string]interface{}{
+				"updated": map[string]interface{}{"me-2": nil},
+			}))
+
+		cmd := NewCmdEnable(f)
+		cmd.SetArgs([]string{"me-2"})
+		cmd.SetOut(stdout)
+		cmd.SetErr(&bytes.Buffer{})
+
+		err := cmd.Execute()
+
+		require.NoError(t, err)
+		assert.Equal(t, map[string]interface{}{"me-2": map[string]interface{}{"state": "enabled"}}, captured)
+	})
+
+	t.Run("reports notUpdated errors", func(t *testing.T) {
+		f, _, _ := setupTest(t)
+
+		var captured map[string]interface{}
+		httpmock.RegisterResponder("POST", "https://api.test.com/jmap/api",
+			mockMaskedSetResponder(&captured, map[string]interface{}{
+				"notUpdated": map[string]interface{}{
+					"me-1": map[string]interface{}{"type": "forbidden", "description": "Cannot change state"},
+				},
+			}))
+
+		cmd := NewCmdDisable(f)
+		cmd.SetArgs([]string{"me-1"})
+		cmd.SetOut(&bytes.Buffer{})
+		cmd.SetErr(&bytes.Buffer{})
+
+		err := cmd.Execute()
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "Cannot change state")
+	})
+
+	t.Run("errors when not found", func(t *testing.T) {
+		f, _, _ := setupTest(t)
+
+		var captured map[string]interface{}
+		httpmock.RegisterResponder("POST", "https://api.test.com/jmap/api",
+			mockMaskedSetResponder(&captured, nil))
+
+		cmd := NewCmdDisable(f)
+		cmd.SetArgs([]string{"nobody@fastmail.com"})
+		cmd.SetOut(&bytes.Buffer{})
+		cmd.SetErr(&bytes.Buffer{})
+
+		err := cmd.Execute()
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "not found")
+		assert.Nil(t, captured)
+	})
+}
+
+// Delete command tests
+
+func TestDeleteCommand(t *testing.T) {
+	t.Run("deletes with --yes --unsafe", func(t *testing.T) {
+		f, stdout, _ := setupTest(t)
+
+		var captured map[string]interface{}
+		httpmock.RegisterResponder("POST", "https://api.test.com/jmap/api",
+			mockMaskedSetResponder(&captured, map[string]interface{}{
+				"updated": map[string]interface{}{"me-1": nil},
+			}))
+
+		cmd := NewCmdDelete(f)
+		cmd.SetArgs([]string{"abc123@fastmail.com", "--yes", "--unsafe"})
+		cmd.SetOut(stdout)
+		cmd.SetErr(&bytes.Buffer{})
+
+		err := cmd.Execute()
+
+		require.NoError(t, err)
+		assert.Equal(t, map[string]interface{}{"me-1": map[string]interface{}{"state": "deleted"}}, captured)
+		assert.Contains(t, stdout.String(), "Masked email deleted: abc123@fastmail.com")
+	})
+
+	t.Run("blocks in safe mode without --unsafe", func(t *testing.T) {
+		f, _, _ := setupTest(t)
+
+		cmd := NewCmdDelete(f)
+		cmd.SetArgs([]string{"abc123@fastmail.com", "--yes"})
+		cmd.SetOut(&bytes.Buffer{})
+		cmd.SetErr(&bytes.Buffer{})
+
+		err := cmd.Execute()
+
+		require.Error(t, err)
+		var safeModeErr *cmdutil.SafeModeError
+		assert.ErrorAs(t, err, &safeModeErr)
+	})
+}
@@ -0,0 +1,128 @@
+package quota
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/marckohlbrugge/fastmail-cli/internal/cmdutil"
+	"github.com/marckohlbrugge/fastmail-cli/internal/iostreams"
+	"github.com/marckohlbrugge/fastmail-cli/internal/jmap"
+	"github.com/spf13/cobra"
+)
+
+const barWidth = 30
+
+type quotaOptions struct {
+	JSON bool
+}
+
+// NewCmdQuota creates the quota command.
+func NewCmdQuota(f *cmdutil.Factory) *cobra.Command {
+	opts := &quotaOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "quota",
+		Short: "Show storage usage",
+		Long:  "Show how much of your storage quota is in use.",
+		Example: `  # Show storage usage
+  fm quota
+
+  # Output as JSON
+  fm quota --json`,
+		GroupID: "utility",
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runQuota(f, opts)
+		},
+	}
+
+	cmd.Flags().BoolVar(&opts.JSON, "json", false, "Output in JSON format")
+
+	return cmd
+}
+
+func runQuota(f *cmdutil.Factory, opts *quotaOptions) error {
+	client, err := f.JMAPClient()
+	if err != nil {
+		return err
+	}
+
+	quotas, err := client.GetQuotas()
+	if err != nil {
+		return err
+	}
+
+	if opts.JSON {
+		if quotas == nil {
+			quotas = []jmap.Quota{}
+		}
+		encoder := json.NewEncoder(f.IOStreams.Out)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(quotas)
+	}
+
+	out := f.IOStreams.Out
+
+	if len(quotas) == 0 {
+		fmt.Fprintln(out, "No quotas found.")
+		return nil
+	}
+
+	for i, q := range quotas {
+		if i > 0 {
+			fmt.Fprintln(out)
+		}
+		printQuota(out, q, f.IOStreams.ColorScheme())
+	}
+
+	return nil
+}
+
+func printQuota(out io.Writer, q jmap.Quota, cs *iostreams.ColorScheme) {
+	name := q.Name
+	if name == "" {
+		name = "Storage"
+	}
+	fmt.Fprintf(out, "%s (%s)\n", name, q.Scope)
+
+	format := func(n int64) string {
+		if q.ResourceType == "octets" {
+			return cmdutil.FormatBytes(n)
+		}
+		return fmt.Sprintf("%d", n)
+	}
+
+	if q.HardLimit <= 0 {
+		fmt.Fprintf(out, "  %s used\n", format(q.Used))
+		return
+	}
+
+	percent := float64(q.Used) / float64(q.HardLimit) * 100
+	fmt.Fprintf(out, "  %s of %s used (%.0f%%)\n", format(q.Used), format(q.HardLimit), percent)
+	fmt.Fprintf(out, "  %s\n", usageBar(percent, cs))
+}
+
+// usageBar renders a fixed-width bar, colored by how full it is.
+func usageBar(percent float64, cs *iostreams.ColorScheme) string {
+	filled := int(percent / 100 * barWidth)
+	if filled > barWidth {
+		filled = barWidth
+	}
+	if filled < 0 {
+		filled = 0
+	}
+
+	bar := strings.Repeat("█", filled)
+	switch {
+	case percent >= 90:
+		bar = cs.Red(bar)
+	case percent >= 75:
+		bar = cs.Yellow(bar)
+	default:
+		bar = cs.Green(bar)
+	}
+
+	return "[" + bar + strings.Repeat("░", barWidth-filled) + "]"
+}
@@ -0,0 +1,147 @@
+package quota
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/marckohlbrugge/fastmail-cli/internal/cmdutil"
+	"github.com/marckohlbrugge/fastmail-cli/internal/iostreams"
+	"github.com/marckohlbrugge/fastmail-cli/internal/jmap"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupTest(t *testing.T, capabilities map[string]interface{}) (*cmdutil.Factory, *bytes.Buffer, *bytes.Buffer) {
+	t.Helper()
+
+	httpmock.Activate()
+	t.Cleanup(httpmock.DeactivateAndReset)
+
+	httpmock.RegisterResponder("GET", "https://api.test.com/jmap/session",
+		httpmock.NewJsonResponderOrPanic(200, map[string]interface{}{
+			"apiUrl": "https://api.test.com/jmap/api",
+			"accounts": map[string]interface{}{
+				"account-1": map[string]interface{}{},
+			},
+			"capabilities": capabilities,
+		}))
+
+	client := jmap.NewClient("test-token")
+	client.SetBaseURL("https://api.test.com")
+
+	ios, _, stdout, stderr := iostreams.Test()
+	f := &cmdutil.Factory{
+		IOStreams: ios,
+	}
+	f.SetJMAPClient(client)
+
+	return f, stdout, stderr
+}
+
+var quotaCapabilities = map[string]interface{}{
+	jmap.CoreCapability:  map[string]interface{}{},
+	jmap.QuotaCapability: map[string]interface{}{},
+}
+
+func mockQuotaResponse() httpmock.Responder {
+	return httpmock.NewJsonResponderOrPanic(200, map[string]interface{}{
+		"methodResponses": [][]interface{}{
+			{"Quota/get", map[string]interface{}{
+				"list": []map[string]interface{}{
+					{
+						"id":           "q-1",
+						"name":         "Mail storage",
+						"resourceType": "octets",
+						"scope":        "account",
+						"used":         3 * 1024 * 1024 * 1024,
+						"hardLimit":    30 * 1024 * 1024 * 1024,
+					},
+				},
+			}, "quotas"},
+		},
+	})
+}
+
+func TestQuotaCommand(t *testing.T) {
+	t.Run("shows usage with bar", func(t *testing.T) {
+		f, stdout, _ := setupTest(t, quotaCapabilities)
+
+		httpmock.RegisterResponder("POST", "https://api.test.com/jmap/api", mockQuotaResponse())
+
+		cmd := NewCmdQuota(f)
+		cmd.SetArgs([]string{})
+		cmd.SetOut(stdout)
+		cmd.SetErr(&bytes.Buffer{})
+
+		err := cmd.Execute()
+
+		require.NoError(t, err)
+		output := stdout.String()
+		assert.Contains(t, output, "Mail storage (account)")
+		assert.Contains(t, output, "3.0 GB of 30.0 GB used (10%)")
+		assert.Contains(t, output, "[███░░░")
+		assert.NotContains(t, output, "\033[")
+	})
+
+	t.Run("colors bar when color is enabled", func(t *testing.T) {
+		f, stdout, _ := setupTest(t, quotaCapabilities)
+		f.IOStreams.SetColorEnabled(true)
+
+		httpmock.RegisterResponder("POST", "https://api.test.com/jmap/api", mockQuotaResponse())
+
+		cmd := NewCmdQuota(f)
+		cmd.SetArgs([]string{})
+		cmd.SetOut(stdout)
+		cmd.SetErr(&bytes.Buffer{})
+
+		err := cmd.Execute()
+
+		require.NoError(t, err)
+		assert.Contains(t, stdout.String(), "\033[32m")
+	})
+
+	t.Run("outputs JSON", func(t *testing.T) {
+		f, stdout, _ := setupTest(t, quotaCapabilities)
+
+		httpmock.RegisterResponder("POST", "https://api.test.com/jmap/api", mockQuotaResponse())
+
+		cmd := NewCmdQuota(f)
+		cmd.SetArgs([]string{"--json"})
+		cmd.SetOut(stdout)
+		cmd.SetErr(&bytes.Buffer{})
+
+		err := cmd.Execute()
+
+		require.NoError(t, err)
+
+		var result []jmap.Quota
+		require.NoError(t, json.Unmarshal(stdout.Bytes(), &result))
+		require.Len(t, result, 1)
+		assert.Equal(t, int64(30*1024*1024*1024), result[0].HardLimit)
+	})
+
+	t.Run("reports missing quota capability", func(t *testing.T) {
+		f, _, _ := setupTest(t, map[string]interface{}{
+			jmap.CoreCapability: map[string]interface{}{},
+		})
+
+		cmd := NewCmdQuota(f)
+		cmd.SetArgs([]string{})
+		cmd.SetOut(&bytes.Buffer{})
+		cmd.SetErr(&bytes.Buffer{})
+
+		err := cmd.Execute()
+
+		assert.ErrorIs(t, err, jmap.ErrQuotaUnavailable)
+	})
+}
+
+func TestUsageBar(t *testing.T) {
+	assert.Equal(t, "["+strings.Repeat("░", barWidth)+"]", usageBar(0, iostreams.NewColorScheme(false)))
+	assert.Equal(t, "["+strings.Repeat("█", barWidth)+"]", usageBar(120, iostreams.NewColorScheme(false)))
+	assert.Contains(t, usageBar(80, iostreams.NewColorScheme(true)), "\033[33m")
+	assert.Contains(t, usageBar(95, iostreams.NewColorScheme(true)), "\033[31m")
+}
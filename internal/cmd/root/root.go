@@ -1,13 +1,16 @@
 package root
 
 import (
+	"errors"
 	"fmt"
 	"io"
 	"os"
 	"strings"
 
 	"github.com/marckohlbrugge/fastmail-cli/internal/cmd/auth"
+	"github.com/marckohlbrugge/fastmail-cli/internal/cmd/blocklist"
 	"github.com/marckohlbrugge/fastmail-cli/internal/cmd/completion"
+	"github.com/marckohlbrugge/fastmail-cli/internal/cmd/contacts"
 	"github.com/marckohlbrugge/fastmail-cli/internal/cmd/draft"
 	"github.com/marckohlbrugge/fastmail-cli/internal/cmd/email"
 	"github.com/marckohlbrugge/fastmail-cli/internal/cmd/folder"
@@ -15,14 +18,26 @@ import (
 	"github.com/marckohlbrugge/fastmail-cli/internal/cmd/identities"
 	"github.com/marckohlbrugge/fastmail-cli/internal/cmd/identity"
 	"github.com/marckohlbrugge/fastmail-cli/internal/cmd/inbox"
+	"github.com/marckohlbrugge/fastmail-cli/internal/cmd/masked"
+	"github.com/marckohlbrugge/fastmail-cli/internal/cmd/quota"
 	"github.com/marckohlbrugge/fastmail-cli/internal/cmd/search"
+	"github.com/marckohlbrugge/fastmail-cli/internal/cmd/sieve"
+	"github.com/marckohlbrugge/fastmail-cli/internal/cmd/snippet"
+	"github.com/marckohlbrugge/fastmail-cli/internal/cmd/stats"
+	"github.com/marckohlbrugge/fastmail-cli/internal/cmd/vacation"
 	"github.com/marckohlbrugge/fastmail-cli/internal/cmd/version"
+	"github.com/marckohlbrugge/fastmail-cli/internal/cmd/whoami"
 	"github.com/marckohlbrugge/fastmail-cli/internal/cmdutil"
+	"github.com/marckohlbrugge/fastmail-cli/internal/jmap"
 	"github.com/spf13/cobra"
 )
 
-// Version is set at build time
-var Version = "dev"
+// Build metadata, set at build time with -ldflags
+var (
+	Version = "dev"
+	Commit  = "none"
+	Date    = "unknown"
+)
 
 // NewCmdRoot creates the root command for the CLI.
 func NewCmdRoot(f *cmdutil.Factory) *cobra.Command {
@@ -51,8 +66,17 @@ func NewCmdRoot(f *cmdutil.Factory) *cobra.Command {
 
 	// Global flags
 	cmd.PersistentFlags().Bool("help", false, "Show help for command")
+	cmd.PersistentFlags().String("profile", "", "Use the named authentication `profile`")
+	cmd.PersistentFlags().String("output", "", "Output `format`: table, json, jsonl, csv, or tsv")
+	cmd.PersistentFlags().String("date-format", "", "Date `format`: relative, iso, rfc822, or a Go time layout")
+	cmd.PersistentFlags().Duration("timeout", jmap.DefaultTimeout, "Abort API requests that take longer than `duration`")
+	cmd.PersistentFlags().BoolP("quiet", "q", false, "Suppress summary lines and confirmation prompts")
 	cmd.Flags().BoolP("version", "v", false, "Show fm version")
 
+	cmd.PersistentPreRunE = func(c *cobra.Command, args []string) error {
+		return applyGlobalFlags(f, c)
+	}
+
 	// Add command groups
 	cmd.AddGroup(&cobra.Group{
 		ID:    "auth",
@@ -78,6 +102,18 @@ func NewCmdRoot(f *cmdutil.Factory) *cobra.Command {
 		ID:    "identity",
 		Title: "Identity commands",
 	})
+	cmd.AddGroup(&cobra.Group{
+		ID:    "contacts",
+		Title: "Contact commands",
+	})
+	cmd.AddGroup(&cobra.Group{
+		ID:    "masked",
+		Title: "Masked email commands",
+	})
+	cmd.AddGroup(&cobra.Group{
+		ID:    "settings",
+		Title: "Settings commands",
+	})
 	cmd.AddGroup(&cobra.Group{
 		ID:    "utility",
 		Title: "Utility commands",
@@ -85,11 +121,13 @@ func NewCmdRoot(f *cmdutil.Factory) *cobra.Command {
 
 	// Auth commands
 	cmd.AddCommand(auth.NewCmdAuth(f))
+	cmd.AddCommand(whoami.NewCmdWhoami(f))
 
 	// Core commands (top-level)
 	cmd.AddCommand(inbox.NewCmdInbox(f))
 	cmd.AddCommand(search.NewCmdSearch(f))
 	cmd.AddCommand(folders.NewCmdFolders(f))
+	cmd.AddCommand(stats.NewCmdStats(f))
 	cmd.AddCommand(identities.NewCmdIdentities(f))
 
 	// Email subcommands
@@ -97,6 +135,7 @@ func NewCmdRoot(f *cmdutil.Factory) *cobra.Command {
 
 	// Draft subcommands
 	cmd.AddCommand(draft.NewCmdDraft(f))
+	cmd.AddCommand(snippet.NewCmdSnippet(f))
 
 	// Folder subcommands
 	cmd.AddCommand(folder.NewCmdFolder(f))
@@ -104,13 +143,70 @@ func NewCmdRoot(f *cmdutil.Factory) *cobra.Command {
 	// Identity subcommands
 	cmd.AddCommand(identity.NewCmdIdentity(f))
 
+	// Contact subcommands
+	cmd.AddCommand(contacts.NewCmdContacts(f))
+
+	// Masked email subcommands
+	cmd.AddCommand(masked.NewCmdMasked(f))
+
+	// Settings commands
+	cmd.AddCommand(vacation.NewCmdVacation(f))
+	cmd.AddCommand(sieve.NewCmdSieve(f))
+	cmd.AddCommand(blocklist.NewCmdBlocklist(f))
+
 	// Utility commands
-	cmd.AddCommand(version.NewCmdVersion(f, Version))
+	cmd.AddCommand(quota.NewCmdQuota(f))
+	cmd.AddCommand(version.NewCmdVersion(f, version.BuildInfo{
+		Version: Version,
+		Commit:  Commit,
+		Date:    Date,
+	}))
 	cmd.AddCommand(completion.NewCmdCompletion(f))
 
 	return cmd
 }
 
+// applyGlobalFlags configures the factory from persistent root flags.
+func applyGlobalFlags(f *cmdutil.Factory, cmd *cobra.Command) error {
+	if err := f.ConfigError(); err != nil {
+		return err
+	}
+
+	if flag := cmd.Flags().Lookup("profile"); flag != nil && flag.Changed {
+		if err := f.SetProfile(flag.Value.String()); err != nil {
+			return cmdutil.FlagErrorWrap(err)
+		}
+	}
+
+	if flag := cmd.Flags().Lookup("output"); flag != nil && flag.Changed {
+		if err := f.SetOutputFormat(flag.Value.String()); err != nil {
+			return cmdutil.FlagErrorWrap(err)
+		}
+	}
+
+	if flag := cmd.Flags().Lookup("date-format"); flag != nil && flag.Changed {
+		if err := f.SetDateFormat(flag.Value.String()); err != nil {
+			return cmdutil.FlagErrorWrap(err)
+		}
+	}
+
+	if flag := cmd.Flags().Lookup("timeout"); flag != nil && flag.Changed {
+		timeout, err := cmd.Flags().GetDuration("timeout")
+		if err != nil {
+			return cmdutil.FlagErrorWrap(err)
+		}
+		if err := f.SetTimeout(timeout); err != nil {
+			return cmdutil.FlagErrorWrap(err)
+		}
+	}
+
+	if flag := cmd.Flags().Lookup("quiet"); flag != nil && flag.Changed {
+		f.Quiet = flag.Value.String() == "true"
+	}
+
+	return nil
+}
+
 // rootHelpFunc provides custom help output similar to gh CLI
 func rootHelpFunc(w io.Writer, cmd *cobra.Command, args []string) {
 	if isRootCmd(cmd) {
@@ -185,8 +281,13 @@ func printRootHelp(w io.Writer, cmd *cobra.Command) {
 
 	// Print flags
 	fmt.Fprintln(w, "FLAGS")
-	fmt.Fprintln(w, "  -h, --help      Show help for command")
-	fmt.Fprintln(w, "  -v, --version   Show fm version")
+	fmt.Fprintln(w, "  -h, --help        Show help for command")
+	fmt.Fprintln(w, "  -v, --version     Show fm version")
+	fmt.Fprintln(w, "  --profile NAME    Use the named authentication profile")
+	fmt.Fprintln(w, "  --output FORMAT   Output format: table, json, jsonl, csv, or tsv")
+	fmt.Fprintln(w, "  --date-format FMT relative, iso, rfc822, or a Go time layout")
+	fmt.Fprintln(w, "  --timeout DUR     Abort API requests after DUR (default 60s)")
+	fmt.Fprintln(w, "  -q, --quiet       Suppress summary lines and confirmation prompts")
 	fmt.Fprintln(w)
 
 	// Print examples
@@ -205,9 +306,13 @@ func printRootHelp(w io.Writer, cmd *cobra.Command) {
 	fmt.Fprintln(w)
 
 	fmt.Fprintln(w, "ENVIRONMENT")
-	fmt.Fprintln(w, "  FASTMAIL_TOKEN  API token (overrides stored credentials)")
-	fmt.Fprintln(w, "  FM_UNSAFE=1     Allow destructive operations in non-interactive mode")
-	fmt.Fprintln(w, "  NO_COLOR        Disable color output")
+	fmt.Fprintln(w, "  FM_CONFIG            Path to the config file (default: ~/.config/fm/config.yml)")
+	fmt.Fprintln(w, "  FASTMAIL_TOKEN       API token (overrides stored credentials)")
+	fmt.Fprintln(w, "  FASTMAIL_TOKEN_FILE  File containing the API token")
+	fmt.Fprintln(w, "  FM_TOKEN_CMD         Command whose output is used as the API token")
+	fmt.Fprintln(w, "  FM_PROFILE           Authentication profile to use (default: default)")
+	fmt.Fprintln(w, "  FM_UNSAFE=1          Allow destructive operations in non-interactive mode")
+	fmt.Fprintln(w, "  NO_COLOR             Disable color output")
 }
 
 func getCommandsInGroup(cmd *cobra.Command, groupID string) []*cobra.Command {
@@ -226,36 +331,61 @@ func rootUsageFunc(w io.Writer, cmd *cobra.Command) error {
 	return nil
 }
 
+// methodErrorExitCode maps a JMAP method error to an exit code matching the
+// equivalent local error: forbidden like an auth error, notFound like a
+// missing resource.
+func methodErrorExitCode(e *jmap.MethodError) int {
+	switch e.Type {
+	case "forbidden", "accountNotFound", "accountReadOnly":
+		return 2
+	case "notFound":
+		return 3
+	default:
+		return 1
+	}
+}
+
 // Execute runs the root command
 func Execute() int {
 	f := cmdutil.NewFactory()
 	rootCmd := NewCmdRoot(f)
 
 	if err := rootCmd.Execute(); err != nil {
-		// Handle different error types
-		switch e := err.(type) {
-		case *cmdutil.FlagError:
-			fmt.Fprintf(os.Stderr, "Error: %s\n", e.Error())
-			return 1
-		case *cmdutil.SafeModeError:
-			fmt.Fprintf(os.Stderr, "Error: %s\n", e.Error())
-			return 1
-		case *cmdutil.AuthError:
-			fmt.Fprintf(os.Stderr, "Authentication error: %s\n", e.Error())
-			return 2
-		case *cmdutil.NotFoundError:
-			fmt.Fprintf(os.Stderr, "Error: %s\n", e.Error())
-			return 3
-		default:
-			if err == cmdutil.SilentError {
-				return 1
-			}
-			if err == cmdutil.CancelError {
-				return 0
-			}
-			fmt.Fprintf(os.Stderr, "Error: %s\n", err.Error())
+		return handleError(os.Stderr, err)
+	}
+	return 0
+}
+
+// handleError reports err on w and returns the exit code for it.
+func handleError(w io.Writer, err error) int {
+	// Handle different error types
+	switch e := err.(type) {
+	case *cmdutil.FlagError:
+		fmt.Fprintf(w, "Error: %s\n", e.Error())
+		return 1
+	case *cmdutil.SafeModeError:
+		fmt.Fprintf(w, "Error: %s\n", e.Error())
+		return 1
+	case *cmdutil.AuthError:
+		fmt.Fprintf(w, "Authentication error: %s\n", e.Error())
+		return 2
+	case *cmdutil.NotFoundError:
+		fmt.Fprintf(w, "Error: %s\n", e.Error())
+		return 3
+	default:
+		if err == cmdutil.SilentError {
 			return 1
 		}
+		if err == cmdutil.CancelError {
+			return 0
+		}
+		fmt.Fprintf(w, "Error: %s\n", err.Error())
+
+		// Method errors are often wrapped with context
+		var methodErr *jmap.MethodError
+		if errors.As(err, &methodErr) {
+			return methodErrorExitCode(methodErr)
+		}
+		return 1
 	}
-	return 0
 }
@@ -2,10 +2,13 @@ package root
 
 import (
 	"bytes"
+	"fmt"
 	"testing"
+	"time"
 
 	"github.com/marckohlbrugge/fastmail-cli/internal/cmdutil"
 	"github.com/marckohlbrugge/fastmail-cli/internal/iostreams"
+	"github.com/marckohlbrugge/fastmail-cli/internal/jmap"
 	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -232,6 +235,20 @@ func TestExecuteErrorHandling(t *testing.T) {
 		assert.True(t, ok)
 	})
 
+	t.Run("MethodError maps its type to an exit code", func(t *testing.T) {
+		assert.Equal(t, 2, methodErrorExitCode(&jmap.MethodError{Type: "forbidden"}))
+		assert.Equal(t, 3, methodErrorExitCode(&jmap.MethodError{Type: "notFound"}))
+		assert.Equal(t, 1, methodErrorExitCode(&jmap.MethodError{Type: "serverFail"}))
+	})
+
+	t.Run("wrapped MethodError keeps its exit code", func(t *testing.T) {
+		var stderr bytes.Buffer
+		err := fmt.Errorf("failed to send: %w", &jmap.MethodError{Type: "forbidden", Description: "no access"})
+
+		assert.Equal(t, 2, handleError(&stderr, err))
+		assert.Equal(t, "Error: "+err.Error()+"\n", stderr.String())
+	})
+
 	t.Run("SilentError is recognized", func(t *testing.T) {
 		assert.Equal(t, cmdutil.SilentError, cmdutil.SilentError)
 	})
@@ -275,3 +292,95 @@ func TestSuggestions(t *testing.T) {
 	// Verify suggestion distance is set
 	assert.Equal(t, 2, cmd.SuggestionsMinimumDistance)
 }
+
+func TestOutputFlag(t *testing.T) {
+	t.Run("sets the factory output format", func(t *testing.T) {
+		ios, _, _, _ := iostreams.Test()
+		f := &cmdutil.Factory{IOStreams: ios}
+
+		cmd := NewCmdRoot(f)
+		require.NoError(t, cmd.ParseFlags([]string{"--output", "csv"}))
+		require.NoError(t, applyGlobalFlags(f, cmd))
+
+		assert.Equal(t, cmdutil.OutputCSV, f.OutputFormat(false))
+		assert.Equal(t, cmdutil.OutputJSON, f.OutputFormat(true), "--json wins over --output")
+	})
+
+	t.Run("rejects unknown formats", func(t *testing.T) {
+		ios, _, _, _ := iostreams.Test()
+		f := &cmdutil.Factory{IOStreams: ios}
+
+		cmd := NewCmdRoot(f)
+		require.NoError(t, cmd.ParseFlags([]string{"--output", "xml"}))
+		err := applyGlobalFlags(f, cmd)
+
+		require.Error(t, err)
+		var flagErr *cmdutil.FlagError
+		assert.ErrorAs(t, err, &flagErr)
+		assert.Contains(t, err.Error(), "invalid output format")
+	})
+}
+
+func TestQuietFlag(t *testing.T) {
+	ios, _, _, _ := iostreams.Test()
+	f := &cmdutil.Factory{IOStreams: ios}
+
+	cmd := NewCmdRoot(f)
+	require.NoError(t, cmd.ParseFlags([]string{"-q"}))
+	require.NoError(t, applyGlobalFlags(f, cmd))
+
+	assert.True(t, f.Quiet)
+}
+
+func TestDateFormatFlag(t *testing.T) {
+	t.Run("sets the factory date format", func(t *testing.T) {
+		ios, _, _, _ := iostreams.Test()
+		f := &cmdutil.Factory{IOStreams: ios}
+
+		cmd := NewCmdRoot(f)
+		require.NoError(t, cmd.ParseFlags([]string{"--date-format", "iso"}))
+		require.NoError(t, applyGlobalFlags(f, cmd))
+
+		date := time.Date(2024, 3, 5, 14, 30, 0, 0, time.UTC)
+		assert.Equal(t, "2024-03-05T14:30:00Z", f.FormatDate(date, "Jan 2"))
+	})
+
+	t.Run("rejects invalid formats", func(t *testing.T) {
+		ios, _, _, _ := iostreams.Test()
+		f := &cmdutil.Factory{IOStreams: ios}
+
+		cmd := NewCmdRoot(f)
+		require.NoError(t, cmd.ParseFlags([]string{"--date-format", "yesterday"}))
+		err := applyGlobalFlags(f, cmd)
+
+		require.Error(t, err)
+		var flagErr *cmdutil.FlagError
+		assert.ErrorAs(t, err, &flagErr)
+		assert.Contains(t, err.Error(), "invalid date format")
+	})
+}
+
+func TestTimeoutFlag(t *testing.T) {
+	t.Run("accepts a duration", func(t *testing.T) {
+		ios, _, _, _ := iostreams.Test()
+		f := &cmdutil.Factory{IOStreams: ios}
+
+		cmd := NewCmdRoot(f)
+		require.NoError(t, cmd.ParseFlags([]string{"--timeout", "30s"}))
+		require.NoError(t, applyGlobalFlags(f, cmd))
+	})
+
+	t.Run("rejects non-positive durations", func(t *testing.T) {
+		ios, _, _, _ := iostreams.Test()
+		f := &cmdutil.Factory{IOStreams: ios}
+
+		cmd := NewCmdRoot(f)
+		require.NoError(t, cmd.ParseFlags([]string{"--timeout", "0s"}))
+		err := applyGlobalFlags(f, cmd)
+
+		require.Error(t, err)
+		var flagErr *cmdutil.FlagError
+		assert.ErrorAs(t, err, &flagErr)
+		assert.Contains(t, err.Error(), "invalid timeout")
+	})
+}
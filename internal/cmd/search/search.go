@@ -1,8 +1,11 @@
 package search
 
 import (
-	"encoding/json"
 	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"text/template"
 	"time"
 
 	"github.com/marckohlbrugge/fastmail-cli/internal/cmdutil"
@@ -11,9 +14,19 @@ import (
 )
 
 type searchOptions struct {
-	Folder     string
-	Limit      int
-	JSONFields []string
+	Folder      string
+	NoTrash     bool
+	NoSpam      bool
+	Thread      string
+	ByThread    bool
+	Limit       int
+	Since       string
+	JSONFields  []string
+	JSONLFields []string
+	JSONAll     bool
+	Template    string
+	Out         string
+	HelpFields  bool
 }
 
 
@@ -26,10 +39,17 @@ func NewCmdSearch(f *cmdutil.Factory) *cobra.Command {
 		Short: "Search emails",
 		Long: `Search emails using Fastmail's search syntax.
 
-Query is optional when using --folder to list all emails in a folder.
+Query is optional when using --folder to list all emails in a folder, or
+--thread to list a whole conversation. --thread takes an email ID or a
+thread ID.
+
+Every folder is searched, Trash and Spam included, so results match what
+Fastmail's own search returns. Use --no-trash and --no-spam to skip them.
 
 Supports text search and filter operators:
   from:alice     - Emails from alice
+  domain:acme.io - Emails from any address at acme.io (a substring match,
+                   so acme.io.uk matches too)
   to:bob         - Emails to bob
   subject:hello  - Subject contains hello
   has:attachment - Has attachments
@@ -39,12 +59,21 @@ Supports text search and filter operators:
   is:draft       - Draft emails
   before:DATE    - Emails before date (YYYY-MM-DD)
   after:DATE     - Emails after date (YYYY-MM-DD)
+  newer_than:7d  - Emails from the last 7 days (m, h, d, or w)
+  older_than:1w  - Emails older than a week (also newer:/older:)
+  inThread:ID    - Emails in the thread with this thread ID
 
 Boolean operators (case-insensitive):
   OR             - Match either term
   AND            - Match both terms (also implicit between terms)
   NOT            - Exclude matching emails
-  ()             - Group expressions`,
+  ()             - Group expressions
+
+JSON output:
+  --json FIELDS  - Only the listed fields
+  --json-all     - Every field: id, threadId, subject, from, to, cc, date,
+                   preview, unread, attachment, size, folder, messageId,
+                   and keywords`,
 		Example: `  # List all drafts
   fm search --folder drafts
 
@@ -69,17 +98,47 @@ Boolean operators (case-insensitive):
   # Grouped expressions
   fm search "(from:alice OR from:bob) AND subject:meeting"
 
+  # Unread emails from the last day
+  fm search "is:unread" --since 24h
+
   # Search within a specific folder
   fm search "from:newsletter" --folder inbox
 
+  # Find the message in a conversation that mentioned the budget
+  fm search "budget" --thread M1234567890
+
+  # Leave out deleted and junk mail
+  fm search "invoice" --no-trash --no-spam
+
   # Output as JSON with specific fields
   fm search "from:alice" --json id,subject,from
 
   # Output all available JSON fields
-  fm search "from:alice" --json id,threadId,subject,from,to,cc,date,preview,unread,attachment`,
+  fm search "from:alice" --json-all
+
+  # Output as TSV
+  fm search "from:alice" --output tsv
+
+  # Format each email with a template
+  fm search "from:alice" --template '{{.Subject}}\t{{addrs .From}}'
+
+  # Write results to a file
+  fm search "from:alice" --json-all --out results/alice.json`,
 		GroupID: "core",
 		Args:    cobra.MaximumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if opts.HelpFields {
+				cmdutil.PrintFieldHelp(f.IOStreams.Out)
+				return nil
+			}
+			if !cmd.Flags().Changed("limit") {
+				opts.Limit = f.DefaultLimit(opts.Limit)
+			}
+			limit, err := cmdutil.ParseLimit(opts.Limit)
+			if err != nil {
+				return err
+			}
+			opts.Limit = limit
 			query := ""
 			if len(args) > 0 {
 				query = args[0]
@@ -89,12 +148,30 @@ Boolean operators (case-insensitive):
 	}
 
 	cmd.Flags().StringVar(&opts.Folder, "folder", "", "Restrict search to folder ID or name")
-	cmd.Flags().IntVar(&opts.Limit, "limit", 50, "Maximum results (max 500)")
-	cmd.Flags().StringSliceVar(&opts.JSONFields, "json", nil, "Output JSON with specified `fields` (id,threadId,subject,from,to,cc,date,preview,unread,attachment)")
+	cmd.Flags().BoolVar(&opts.NoTrash, "no-trash", false, "Skip emails in Trash")
+	cmd.Flags().BoolVar(&opts.NoSpam, "no-spam", false, "Skip emails in Spam")
+	cmd.Flags().StringVar(&opts.Thread, "thread", "", "Restrict search to the thread of this email or thread `id`")
+	cmd.Flags().StringVar(&opts.Since, "since", "", "Only show emails from the last `duration` (e.g. 24h, 7d, 2w)")
+	cmd.Flags().IntVar(&opts.Limit, "limit", 50, fmt.Sprintf("Maximum results (max 500, or 0 for every match; fails if more than %d match)", jmap.MaxAllEmails))
+	cmd.Flags().BoolVar(&opts.ByThread, "group-by-thread", false, "Show only the latest match of each thread, with a match count")
+	cmd.Flags().StringSliceVar(&opts.JSONFields, "json", nil, "Output JSON with specified `fields` ("+strings.Join(cmdutil.AvailableEmailFields, ",")+")")
+	cmd.Flags().BoolVar(&opts.JSONAll, "json-all", false, "Output JSON with every available field")
+	cmd.Flags().StringSliceVar(&opts.JSONLFields, "jsonl", nil, "Output one compact JSON object per line with specified `fields`")
+	cmd.Flags().StringVar(&opts.Template, "template", "", "Format each email with a Go `template`")
+	cmd.Flags().BoolVar(&opts.HelpFields, "help-fields", false, "List the available fields with descriptions and exit")
+	cmd.Flags().StringVar(&opts.Out, "out", "", "Write results to `file` instead of stdout (\"-\" for stdout)")
+	cmd.MarkFlagsMutuallyExclusive("json", "json-all", "jsonl", "template")
+	_ = cmd.RegisterFlagCompletionFunc("folder", cmdutil.CompleteFolders(f))
 
 	return cmd
 }
 
+// RunQuery searches for query and prints the results as 'fm search' does,
+// for commands that are shortcuts over it.
+func RunQuery(f *cmdutil.Factory, query string, limit int, jsonFields []string) error {
+	return runSearch(f, &searchOptions{Limit: limit, JSONFields: jsonFields}, query)
+}
+
 func runSearch(f *cmdutil.Factory, opts *searchOptions, query string) error {
 	client, err := f.JMAPClient()
 	if err != nil {
@@ -102,8 +179,15 @@ func runSearch(f *cmdutil.Factory, opts *searchOptions, query string) error {
 	}
 
 	// Validate JSON fields if provided
-	if opts.JSONFields != nil {
-		if err := cmdutil.ValidateFields(opts.JSONFields); err != nil {
+	for _, fields := range [][]string{opts.JSONFields, opts.JSONLFields} {
+		if err := cmdutil.ValidateFields(fields); err != nil {
+			return err
+		}
+	}
+
+	var tmpl *template.Template
+	if opts.Template != "" {
+		if tmpl, err = cmdutil.ParseEmailTemplate(opts.Template); err != nil {
 			return err
 		}
 	}
@@ -113,120 +197,120 @@ func runSearch(f *cmdutil.Factory, opts *searchOptions, query string) error {
 		Limit: opts.Limit,
 	}
 
+	if opts.Since != "" {
+		d, err := jmap.ParseRelativeDuration(opts.Since)
+		if err != nil {
+			return cmdutil.FlagErrorWrap(err)
+		}
+		filters.After = jmap.UTCDate(time.Now().Add(-d))
+	}
+
+	if opts.Thread != "" {
+		if filters.ThreadID, err = client.ResolveThreadID(opts.Thread); err != nil {
+			return err
+		}
+	}
+
 	// Resolve folder if specified
 	if opts.Folder != "" {
-		mailbox, err := resolveMailbox(client, opts.Folder)
+		mailbox, err := cmdutil.ResolveMailbox(f, client, opts.Folder)
 		if err != nil {
 			return err
 		}
 		filters.MailboxID = mailbox.ID
 	}
 
+	if opts.NoTrash || opts.NoSpam {
+		if filters.ExcludeMailboxIDs, err = excludedMailboxIDs(client, opts.NoTrash, opts.NoSpam); err != nil {
+			return err
+		}
+	}
+
 	emails, err := client.Search(filters)
 	if err != nil {
 		return err
 	}
 
-	if opts.JSONFields != nil {
-		return outputJSON(f, emails, opts.JSONFields)
+	// Grouping happens after --limit, so threads are counted within the
+	// returned matches only.
+	if opts.ByThread {
+		emails = cmdutil.GroupByThread(emails)
 	}
 
-	return outputHuman(f, emails, query)
-}
-
-func resolveMailbox(client *jmap.Client, folderRef string) (*jmap.Mailbox, error) {
-	// Try by ID first
-	mailbox, err := client.GetMailboxByID(folderRef)
-	if err == nil {
-		return mailbox, nil
+	format := f.OutputFormat(opts.JSONFields != nil || opts.JSONAll)
+	fields := opts.JSONFields
+	if opts.JSONAll {
+		fields = cmdutil.AvailableEmailFields
 	}
-
-	// Try by name
-	mailbox, err = client.GetMailboxByName(folderRef)
-	if err == nil {
-		return mailbox, nil
+	if opts.JSONLFields != nil {
+		format = cmdutil.OutputJSONL
+		fields = opts.JSONLFields
 	}
-
-	// Try by role
-	return client.GetMailboxByRole(folderRef)
-}
-
-func outputJSON(f *cmdutil.Factory, emails []jmap.Email, fields []string) error {
-	// If no fields specified, output all fields
 	if len(fields) == 0 {
-		type jsonEmail struct {
-			ID            string              `json:"id"`
-			ThreadID      string              `json:"threadId"`
-			Subject       string              `json:"subject"`
-			From          []jmap.EmailAddress `json:"from"`
-			To            []jmap.EmailAddress `json:"to"`
-			CC            []jmap.EmailAddress `json:"cc,omitempty"`
-			ReceivedAt    time.Time           `json:"receivedAt"`
-			IsUnread      bool                `json:"isUnread"`
-			HasAttachment bool                `json:"hasAttachment"`
-			Preview       string              `json:"preview"`
+		fields = cmdutil.DefaultEmailFields
+		if format == cmdutil.OutputJSON || format == cmdutil.OutputJSONL {
+			fields = cmdutil.AvailableEmailFields
 		}
+	}
 
-		output := make([]jsonEmail, len(emails))
-		for i, e := range emails {
-			output[i] = jsonEmail{
-				ID:            e.ID,
-				ThreadID:      e.ThreadID,
-				Subject:       e.Subject,
-				From:          e.From,
-				To:            e.To,
-				CC:            e.CC,
-				ReceivedAt:    e.ReceivedAt,
-				IsUnread:      e.IsUnread(),
-				HasAttachment: e.HasAttachment,
-				Preview:       e.Preview,
-			}
+	if tmpl == nil && slices.Contains(fields, "folder") {
+		if err := client.ResolveMailboxNames(emails); err != nil {
+			return fmt.Errorf("failed to resolve folders: %w", err)
 		}
+	}
 
-		encoder := json.NewEncoder(f.IOStreams.Out)
-		encoder.SetIndent("", "  ")
-		return encoder.Encode(output)
-	}
-
-	// Output only specified fields
-	output := make([]map[string]interface{}, len(emails))
-	for i, e := range emails {
-		row := make(map[string]interface{})
-		for _, field := range fields {
-			switch field {
-			case "id":
-				row["id"] = e.ID
-			case "threadId":
-				row["threadId"] = e.ThreadID
-			case "subject":
-				row["subject"] = e.Subject
-			case "from":
-				row["from"] = e.From
-			case "to":
-				row["to"] = e.To
-			case "cc":
-				row["cc"] = e.CC
-			case "date":
-				row["receivedAt"] = e.ReceivedAt
-			case "preview":
-				row["preview"] = e.Preview
-			case "unread":
-				row["isUnread"] = e.IsUnread()
-			case "attachment":
-				row["hasAttachment"] = e.HasAttachment
-			}
-		}
-		output[i] = row
+	out, err := cmdutil.OpenOutput(f.IOStreams, opts.Out)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+
+	toFile := cmdutil.IsOutputFile(opts.Out)
+	switch {
+	case tmpl != nil:
+		err = cmdutil.WriteEmailsTemplate(out, tmpl, emails)
+	case format == cmdutil.OutputJSON:
+		err = cmdutil.WriteJSON(out, cmdutil.EmailsJSON(emails, fields))
+	case format == cmdutil.OutputJSONL:
+		err = cmdutil.WriteEmailsJSONL(out, emails, fields)
+	case cmdutil.IsDelimited(format):
+		err = cmdutil.WriteEmailsDelimited(out, format, emails, fields)
+	default:
+		err = outputHuman(f, out, emails, query, toFile)
+	}
+	if err != nil || !toFile {
+		return err
 	}
 
-	encoder := json.NewEncoder(f.IOStreams.Out)
-	encoder.SetIndent("", "  ")
-	return encoder.Encode(output)
+	if err := out.Close(); err != nil {
+		return err
+	}
+	if !f.Quiet {
+		fmt.Fprintf(f.IOStreams.ErrOut, "Wrote %d results to %s\n", len(emails), opts.Out)
+	}
+	return nil
 }
 
-func outputHuman(f *cmdutil.Factory, emails []jmap.Email, query string) error {
-	out := f.IOStreams.Out
+// excludedMailboxIDs returns the IDs of the Trash and/or Spam mailboxes.
+// An account without one of them simply has nothing to exclude.
+func excludedMailboxIDs(client *jmap.Client, trash, spam bool) ([]string, error) {
+	mailboxes, err := client.GetMailboxes()
+	if err != nil {
+		return nil, err
+	}
+
+	var ids []string
+	for _, mb := range mailboxes {
+		if (trash && mb.Role == "trash") || (spam && mb.Role == "junk") {
+			ids = append(ids, mb.ID)
+		}
+	}
+	return ids, nil
+}
 
+// outputHuman prints the result list. Written to a file, it has no color and
+// no results footer, since the count is reported on stderr instead.
+func outputHuman(f *cmdutil.Factory, out io.Writer, emails []jmap.Email, query string, toFile bool) error {
 	if len(emails) == 0 {
 		if query == "" {
 			fmt.Fprintln(out, "No emails found")
@@ -236,9 +320,15 @@ func outputHuman(f *cmdutil.Factory, emails []jmap.Email, query string) error {
 		return nil
 	}
 
-	cmdutil.PrintEmailList(out, emails, cmdutil.DefaultEmailFields)
+	style := f.EmailListStyle()
+	if toFile {
+		style.Color = nil
+	}
+	cmdutil.PrintEmailList(out, style, emails, cmdutil.DefaultEmailFields)
 
-	fmt.Fprintf(out, "\n%d results\n", len(emails))
+	if !f.Quiet && !toFile {
+		fmt.Fprintf(out, "\n%d results\n", len(emails))
+	}
 	return nil
 }
 
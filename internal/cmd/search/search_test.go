@@ -3,7 +3,10 @@ package search
 import (
 	"bytes"
 	"encoding/json"
+	"fmt"
 	"net/http"
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -123,6 +126,100 @@ func TestSearchCommand(t *testing.T) {
 		assert.Contains(t, output, "1 results")
 	})
 
+	t.Run("omits the results footer when quiet", func(t *testing.T) {
+		f, stdout, _ := setupTest(t)
+		f.Quiet = true
+
+		httpmock.RegisterResponder("POST", "https://api.test.com/jmap/api",
+			mockSearchResponse([]map[string]interface{}{
+				{"id": "email-1", "subject": "Hello World", "receivedAt": time.Now().Format(time.RFC3339)},
+			}))
+
+		cmd := NewCmdSearch(f)
+		cmd.SetArgs([]string{"hello"})
+		cmd.SetOut(stdout)
+		cmd.SetErr(&bytes.Buffer{})
+
+		err := cmd.Execute()
+
+		require.NoError(t, err)
+		assert.Contains(t, stdout.String(), "email-1")
+		assert.NotContains(t, stdout.String(), "results")
+	})
+
+	t.Run("streams results with --jsonl", func(t *testing.T) {
+		f, stdout, _ := setupTest(t)
+
+		httpmock.RegisterResponder("POST", "https://api.test.com/jmap/api",
+			mockSearchResponse([]map[string]interface{}{
+				{"id": "email-1", "subject": "First", "receivedAt": time.Now().Format(time.RFC3339)},
+				{"id": "email-2", "subject": "Second", "receivedAt": time.Now().Format(time.RFC3339)},
+			}))
+
+		cmd := NewCmdSearch(f)
+		cmd.SetArgs([]string{"hello", "--jsonl", "id,subject"})
+		cmd.SetOut(stdout)
+		cmd.SetErr(&bytes.Buffer{})
+
+		err := cmd.Execute()
+
+		require.NoError(t, err)
+		assert.Equal(t, `{"id":"email-1","subject":"First"}`+"\n"+`{"id":"email-2","subject":"Second"}`+"\n", stdout.String())
+	})
+
+	t.Run("rejects --json with --jsonl", func(t *testing.T) {
+		f, _, _ := setupTest(t)
+
+		cmd := NewCmdSearch(f)
+		cmd.SetArgs([]string{"hello", "--json", "id", "--jsonl", "id"})
+		cmd.SetOut(&bytes.Buffer{})
+		cmd.SetErr(&bytes.Buffer{})
+
+		err := cmd.Execute()
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "none of the others can be")
+	})
+
+	t.Run("formats results with --template", func(t *testing.T) {
+		f, stdout, _ := setupTest(t)
+
+		httpmock.RegisterResponder("POST", "https://api.test.com/jmap/api",
+			mockSearchResponse([]map[string]interface{}{
+				{
+					"id":         "email-1",
+					"subject":    "Hello World",
+					"from":       []map[string]string{{"name": "Alice", "email": "alice@example.com"}},
+					"receivedAt": time.Now().Format(time.RFC3339),
+				},
+			}))
+
+		cmd := NewCmdSearch(f)
+		cmd.SetArgs([]string{"hello", "--template", `{{.Subject}}\t{{addrs .From}}`})
+		cmd.SetOut(stdout)
+		cmd.SetErr(&bytes.Buffer{})
+
+		err := cmd.Execute()
+
+		require.NoError(t, err)
+		assert.Equal(t, "Hello World\tAlice <alice@example.com>\n", stdout.String())
+	})
+
+	t.Run("rejects an invalid --template before searching", func(t *testing.T) {
+		f, _, _ := setupTest(t)
+
+		cmd := NewCmdSearch(f)
+		cmd.SetArgs([]string{"hello", "--template", "{{.Subject"})
+		cmd.SetOut(&bytes.Buffer{})
+		cmd.SetErr(&bytes.Buffer{})
+
+		err := cmd.Execute()
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid --template")
+		assert.Equal(t, 0, httpmock.GetTotalCallCount())
+	})
+
 	t.Run("searches without query using folder", func(t *testing.T) {
 		f, stdout, _ := setupTest(t)
 
@@ -192,6 +289,56 @@ func TestSearchCommand(t *testing.T) {
 		assert.Len(t, conditions, 2)
 	})
 
+	t.Run("adds an after filter for --since", func(t *testing.T) {
+		f, stdout, _ := setupTest(t)
+
+		var capturedFilter map[string]interface{}
+
+		httpmock.RegisterResponder("POST", "https://api.test.com/jmap/api",
+			func(req *http.Request) (*http.Response, error) {
+				var jmapReq jmap.Request
+				json.NewDecoder(req.Body).Decode(&jmapReq)
+
+				args := jmapReq.MethodCalls[0][1].(map[string]interface{})
+				if filter, ok := args["filter"].(map[string]interface{}); ok {
+					capturedFilter = filter
+				}
+
+				return httpmock.NewJsonResponse(200, map[string]interface{}{
+					"methodResponses": [][]interface{}{
+						{"Email/query", map[string]interface{}{"ids": []string{}}, "query"},
+						{"Email/get", map[string]interface{}{"list": []interface{}{}}, "emails"},
+					},
+				})
+			})
+
+		cmd := NewCmdSearch(f)
+		cmd.SetArgs([]string{"--since", "24h"})
+		cmd.SetOut(stdout)
+		cmd.SetErr(&bytes.Buffer{})
+
+		err := cmd.Execute()
+
+		require.NoError(t, err)
+		after, err := time.Parse(time.RFC3339, capturedFilter["after"].(string))
+		require.NoError(t, err)
+		assert.WithinDuration(t, time.Now().Add(-24*time.Hour), after, time.Minute)
+	})
+
+	t.Run("rejects an invalid --since", func(t *testing.T) {
+		f, _, _ := setupTest(t)
+
+		cmd := NewCmdSearch(f)
+		cmd.SetArgs([]string{"--since", "yesterday"})
+		cmd.SetOut(&bytes.Buffer{})
+		cmd.SetErr(&bytes.Buffer{})
+
+		err := cmd.Execute()
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid duration")
+	})
+
 	t.Run("sends correct filter for AND operator", func(t *testing.T) {
 		f, stdout, _ := setupTest(t)
 
@@ -295,6 +442,162 @@ func TestSearchCommand(t *testing.T) {
 		assert.False(t, hasThreadId)
 	})
 
+	t.Run("resolves folder names for the folder field", func(t *testing.T) {
+		f, stdout, _ := setupTest(t)
+
+		httpmock.RegisterResponder("POST", "https://api.test.com/jmap/api",
+			mockMailboxAndSearchResponse(
+				[]map[string]interface{}{
+					{"id": "inbox-1", "name": "Inbox", "role": "inbox"},
+					{"id": "work-1", "name": "Work"},
+				},
+				[]map[string]interface{}{
+					{
+						"id":         "email-1",
+						"subject":    "Quarterly report",
+						"mailboxIds": map[string]bool{"work-1": true, "inbox-1": true},
+						"receivedAt": time.Now().Format(time.RFC3339),
+					},
+				}))
+
+		cmd := NewCmdSearch(f)
+		cmd.SetArgs([]string{"report", "--json", "id,folder"})
+		cmd.SetOut(stdout)
+		cmd.SetErr(&bytes.Buffer{})
+
+		err := cmd.Execute()
+
+		require.NoError(t, err)
+
+		var result []map[string]interface{}
+		require.NoError(t, json.Unmarshal(stdout.Bytes(), &result))
+		require.Len(t, result, 1)
+		assert.Equal(t, []interface{}{"Inbox", "Work"}, result[0]["folder"])
+	})
+
+	t.Run("outputs every field with --json-all", func(t *testing.T) {
+		f, stdout, _ := setupTest(t)
+
+		httpmock.RegisterResponder("POST", "https://api.test.com/jmap/api",
+			mockMailboxAndSearchResponse(
+				[]map[string]interface{}{
+					{"id": "inbox-1", "name": "Inbox", "role": "inbox"},
+				},
+				[]map[string]interface{}{
+					{
+						"id":         "email-1",
+						"threadId":   "thread-1",
+						"subject":    "Quarterly report",
+						"mailboxIds": map[string]bool{"inbox-1": true},
+						"receivedAt": time.Now().Format(time.RFC3339),
+						"messageId":  []string{"abc@example.com"},
+						"keywords":   map[string]bool{"$seen": true, "$flagged": true},
+						"size":       2048,
+					},
+				}))
+
+		cmd := NewCmdSearch(f)
+		cmd.SetArgs([]string{"report", "--json-all"})
+		cmd.SetOut(stdout)
+		cmd.SetErr(&bytes.Buffer{})
+
+		err := cmd.Execute()
+
+		require.NoError(t, err)
+
+		var result []map[string]interface{}
+		require.NoError(t, json.Unmarshal(stdout.Bytes(), &result))
+		require.Len(t, result, 1)
+		assert.Len(t, result[0], len(cmdutil.AvailableEmailFields))
+		assert.Equal(t, []interface{}{"abc@example.com"}, result[0]["messageId"])
+		assert.Equal(t, []interface{}{"$flagged", "$seen"}, result[0]["keywords"])
+		assert.Equal(t, float64(2048), result[0]["size"])
+		assert.Equal(t, []interface{}{"Inbox"}, result[0]["folder"])
+	})
+
+	t.Run("writes results to --out, creating directories", func(t *testing.T) {
+		f, stdout, stderr := setupTest(t)
+
+		httpmock.RegisterResponder("POST", "https://api.test.com/jmap/api",
+			mockSearchResponse([]map[string]interface{}{
+				{"id": "email-1", "subject": "One", "receivedAt": "2024-01-15T10:30:00Z"},
+				{"id": "email-2", "subject": "Two", "receivedAt": "2024-01-14T10:30:00Z"},
+			}))
+
+		path := filepath.Join(t.TempDir(), "results", "search.json")
+		cmd := NewCmdSearch(f)
+		cmd.SetArgs([]string{"test", "--json", "id,subject", "--out", path})
+		cmd.SetOut(stdout)
+		cmd.SetErr(&bytes.Buffer{})
+
+		err := cmd.Execute()
+
+		require.NoError(t, err)
+		assert.Empty(t, stdout.String())
+		assert.Equal(t, "Wrote 2 results to "+path+"\n", stderr.String())
+
+		data, err := os.ReadFile(path)
+		require.NoError(t, err)
+		var result []map[string]interface{}
+		require.NoError(t, json.Unmarshal(data, &result))
+		require.Len(t, result, 2)
+		assert.Equal(t, "Two", result[1]["subject"])
+	})
+
+	t.Run("writes human output to --out without the footer", func(t *testing.T) {
+		f, _, _ := setupTest(t)
+
+		httpmock.RegisterResponder("POST", "https://api.test.com/jmap/api",
+			mockSearchResponse([]map[string]interface{}{
+				{"id": "email-1", "subject": "One", "receivedAt": time.Now().Format(time.RFC3339)},
+			}))
+
+		path := filepath.Join(t.TempDir(), "results.txt")
+		cmd := NewCmdSearch(f)
+		cmd.SetArgs([]string{"test", "--out", path})
+		cmd.SetOut(&bytes.Buffer{})
+		cmd.SetErr(&bytes.Buffer{})
+
+		require.NoError(t, cmd.Execute())
+
+		data, err := os.ReadFile(path)
+		require.NoError(t, err)
+		assert.Contains(t, string(data), "email-1")
+		assert.NotContains(t, string(data), "results")
+	})
+
+	t.Run("writes to stdout with --out -", func(t *testing.T) {
+		f, stdout, stderr := setupTest(t)
+
+		httpmock.RegisterResponder("POST", "https://api.test.com/jmap/api",
+			mockSearchResponse([]map[string]interface{}{
+				{"id": "email-1", "subject": "One", "receivedAt": "2024-01-15T10:30:00Z"},
+			}))
+
+		cmd := NewCmdSearch(f)
+		cmd.SetArgs([]string{"test", "--jsonl", "id", "--out", "-"})
+		cmd.SetOut(stdout)
+		cmd.SetErr(&bytes.Buffer{})
+
+		require.NoError(t, cmd.Execute())
+		assert.Equal(t, "{\"id\":\"email-1\"}\n", stdout.String())
+		assert.Empty(t, stderr.String())
+	})
+
+	t.Run("rejects --json with --json-all", func(t *testing.T) {
+		f, _, _ := setupTest(t)
+
+		cmd := NewCmdSearch(f)
+		cmd.SetArgs([]string{"report", "--json", "id", "--json-all"})
+		cmd.SetOut(&bytes.Buffer{})
+		cmd.SetErr(&bytes.Buffer{})
+
+		err := cmd.Execute()
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "none of the others can be")
+	})
+
 	t.Run("shows empty message when no results with query", func(t *testing.T) {
 		f, stdout, _ := setupTest(t)
 
@@ -418,6 +721,236 @@ func TestSearchCommand(t *testing.T) {
 		// Should be an AND filter combining text and inMailbox
 		assert.Equal(t, "AND", capturedFilter["operator"])
 	})
+
+	t.Run("excludes Trash and Spam with --no-trash and --no-spam", func(t *testing.T) {
+		f, stdout, _ := setupTest(t)
+
+		var capturedFilter map[string]interface{}
+
+		httpmock.RegisterResponder("POST", "https://api.test.com/jmap/api",
+			func(req *http.Request) (*http.Response, error) {
+				var jmapReq jmap.Request
+				json.NewDecoder(req.Body).Decode(&jmapReq)
+
+				method := jmapReq.MethodCalls[0][0].(string)
+
+				switch method {
+				case "Mailbox/get":
+					return httpmock.NewJsonResponse(200, map[string]interface{}{
+						"methodResponses": [][]interface{}{
+							{"Mailbox/get", map[string]interface{}{
+								"list": []map[string]interface{}{
+									{"id": "inbox-1", "name": "Inbox", "role": "inbox"},
+									{"id": "trash-1", "name": "Trash", "role": "trash"},
+									{"id": "junk-1", "name": "Spam", "role": "junk"},
+								},
+							}, "mailboxes"},
+						},
+					})
+				case "Email/query":
+					args := jmapReq.MethodCalls[0][1].(map[string]interface{})
+					if filter, ok := args["filter"].(map[string]interface{}); ok {
+						capturedFilter = filter
+					}
+					return httpmock.NewJsonResponse(200, map[string]interface{}{
+						"methodResponses": [][]interface{}{
+							{"Email/query", map[string]interface{}{"ids": []string{}}, "query"},
+							{"Email/get", map[string]interface{}{"list": []interface{}{}}, "emails"},
+						},
+					})
+				default:
+					return httpmock.NewStringResponse(400, "unexpected"), nil
+				}
+			})
+
+		cmd := NewCmdSearch(f)
+		cmd.SetArgs([]string{"invoice", "--no-trash", "--no-spam"})
+		cmd.SetOut(stdout)
+		cmd.SetErr(&bytes.Buffer{})
+
+		err := cmd.Execute()
+
+		require.NoError(t, err)
+		assert.Equal(t, "AND", capturedFilter["operator"])
+		conditions := capturedFilter["conditions"].([]interface{})
+		require.Len(t, conditions, 2)
+		assert.Equal(t, map[string]interface{}{"text": "invoice"}, conditions[0])
+		assert.Equal(t, map[string]interface{}{"inMailboxOtherThan": []interface{}{"trash-1", "junk-1"}}, conditions[1])
+	})
+
+	t.Run("searches every folder by default", func(t *testing.T) {
+		f, stdout, _ := setupTest(t)
+
+		var capturedFilter map[string]interface{}
+
+		httpmock.RegisterResponder("POST", "https://api.test.com/jmap/api",
+			func(req *http.Request) (*http.Response, error) {
+				var jmapReq jmap.Request
+				json.NewDecoder(req.Body).Decode(&jmapReq)
+
+				args := jmapReq.MethodCalls[0][1].(map[string]interface{})
+				capturedFilter, _ = args["filter"].(map[string]interface{})
+
+				return httpmock.NewJsonResponse(200, map[string]interface{}{
+					"methodResponses": [][]interface{}{
+						{"Email/query", map[string]interface{}{"ids": []string{}}, "query"},
+						{"Email/get", map[string]interface{}{"list": []interface{}{}}, "emails"},
+					},
+				})
+			})
+
+		cmd := NewCmdSearch(f)
+		cmd.SetArgs([]string{"invoice"})
+		cmd.SetOut(stdout)
+		cmd.SetErr(&bytes.Buffer{})
+
+		err := cmd.Execute()
+
+		require.NoError(t, err)
+		assert.Equal(t, map[string]interface{}{"text": "invoice"}, capturedFilter)
+		assert.Equal(t, 1, httpmock.GetCallCountInfo()["POST https://api.test.com/jmap/api"])
+	})
+
+	t.Run("restricts to the email's thread with --thread", func(t *testing.T) {
+		f, stdout, _ := setupTest(t)
+
+		var capturedFilter map[string]interface{}
+
+		httpmock.RegisterResponder("POST", "https://api.test.com/jmap/api",
+			func(req *http.Request) (*http.Response, error) {
+				var jmapReq jmap.Request
+				json.NewDecoder(req.Body).Decode(&jmapReq)
+
+				if jmapReq.MethodCalls[0][0] == "Email/get" {
+					return httpmock.NewJsonResponse(200, map[string]interface{}{
+						"methodResponses": [][]interface{}{
+							{"Email/get", map[string]interface{}{
+								"list": []map[string]interface{}{{"id": "email-1", "threadId": "thread-a"}},
+							}, "email"},
+						},
+					})
+				}
+
+				args := jmapReq.MethodCalls[0][1].(map[string]interface{})
+				capturedFilter, _ = args["filter"].(map[string]interface{})
+
+				return httpmock.NewJsonResponse(200, map[string]interface{}{
+					"methodResponses": [][]interface{}{
+						{"Email/query", map[string]interface{}{"ids": []string{}}, "query"},
+						{"Email/get", map[string]interface{}{"list": []interface{}{}}, "emails"},
+					},
+				})
+			})
+
+		cmd := NewCmdSearch(f)
+		cmd.SetArgs([]string{"budget", "--thread", "email-1"})
+		cmd.SetOut(stdout)
+		cmd.SetErr(&bytes.Buffer{})
+
+		err := cmd.Execute()
+
+		require.NoError(t, err)
+		assert.Equal(t, map[string]interface{}{
+			"operator": "AND",
+			"conditions": []interface{}{
+				map[string]interface{}{"text": "budget"},
+				map[string]interface{}{"threadId": "thread-a"},
+			},
+		}, capturedFilter)
+	})
+
+	t.Run("pages through every match with --limit 0", func(t *testing.T) {
+		f, stdout, _ := setupTest(t)
+
+		var positions []interface{}
+		httpmock.RegisterResponder("POST", "https://api.test.com/jmap/api",
+			func(req *http.Request) (*http.Response, error) {
+				var jmapReq jmap.Request
+				json.NewDecoder(req.Body).Decode(&jmapReq)
+
+				args := jmapReq.MethodCalls[0][1].(map[string]interface{})
+				positions = append(positions, args["position"])
+
+				// Two pages: a full one of 500, then the last 3
+				ids := []string{}
+				list := []map[string]interface{}{}
+				count := 500
+				if args["position"].(float64) > 0 {
+					count = 3
+				}
+				for i := 0; i < count; i++ {
+					id := fmt.Sprintf("email-%d", int(args["position"].(float64))+i)
+					ids = append(ids, id)
+					list = append(list, map[string]interface{}{"id": id})
+				}
+
+				return httpmock.NewJsonResponse(200, map[string]interface{}{
+					"methodResponses": [][]interface{}{
+						{"Email/query", map[string]interface{}{"ids": ids, "total": 503}, "query"},
+						{"Email/get", map[string]interface{}{"list": list}, "emails"},
+					},
+				})
+			})
+
+		cmd := NewCmdSearch(f)
+		cmd.SetArgs([]string{"invoice", "--limit", "0", "--json", "id"})
+		cmd.SetOut(stdout)
+		cmd.SetErr(&bytes.Buffer{})
+
+		err := cmd.Execute()
+
+		require.NoError(t, err)
+		var result []map[string]interface{}
+		require.NoError(t, json.Unmarshal(stdout.Bytes(), &result))
+		assert.Len(t, result, 503)
+		assert.Equal(t, []interface{}{float64(0), float64(500)}, positions)
+	})
+
+	t.Run("rejects a negative --limit", func(t *testing.T) {
+		f, _, _ := setupTest(t)
+
+		cmd := NewCmdSearch(f)
+		cmd.SetArgs([]string{"invoice", "--limit", "-1"})
+		cmd.SetOut(&bytes.Buffer{})
+		cmd.SetErr(&bytes.Buffer{})
+
+		err := cmd.Execute()
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid --limit -1")
+	})
+
+	t.Run("collapses threads with --group-by-thread", func(t *testing.T) {
+		f, stdout, _ := setupTest(t)
+
+		httpmock.RegisterResponder("POST", "https://api.test.com/jmap/api",
+			httpmock.NewJsonResponderOrPanic(200, map[string]interface{}{
+				"methodResponses": [][]interface{}{
+					{"Email/query", map[string]interface{}{"ids": []string{"email-3", "email-2", "email-1"}}, "query"},
+					{"Email/get", map[string]interface{}{
+						"list": []map[string]interface{}{
+							{"id": "email-3", "threadId": "thread-a", "subject": "Re: Invoice", "receivedAt": "2024-01-15T12:00:00Z"},
+							{"id": "email-2", "threadId": "thread-b", "subject": "Another invoice", "receivedAt": "2024-01-15T11:00:00Z"},
+							{"id": "email-1", "threadId": "thread-a", "subject": "Invoice", "receivedAt": "2024-01-15T10:00:00Z"},
+						},
+					}, "emails"},
+				},
+			}))
+
+		cmd := NewCmdSearch(f)
+		cmd.SetArgs([]string{"invoice", "--group-by-thread", "--json", "id,subject"})
+		cmd.SetOut(stdout)
+		cmd.SetErr(&bytes.Buffer{})
+
+		err := cmd.Execute()
+
+		require.NoError(t, err)
+		var result []map[string]interface{}
+		require.NoError(t, json.Unmarshal(stdout.Bytes(), &result))
+		require.Len(t, result, 2)
+		assert.Equal(t, "email-3", result[0]["id"])
+		assert.Equal(t, "email-2", result[1]["id"])
+	})
 }
 
 func TestSearchCommand_FlagParsing(t *testing.T) {
@@ -0,0 +1,93 @@
+package sieve
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/marckohlbrugge/fastmail-cli/internal/cmdutil"
+	"github.com/marckohlbrugge/fastmail-cli/internal/jmap"
+	"github.com/spf13/cobra"
+)
+
+type listOptions struct {
+	JSON bool
+}
+
+// NewCmdList creates the sieve list command.
+func NewCmdList(f *cmdutil.Factory) *cobra.Command {
+	opts := &listOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List sieve scripts",
+		Long: `List your sieve scripts and show which one is active.
+
+Only the active script runs on incoming mail, so it is the place to look
+when a message skips your inbox or lands in an unexpected folder.`,
+		Example: `  # List sieve scripts
+  fm sieve list
+
+  # Output as JSON
+  fm sieve list --json`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runList(f, opts)
+		},
+	}
+
+	cmd.Flags().BoolVar(&opts.JSON, "json", false, "Output in JSON format")
+
+	return cmd
+}
+
+func runList(f *cmdutil.Factory, opts *listOptions) error {
+	client, err := f.JMAPClient()
+	if err != nil {
+		return err
+	}
+
+	scripts, err := client.GetSieveScripts()
+	if err != nil {
+		return err
+	}
+
+	if opts.JSON {
+		return outputJSON(f, scripts)
+	}
+
+	return outputHuman(f, scripts)
+}
+
+func outputJSON(f *cmdutil.Factory, scripts []jmap.SieveScript) error {
+	if scripts == nil {
+		scripts = []jmap.SieveScript{}
+	}
+	encoder := json.NewEncoder(f.IOStreams.Out)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(scripts)
+}
+
+func outputHuman(f *cmdutil.Factory, scripts []jmap.SieveScript) error {
+	out := f.IOStreams.Out
+
+	if len(scripts) == 0 {
+		fmt.Fprintln(out, "No sieve scripts found.")
+		return nil
+	}
+
+	for _, s := range scripts {
+		state := "inactive"
+		if s.IsActive {
+			state = "active"
+		}
+
+		name := s.Name
+		if name == "" {
+			name = "(unnamed)"
+		}
+
+		fmt.Fprintf(out, "%-24s  %-8s  %s\n", s.ID, state, name)
+	}
+
+	return nil
+}
@@ -0,0 +1,22 @@
+package sieve
+
+import (
+	"github.com/marckohlbrugge/fastmail-cli/internal/cmdutil"
+	"github.com/spf13/cobra"
+)
+
+// NewCmdSieve creates the sieve command group.
+func NewCmdSieve(f *cmdutil.Factory) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "sieve <command>",
+		Short:   "View sieve filtering scripts",
+		Long:    "View the server-side sieve scripts that filter incoming mail.",
+		GroupID: "settings",
+		Example: `  $ fm sieve list
+  $ fm sieve list --json`,
+	}
+
+	cmd.AddCommand(NewCmdList(f))
+
+	return cmd
+}
@@ -0,0 +1,115 @@
+package sieve
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/marckohlbrugge/fastmail-cli/internal/cmdutil"
+	"github.com/marckohlbrugge/fastmail-cli/internal/iostreams"
+	"github.com/marckohlbrugge/fastmail-cli/internal/jmap"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupTest(t *testing.T, capabilities map[string]interface{}) (*cmdutil.Factory, *bytes.Buffer, *bytes.Buffer) {
+	t.Helper()
+
+	httpmock.Activate()
+	t.Cleanup(httpmock.DeactivateAndReset)
+
+	httpmock.RegisterResponder("GET", "https://api.test.com/jmap/session",
+		httpmock.NewJsonResponderOrPanic(200, map[string]interface{}{
+			"apiUrl": "https://api.test.com/jmap/api",
+			"accounts": map[string]interface{}{
+				"account-1": map[string]interface{}{},
+			},
+			"capabilities": capabilities,
+		}))
+
+	client := jmap.NewClient("test-token")
+	client.SetBaseURL("https://api.test.com")
+
+	ios, _, stdout, stderr := iostreams.Test()
+	f := &cmdutil.Factory{
+		IOStreams: ios,
+	}
+	f.SetJMAPClient(client)
+
+	return f, stdout, stderr
+}
+
+var sieveCapabilities = map[string]interface{}{
+	jmap.CoreCapability:  map[string]interface{}{},
+	jmap.SieveCapability: map[string]interface{}{},
+}
+
+func mockSieveScriptsResponse() httpmock.Responder {
+	return httpmock.NewJsonResponderOrPanic(200, map[string]interface{}{
+		"methodResponses": [][]interface{}{
+			{"SieveScript/get", map[string]interface{}{
+				"list": []map[string]interface{}{
+					{"id": "S1", "name": "filters", "blobId": "B1", "isActive": true},
+					{"id": "S2", "name": "old rules", "blobId": "B2", "isActive": false},
+				},
+			}, "sieveScripts"},
+		},
+	})
+}
+
+func TestListCommand(t *testing.T) {
+	t.Run("lists scripts in human format", func(t *testing.T) {
+		f, stdout, _ := setupTest(t, sieveCapabilities)
+		httpmock.RegisterResponder("POST", "https://api.test.com/jmap/api", mockSieveScriptsResponse())
+
+		cmd := NewCmdList(f)
+		cmd.SetArgs([]string{})
+		cmd.SetOut(stdout)
+		cmd.SetErr(&bytes.Buffer{})
+
+		err := cmd.Execute()
+
+		require.NoError(t, err)
+		lines := bytes.Split(bytes.TrimSpace(stdout.Bytes()), []byte("\n"))
+		require.Len(t, lines, 2)
+		assert.Regexp(t, `^S1\s+active\s+filters$`, string(lines[0]))
+		assert.Regexp(t, `^S2\s+inactive\s+old rules$`, string(lines[1]))
+	})
+
+	t.Run("outputs JSON", func(t *testing.T) {
+		f, stdout, _ := setupTest(t, sieveCapabilities)
+		httpmock.RegisterResponder("POST", "https://api.test.com/jmap/api", mockSieveScriptsResponse())
+
+		cmd := NewCmdList(f)
+		cmd.SetArgs([]string{"--json"})
+		cmd.SetOut(stdout)
+
+		err := cmd.Execute()
+
+		require.NoError(t, err)
+		var scripts []jmap.SieveScript
+		require.NoError(t, json.Unmarshal(stdout.Bytes(), &scripts))
+		require.Len(t, scripts, 2)
+		assert.Equal(t, "filters", scripts[0].Name)
+		assert.True(t, scripts[0].IsActive)
+		assert.False(t, scripts[1].IsActive)
+	})
+
+	t.Run("reports missing capability", func(t *testing.T) {
+		f, _, _ := setupTest(t, map[string]interface{}{
+			jmap.CoreCapability: map[string]interface{}{},
+		})
+
+		cmd := NewCmdList(f)
+		cmd.SetArgs([]string{})
+		cmd.SetOut(&bytes.Buffer{})
+		cmd.SetErr(&bytes.Buffer{})
+
+		err := cmd.Execute()
+
+		require.Error(t, err)
+		assert.Equal(t, "your account doesn't support sieve scripts (urn:ietf:params:jmap:sieve)", err.Error())
+		assert.Equal(t, 0, httpmock.GetCallCountInfo()["POST https://api.test.com/jmap/api"])
+	})
+}
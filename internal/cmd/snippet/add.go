@@ -0,0 +1,93 @@
+package snippet
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/marckohlbrugge/fastmail-cli/internal/cmdutil"
+	"github.com/marckohlbrugge/fastmail-cli/internal/snippet"
+	"github.com/spf13/cobra"
+)
+
+type addOptions struct {
+	Body     string
+	BodyFile string
+	Force    bool
+}
+
+// NewCmdAdd creates the snippet add command.
+func NewCmdAdd(f *cmdutil.Factory) *cobra.Command {
+	opts := &addOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "add <name>",
+		Short: "Add a snippet",
+		Long: `Save a snippet under a name.
+
+The text is taken from --body, --body-file, or stdin when it is a pipe.
+Names may contain letters, digits, '-' and '_'. An existing snippet is
+only replaced with --force.`,
+		Example: `  # Add a snippet
+  fm snippet add thanks --body "Thanks, I'll take a look."
+
+  # Add a longer snippet from a file
+  fm snippet add out-of-office --body-file ooo.txt
+
+  # Replace a snippet from stdin
+  pbpaste | fm snippet add thanks --force`,
+		Args: cmdutil.ExactArgs(1, "snippet name required\n\nUsage: fm snippet add <name>"),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runAdd(f, opts, args[0])
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.Body, "body", "", "Snippet text")
+	cmd.Flags().StringVar(&opts.BodyFile, "body-file", "", "Read snippet text from file")
+	cmd.Flags().BoolVar(&opts.Force, "force", false, "Replace an existing snippet")
+
+	return cmd
+}
+
+func runAdd(f *cmdutil.Factory, opts *addOptions, name string) error {
+	if err := snippet.ValidateName(name); err != nil {
+		return cmdutil.FlagErrorWrap(err)
+	}
+
+	text := opts.Body
+	switch {
+	case opts.BodyFile != "":
+		content, err := os.ReadFile(opts.BodyFile)
+		if err != nil {
+			return fmt.Errorf("failed to read body file: %w", err)
+		}
+		text = string(content)
+	case text == "" && !f.IOStreams.IsStdinTTY():
+		content, err := io.ReadAll(f.IOStreams.In)
+		if err != nil {
+			return fmt.Errorf("failed to read snippet from stdin: %w", err)
+		}
+		text = string(content)
+	}
+
+	if strings.TrimSpace(text) == "" {
+		return cmdutil.FlagErrorf("--body, --body-file, or text on stdin required")
+	}
+
+	store, err := f.Snippets()
+	if err != nil {
+		return err
+	}
+
+	if store.Exists(name) && !opts.Force {
+		return fmt.Errorf("snippet %q already exists: use --force to replace it", name)
+	}
+
+	if err := store.Save(name, text); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(f.IOStreams.Out, "Snippet %q saved.\n", name)
+	return nil
+}
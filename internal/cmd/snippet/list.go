@@ -0,0 +1,85 @@
+package snippet
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/marckohlbrugge/fastmail-cli/internal/cmdutil"
+	"github.com/spf13/cobra"
+)
+
+type listOptions struct {
+	JSON bool
+}
+
+// snippetInfo is the JSON output of fm snippet list.
+type snippetInfo struct {
+	Name string `json:"name"`
+	Text string `json:"text"`
+}
+
+// NewCmdList creates the snippet list command.
+func NewCmdList(f *cmdutil.Factory) *cobra.Command {
+	opts := &listOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List snippets",
+		Long:  "List saved snippets with the first line of each.",
+		Example: `  # List snippets
+  fm snippet list
+
+  # Output as JSON, including the full text
+  fm snippet list --json`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runList(f, opts)
+		},
+	}
+
+	cmd.Flags().BoolVar(&opts.JSON, "json", false, "Output in JSON format")
+
+	return cmd
+}
+
+func runList(f *cmdutil.Factory, opts *listOptions) error {
+	store, err := f.Snippets()
+	if err != nil {
+		return err
+	}
+
+	names, err := store.List()
+	if err != nil {
+		return err
+	}
+
+	snippets := make([]snippetInfo, 0, len(names))
+	for _, name := range names {
+		text, err := store.Get(name)
+		if err != nil {
+			return err
+		}
+		snippets = append(snippets, snippetInfo{Name: name, Text: text})
+	}
+
+	if f.OutputFormat(opts.JSON) == cmdutil.OutputJSON {
+		return cmdutil.WriteJSON(f.IOStreams.Out, snippets)
+	}
+
+	out := f.IOStreams.Out
+	if len(snippets) == 0 {
+		fmt.Fprintln(out, "No snippets found. Add one with 'fm snippet add <name>'.")
+		return nil
+	}
+
+	width := 0
+	for _, s := range snippets {
+		width = max(width, len(s.Name))
+	}
+	for _, s := range snippets {
+		firstLine, _, _ := strings.Cut(s.Text, "\n")
+		fmt.Fprintf(out, "%-*s  %s\n", width, s.Name, firstLine)
+	}
+
+	return nil
+}
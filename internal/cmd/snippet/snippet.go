@@ -0,0 +1,28 @@
+package snippet
+
+import (
+	"github.com/marckohlbrugge/fastmail-cli/internal/cmdutil"
+	"github.com/spf13/cobra"
+)
+
+// NewCmdSnippet creates the snippet parent command.
+func NewCmdSnippet(f *cmdutil.Factory) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "snippet <command>",
+		Short: "Manage canned responses",
+		Long: `Manage snippets: canned text for common replies.
+
+Snippets are stored as text files in the snippets directory beside the
+config file (~/.config/fm/snippets/<name>.txt by default). Use them with
+'fm draft new --snippet' or 'fm draft reply --snippet'.`,
+		Example: `  $ fm snippet add thanks --body "Thanks, I'll take a look."
+  $ fm snippet list
+  $ fm draft reply M1234567890 --snippet thanks`,
+		GroupID: "draft",
+	}
+
+	cmd.AddCommand(NewCmdList(f))
+	cmd.AddCommand(NewCmdAdd(f))
+
+	return cmd
+}
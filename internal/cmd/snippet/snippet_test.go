@@ -0,0 +1,183 @@
+package snippet
+
+import (
+	"bytes"
+	"encoding/json"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/marckohlbrugge/fastmail-cli/internal/cmdutil"
+	"github.com/marckohlbrugge/fastmail-cli/internal/iostreams"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupTest(t *testing.T) (*cmdutil.Factory, *bytes.Buffer, *bytes.Buffer) {
+	t.Helper()
+
+	ios, _, stdout, stderr := iostreams.Test()
+	ios.SetStdinTTY(true)
+	f := &cmdutil.Factory{
+		IOStreams:  ios,
+		ConfigPath: filepath.Join(t.TempDir(), "config.yml"),
+	}
+
+	return f, stdout, stderr
+}
+
+func TestAddCommand(t *testing.T) {
+	t.Run("saves a snippet from --body", func(t *testing.T) {
+		f, stdout, _ := setupTest(t)
+
+		cmd := NewCmdAdd(f)
+		cmd.SetArgs([]string{"thanks", "--body", "Thanks!"})
+		cmd.SetOut(stdout)
+		cmd.SetErr(&bytes.Buffer{})
+
+		err := cmd.Execute()
+
+		require.NoError(t, err)
+		assert.Equal(t, "Snippet \"thanks\" saved.\n", stdout.String())
+
+		store, err := f.Snippets()
+		require.NoError(t, err)
+		text, err := store.Get("thanks")
+		require.NoError(t, err)
+		assert.Equal(t, "Thanks!", text)
+	})
+
+	t.Run("reads the snippet from stdin", func(t *testing.T) {
+		f, _, _ := setupTest(t)
+		f.IOStreams.SetStdinTTY(false)
+		f.IOStreams.In = strings.NewReader("Piped text\n")
+
+		cmd := NewCmdAdd(f)
+		cmd.SetArgs([]string{"piped"})
+		cmd.SetOut(&bytes.Buffer{})
+		cmd.SetErr(&bytes.Buffer{})
+
+		err := cmd.Execute()
+
+		require.NoError(t, err)
+		store, _ := f.Snippets()
+		text, err := store.Get("piped")
+		require.NoError(t, err)
+		assert.Equal(t, "Piped text", text)
+	})
+
+	t.Run("refuses to replace without --force", func(t *testing.T) {
+		f, _, _ := setupTest(t)
+		store, _ := f.Snippets()
+		require.NoError(t, store.Save("thanks", "Old"))
+
+		cmd := NewCmdAdd(f)
+		cmd.SetArgs([]string{"thanks", "--body", "New"})
+		cmd.SetOut(&bytes.Buffer{})
+		cmd.SetErr(&bytes.Buffer{})
+
+		err := cmd.Execute()
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "already exists")
+		text, _ := store.Get("thanks")
+		assert.Equal(t, "Old", text)
+	})
+
+	t.Run("replaces with --force", func(t *testing.T) {
+		f, _, _ := setupTest(t)
+		store, _ := f.Snippets()
+		require.NoError(t, store.Save("thanks", "Old"))
+
+		cmd := NewCmdAdd(f)
+		cmd.SetArgs([]string{"thanks", "--body", "New", "--force"})
+		cmd.SetOut(&bytes.Buffer{})
+		cmd.SetErr(&bytes.Buffer{})
+
+		err := cmd.Execute()
+
+		require.NoError(t, err)
+		text, _ := store.Get("thanks")
+		assert.Equal(t, "New", text)
+	})
+
+	t.Run("requires text", func(t *testing.T) {
+		f, _, _ := setupTest(t)
+
+		cmd := NewCmdAdd(f)
+		cmd.SetArgs([]string{"empty"})
+		cmd.SetOut(&bytes.Buffer{})
+		cmd.SetErr(&bytes.Buffer{})
+
+		err := cmd.Execute()
+
+		require.Error(t, err)
+		var flagErr *cmdutil.FlagError
+		assert.ErrorAs(t, err, &flagErr)
+	})
+
+	t.Run("rejects invalid names", func(t *testing.T) {
+		f, _, _ := setupTest(t)
+
+		cmd := NewCmdAdd(f)
+		cmd.SetArgs([]string{"my/snippet", "--body", "x"})
+		cmd.SetOut(&bytes.Buffer{})
+		cmd.SetErr(&bytes.Buffer{})
+
+		err := cmd.Execute()
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid snippet name")
+	})
+}
+
+func TestListCommand(t *testing.T) {
+	t.Run("lists snippets with their first line", func(t *testing.T) {
+		f, stdout, _ := setupTest(t)
+		store, _ := f.Snippets()
+		require.NoError(t, store.Save("thanks", "Thanks!\nBest, Me"))
+		require.NoError(t, store.Save("out-of-office", "I'm away until Monday."))
+
+		cmd := NewCmdList(f)
+		cmd.SetArgs([]string{})
+		cmd.SetOut(stdout)
+		cmd.SetErr(&bytes.Buffer{})
+
+		err := cmd.Execute()
+
+		require.NoError(t, err)
+		assert.Equal(t, "out-of-office  I'm away until Monday.\nthanks         Thanks!\n", stdout.String())
+	})
+
+	t.Run("outputs JSON format", func(t *testing.T) {
+		f, stdout, _ := setupTest(t)
+		store, _ := f.Snippets()
+		require.NoError(t, store.Save("thanks", "Thanks!\nBest, Me"))
+
+		cmd := NewCmdList(f)
+		cmd.SetArgs([]string{"--json"})
+		cmd.SetOut(stdout)
+		cmd.SetErr(&bytes.Buffer{})
+
+		err := cmd.Execute()
+
+		require.NoError(t, err)
+		var result []map[string]string
+		require.NoError(t, json.Unmarshal(stdout.Bytes(), &result))
+		assert.Equal(t, []map[string]string{{"name": "thanks", "text": "Thanks!\nBest, Me"}}, result)
+	})
+
+	t.Run("shows message when there are no snippets", func(t *testing.T) {
+		f, stdout, _ := setupTest(t)
+
+		cmd := NewCmdList(f)
+		cmd.SetArgs([]string{})
+		cmd.SetOut(stdout)
+		cmd.SetErr(&bytes.Buffer{})
+
+		err := cmd.Execute()
+
+		require.NoError(t, err)
+		assert.Contains(t, stdout.String(), "No snippets found")
+	})
+}
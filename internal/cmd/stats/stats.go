@@ -0,0 +1,178 @@
+package stats
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/marckohlbrugge/fastmail-cli/internal/cmdutil"
+	"github.com/marckohlbrugge/fastmail-cli/internal/iostreams"
+	"github.com/marckohlbrugge/fastmail-cli/internal/jmap"
+	"github.com/spf13/cobra"
+)
+
+type statsOptions struct {
+	JSON bool
+}
+
+// FolderStats holds counts for a top-level folder, including its subfolders.
+type FolderStats struct {
+	ID     string `json:"id"`
+	Name   string `json:"name"`
+	Role   string `json:"role,omitempty"`
+	Total  int    `json:"total"`
+	Unread int    `json:"unread"`
+}
+
+// Stats is an overview of the account's mailboxes.
+type Stats struct {
+	Unread      int           `json:"unread"`
+	InboxTotal  int           `json:"inboxTotal"`
+	InboxUnread int           `json:"inboxUnread"`
+	Folders     []FolderStats `json:"folders"`
+}
+
+// NewCmdStats creates the stats command.
+func NewCmdStats(f *cmdutil.Factory) *cobra.Command {
+	opts := &statsOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "stats",
+		Short: "Show an account overview",
+		Long: `Show an overview of your account: total unread emails, unread emails
+per top-level folder (including its subfolders), and the inbox size.`,
+		Example: `  # Show account overview
+  fm stats
+
+  # Output as JSON
+  fm stats --json`,
+		GroupID: "core",
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runStats(f, opts)
+		},
+	}
+
+	cmd.Flags().BoolVar(&opts.JSON, "json", false, "Output in JSON format")
+
+	return cmd
+}
+
+func runStats(f *cmdutil.Factory, opts *statsOptions) error {
+	client, err := f.JMAPClient()
+	if err != nil {
+		return err
+	}
+
+	mailboxes, err := client.GetMailboxes()
+	if err != nil {
+		return err
+	}
+
+	stats := buildStats(mailboxes)
+
+	if opts.JSON {
+		encoder := json.NewEncoder(f.IOStreams.Out)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(stats)
+	}
+
+	return outputHuman(f, stats)
+}
+
+// buildStats aggregates mailbox counts, rolling subfolders up into their
+// top-level folder.
+func buildStats(mailboxes []jmap.Mailbox) Stats {
+	byID := make(map[string]jmap.Mailbox, len(mailboxes))
+	for _, mb := range mailboxes {
+		byID[mb.ID] = mb
+	}
+
+	// topLevel follows parent links to the root, guarding against cycles
+	topLevel := func(mb jmap.Mailbox) string {
+		seen := map[string]bool{}
+		for mb.ParentID != "" && !seen[mb.ID] {
+			parent, ok := byID[mb.ParentID]
+			if !ok {
+				break
+			}
+			seen[mb.ID] = true
+			mb = parent
+		}
+		return mb.ID
+	}
+
+	stats := Stats{Folders: []FolderStats{}}
+	index := make(map[string]int)
+
+	var roots []jmap.Mailbox
+	for _, mb := range mailboxes {
+		if topLevel(mb) == mb.ID {
+			roots = append(roots, mb)
+		}
+	}
+	sort.SliceStable(roots, func(i, j int) bool {
+		if roots[i].SortOrder != roots[j].SortOrder {
+			return roots[i].SortOrder < roots[j].SortOrder
+		}
+		return strings.ToLower(roots[i].Name) < strings.ToLower(roots[j].Name)
+	})
+	for _, mb := range roots {
+		index[mb.ID] = len(stats.Folders)
+		stats.Folders = append(stats.Folders, FolderStats{ID: mb.ID, Name: mb.Name, Role: mb.Role})
+	}
+
+	for _, mb := range mailboxes {
+		folder := &stats.Folders[index[topLevel(mb)]]
+		folder.Total += mb.TotalEmails
+		folder.Unread += mb.UnreadEmails
+
+		stats.Unread += mb.UnreadEmails
+		if mb.Role == "inbox" {
+			stats.InboxTotal = mb.TotalEmails
+			stats.InboxUnread = mb.UnreadEmails
+		}
+	}
+
+	return stats
+}
+
+func outputHuman(f *cmdutil.Factory, stats Stats) error {
+	out := f.IOStreams.Out
+	cs := f.IOStreams.ColorScheme()
+
+	fmt.Fprintf(out, "Unread: %s\n", highlight(stats.Unread, cs))
+	fmt.Fprintf(out, "Inbox:  %s emails, %s unread\n",
+		highlight(stats.InboxTotal, cs), highlight(stats.InboxUnread, cs))
+
+	var unread []FolderStats
+	width := 0
+	for _, folder := range stats.Folders {
+		if folder.Unread > 0 {
+			unread = append(unread, folder)
+			width = max(width, len(folder.Name))
+		}
+	}
+
+	if len(unread) == 0 {
+		fmt.Fprintln(out, "\nNo unread emails.")
+		return nil
+	}
+
+	fmt.Fprintln(out, "\nUnread by folder:")
+	for _, folder := range unread {
+		fmt.Fprintf(out, "  %-*s  %s\n", width, folder.Name, highlight(folder.Unread, cs))
+	}
+
+	return nil
+}
+
+// highlight formats a count, in bold when it is non-zero.
+func highlight(n int, cs *iostreams.ColorScheme) string {
+	s := fmt.Sprintf("%d", n)
+	if n > 0 {
+		return cs.Bold(s)
+	}
+	return s
+}
@@ -0,0 +1,122 @@
+package stats
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/marckohlbrugge/fastmail-cli/internal/cmdutil"
+	"github.com/marckohlbrugge/fastmail-cli/internal/iostreams"
+	"github.com/marckohlbrugge/fastmail-cli/internal/jmap"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupTest(t *testing.T) (*cmdutil.Factory, *bytes.Buffer, *bytes.Buffer) {
+	t.Helper()
+
+	httpmock.Activate()
+	t.Cleanup(httpmock.DeactivateAndReset)
+
+	httpmock.RegisterResponder("GET", "https://api.test.com/jmap/session",
+		httpmock.NewJsonResponderOrPanic(200, map[string]interface{}{
+			"apiUrl": "https://api.test.com/jmap/api",
+			"accounts": map[string]interface{}{
+				"account-1": map[string]interface{}{},
+			},
+		}))
+
+	client := jmap.NewClient("test-token")
+	client.SetBaseURL("https://api.test.com")
+
+	ios, _, stdout, stderr := iostreams.Test()
+	f := &cmdutil.Factory{
+		IOStreams: ios,
+	}
+	f.SetJMAPClient(client)
+
+	return f, stdout, stderr
+}
+
+func mockMailboxes(mailboxes []map[string]interface{}) httpmock.Responder {
+	return httpmock.NewJsonResponderOrPanic(200, map[string]interface{}{
+		"methodResponses": [][]interface{}{
+			{"Mailbox/get", map[string]interface{}{"list": mailboxes}, "mailboxes"},
+		},
+	})
+}
+
+var testMailboxes = []map[string]interface{}{
+	{"id": "inbox-1", "name": "Inbox", "role": "inbox", "sortOrder": 1, "totalEmails": 120, "unreadEmails": 4},
+	{"id": "work-1", "name": "Work", "sortOrder": 10, "totalEmails": 50, "unreadEmails": 2},
+	{"id": "work-2", "name": "Clients", "parentId": "work-1", "sortOrder": 10, "totalEmails": 30, "unreadEmails": 3},
+	{"id": "archive-1", "name": "Archive", "role": "archive", "sortOrder": 5, "totalEmails": 900, "unreadEmails": 0},
+}
+
+func TestStatsCommand(t *testing.T) {
+	t.Run("shows unread totals per top-level folder", func(t *testing.T) {
+		f, stdout, _ := setupTest(t)
+
+		httpmock.RegisterResponder("POST", "https://api.test.com/jmap/api", mockMailboxes(testMailboxes))
+
+		cmd := NewCmdStats(f)
+		cmd.SetArgs([]string{})
+		cmd.SetOut(stdout)
+		cmd.SetErr(&bytes.Buffer{})
+
+		err := cmd.Execute()
+
+		require.NoError(t, err)
+		assert.Equal(t, "Unread: 9\n"+
+			"Inbox:  120 emails, 4 unread\n"+
+			"\n"+
+			"Unread by folder:\n"+
+			"  Inbox  4\n"+
+			"  Work   5\n", stdout.String())
+	})
+
+	t.Run("reports when nothing is unread", func(t *testing.T) {
+		f, stdout, _ := setupTest(t)
+
+		httpmock.RegisterResponder("POST", "https://api.test.com/jmap/api", mockMailboxes([]map[string]interface{}{
+			{"id": "inbox-1", "name": "Inbox", "role": "inbox", "totalEmails": 3, "unreadEmails": 0},
+		}))
+
+		cmd := NewCmdStats(f)
+		cmd.SetArgs([]string{})
+		cmd.SetOut(stdout)
+		cmd.SetErr(&bytes.Buffer{})
+
+		err := cmd.Execute()
+
+		require.NoError(t, err)
+		assert.Contains(t, stdout.String(), "No unread emails.")
+	})
+
+	t.Run("outputs JSON", func(t *testing.T) {
+		f, stdout, _ := setupTest(t)
+
+		httpmock.RegisterResponder("POST", "https://api.test.com/jmap/api", mockMailboxes(testMailboxes))
+
+		cmd := NewCmdStats(f)
+		cmd.SetArgs([]string{"--json"})
+		cmd.SetOut(stdout)
+		cmd.SetErr(&bytes.Buffer{})
+
+		err := cmd.Execute()
+
+		require.NoError(t, err)
+
+		var result Stats
+		require.NoError(t, json.Unmarshal(stdout.Bytes(), &result))
+		assert.Equal(t, 9, result.Unread)
+		assert.Equal(t, 120, result.InboxTotal)
+		assert.Equal(t, 4, result.InboxUnread)
+		assert.Equal(t, []FolderStats{
+			{ID: "inbox-1", Name: "Inbox", Role: "inbox", Total: 120, Unread: 4},
+			{ID: "archive-1", Name: "Archive", Role: "archive", Total: 900, Unread: 0},
+			{ID: "work-1", Name: "Work", Total: 80, Unread: 5},
+		}, result.Folders)
+	})
+}
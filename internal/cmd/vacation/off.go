@@ -0,0 +1,38 @@
+package vacation
+
+import (
+	"fmt"
+
+	"github.com/marckohlbrugge/fastmail-cli/internal/cmdutil"
+	"github.com/spf13/cobra"
+)
+
+// NewCmdOff creates the vacation off command.
+func NewCmdOff(f *cmdutil.Factory) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "off",
+		Short:   "Turn off the vacation responder",
+		Long:    "Turn off the vacation responder. The message is kept for next time.",
+		Example: `  fm vacation off`,
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runOff(f)
+		},
+	}
+
+	return cmd
+}
+
+func runOff(f *cmdutil.Factory) error {
+	client, err := f.JMAPClient()
+	if err != nil {
+		return err
+	}
+
+	if err := client.UpdateVacationResponse(map[string]interface{}{"isEnabled": false}); err != nil {
+		return err
+	}
+
+	fmt.Fprintln(f.IOStreams.Out, "Vacation responder turned off.")
+	return nil
+}
@@ -0,0 +1,113 @@
+package vacation
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/marckohlbrugge/fastmail-cli/internal/cmdutil"
+	"github.com/spf13/cobra"
+)
+
+type setOptions struct {
+	Subject string
+	Message string
+	From    string
+	To      string
+}
+
+// NewCmdSet creates the vacation set command.
+func NewCmdSet(f *cmdutil.Factory) *cobra.Command {
+	opts := &setOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "set",
+		Short: "Turn on the vacation responder",
+		Long: `Turn on the vacation responder with the given message.
+
+Dates may be given as YYYY-MM-DD or as RFC 3339 timestamps. A --to date
+without a time includes that whole day. Without --from the reply starts
+immediately; without --to it runs until turned off.`,
+		Example: `  # Reply until turned off
+  fm vacation set --message "I'm away and will reply when I'm back."
+
+  # Reply during a date range
+  fm vacation set --subject "Out of office" --message "Back on the 15th" \
+    --from 2024-07-01 --to 2024-07-14`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			updates, err := buildVacationUpdates(cmd, opts)
+			if err != nil {
+				return err
+			}
+			return runSet(f, updates)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.Subject, "subject", "", "Subject of the auto-reply")
+	cmd.Flags().StringVar(&opts.Message, "message", "", "Plain text body of the auto-reply")
+	cmd.Flags().StringVar(&opts.From, "from", "", "Start `date` of the vacation")
+	cmd.Flags().StringVar(&opts.To, "to", "", "End `date` of the vacation")
+
+	_ = cmd.MarkFlagRequired("message")
+
+	return cmd
+}
+
+// buildVacationUpdates validates the flags and returns the VacationResponse patch.
+func buildVacationUpdates(cmd *cobra.Command, opts *setOptions) (map[string]interface{}, error) {
+	if opts.Message == "" {
+		return nil, cmdutil.FlagErrorf("--message is required")
+	}
+
+	updates := map[string]interface{}{
+		"isEnabled": true,
+		"textBody":  opts.Message,
+		"fromDate":  nil,
+		"toDate":    nil,
+	}
+	if cmd.Flags().Changed("subject") {
+		updates["subject"] = opts.Subject
+	}
+
+	var from, to time.Time
+	if opts.From != "" {
+		t, _, err := cmdutil.ParseDate(opts.From)
+		if err != nil {
+			return nil, cmdutil.FlagErrorf("--from: %s", err)
+		}
+		from = t
+		updates["fromDate"] = from.UTC().Format(time.RFC3339)
+	}
+	if opts.To != "" {
+		t, dateOnly, err := cmdutil.ParseDate(opts.To)
+		if err != nil {
+			return nil, cmdutil.FlagErrorf("--to: %s", err)
+		}
+		if dateOnly {
+			// Include the whole end day
+			t = t.AddDate(0, 0, 1)
+		}
+		to = t
+		updates["toDate"] = to.UTC().Format(time.RFC3339)
+	}
+
+	if !from.IsZero() && !to.IsZero() && !to.After(from) {
+		return nil, cmdutil.FlagErrorf("--to must be after --from")
+	}
+
+	return updates, nil
+}
+
+func runSet(f *cmdutil.Factory, updates map[string]interface{}) error {
+	client, err := f.JMAPClient()
+	if err != nil {
+		return err
+	}
+
+	if err := client.UpdateVacationResponse(updates); err != nil {
+		return err
+	}
+
+	fmt.Fprintln(f.IOStreams.Out, "Vacation responder turned on.")
+	return nil
+}
@@ -0,0 +1,78 @@
+package vacation
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/marckohlbrugge/fastmail-cli/internal/cmdutil"
+	"github.com/spf13/cobra"
+)
+
+type statusOptions struct {
+	JSON bool
+}
+
+// NewCmdStatus creates the vacation status command.
+func NewCmdStatus(f *cmdutil.Factory) *cobra.Command {
+	opts := &statusOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "status",
+		Short: "Show the vacation responder",
+		Long:  "Show whether the vacation responder is on, its date range, and its message.",
+		Example: `  # Show the current auto-reply
+  fm vacation status
+
+  # Output as JSON
+  fm vacation status --json`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runStatus(f, opts)
+		},
+	}
+
+	cmd.Flags().BoolVar(&opts.JSON, "json", false, "Output in JSON format")
+
+	return cmd
+}
+
+func runStatus(f *cmdutil.Factory, opts *statusOptions) error {
+	client, err := f.JMAPClient()
+	if err != nil {
+		return err
+	}
+
+	vacation, err := client.GetVacationResponse()
+	if err != nil {
+		return err
+	}
+
+	if opts.JSON {
+		encoder := json.NewEncoder(f.IOStreams.Out)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(vacation)
+	}
+
+	out := f.IOStreams.Out
+
+	if !vacation.IsEnabled {
+		fmt.Fprintln(out, "Vacation responder: off")
+		return nil
+	}
+
+	fmt.Fprintln(out, "Vacation responder: on")
+	fmt.Fprintf(out, "From:    %s\n", valueOr(vacation.FromDate, "(now)"))
+	fmt.Fprintf(out, "Until:   %s\n", valueOr(vacation.ToDate, "(until turned off)"))
+	fmt.Fprintf(out, "Subject: %s\n", valueOr(vacation.Subject, "(default)"))
+	fmt.Fprintln(out, "")
+	fmt.Fprintln(out, valueOr(vacation.TextBody, ""))
+
+	return nil
+}
+
+func valueOr(value *string, fallback string) string {
+	if value == nil || *value == "" {
+		return fallback
+	}
+	return *value
+}
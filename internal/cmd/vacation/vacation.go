@@ -0,0 +1,25 @@
+package vacation
+
+import (
+	"github.com/marckohlbrugge/fastmail-cli/internal/cmdutil"
+	"github.com/spf13/cobra"
+)
+
+// NewCmdVacation creates the vacation command group.
+func NewCmdVacation(f *cmdutil.Factory) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "vacation <command>",
+		Short:   "Manage the vacation responder",
+		Long:    "View and change the automatic vacation reply.",
+		GroupID: "settings",
+		Example: `  $ fm vacation status
+  $ fm vacation set --subject "Out of office" --message "Back on Monday" --to 2024-07-14
+  $ fm vacation off`,
+	}
+
+	cmd.AddCommand(NewCmdStatus(f))
+	cmd.AddCommand(NewCmdSet(f))
+	cmd.AddCommand(NewCmdOff(f))
+
+	return cmd
+}
@@ -0,0 +1,248 @@
+package vacation
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/marckohlbrugge/fastmail-cli/internal/cmdutil"
+	"github.com/marckohlbrugge/fastmail-cli/internal/iostreams"
+	"github.com/marckohlbrugge/fastmail-cli/internal/jmap"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupTest(t *testing.T) (*cmdutil.Factory, *bytes.Buffer, *bytes.Buffer) {
+	t.Helper()
+
+	httpmock.Activate()
+	t.Cleanup(httpmock.DeactivateAndReset)
+
+	httpmock.RegisterResponder("GET", "https://api.test.com/jmap/session",
+		httpmock.NewJsonResponderOrPanic(200, map[string]interface{}{
+			"apiUrl": "https://api.test.com/jmap/api",
+			"accounts": map[string]interface{}{
+				"account-1": map[string]interface{}{},
+			},
+			"capabilities": map[string]interface{}{
+				jmap.CoreCapability:     map[string]interface{}{},
+				jmap.VacationCapability: map[string]interface{}{},
+			},
+		}))
+
+	client := jmap.NewClient("test-token")
+	client.SetBaseURL("https://api.test.com")
+
+	ios, _, stdout, stderr := iostreams.Test()
+	f := &cmdutil.Factory{
+		IOStreams: ios,
+	}
+	f.SetJMAPClient(client)
+
+	return f, stdout, stderr
+}
+
+// captureVacationSet records the patch sent with VacationResponse/set.
+func captureVacationSet(captured *map[string]interface{}) httpmock.Responder {
+	return func(req *http.Request) (*http.Response, error) {
+		var jmapReq jmap.Request
+		json.NewDecoder(req.Body).Decode(&jmapReq)
+
+		args := jmapReq.MethodCalls[0][1].(map[string]interface{})
+		update := args["update"].(map[string]interface{})
+		*captured = update["singleton"].(map[string]interface{})
+
+		return httpmock.NewJsonResponse(200, map[string]interface{}{
+			"methodResponses": [][]interface{}{
+				{"VacationResponse/set", map[string]interface{}{
+					"updated": map[string]interface{}{"singleton": nil},
+				}, "updateVacation"},
+			},
+		})
+	}
+}
+
+// Status command tests
+
+func TestStatusCommand(t *testing.T) {
+	t.Run("shows enabled responder", func(t *testing.T) {
+		f, stdout, _ := setupTest(t)
+
+		httpmock.RegisterResponder("POST", "https://api.test.com/jmap/api",
+			httpmock.NewJsonResponderOrPanic(200, map[string]interface{}{
+				"methodResponses": [][]interface{}{
+					{"VacationResponse/get", map[string]interface{}{
+						"list": []map[string]interface{}{
+							{
+								"id":        "singleton",
+								"isEnabled": true,
+								"fromDate":  "2024-07-01T00:00:00Z",
+								"toDate":    nil,
+								"subject":   "Out of office",
+								"textBody":  "Back soon",
+							},
+						},
+					}, "vacation"},
+				},
+			}))
+
+		cmd := NewCmdStatus(f)
+		cmd.SetArgs([]string{})
+		cmd.SetOut(stdout)
+		cmd.SetErr(&bytes.Buffer{})
+
+		err := cmd.Execute()
+
+		require.NoError(t, err)
+		output := stdout.String()
+		assert.Contains(t, output, "Vacation responder: on")
+		assert.Contains(t, output, "2024-07-01T00:00:00Z")
+		assert.Contains(t, output, "(until turned off)")
+		assert.Contains(t, output, "Out of office")
+		assert.Contains(t, output, "Back soon")
+	})
+
+	t.Run("shows disabled responder", func(t *testing.T) {
+		f, stdout, _ := setupTest(t)
+
+		httpmock.RegisterResponder("POST", "https://api.test.com/jmap/api",
+			httpmock.NewJsonResponderOrPanic(200, map[string]interface{}{
+				"methodResponses": [][]interface{}{
+					{"VacationResponse/get", map[string]interface{}{
+						"list": []map[string]interface{}{
+							{"id": "singleton", "isEnabled": false},
+						},
+					}, "vacation"},
+				},
+			}))
+
+		cmd := NewCmdStatus(f)
+		cmd.SetArgs([]string{"--json"})
+		cmd.SetOut(stdout)
+		cmd.SetErr(&bytes.Buffer{})
+
+		err := cmd.Execute()
+
+		require.NoError(t, err)
+
+		var result jmap.VacationResponse
+		require.NoError(t, json.Unmarshal(stdout.Bytes(), &result))
+		assert.False(t, result.IsEnabled)
+	})
+}
+
+// Set command tests
+
+func TestSetCommand(t *testing.T) {
+	t.Run("enables with date range", func(t *testing.T) {
+		f, stdout, _ := setupTest(t)
+
+		var captured map[string]interface{}
+		httpmock.RegisterResponder("POST", "https://api.test.com/jmap/api", captureVacationSet(&captured))
+
+		cmd := NewCmdSet(f)
+		cmd.SetArgs([]string{"--subject", "Away", "--message", "Back soon",
+			"--from", "2024-07-01T00:00:00Z", "--to", "2024-07-14T18:00:00Z"})
+		cmd.SetOut(stdout)
+		cmd.SetErr(&bytes.Buffer{})
+
+		err := cmd.Execute()
+
+		require.NoError(t, err)
+		assert.Equal(t, true, captured["isEnabled"])
+		assert.Equal(t, "Away", captured["subject"])
+		assert.Equal(t, "Back soon", captured["textBody"])
+		assert.Equal(t, "2024-07-01T00:00:00Z", captured["fromDate"])
+		assert.Equal(t, "2024-07-14T18:00:00Z", captured["toDate"])
+		assert.Contains(t, stdout.String(), "Vacation responder turned on.")
+	})
+
+	t.Run("clears dates when not given", func(t *testing.T) {
+		f, _, _ := setupTest(t)
+
+		var captured map[string]interface{}
+		httpmock.RegisterResponder("POST", "https://api.test.com/jmap/api", captureVacationSet(&captured))
+
+		cmd := NewCmdSet(f)
+		cmd.SetArgs([]string{"--message", "Away"})
+		cmd.SetOut(&bytes.Buffer{})
+		cmd.SetErr(&bytes.Buffer{})
+
+		err := cmd.Execute()
+
+		require.NoError(t, err)
+		assert.Contains(t, captured, "fromDate")
+		assert.Nil(t, captured["fromDate"])
+		assert.Nil(t, captured["toDate"])
+		assert.NotContains(t, captured, "subject")
+	})
+
+	t.Run("rejects end before start", func(t *testing.T) {
+		f, _, _ := setupTest(t)
+
+		cmd := NewCmdSet(f)
+		cmd.SetArgs([]string{"--message", "Away", "--from", "2024-07-14", "--to", "2024-07-01"})
+		cmd.SetOut(&bytes.Buffer{})
+		cmd.SetErr(&bytes.Buffer{})
+
+		err := cmd.Execute()
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "--to must be after --from")
+	})
+
+	t.Run("single day range is valid", func(t *testing.T) {
+		f, _, _ := setupTest(t)
+
+		var captured map[string]interface{}
+		httpmock.RegisterResponder("POST", "https://api.test.com/jmap/api", captureVacationSet(&captured))
+
+		cmd := NewCmdSet(f)
+		cmd.SetArgs([]string{"--message", "Away", "--from", "2024-07-01", "--to", "2024-07-01"})
+		cmd.SetOut(&bytes.Buffer{})
+		cmd.SetErr(&bytes.Buffer{})
+
+		err := cmd.Execute()
+
+		require.NoError(t, err)
+		assert.NotNil(t, captured["toDate"])
+	})
+
+	t.Run("rejects invalid date", func(t *testing.T) {
+		f, _, _ := setupTest(t)
+
+		cmd := NewCmdSet(f)
+		cmd.SetArgs([]string{"--message", "Away", "--from", "tomorrow"})
+		cmd.SetOut(&bytes.Buffer{})
+		cmd.SetErr(&bytes.Buffer{})
+
+		err := cmd.Execute()
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid date")
+	})
+}
+
+// Off command tests
+
+func TestOffCommand(t *testing.T) {
+	t.Run("disables responder", func(t *testing.T) {
+		f, stdout, _ := setupTest(t)
+
+		var captured map[string]interface{}
+		httpmock.RegisterResponder("POST", "https://api.test.com/jmap/api", captureVacationSet(&captured))
+
+		cmd := NewCmdOff(f)
+		cmd.SetArgs([]string{})
+		cmd.SetOut(stdout)
+		cmd.SetErr(&bytes.Buffer{})
+
+		err := cmd.Execute()
+
+		require.NoError(t, err)
+		assert.Equal(t, map[string]interface{}{"isEnabled": false}, captured)
+		assert.Contains(t, stdout.String(), "Vacation responder turned off.")
+	})
+}
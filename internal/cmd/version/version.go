@@ -2,22 +2,77 @@ package version
 
 import (
 	"fmt"
+	"runtime"
 
 	"github.com/marckohlbrugge/fastmail-cli/internal/cmdutil"
 	"github.com/spf13/cobra"
 )
 
+// BuildInfo describes the build, as set with -ldflags at build time.
+type BuildInfo struct {
+	Version string
+	Commit  string
+	Date    string
+}
+
+// versionInfo is the JSON output of fm version.
+type versionInfo struct {
+	Version   string `json:"version"`
+	Commit    string `json:"commit"`
+	Date      string `json:"date"`
+	GoVersion string `json:"goVersion"`
+	OS        string `json:"os"`
+	Arch      string `json:"arch"`
+}
+
+type versionOptions struct {
+	JSON bool
+}
+
 // NewCmdVersion creates the version command.
-func NewCmdVersion(f *cmdutil.Factory, version string) *cobra.Command {
+func NewCmdVersion(f *cmdutil.Factory, build BuildInfo) *cobra.Command {
+	opts := &versionOptions{}
+
 	cmd := &cobra.Command{
-		Use:     "version",
-		Short:   "Show fm version",
+		Use:   "version",
+		Short: "Show fm version",
+		Long: `Show the fm version along with the commit and date it was built from,
+the Go version, and the platform. Include this when reporting bugs.`,
+		Example: `  # Show version details
+  fm version
+
+  # Output as JSON
+  fm version --json`,
 		GroupID: "utility",
 		Args:    cobra.NoArgs,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			fmt.Fprintf(f.IOStreams.Out, "fm version %s\n", version)
-			return nil
+			return runVersion(f, opts, build)
 		},
 	}
+
+	cmd.Flags().BoolVar(&opts.JSON, "json", false, "Output in JSON format")
+
 	return cmd
 }
+
+func runVersion(f *cmdutil.Factory, opts *versionOptions, build BuildInfo) error {
+	info := versionInfo{
+		Version:   build.Version,
+		Commit:    build.Commit,
+		Date:      build.Date,
+		GoVersion: runtime.Version(),
+		OS:        runtime.GOOS,
+		Arch:      runtime.GOARCH,
+	}
+
+	if f.OutputFormat(opts.JSON) == cmdutil.OutputJSON {
+		return cmdutil.WriteJSON(f.IOStreams.Out, info)
+	}
+
+	out := f.IOStreams.Out
+	fmt.Fprintf(out, "fm version %s\n", info.Version)
+	fmt.Fprintf(out, "Commit: %s\n", info.Commit)
+	fmt.Fprintf(out, "Built:  %s\n", info.Date)
+	fmt.Fprintf(out, "Go:     %s %s/%s\n", info.GoVersion, info.OS, info.Arch)
+	return nil
+}
@@ -0,0 +1,60 @@
+package version
+
+import (
+	"bytes"
+	"encoding/json"
+	"runtime"
+	"testing"
+
+	"github.com/marckohlbrugge/fastmail-cli/internal/cmdutil"
+	"github.com/marckohlbrugge/fastmail-cli/internal/iostreams"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testBuild = BuildInfo{Version: "1.2.3", Commit: "abc1234", Date: "2024-03-05T14:30:00Z"}
+
+func TestVersionCommand(t *testing.T) {
+	t.Run("prints version details", func(t *testing.T) {
+		ios, _, stdout, _ := iostreams.Test()
+		f := &cmdutil.Factory{IOStreams: ios}
+
+		cmd := NewCmdVersion(f, testBuild)
+		cmd.SetArgs([]string{})
+		cmd.SetOut(stdout)
+		cmd.SetErr(&bytes.Buffer{})
+
+		err := cmd.Execute()
+
+		require.NoError(t, err)
+		output := stdout.String()
+		assert.Contains(t, output, "fm version 1.2.3\n")
+		assert.Contains(t, output, "Commit: abc1234\n")
+		assert.Contains(t, output, "Built:  2024-03-05T14:30:00Z\n")
+		assert.Contains(t, output, runtime.Version()+" "+runtime.GOOS+"/"+runtime.GOARCH)
+	})
+
+	t.Run("outputs JSON format", func(t *testing.T) {
+		ios, _, stdout, _ := iostreams.Test()
+		f := &cmdutil.Factory{IOStreams: ios}
+
+		cmd := NewCmdVersion(f, testBuild)
+		cmd.SetArgs([]string{"--json"})
+		cmd.SetOut(stdout)
+		cmd.SetErr(&bytes.Buffer{})
+
+		err := cmd.Execute()
+
+		require.NoError(t, err)
+		var result map[string]string
+		require.NoError(t, json.Unmarshal(stdout.Bytes(), &result))
+		assert.Equal(t, map[string]string{
+			"version":   "1.2.3",
+			"commit":    "abc1234",
+			"date":      "2024-03-05T14:30:00Z",
+			"goVersion": runtime.Version(),
+			"os":        runtime.GOOS,
+			"arch":      runtime.GOARCH,
+		}, result)
+	})
+}
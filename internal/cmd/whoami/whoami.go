@@ -0,0 +1,82 @@
+package whoami
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/marckohlbrugge/fastmail-cli/internal/cmdutil"
+	"github.com/marckohlbrugge/fastmail-cli/internal/jmap"
+	"github.com/spf13/cobra"
+)
+
+type whoamiOptions struct {
+	JSON bool
+}
+
+// whoamiJSON is the --json output of whoami.
+type whoamiJSON struct {
+	Email     string `json:"email"`
+	AccountID string `json:"accountId"`
+}
+
+// NewCmdWhoami creates the whoami command.
+func NewCmdWhoami(f *cmdutil.Factory) *cobra.Command {
+	opts := &whoamiOptions{}
+
+	cmd := &cobra.Command{
+		Use:   "whoami",
+		Short: "Show which account you are signed in as",
+		Long: `Show the primary email address and account ID of the account fm is
+operating on, in one line.
+
+The address is that of your default sending identity, or your login name if
+the token cannot send email. Use 'fm auth status' for details about the token
+itself.`,
+		Example: `  # Show the current account
+  fm whoami
+
+  # Output as JSON
+  fm whoami --json`,
+		GroupID: "auth",
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runWhoami(f, opts)
+		},
+	}
+
+	cmd.Flags().BoolVar(&opts.JSON, "json", false, "Output in JSON format")
+
+	return cmd
+}
+
+func runWhoami(f *cmdutil.Factory, opts *whoamiOptions) error {
+	client, err := f.JMAPClient()
+	if err != nil {
+		return err
+	}
+
+	session, err := client.GetSession()
+	if err != nil {
+		return err
+	}
+
+	email := session.Username
+	identity, err := client.GetDefaultIdentity()
+	var capErr *jmap.CapabilityError
+	switch {
+	case err == nil:
+		email = identity.Email
+	case !errors.As(err, &capErr):
+		return err
+	}
+
+	if opts.JSON {
+		return cmdutil.WriteJSON(f.IOStreams.Out, whoamiJSON{
+			Email:     email,
+			AccountID: session.AccountID,
+		})
+	}
+
+	fmt.Fprintf(f.IOStreams.Out, "%s (account %s)\n", email, session.AccountID)
+	return nil
+}
@@ -0,0 +1,111 @@
+package whoami
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/marckohlbrugge/fastmail-cli/internal/cmdutil"
+	"github.com/marckohlbrugge/fastmail-cli/internal/iostreams"
+	"github.com/marckohlbrugge/fastmail-cli/internal/jmap"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupTest(t *testing.T, capabilities map[string]interface{}) (*cmdutil.Factory, *bytes.Buffer) {
+	t.Helper()
+
+	httpmock.Activate()
+	t.Cleanup(httpmock.DeactivateAndReset)
+
+	httpmock.RegisterResponder("GET", "https://api.test.com/jmap/session",
+		httpmock.NewJsonResponderOrPanic(200, map[string]interface{}{
+			"apiUrl":   "https://api.test.com/jmap/api",
+			"username": "login@example.com",
+			"accounts": map[string]interface{}{
+				"account-1": map[string]interface{}{},
+			},
+			"capabilities": capabilities,
+		}))
+
+	httpmock.RegisterResponder("POST", "https://api.test.com/jmap/api",
+		httpmock.NewJsonResponderOrPanic(200, map[string]interface{}{
+			"methodResponses": [][]interface{}{
+				{"Identity/get", map[string]interface{}{
+					"list": []map[string]interface{}{
+						{"id": "id-2", "email": "alias@example.com", "mayDelete": true},
+						{"id": "id-1", "email": "me@example.com", "mayDelete": false},
+					},
+				}, "identities"},
+			},
+		}))
+
+	client := jmap.NewClient("test-token")
+	client.SetBaseURL("https://api.test.com")
+
+	ios, _, stdout, _ := iostreams.Test()
+	f := &cmdutil.Factory{
+		IOStreams: ios,
+	}
+	f.SetJMAPClient(client)
+
+	return f, stdout
+}
+
+var submissionCapabilities = map[string]interface{}{
+	jmap.CoreCapability:       map[string]interface{}{},
+	jmap.MailCapability:       map[string]interface{}{},
+	jmap.SubmissionCapability: map[string]interface{}{},
+}
+
+func TestWhoamiCommand(t *testing.T) {
+	t.Run("prints the primary identity and account ID", func(t *testing.T) {
+		f, stdout := setupTest(t, submissionCapabilities)
+
+		cmd := NewCmdWhoami(f)
+		cmd.SetArgs([]string{})
+		cmd.SetOut(stdout)
+		cmd.SetErr(&bytes.Buffer{})
+
+		err := cmd.Execute()
+
+		require.NoError(t, err)
+		assert.Equal(t, "me@example.com (account account-1)\n", stdout.String())
+	})
+
+	t.Run("outputs JSON", func(t *testing.T) {
+		f, stdout := setupTest(t, submissionCapabilities)
+
+		cmd := NewCmdWhoami(f)
+		cmd.SetArgs([]string{"--json"})
+		cmd.SetOut(stdout)
+		cmd.SetErr(&bytes.Buffer{})
+
+		err := cmd.Execute()
+
+		require.NoError(t, err)
+		var result map[string]interface{}
+		require.NoError(t, json.Unmarshal(stdout.Bytes(), &result))
+		assert.Equal(t, "me@example.com", result["email"])
+		assert.Equal(t, "account-1", result["accountId"])
+	})
+
+	t.Run("falls back to the login name without submission", func(t *testing.T) {
+		f, stdout := setupTest(t, map[string]interface{}{
+			jmap.CoreCapability: map[string]interface{}{},
+			jmap.MailCapability: map[string]interface{}{},
+		})
+
+		cmd := NewCmdWhoami(f)
+		cmd.SetArgs([]string{})
+		cmd.SetOut(stdout)
+		cmd.SetErr(&bytes.Buffer{})
+
+		err := cmd.Execute()
+
+		require.NoError(t, err)
+		assert.Equal(t, "login@example.com (account account-1)\n", stdout.String())
+		assert.Zero(t, httpmock.GetCallCountInfo()["POST https://api.test.com/jmap/api"])
+	})
+}
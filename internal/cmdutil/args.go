@@ -1,6 +1,11 @@
 package cmdutil
 
 import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/marckohlbrugge/fastmail-cli/internal/iostreams"
 	"github.com/spf13/cobra"
 )
 
@@ -62,3 +67,27 @@ func RangeArgs(min, max int, msg string) cobra.PositionalArgs {
 		return nil
 	}
 }
+
+// IDsOrStdin returns ids, or if there are none and stdin is a pipe, the
+// whitespace-separated IDs read from stdin. This lets action commands take
+// the output of e.g. fm search --json id | jq -r '.[].id'.
+// It returns a FlagError with msg if no IDs were given either way.
+func IDsOrStdin(ios *iostreams.IOStreams, ids []string, msg string) ([]string, error) {
+	if len(ids) > 0 {
+		return ids, nil
+	}
+	if ios == nil || ios.IsStdinTTY() {
+		return nil, FlagErrorf("%s", msg)
+	}
+
+	data, err := io.ReadAll(ios.In)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read email IDs from stdin: %w", err)
+	}
+
+	ids = strings.Fields(string(data))
+	if len(ids) == 0 {
+		return nil, FlagErrorf("%s", msg)
+	}
+	return ids, nil
+}
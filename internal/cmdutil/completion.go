@@ -0,0 +1,53 @@
+package cmdutil
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// CompletionFunc is the signature cobra uses for argument and flag completion.
+type CompletionFunc func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective)
+
+// CompleteFolders suggests live mailbox names and roles. It suggests nothing,
+// rather than failing, when offline or not authenticated.
+func CompleteFolders(f *Factory) CompletionFunc {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return folderCompletions(f, toComplete), cobra.ShellCompDirectiveNoFileComp
+	}
+}
+
+// CompleteFoldersAfter is like CompleteFolders for positional arguments, but
+// only suggests folders once at least n arguments (such as email IDs) are given.
+func CompleteFoldersAfter(f *Factory, n int) CompletionFunc {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) < n {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		return folderCompletions(f, toComplete), cobra.ShellCompDirectiveNoFileComp
+	}
+}
+
+func folderCompletions(f *Factory, toComplete string) []string {
+	client, err := f.JMAPClient()
+	if err != nil {
+		return nil
+	}
+
+	mailboxes, err := client.GetMailboxes()
+	if err != nil {
+		return nil
+	}
+
+	prefix := strings.ToLower(toComplete)
+	var suggestions []string
+	for _, mb := range mailboxes {
+		for _, value := range []string{mb.Name, mb.Role} {
+			if value != "" && strings.HasPrefix(strings.ToLower(value), prefix) && !slices.Contains(suggestions, value) {
+				suggestions = append(suggestions, value)
+			}
+		}
+	}
+	return suggestions
+}
@@ -0,0 +1,74 @@
+package cmdutil
+
+import (
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/marckohlbrugge/fastmail-cli/internal/jmap"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+)
+
+func setupCompletionTest(t *testing.T) *Factory {
+	t.Helper()
+
+	httpmock.Activate()
+	t.Cleanup(httpmock.DeactivateAndReset)
+
+	client := jmap.NewClient("test-token")
+	client.SetBaseURL("https://api.test.com")
+
+	f := &Factory{}
+	f.SetJMAPClient(client)
+	return f
+}
+
+func TestCompleteFolders(t *testing.T) {
+	t.Run("suggests mailbox names and roles", func(t *testing.T) {
+		f := setupCompletionTest(t)
+
+		httpmock.RegisterResponder("GET", "https://api.test.com/jmap/session",
+			httpmock.NewJsonResponderOrPanic(200, map[string]interface{}{
+				"apiUrl":   "https://api.test.com/jmap/api",
+				"accounts": map[string]interface{}{"account-1": map[string]interface{}{}},
+			}))
+		httpmock.RegisterResponder("POST", "https://api.test.com/jmap/api",
+			httpmock.NewJsonResponderOrPanic(200, map[string]interface{}{
+				"methodResponses": [][]interface{}{
+					{"Mailbox/get", map[string]interface{}{
+						"list": []map[string]interface{}{
+							{"id": "mb-1", "name": "Inbox", "role": "inbox"},
+							{"id": "mb-2", "name": "Archive", "role": "archive"},
+							{"id": "mb-3", "name": "Work Projects"},
+						},
+					}, "mailboxes"},
+				},
+			}))
+
+		all, directive := CompleteFolders(f)(&cobra.Command{}, nil, "")
+		assert.Equal(t, []string{"Inbox", "inbox", "Archive", "archive", "Work Projects"}, all)
+		assert.Equal(t, cobra.ShellCompDirectiveNoFileComp, directive)
+
+		matches, _ := CompleteFolders(f)(&cobra.Command{}, nil, "wo")
+		assert.Equal(t, []string{"Work Projects"}, matches)
+	})
+
+	t.Run("suggests nothing when the API is unreachable", func(t *testing.T) {
+		f := setupCompletionTest(t)
+
+		httpmock.RegisterResponder("GET", "https://api.test.com/jmap/session",
+			httpmock.NewStringResponder(503, "unavailable"))
+
+		suggestions, directive := CompleteFolders(f)(&cobra.Command{}, nil, "")
+		assert.Empty(t, suggestions)
+		assert.Equal(t, cobra.ShellCompDirectiveNoFileComp, directive)
+	})
+
+	t.Run("waits for the leading arguments", func(t *testing.T) {
+		f := setupCompletionTest(t)
+
+		suggestions, _ := CompleteFoldersAfter(f, 1)(&cobra.Command{}, nil, "")
+		assert.Empty(t, suggestions)
+		assert.Zero(t, httpmock.GetTotalCallCount())
+	})
+}
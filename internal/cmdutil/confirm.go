@@ -0,0 +1,30 @@
+package cmdutil
+
+import (
+	"bufio"
+	"fmt"
+	"strings"
+
+	"github.com/marckohlbrugge/fastmail-cli/internal/iostreams"
+)
+
+// Confirm prints prompt followed by " [y/N] " to stderr and reads one line
+// from stdin. It returns true if the answer starts with "y". Any other
+// answer, including an empty line or EOF, returns false with CancelError.
+func Confirm(ios *iostreams.IOStreams, prompt string) (bool, error) {
+	fmt.Fprintf(ios.ErrOut, "%s [y/N] ", prompt)
+
+	scanner := bufio.NewScanner(ios.In)
+	response := ""
+	if scanner.Scan() {
+		response = scanner.Text()
+	}
+	if err := scanner.Err(); err != nil {
+		return false, err
+	}
+
+	if !strings.HasPrefix(strings.ToLower(strings.TrimSpace(response)), "y") {
+		return false, CancelError
+	}
+	return true, nil
+}
@@ -0,0 +1,41 @@
+package cmdutil
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/marckohlbrugge/fastmail-cli/internal/iostreams"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfirm(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  bool
+	}{
+		{"y", "y\n", true},
+		{"yes", "yes\n", true},
+		{"uppercase with spaces", "  YES  \n", true},
+		{"n", "n\n", false},
+		{"empty line", "\n", false},
+		{"EOF", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ios, _, _, stderr := iostreams.Test()
+			ios.In = strings.NewReader(tt.input)
+
+			ok, err := Confirm(ios, "Delete this email?")
+
+			assert.Equal(t, tt.want, ok)
+			if tt.want {
+				assert.NoError(t, err)
+			} else {
+				assert.Equal(t, CancelError, err)
+			}
+			assert.Equal(t, "Delete this email? [y/N] ", stderr.String())
+		})
+	}
+}
@@ -0,0 +1,32 @@
+package cmdutil
+
+import (
+	"strings"
+
+	"github.com/marckohlbrugge/fastmail-cli/internal/jmap"
+)
+
+// MatchContacts returns contacts with an email whose name matches query.
+// A case-insensitive exact match on the full name wins over partial matches.
+func MatchContacts(contacts []jmap.Contact, query string) []jmap.Contact {
+	query = strings.ToLower(strings.TrimSpace(query))
+
+	var exact, partial []jmap.Contact
+	for _, c := range contacts {
+		if c.PrimaryEmail() == "" {
+			continue
+		}
+		name := strings.ToLower(c.FullName())
+		switch {
+		case name == query:
+			exact = append(exact, c)
+		case name != "" && strings.Contains(name, query):
+			partial = append(partial, c)
+		}
+	}
+
+	if len(exact) > 0 {
+		return exact
+	}
+	return partial
+}
@@ -5,7 +5,9 @@ import (
 	"io"
 	"strings"
 	"time"
+	"unicode/utf8"
 
+	"github.com/marckohlbrugge/fastmail-cli/internal/iostreams"
 	"github.com/marckohlbrugge/fastmail-cli/internal/jmap"
 )
 
@@ -13,26 +15,45 @@ import (
 var DefaultEmailFields = []string{"id", "date", "from", "subject"}
 
 // AvailableEmailFields lists all fields that can be displayed.
-var AvailableEmailFields = []string{"id", "threadId", "subject", "from", "to", "cc", "date", "preview", "unread", "attachment"}
+var AvailableEmailFields = []string{"id", "threadId", "subject", "from", "to", "cc", "date", "preview", "unread", "attachment", "size", "folder", "messageId", "keywords"}
 
-// FieldConfig defines display width for a field.
+// FieldConfig defines how a field is displayed and described.
 type FieldConfig struct {
-	Width  int
-	Getter func(jmap.Email) string
+	Width       int
+	Getter      func(jmap.Email) string
+	Description string
+	Example     string
 }
 
 // EmailFieldConfigs maps field names to their display configuration.
 var EmailFieldConfigs = map[string]FieldConfig{
-	"id":         {Width: 12, Getter: func(e jmap.Email) string { return e.ID }},
-	"threadId":   {Width: 12, Getter: func(e jmap.Email) string { return e.ThreadID }},
-	"subject":    {Width: 50, Getter: func(e jmap.Email) string { return e.Subject }},
-	"from":       {Width: 30, Getter: func(e jmap.Email) string { return formatAddresses(e.From) }},
-	"to":         {Width: 30, Getter: func(e jmap.Email) string { return formatAddresses(e.To) }},
-	"cc":         {Width: 30, Getter: func(e jmap.Email) string { return formatAddresses(e.CC) }},
-	"date":       {Width: 12, Getter: func(e jmap.Email) string { return FormatRelativeDate(e.ReceivedAt) }},
-	"preview":    {Width: 60, Getter: func(e jmap.Email) string { return e.Preview }},
-	"unread":     {Width: 1, Getter: func(e jmap.Email) string { if e.IsUnread() { return "*" }; return " " }},
-	"attachment": {Width: 1, Getter: func(e jmap.Email) string { if e.HasAttachment { return "+" }; return " " }},
+	"id":         {Width: 12, Getter: func(e jmap.Email) string { return e.ID }, Description: "Email ID", Example: "M1234567890"},
+	"threadId":   {Width: 12, Getter: func(e jmap.Email) string { return e.ThreadID }, Description: "Conversation ID shared by replies", Example: "T9876543210"},
+	"subject":    {Width: 50, Getter: func(e jmap.Email) string { return e.Subject }, Description: "Subject line", Example: "Quarterly numbers"},
+	"from":       {Width: 30, Getter: func(e jmap.Email) string { return formatAddresses(e.From) }, Description: "Sender", Example: "Alice <alice@example.com>"},
+	"to":         {Width: 30, Getter: func(e jmap.Email) string { return formatAddresses(e.To) }, Description: "Recipients", Example: "bob@example.com"},
+	"cc":         {Width: 30, Getter: func(e jmap.Email) string { return formatAddresses(e.CC) }, Description: "CC recipients", Example: "carol@example.com"},
+	"date":       {Width: 12, Getter: func(e jmap.Email) string { return FormatRelativeDate(e.ReceivedAt) }, Description: "When the email was received (JSON key receivedAt)", Example: "2h ago"},
+	"preview":    {Width: 60, Getter: func(e jmap.Email) string { return e.Preview }, Description: "Start of the body text", Example: "Hi Bob, attached are the..."},
+	"unread":     {Width: 1, Getter: func(e jmap.Email) string { if e.IsUnread() { return "*" }; return " " }, Description: "* if unread (JSON key isUnread)", Example: "*"},
+	"attachment": {Width: 1, Getter: func(e jmap.Email) string { if e.HasAttachment { return "+" }; return " " }, Description: "+ if it has attachments (JSON key hasAttachment)", Example: "+"},
+	"size":       {Width: 9, Getter: func(e jmap.Email) string { return FormatBytes(e.Size) }, Description: "Size of the whole message", Example: "24.1 KB"},
+	"folder":     {Width: 20, Getter: func(e jmap.Email) string { return strings.Join(e.MailboxNames, ", ") }, Description: "Folders the email is in", Example: "Inbox"},
+	"messageId":  {Width: 30, Getter: func(e jmap.Email) string { return strings.Join(e.MessageID, ", ") }, Description: "Message-ID header", Example: "<abc123@example.com>"},
+	"keywords":   {Width: 20, Getter: func(e jmap.Email) string { return strings.Join(e.KeywordList(), ",") }, Description: "Keywords such as $seen and $flagged", Example: "$seen,$flagged"},
+}
+
+// PrintFieldHelp lists every available email field with its description
+// and an example value.
+func PrintFieldHelp(out io.Writer) {
+	width := 0
+	for _, field := range AvailableEmailFields {
+		width = max(width, len(field))
+	}
+	for _, field := range AvailableEmailFields {
+		config := EmailFieldConfigs[field]
+		fmt.Fprintf(out, "%-*s  %s, e.g. %s\n", width, field, config.Description, config.Example)
+	}
 }
 
 // ParseFields parses a comma-separated fields string, returning defaults if empty.
@@ -57,12 +78,34 @@ func ValidateFields(fields []string) error {
 	return nil
 }
 
+// EmailListStyle controls how PrintEmailList renders rows.
+type EmailListStyle struct {
+	// Color highlights unread emails and dims dates when enabled
+	Color *iostreams.ColorScheme
+
+	// DateFormat is a --date-format value; empty means relative dates
+	DateFormat string
+}
+
 // FormatEmailRow formats a single email according to the specified fields.
 func FormatEmailRow(email jmap.Email, fields []string) string {
+	return formatEmailRow(email, fields, EmailListStyle{})
+}
+
+// formatEmailRow formats an email row with the given style: unread emails are
+// bold and the date column is dimmed when color is enabled.
+func formatEmailRow(email jmap.Email, fields []string, style EmailListStyle) string {
+	cs := style.Color
 	var parts []string
 	for _, field := range fields {
 		config := EmailFieldConfigs[field]
+		width := config.Width
 		value := config.Getter(email)
+		if field == "date" {
+			// Fixed-layout dates may be wider than the column; don't cut them off
+			value = FormatDate(email.ReceivedAt, style.DateFormat)
+			width = max(width, utf8.RuneCountInString(value))
+		}
 		if value == "" {
 			if field == "subject" {
 				value = "(no subject)"
@@ -70,25 +113,72 @@ func FormatEmailRow(email jmap.Email, fields []string) string {
 				value = "(unknown)"
 			}
 		}
-		value = Truncate(value, config.Width)
-		parts = append(parts, fmt.Sprintf("%-*s", config.Width, value))
+		if field == "subject" && email.ThreadCount > 1 {
+			value = fmt.Sprintf("(%d) %s", email.ThreadCount, value)
+		}
+		value = Truncate(value, width)
+		value = fmt.Sprintf("%-*s", width, value)
+		switch {
+		case field == "date":
+			value = cs.Dim(value)
+		case email.IsUnread():
+			value = cs.Bold(value)
+		}
+		parts = append(parts, value)
 	}
 	return strings.Join(parts, "  ")
 }
 
 // PrintEmailList prints a list of emails with the specified fields.
-func PrintEmailList(out io.Writer, emails []jmap.Email, fields []string) {
+func PrintEmailList(out io.Writer, style EmailListStyle, emails []jmap.Email, fields []string) {
 	for _, email := range emails {
-		fmt.Fprintln(out, FormatEmailRow(email, fields))
+		fmt.Fprintln(out, formatEmailRow(email, fields, style))
 	}
 }
 
-// Truncate shortens a string to maxLen, adding ellipsis if needed.
+// Truncate shortens a string to maxLen runes, adding ellipsis if needed.
+// It cuts on rune boundaries so multi-byte characters are never split.
 func Truncate(s string, maxLen int) string {
-	if len(s) <= maxLen {
+	runes := []rune(s)
+	if len(runes) <= maxLen {
 		return s
 	}
-	return s[:maxLen-1] + "…"
+	return string(runes[:maxLen-1]) + "…"
+}
+
+// Named --date-format values. Any other value is used as a Go time layout.
+const (
+	DateFormatRelative = "relative"
+	DateFormatISO      = "iso"
+	DateFormatRFC822   = "rfc822"
+)
+
+// ValidateDateFormat checks that format is a named date format or a Go time
+// layout. A layout without any reference fields (2006, Jan, 15, ...) would
+// print itself verbatim, so it is rejected.
+func ValidateDateFormat(format string) error {
+	switch format {
+	case DateFormatRelative, DateFormatISO, DateFormatRFC822:
+		return nil
+	}
+	if format == "" || time.Date(2001, 2, 3, 4, 5, 6, 0, time.UTC).Format(format) == format {
+		return fmt.Errorf("invalid date format %q: use relative, iso, rfc822, or a Go time layout such as 2006-01-02", format)
+	}
+	return nil
+}
+
+// FormatDate formats t according to a --date-format value. An empty format
+// means relative.
+func FormatDate(t time.Time, format string) string {
+	switch format {
+	case "", DateFormatRelative:
+		return FormatRelativeDate(t)
+	case DateFormatISO:
+		return t.Format(time.RFC3339)
+	case DateFormatRFC822:
+		return t.Format(time.RFC1123Z)
+	}
+	return t.Format(format)
 }
 
 // FormatRelativeDate formats a time as a relative date string.
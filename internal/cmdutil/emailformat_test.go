@@ -6,6 +6,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/marckohlbrugge/fastmail-cli/internal/iostreams"
 	"github.com/marckohlbrugge/fastmail-cli/internal/jmap"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -134,10 +135,22 @@ func TestTruncate(t *testing.T) {
 			want:   "…",
 		},
 		{
-			name:   "unicode string (byte-based truncation)",
+			name:   "unicode string",
 			s:      "héllo wörld",
 			maxLen: 8,
-			want:   "héllo …", // Note: uses byte length, not rune count
+			want:   "héllo w…",
+		},
+		{
+			name:   "CJK string",
+			s:      "日本語のメールの件名",
+			maxLen: 5,
+			want:   "日本語の…",
+		},
+		{
+			name:   "unicode string equal to max",
+			s:      "héllo",
+			maxLen: 5,
+			want:   "héllo",
 		},
 	}
 
@@ -207,6 +220,36 @@ func TestFormatRelativeDate(t *testing.T) {
 	})
 }
 
+func TestFormatDate(t *testing.T) {
+	date := time.Date(2024, 3, 5, 14, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		format string
+		want   string
+	}{
+		{format: "iso", want: "2024-03-05T14:30:00Z"},
+		{format: "rfc822", want: "Tue, 05 Mar 2024 14:30:00 +0000"},
+		{format: "2006-01-02", want: "2024-03-05"},
+		{format: "relative", want: FormatRelativeDate(date)},
+		{format: "", want: FormatRelativeDate(date)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			assert.Equal(t, tt.want, FormatDate(date, tt.format))
+		})
+	}
+}
+
+func TestValidateDateFormat(t *testing.T) {
+	for _, format := range []string{"relative", "iso", "rfc822", "2006-01-02", "Jan 2 15:04"} {
+		assert.NoError(t, ValidateDateFormat(format), format)
+	}
+	for _, format := range []string{"", "yesterday", "YYYY-MM-DD"} {
+		assert.Error(t, ValidateDateFormat(format), format)
+	}
+}
+
 func TestFormatAddresses(t *testing.T) {
 	tests := []struct {
 		name  string
@@ -287,6 +330,18 @@ func TestFormatEmailRow(t *testing.T) {
 		row := FormatEmailRow(noFrom, []string{"from"})
 		assert.Contains(t, row, "(unknown)")
 	})
+
+	t.Run("widens the date column for long date formats", func(t *testing.T) {
+		dated := jmap.Email{ID: "123", ReceivedAt: time.Date(2024, 3, 5, 14, 30, 0, 0, time.UTC)}
+		row := formatEmailRow(dated, []string{"date", "id"}, EmailListStyle{DateFormat: "iso"})
+		assert.True(t, strings.HasPrefix(row, "2024-03-05T14:30:00Z  123"), row)
+	})
+
+	t.Run("formats size in human-readable units", func(t *testing.T) {
+		large := jmap.Email{ID: "123", Size: 3 * 1024 * 1024}
+		row := FormatEmailRow(large, []string{"size"})
+		assert.Equal(t, "3.0 MB   ", row)
+	})
 }
 
 func TestPrintEmailList(t *testing.T) {
@@ -296,7 +351,7 @@ func TestPrintEmailList(t *testing.T) {
 	}
 
 	var buf bytes.Buffer
-	PrintEmailList(&buf, emails, []string{"id", "subject"})
+	PrintEmailList(&buf, EmailListStyle{Color: iostreams.NewColorScheme(false)}, emails, []string{"id", "subject"})
 
 	output := buf.String()
 	lines := strings.Split(strings.TrimSpace(output), "\n")
@@ -304,4 +359,38 @@ func TestPrintEmailList(t *testing.T) {
 	require.Len(t, lines, 2)
 	assert.Contains(t, lines[0], "email1")
 	assert.Contains(t, lines[1], "email2")
+	assert.NotContains(t, output, "\033[")
+}
+
+func TestPrintFieldHelp(t *testing.T) {
+	var buf bytes.Buffer
+	PrintFieldHelp(&buf)
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Len(t, lines, len(AvailableEmailFields))
+	for i, field := range AvailableEmailFields {
+		config, ok := EmailFieldConfigs[field]
+		require.True(t, ok, field)
+		assert.NotEmpty(t, config.Description, field)
+		assert.NotEmpty(t, config.Example, field)
+		assert.True(t, strings.HasPrefix(lines[i], field+" "), lines[i])
+		assert.Contains(t, lines[i], config.Description)
+	}
+}
+
+func TestPrintEmailListColor(t *testing.T) {
+	emails := []jmap.Email{
+		{ID: "unread1", Subject: "New"},
+		{ID: "read1", Subject: "Old", Keywords: map[string]bool{"$seen": true}},
+	}
+
+	var buf bytes.Buffer
+	PrintEmailList(&buf, EmailListStyle{Color: iostreams.NewColorScheme(true)}, emails, []string{"id", "date"})
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+
+	require.Len(t, lines, 2)
+	assert.True(t, strings.HasPrefix(lines[0], "\033[1munread1"), "unread row should be bold")
+	assert.True(t, strings.HasPrefix(lines[1], "read1"), "read row should not be bold")
+	assert.Contains(t, lines[1], "\033[2m", "date should be dimmed")
 }
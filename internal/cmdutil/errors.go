@@ -34,6 +34,10 @@ var SilentError = errors.New("SilentError")
 // CancelError signals user-initiated cancellation
 var CancelError = errors.New("CancelError")
 
+// QuietConfirmError is returned when a command would prompt for confirmation
+// but --quiet suppresses prompts
+var QuietConfirmError = FlagErrorf("--quiet suppresses confirmation prompts; pass --yes to confirm")
+
 // SafeModeError indicates a command was blocked due to safe mode
 type SafeModeError struct {
 	Command string
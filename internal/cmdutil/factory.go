@@ -1,9 +1,16 @@
 package cmdutil
 
 import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
 	"github.com/marckohlbrugge/fastmail-cli/internal/auth"
+	"github.com/marckohlbrugge/fastmail-cli/internal/config"
 	"github.com/marckohlbrugge/fastmail-cli/internal/iostreams"
 	"github.com/marckohlbrugge/fastmail-cli/internal/jmap"
+	"github.com/marckohlbrugge/fastmail-cli/internal/snippet"
 )
 
 // Factory provides dependencies for commands.
@@ -11,16 +18,107 @@ type Factory struct {
 	IOStreams   *iostreams.IOStreams
 	TokenSource *auth.TokenSource
 
+	// Config holds defaults from the config file (nil if none was loaded)
+	Config *config.Config
+
+	// ConfigPath is where the config file is read from and created
+	ConfigPath string
+
+	// Error from loading the config file, reported before commands run
+	configErr error
+
 	// Lazy-initialized JMAP client
 	jmapClient *jmap.Client
+
+	// Output format selected with --output (empty means use config or table)
+	outputFormat string
+
+	// Date format selected with --date-format (empty means each command's default)
+	dateFormat string
+
+	// Per-request timeout selected with --timeout (zero means the client default)
+	timeout time.Duration
+
+	// Quiet suppresses summary lines and confirmation prompts (--quiet)
+	Quiet bool
 }
 
 // NewFactory creates a new Factory with default dependencies.
 func NewFactory() *Factory {
-	return &Factory{
+	f := &Factory{
 		IOStreams:   iostreams.System(),
 		TokenSource: auth.NewTokenSource(),
 	}
+	f.loadConfig()
+	return f
+}
+
+// loadConfig reads the config file, recording any error for ConfigError.
+func (f *Factory) loadConfig() {
+	path, err := config.DefaultPath()
+	if err != nil {
+		f.configErr = err
+		return
+	}
+	f.ConfigPath = path
+
+	cfg, err := config.Load(path)
+	if err != nil {
+		f.configErr = err
+		return
+	}
+
+	f.configErr = f.ApplyConfig(cfg)
+}
+
+// ApplyConfig sets defaults from cfg. Environment variables and flags take
+// precedence: flag > env > config > built-in default.
+func (f *Factory) ApplyConfig(cfg *config.Config) error {
+	if cfg.Output != "" {
+		if err := ValidateOutputFormat(cfg.Output); err != nil {
+			return fmt.Errorf("config file: %w", err)
+		}
+	}
+	if cfg.Profile != "" {
+		if err := auth.ValidateProfileName(cfg.Profile); err != nil {
+			return fmt.Errorf("config file: %w", err)
+		}
+		if os.Getenv("FM_PROFILE") == "" {
+			if f.TokenSource == nil {
+				f.TokenSource = auth.NewTokenSource()
+			}
+			f.TokenSource.SetProfile(cfg.Profile)
+		}
+	}
+
+	f.Config = cfg
+	return nil
+}
+
+// ConfigError returns the error, if any, from loading the config file.
+func (f *Factory) ConfigError() error {
+	return f.configErr
+}
+
+// EnsureConfigFile creates a commented config file if none exists yet.
+// It reports whether a file was created.
+func (f *Factory) EnsureConfigFile() (bool, error) {
+	if f.ConfigPath == "" {
+		return false, nil
+	}
+	return config.EnsureExists(f.ConfigPath)
+}
+
+// Snippets returns the snippet store kept beside the config file.
+func (f *Factory) Snippets() (*snippet.Store, error) {
+	path := f.ConfigPath
+	if path == "" {
+		var err error
+		if path, err = config.DefaultPath(); err != nil {
+			return nil, err
+		}
+	}
+	return snippet.NewStore(path), nil
 }
 
 // JMAPClient returns the JMAP client, initializing it if necessary.
@@ -34,10 +132,109 @@ func (f *Factory) JMAPClient() (*jmap.Client, error) {
 		return nil, err
 	}
 
-	f.jmapClient = jmap.NewClient(token)
+	f.jmapClient = f.NewJMAPClient(token)
 	return f.jmapClient, nil
 }
 
+// NewJMAPClient creates a JMAP client for token, honoring the configured API URL.
+func (f *Factory) NewJMAPClient(token string) *jmap.Client {
+	client := jmap.NewClient(token)
+	if f.Config != nil && f.Config.APIURL != "" {
+		client.SetBaseURL(strings.TrimRight(f.Config.APIURL, "/"))
+	}
+	if f.timeout > 0 {
+		client.SetTimeout(f.timeout)
+	}
+	return client
+}
+
+// Profile returns the name of the selected authentication profile.
+func (f *Factory) Profile() string {
+	if f.TokenSource == nil {
+		return auth.DefaultProfile
+	}
+	return f.TokenSource.Profile()
+}
+
+// SetProfile selects the authentication profile used to load the token.
+func (f *Factory) SetProfile(profile string) error {
+	if err := auth.ValidateProfileName(profile); err != nil {
+		return err
+	}
+	if f.TokenSource == nil {
+		f.TokenSource = auth.NewTokenSource()
+	}
+	f.TokenSource.SetProfile(profile)
+	return nil
+}
+
+// SetOutputFormat sets the output format used by list commands.
+func (f *Factory) SetOutputFormat(format string) error {
+	if err := ValidateOutputFormat(format); err != nil {
+		return err
+	}
+	f.outputFormat = format
+	return nil
+}
+
+// OutputFormat returns the output format for a command. A command's own
+// --json flag is an alias for --output json.
+func (f *Factory) OutputFormat(jsonFlag bool) string {
+	if jsonFlag {
+		return OutputJSON
+	}
+	if f.outputFormat != "" {
+		return f.outputFormat
+	}
+	if f.Config != nil && f.Config.Output != "" {
+		return f.Config.Output
+	}
+	return OutputTable
+}
+
+// SetDateFormat sets how dates are displayed (see ValidateDateFormat).
+func (f *Factory) SetDateFormat(format string) error {
+	if err := ValidateDateFormat(format); err != nil {
+		return err
+	}
+	f.dateFormat = format
+	return nil
+}
+
+// FormatDate formats t with the --date-format value, or with defaultFormat
+// (a named format or Go layout) if none was given.
+func (f *Factory) FormatDate(t time.Time, defaultFormat string) string {
+	if f.dateFormat != "" {
+		return FormatDate(t, f.dateFormat)
+	}
+	return FormatDate(t, defaultFormat)
+}
+
+// SetTimeout sets how long each API request may take before it is aborted.
+func (f *Factory) SetTimeout(timeout time.Duration) error {
+	if timeout <= 0 {
+		return fmt.Errorf("invalid timeout %s: must be greater than zero", timeout)
+	}
+	f.timeout = timeout
+	return nil
+}
+
+// EmailListStyle returns the style for PrintEmailList.
+func (f *Factory) EmailListStyle() EmailListStyle {
+	return EmailListStyle{
+		Color:      f.IOStreams.ColorScheme(),
+		DateFormat: f.dateFormat,
+	}
+}
+
+// DefaultLimit returns the configured list limit, or builtin if none is set.
+func (f *Factory) DefaultLimit(builtin int) int {
+	if f.Config != nil && f.Config.Limit > 0 {
+		return f.Config.Limit
+	}
+	return builtin
+}
+
 // SetJMAPClient sets a pre-configured JMAP client (for testing).
 func (f *Factory) SetJMAPClient(client *jmap.Client) {
 	f.jmapClient = client
@@ -0,0 +1,87 @@
+package cmdutil
+
+import (
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/marckohlbrugge/fastmail-cli/internal/auth"
+	"github.com/marckohlbrugge/fastmail-cli/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFactoryConfigPrecedence(t *testing.T) {
+	t.Run("profile: config over default", func(t *testing.T) {
+		t.Setenv("FM_PROFILE", "")
+		f := &Factory{TokenSource: auth.NewTokenSource()}
+
+		require.NoError(t, f.ApplyConfig(&config.Config{Profile: "work"}))
+
+		assert.Equal(t, "work", f.Profile())
+	})
+
+	t.Run("profile: env over config", func(t *testing.T) {
+		t.Setenv("FM_PROFILE", "personal")
+		f := &Factory{TokenSource: auth.NewTokenSource()}
+
+		require.NoError(t, f.ApplyConfig(&config.Config{Profile: "work"}))
+
+		assert.Equal(t, "personal", f.Profile())
+	})
+
+	t.Run("profile: flag over env and config", func(t *testing.T) {
+		t.Setenv("FM_PROFILE", "personal")
+		f := &Factory{TokenSource: auth.NewTokenSource()}
+
+		require.NoError(t, f.ApplyConfig(&config.Config{Profile: "work"}))
+		require.NoError(t, f.SetProfile("client"))
+
+		assert.Equal(t, "client", f.Profile())
+	})
+
+	t.Run("output: config over default, flag over config", func(t *testing.T) {
+		f := &Factory{}
+		assert.Equal(t, OutputTable, f.OutputFormat(false))
+
+		require.NoError(t, f.ApplyConfig(&config.Config{Output: "csv"}))
+		assert.Equal(t, OutputCSV, f.OutputFormat(false))
+
+		require.NoError(t, f.SetOutputFormat(OutputTSV))
+		assert.Equal(t, OutputTSV, f.OutputFormat(false))
+		assert.Equal(t, OutputJSON, f.OutputFormat(true))
+	})
+
+	t.Run("limit: config over built-in default", func(t *testing.T) {
+		f := &Factory{}
+		assert.Equal(t, 20, f.DefaultLimit(20))
+
+		require.NoError(t, f.ApplyConfig(&config.Config{Limit: 5}))
+		assert.Equal(t, 5, f.DefaultLimit(20))
+	})
+
+	t.Run("api-url: used for new clients", func(t *testing.T) {
+		httpmock.Activate()
+		t.Cleanup(httpmock.DeactivateAndReset)
+
+		httpmock.RegisterResponder("GET", "https://jmap.example.com/jmap/session",
+			httpmock.NewJsonResponderOrPanic(200, map[string]interface{}{
+				"apiUrl":   "https://jmap.example.com/jmap/api",
+				"accounts": map[string]interface{}{"account-1": map[string]interface{}{}},
+			}))
+
+		f := &Factory{}
+		require.NoError(t, f.ApplyConfig(&config.Config{APIURL: "https://jmap.example.com/"}))
+
+		session, err := f.NewJMAPClient("token").GetSession()
+
+		require.NoError(t, err)
+		assert.Equal(t, "account-1", session.AccountID)
+	})
+
+	t.Run("rejects invalid values", func(t *testing.T) {
+		f := &Factory{}
+		assert.Error(t, f.ApplyConfig(&config.Config{Output: "xml"}))
+		assert.Error(t, f.ApplyConfig(&config.Config{Profile: "bad name"}))
+		assert.Nil(t, f.Config)
+	})
+}
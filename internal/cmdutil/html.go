@@ -0,0 +1,147 @@
+package cmdutil
+
+import (
+	"fmt"
+	"html"
+	"regexp"
+	"strings"
+)
+
+// Markers used while converting HTML so structure survives whitespace cleanup.
+const (
+	quoteStart = "\x01"
+	quoteEnd   = "\x02"
+	cellEnd    = "\x03"
+)
+
+var (
+	anchorRe     = regexp.MustCompile(`(?is)<a\s[^>]*?href\s*=\s*["']([^"']*)["'][^>]*>(.*?)</a>`)
+	labelTagRe   = regexp.MustCompile(`<[^>]+>`)
+	orderedRe    = regexp.MustCompile(`(?is)<ol(\s[^>]*)?>(.*?)</ol>`)
+	listItemRe   = regexp.MustCompile(`(?is)\s*<li(\s[^>]*)?>`)
+	markerTrimRe = regexp.MustCompile(`\x01\s+|\s+\x02`)
+	cellTrimRe   = regexp.MustCompile(`\x03+(\n|$)`)
+
+	plainTextReplacer = strings.NewReplacer(
+		"\u00a0", " ",
+		"\u2018", "'", "\u2019", "'",
+		"\u201c", `"`, "\u201d", `"`,
+	)
+)
+
+// HTMLToText converts an HTML email body to readable plain text, keeping
+// links, lists, tables, and quotes recognizable.
+func HTMLToText(body string) string {
+	text := body
+
+	// Remove style and script content
+	styleRe := regexp.MustCompile(`(?is)<style[^>]*>.*?</style>`)
+	scriptRe := regexp.MustCompile(`(?is)<script[^>]*>.*?</script>`)
+	text = styleRe.ReplaceAllString(text, "")
+	text = scriptRe.ReplaceAllString(text, "")
+
+	// Render links as "text (url)"
+	text = anchorRe.ReplaceAllStringFunc(text, renderLink)
+
+	// Number ordered list items; remaining items are bullets
+	text = orderedRe.ReplaceAllStringFunc(text, func(list string) string {
+		n := 0
+		return listItemRe.ReplaceAllStringFunc(list, func(string) string {
+			n++
+			return fmt.Sprintf("\n%d. ", n)
+		})
+	})
+	text = listItemRe.ReplaceAllString(text, "\n- ")
+
+	// Add newlines for block elements
+	replacements := []struct {
+		pattern string
+		replace string
+	}{
+		{`<br\s*/?>`, "\n"},
+		{`</p>`, "\n\n"},
+		{`</div>`, "\n"},
+		{`</t[dh]>\s*`, cellEnd},
+		{`</tr>\s*`, "\n"},
+		{`\s*</li>`, ""},
+		{`</?[uo]l(\s[^>]*)?>`, "\n"},
+		{`<blockquote[^>]*>`, "\n" + quoteStart},
+		{`</blockquote>`, quoteEnd + "\n"},
+		{`<hr\s*/?>`, "\n───\n"},
+	}
+
+	// Apply replacements
+	for _, r := range replacements {
+		re := regexp.MustCompile("(?i)" + r.pattern)
+		text = re.ReplaceAllString(text, r.replace)
+	}
+
+	// Remove remaining tags
+	tagRe := regexp.MustCompile(`<[^>]+>`)
+	text = tagRe.ReplaceAllString(text, "")
+
+	// Decode named and numeric entities (&#8217;, &#x2019;), folding curly
+	// quotes and non-breaking spaces to their plain equivalents
+	text = plainTextReplacer.Replace(html.UnescapeString(text))
+
+	// Clean up whitespace
+	text = regexp.MustCompile(`[ \t]+`).ReplaceAllString(text, " ")
+	text = regexp.MustCompile(`\n `).ReplaceAllString(text, "\n")
+	text = regexp.MustCompile(` \n`).ReplaceAllString(text, "\n")
+	text = regexp.MustCompile(`\n{3,}`).ReplaceAllString(text, "\n\n")
+
+	// Separate table cells with tabs, without trailing tabs on each row
+	text = cellTrimRe.ReplaceAllString(text, "$1")
+	text = strings.ReplaceAll(text, cellEnd, "\t")
+
+	text = quoteBlocks(markerTrimRe.ReplaceAllStringFunc(text, strings.TrimSpace))
+
+	return strings.TrimSpace(text)
+}
+
+// renderLink formats an anchor as "text (url)", or just one of them when the
+// other is empty, redundant, or an in-page reference.
+func renderLink(anchor string) string {
+	m := anchorRe.FindStringSubmatch(anchor)
+	href := strings.TrimSpace(m[1])
+	label := strings.TrimSpace(labelTagRe.ReplaceAllString(m[2], ""))
+
+	switch {
+	case href == "" || strings.HasPrefix(href, "#"):
+		return label
+	case label == "":
+		return href
+	case label == href || "mailto:"+label == href:
+		return label
+	}
+	return label + " (" + href + ")"
+}
+
+// quoteBlocks prefixes lines between blockquote markers with "> ", one per
+// nesting level, and removes the markers.
+func quoteBlocks(text string) string {
+	if !strings.Contains(text, quoteStart) {
+		return text
+	}
+
+	var lines []string
+	depth := 0
+	for _, line := range strings.Split(text, "\n") {
+		for strings.HasPrefix(line, quoteStart) {
+			depth++
+			line = strings.TrimPrefix(line, quoteStart)
+		}
+		closes := strings.Count(line, quoteEnd)
+		line = strings.ReplaceAll(line, quoteEnd, "")
+		line = strings.ReplaceAll(line, quoteStart, "")
+
+		if depth > 0 {
+			line = strings.TrimRight(strings.Repeat("> ", depth)+line, " ")
+		}
+		lines = append(lines, line)
+
+		depth = max(depth-closes, 0)
+	}
+
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,110 @@
+package cmdutil
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHTMLToText(t *testing.T) {
+	tests := []struct {
+		name     string
+		html     string
+		expected string
+	}{
+		{
+			name:     "simple text",
+			html:     "<p>Hello world</p>",
+			expected: "Hello world",
+		},
+		{
+			name:     "line breaks",
+			html:     "Line 1<br>Line 2<br/>Line 3",
+			expected: "Line 1\nLine 2\nLine 3",
+		},
+		{
+			name:     "paragraphs",
+			html:     "<p>First paragraph</p><p>Second paragraph</p>",
+			expected: "First paragraph\n\nSecond paragraph",
+		},
+		{
+			name:     "HTML entities",
+			html:     "Tom &amp; Jerry &lt;3 &quot;movies&quot;",
+			expected: "Tom & Jerry <3 \"movies\"",
+		},
+		{
+			name:     "named smart quotes and dashes",
+			html:     "It&rsquo;s &ldquo;done&rdquo; &ndash; mostly&nbsp;&mdash; yes",
+			expected: "It's \"done\" – mostly — yes",
+		},
+		{
+			name:     "decimal numeric entities",
+			html:     "It&#8217;s &#8220;done&#8221; &#8212; finally",
+			expected: "It's \"done\" — finally",
+		},
+		{
+			name:     "hex numeric entities",
+			html:     "Don&#x2019;t &#x2014; really &#X2013; stop",
+			expected: "Don't — really – stop",
+		},
+		{
+			name:     "escaped entities are decoded once",
+			html:     "Write &amp;lt;b&amp;gt; for bold &copy; 2024",
+			expected: "Write &lt;b&gt; for bold © 2024",
+		},
+		{
+			name:     "strips style tags",
+			html:     "<style>body { color: red; }</style><p>Content</p>",
+			expected: "Content",
+		},
+		{
+			name:     "strips script tags",
+			html:     "<script>alert('hi');</script><p>Content</p>",
+			expected: "Content",
+		},
+		{
+			name:     "links include URL",
+			html:     `See <a href="https://example.com/docs">the docs</a> for details`,
+			expected: "See the docs (https://example.com/docs) for details",
+		},
+		{
+			name:     "links whose text is the URL",
+			html:     `<a href="https://example.com">https://example.com</a>`,
+			expected: "https://example.com",
+		},
+		{
+			name:     "unordered list",
+			html:     "<p>Agenda:</p><ul>\n<li>Budget</li>\n<li>Hiring</li>\n</ul>",
+			expected: "Agenda:\n\n- Budget\n- Hiring",
+		},
+		{
+			name:     "ordered list",
+			html:     "<ol><li>First</li><li>Second</li><li>Third</li></ol>",
+			expected: "1. First\n2. Second\n3. Third",
+		},
+		{
+			name:     "blockquote",
+			html:     "<p>Sounds good</p><blockquote>Line 1<br>Line 2</blockquote>",
+			expected: "Sounds good\n\n> Line 1\n> Line 2",
+		},
+		{
+			name:     "nested blockquote",
+			html:     "<blockquote>Outer<blockquote>Inner</blockquote></blockquote>",
+			expected: "> Outer\n> > Inner",
+		},
+		{
+			name:     "table cells",
+			html:     "<table><tr><td>Name</td><td>Qty</td></tr>\n<tr><td>Apples</td><td>3</td></tr></table>",
+			expected: "Name\tQty\nApples\t3",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := HTMLToText(tt.html)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+// Reading IDs from stdin
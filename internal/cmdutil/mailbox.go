@@ -0,0 +1,206 @@
+package cmdutil
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/marckohlbrugge/fastmail-cli/internal/jmap"
+)
+
+// ErrFolderNotFound is returned by ResolveMailbox when nothing matches.
+var ErrFolderNotFound = errors.New("folder not found")
+
+// MailboxSorts are the orders folder listings accept for --sort.
+var MailboxSorts = []string{"order", "name", "unread", "role"}
+
+// roleRank orders the standard mailbox roles the way mail clients show them.
+var roleRank = map[string]int{
+	"inbox":   0,
+	"drafts":  1,
+	"sent":    2,
+	"archive": 3,
+	"junk":    4,
+	"trash":   5,
+}
+
+// SortMailboxes sorts mailboxes in place for a --sort value:
+//
+//	order   - the account's folder order (sortOrder), then name
+//	name    - alphabetical, ignoring case
+//	unread  - most unread first, then name
+//	role    - inbox, drafts, sent, archive, junk, trash, other roles, then
+//	          folders without a role, each by name
+func SortMailboxes(mailboxes []jmap.Mailbox, by string) error {
+	var compare func(a, b jmap.Mailbox) int
+	switch by {
+	case "", "order":
+		compare = func(a, b jmap.Mailbox) int { return a.SortOrder - b.SortOrder }
+	case "name":
+		compare = func(a, b jmap.Mailbox) int { return 0 }
+	case "unread":
+		compare = func(a, b jmap.Mailbox) int { return b.UnreadEmails - a.UnreadEmails }
+	case "role":
+		compare = func(a, b jmap.Mailbox) int { return mailboxRoleRank(a) - mailboxRoleRank(b) }
+	default:
+		return FlagErrorf("invalid sort %q: use %s", by, strings.Join(MailboxSorts, ", "))
+	}
+
+	sort.SliceStable(mailboxes, func(i, j int) bool {
+		if c := compare(mailboxes[i], mailboxes[j]); c != 0 {
+			return c < 0
+		}
+		return strings.ToLower(mailboxes[i].Name) < strings.ToLower(mailboxes[j].Name)
+	})
+	return nil
+}
+
+func mailboxRoleRank(mb jmap.Mailbox) int {
+	if mb.Role == "" {
+		return len(roleRank) + 1
+	}
+	if rank, ok := roleRank[mb.Role]; ok {
+		return rank
+	}
+	return len(roleRank)
+}
+
+// ResolveMailbox finds a folder by ID, name, or role. If none matches
+// exactly, folders whose name contains folderRef are tried: a single match is
+// used, and several are offered as a choice when interactive.
+func ResolveMailbox(f *Factory, client *jmap.Client, folderRef string) (*jmap.Mailbox, error) {
+	mailboxes, err := client.GetMailboxes()
+	if err != nil {
+		return nil, err
+	}
+
+	exact, matches := matchMailboxes(mailboxes, folderRef)
+	if exact != nil {
+		return exact, nil
+	}
+
+	switch len(matches) {
+	case 0:
+		return nil, fmt.Errorf("%w: %s", ErrFolderNotFound, folderRef)
+	case 1:
+		if !f.Quiet {
+			fmt.Fprintf(f.IOStreams.ErrOut, "Resolved %q to folder %s\n", folderRef, matches[0].Name)
+		}
+		return &matches[0], nil
+	}
+
+	if !f.IOStreams.IsInteractive() || f.Quiet {
+		names := make([]string, len(matches))
+		for i, mb := range matches {
+			names[i] = mb.Name
+		}
+		return nil, fmt.Errorf("%q matches multiple folders: %s\n\nUse the full folder name or ID instead.", folderRef, strings.Join(names, ", "))
+	}
+
+	return chooseMailbox(f, folderRef, matches)
+}
+
+// ResolveMailboxExact finds a folder by ID, name, role, or "Parent/Child"
+// path, like ResolveMailbox but without trying partial name matches.
+func ResolveMailboxExact(client *jmap.Client, folderRef string) (*jmap.Mailbox, error) {
+	mailboxes, err := client.GetMailboxes()
+	if err != nil {
+		return nil, err
+	}
+
+	if exact, _ := matchMailboxes(mailboxes, folderRef); exact != nil {
+		return exact, nil
+	}
+	return nil, fmt.Errorf("%w: %s", ErrFolderNotFound, folderRef)
+}
+
+// matchMailboxes returns the folder whose ID, name, role, or "Parent/Child"
+// path is folderRef, in that order of preference. Failing that, it returns
+// the folders whose name contains folderRef. Names and roles are compared
+// ignoring case.
+func matchMailboxes(mailboxes []jmap.Mailbox, folderRef string) (*jmap.Mailbox, []jmap.Mailbox) {
+	ref := strings.ToLower(strings.TrimSpace(folderRef))
+
+	for i, mb := range mailboxes {
+		if mb.ID == folderRef {
+			return &mailboxes[i], nil
+		}
+	}
+	for i, mb := range mailboxes {
+		if strings.ToLower(mb.Name) == ref {
+			return &mailboxes[i], nil
+		}
+	}
+	for i, mb := range mailboxes {
+		if mb.Role != "" && strings.ToLower(mb.Role) == ref {
+			return &mailboxes[i], nil
+		}
+	}
+
+	if strings.Contains(folderRef, "/") {
+		if mb := findFolderPath(mailboxes, folderRef); mb != nil {
+			return mb, nil
+		}
+	}
+
+	var matches []jmap.Mailbox
+	if ref != "" {
+		for _, mb := range mailboxes {
+			if strings.Contains(strings.ToLower(mb.Name), ref) {
+				matches = append(matches, mb)
+			}
+		}
+	}
+	return nil, matches
+}
+
+// findFolderPath finds the folder a "Parent/Child" path leads to, starting
+// from the top level, or nil if any folder along it is missing.
+func findFolderPath(mailboxes []jmap.Mailbox, path string) *jmap.Mailbox {
+	var folder *jmap.Mailbox
+	parentID := ""
+	for _, name := range strings.Split(path, "/") {
+		if folder = FindChildFolder(mailboxes, parentID, strings.TrimSpace(name)); folder == nil {
+			return nil
+		}
+		parentID = folder.ID
+	}
+	return folder
+}
+
+// FindChildFolder finds the folder called name (ignoring case) directly
+// under parentID, or at the top level when parentID is empty.
+func FindChildFolder(mailboxes []jmap.Mailbox, parentID, name string) *jmap.Mailbox {
+	for i, mb := range mailboxes {
+		if mb.ParentID == parentID && strings.EqualFold(mb.Name, name) {
+			return &mailboxes[i]
+		}
+	}
+	return nil
+}
+
+// chooseMailbox prompts the user to pick one of several matching folders.
+func chooseMailbox(f *Factory, folderRef string, matches []jmap.Mailbox) (*jmap.Mailbox, error) {
+	errOut := f.IOStreams.ErrOut
+
+	fmt.Fprintf(errOut, "Multiple folders match %q:\n", folderRef)
+	for i, mb := range matches {
+		fmt.Fprintf(errOut, "  %d. %s\n", i+1, mb.Name)
+	}
+	fmt.Fprintf(errOut, "Choose a folder [1-%d]: ", len(matches))
+
+	scanner := bufio.NewScanner(f.IOStreams.In)
+	response := ""
+	if scanner.Scan() {
+		response = strings.TrimSpace(scanner.Text())
+	}
+
+	n, err := strconv.Atoi(response)
+	if err != nil || n < 1 || n > len(matches) {
+		return nil, CancelError
+	}
+	return &matches[n-1], nil
+}
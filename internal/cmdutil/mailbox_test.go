@@ -0,0 +1,93 @@
+package cmdutil
+
+import (
+	"testing"
+
+	"github.com/marckohlbrugge/fastmail-cli/internal/jmap"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSortMailboxes(t *testing.T) {
+	mailboxes := func() []jmap.Mailbox {
+		return []jmap.Mailbox{
+			{ID: "work", Name: "work", UnreadEmails: 2, SortOrder: 10},
+			{ID: "trash", Name: "Trash", Role: "trash", SortOrder: 5},
+			{ID: "inbox", Name: "Inbox", Role: "inbox", UnreadEmails: 7, SortOrder: 1},
+			{ID: "archive", Name: "Archive", Role: "archive", UnreadEmails: 2, SortOrder: 5},
+		}
+	}
+	ids := func(mbs []jmap.Mailbox) []string {
+		out := make([]string, len(mbs))
+		for i, mb := range mbs {
+			out[i] = mb.ID
+		}
+		return out
+	}
+
+	tests := []struct {
+		by   string
+		want []string
+	}{
+		{"", []string{"inbox", "archive", "trash", "work"}},
+		{"order", []string{"inbox", "archive", "trash", "work"}},
+		{"name", []string{"archive", "inbox", "trash", "work"}},
+		{"unread", []string{"inbox", "archive", "work", "trash"}},
+		{"role", []string{"inbox", "archive", "trash", "work"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.by, func(t *testing.T) {
+			mbs := mailboxes()
+			require.NoError(t, SortMailboxes(mbs, tt.by))
+			assert.Equal(t, tt.want, ids(mbs))
+		})
+	}
+
+	err := SortMailboxes(mailboxes(), "size")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `invalid sort "size": use order, name, unread, role`)
+}
+
+func TestMatchMailboxes(t *testing.T) {
+	mailboxes := []jmap.Mailbox{
+		{ID: "inbox", Name: "Inbox", Role: "inbox"},
+		{ID: "arc", Name: "Archive", Role: "archive"},
+		{ID: "work", Name: "Work Projects"},
+		{ID: "home", Name: "Home Projects"},
+		{ID: "clients", Name: "Clients"},
+		{ID: "acme", Name: "Acme", ParentID: "clients"},
+	}
+
+	tests := []struct {
+		name    string
+		ref     string
+		exact   string
+		partial []string
+	}{
+		{"by ID", "work", "work", nil},
+		{"by name ignoring case", "work projects", "work", nil},
+		{"by role", "archive", "arc", nil},
+		{"single partial match", "hom", "", []string{"home"}},
+		{"several partial matches", "projects", "", []string{"work", "home"}},
+		{"by path ignoring case", "clients/acme", "acme", nil},
+		{"missing path", "Clients/Globex", "", nil},
+		{"no match", "taxes", "", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exact, partial := matchMailboxes(mailboxes, tt.ref)
+
+			if tt.exact == "" {
+				assert.Nil(t, exact)
+			} else {
+				require.NotNil(t, exact)
+				assert.Equal(t, tt.exact, exact.ID)
+			}
+			var ids []string
+			for _, mb := range partial {
+				ids = append(ids, mb.ID)
+			}
+			assert.Equal(t, tt.partial, ids)
+		})
+	}
+}
@@ -0,0 +1,215 @@
+package cmdutil
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/marckohlbrugge/fastmail-cli/internal/iostreams"
+	"github.com/marckohlbrugge/fastmail-cli/internal/jmap"
+)
+
+// Output formats accepted by --output.
+const (
+	OutputTable = "table"
+	OutputJSON  = "json"
+	OutputJSONL = "jsonl"
+	OutputCSV   = "csv"
+	OutputTSV   = "tsv"
+)
+
+// OutputFormats lists the valid --output values.
+var OutputFormats = []string{OutputTable, OutputJSON, OutputJSONL, OutputCSV, OutputTSV}
+
+// ValidateOutputFormat checks that format is one of OutputFormats.
+func ValidateOutputFormat(format string) error {
+	for _, f := range OutputFormats {
+		if format == f {
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid output format %q, available: %s", format, strings.Join(OutputFormats, ", "))
+}
+
+// IsDelimited reports whether format is CSV or TSV.
+func IsDelimited(format string) bool {
+	return format == OutputCSV || format == OutputTSV
+}
+
+// IsOutputFile reports whether an --out value names a file rather than
+// stdout. An empty value and "-" both mean stdout.
+func IsOutputFile(path string) bool {
+	return path != "" && path != "-"
+}
+
+// OpenOutput returns the writer for an --out value: stdout, or the named file,
+// created along with any missing parent directories. Closing the writer
+// closes the file; closing stdout is a no-op.
+func OpenOutput(ios *iostreams.IOStreams, path string) (io.WriteCloser, error) {
+	if !IsOutputFile(path) {
+		return nopWriteCloser{ios.Out}, nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create %s: %w", filepath.Dir(path), err)
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create %s: %w", path, err)
+	}
+	return file, nil
+}
+
+type nopWriteCloser struct {
+	io.Writer
+}
+
+func (nopWriteCloser) Close() error { return nil }
+
+// WriteJSON writes v as indented JSON.
+func WriteJSON(w io.Writer, v interface{}) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(v)
+}
+
+// WriteJSONL writes each item as compact JSON on its own line.
+func WriteJSONL[T any](w io.Writer, items []T) error {
+	encoder := json.NewEncoder(w)
+	for _, item := range items {
+		if err := encoder.Encode(item); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// WriteDelimited writes a header and rows as CSV or TSV.
+func WriteDelimited(w io.Writer, format string, header []string, rows [][]string) error {
+	writer := csv.NewWriter(w)
+	if format == OutputTSV {
+		writer.Comma = '\t'
+	}
+
+	if err := writer.Write(header); err != nil {
+		return err
+	}
+	if err := writer.WriteAll(rows); err != nil {
+		return err
+	}
+	return writer.Error()
+}
+
+// EmailsJSON builds the JSON objects for emails, keeping only the given fields.
+func EmailsJSON(emails []jmap.Email, fields []string) []map[string]interface{} {
+	output := make([]map[string]interface{}, len(emails))
+	for i, e := range emails {
+		output[i] = EmailJSON(e, fields)
+	}
+	return output
+}
+
+// WriteEmailsJSONL writes one compact JSON object per email, with the given
+// fields, encoding each as it goes rather than building the whole array.
+func WriteEmailsJSONL(w io.Writer, emails []jmap.Email, fields []string) error {
+	encoder := json.NewEncoder(w)
+	for _, e := range emails {
+		if err := encoder.Encode(EmailJSON(e, fields)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// EmailJSON builds the JSON object for one email, keeping only the given fields.
+func EmailJSON(e jmap.Email, fields []string) map[string]interface{} {
+	row := make(map[string]interface{})
+	for _, field := range fields {
+		switch field {
+		case "id":
+			row["id"] = e.ID
+		case "threadId":
+			row["threadId"] = e.ThreadID
+		case "subject":
+			row["subject"] = e.Subject
+		case "from":
+			row["from"] = e.From
+		case "to":
+			row["to"] = e.To
+		case "cc":
+			row["cc"] = e.CC
+		case "date":
+			row["receivedAt"] = e.ReceivedAt
+		case "preview":
+			row["preview"] = e.Preview
+		case "unread":
+			row["isUnread"] = e.IsUnread()
+		case "attachment":
+			row["hasAttachment"] = e.HasAttachment
+		case "size":
+			row["size"] = e.Size
+		case "folder":
+			row["folder"] = e.MailboxNames
+		case "messageId":
+			row["messageId"] = e.MessageID
+		case "keywords":
+			row["keywords"] = e.KeywordList()
+		}
+	}
+	return row
+}
+
+// WriteEmailsDelimited writes emails as CSV or TSV with one column per field.
+// Values are written in full (dates as RFC 3339, addresses with email) rather
+// than truncated for display.
+func WriteEmailsDelimited(w io.Writer, format string, emails []jmap.Email, fields []string) error {
+	rows := make([][]string, len(emails))
+	for i, e := range emails {
+		row := make([]string, len(fields))
+		for j, field := range fields {
+			row[j] = emailFieldValue(e, field)
+		}
+		rows[i] = row
+	}
+	return WriteDelimited(w, format, fields, rows)
+}
+
+func emailFieldValue(e jmap.Email, field string) string {
+	switch field {
+	case "id":
+		return e.ID
+	case "threadId":
+		return e.ThreadID
+	case "subject":
+		return e.Subject
+	case "from":
+		return jmap.FormatAddresses(e.From)
+	case "to":
+		return jmap.FormatAddresses(e.To)
+	case "cc":
+		return jmap.FormatAddresses(e.CC)
+	case "date":
+		return e.ReceivedAt.Format(time.RFC3339)
+	case "preview":
+		return e.Preview
+	case "unread":
+		return fmt.Sprintf("%t", e.IsUnread())
+	case "attachment":
+		return fmt.Sprintf("%t", e.HasAttachment)
+	case "size":
+		return fmt.Sprintf("%d", e.Size)
+	case "folder":
+		return strings.Join(e.MailboxNames, ", ")
+	case "messageId":
+		return strings.Join(e.MessageID, ", ")
+	case "keywords":
+		return strings.Join(e.KeywordList(), ",")
+	}
+	return ""
+}
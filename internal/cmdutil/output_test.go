@@ -0,0 +1,92 @@
+package cmdutil
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/marckohlbrugge/fastmail-cli/internal/jmap"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateOutputFormat(t *testing.T) {
+	for _, format := range OutputFormats {
+		assert.NoError(t, ValidateOutputFormat(format))
+	}
+	assert.Error(t, ValidateOutputFormat("xml"))
+	assert.Error(t, ValidateOutputFormat(""))
+}
+
+func TestFactoryOutputFormat(t *testing.T) {
+	f := &Factory{}
+	assert.Equal(t, OutputTable, f.OutputFormat(false))
+	assert.Equal(t, OutputJSON, f.OutputFormat(true))
+
+	require.NoError(t, f.SetOutputFormat(OutputTSV))
+	assert.Equal(t, OutputTSV, f.OutputFormat(false))
+	assert.Equal(t, OutputJSON, f.OutputFormat(true))
+
+	assert.Error(t, f.SetOutputFormat("yaml"))
+	assert.Equal(t, OutputTSV, f.OutputFormat(false), "invalid format leaves setting unchanged")
+}
+
+func TestWriteDelimited(t *testing.T) {
+	header := []string{"id", "name"}
+	rows := [][]string{{"1", "Work, Projects"}, {"2", `Say "hi"`}}
+
+	t.Run("csv quotes as needed", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, WriteDelimited(&buf, OutputCSV, header, rows))
+		assert.Equal(t, "id,name\n1,\"Work, Projects\"\n2,\"Say \"\"hi\"\"\"\n", buf.String())
+	})
+
+	t.Run("tsv uses tabs", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, WriteDelimited(&buf, OutputTSV, header, rows[:1]))
+		assert.Equal(t, "id\tname\n1\tWork, Projects\n", buf.String())
+	})
+}
+
+func TestWriteEmailsDelimited(t *testing.T) {
+	emails := []jmap.Email{
+		{
+			ID:         "email-1",
+			Subject:    "Hello",
+			From:       []jmap.EmailAddress{{Name: "Alice", Email: "alice@example.com"}},
+			ReceivedAt: time.Date(2024, 7, 1, 9, 0, 0, 0, time.UTC),
+		},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteEmailsDelimited(&buf, OutputCSV, emails, []string{"id", "date", "from", "subject", "unread"}))
+	assert.Equal(t, "id,date,from,subject,unread\nemail-1,2024-07-01T09:00:00Z,Alice <alice@example.com>,Hello,true\n", buf.String())
+}
+
+func TestEmailsJSON(t *testing.T) {
+	emails := []jmap.Email{{ID: "email-1", Subject: "Hello", Keywords: map[string]bool{"$seen": true}, Size: 2048}}
+
+	result := EmailsJSON(emails, []string{"id", "unread", "size"})
+
+	require.Len(t, result, 1)
+	assert.Equal(t, map[string]interface{}{"id": "email-1", "isUnread": false, "size": int64(2048)}, result[0])
+}
+
+func TestWriteEmailsJSONL(t *testing.T) {
+	emails := []jmap.Email{
+		{ID: "email-1", Subject: "Hello"},
+		{ID: "email-2", Subject: "Bye"},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteEmailsJSONL(&buf, emails, []string{"id", "subject"}))
+
+	assert.Equal(t, `{"id":"email-1","subject":"Hello"}`+"\n"+`{"id":"email-2","subject":"Bye"}`+"\n", buf.String())
+}
+
+func TestWriteJSONL(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, WriteJSONL(&buf, []jmap.Mailbox{{ID: "mb-1", Name: "Inbox"}}))
+
+	assert.Equal(t, `{"id":"mb-1","name":"Inbox","sortOrder":0,"totalEmails":0,"unreadEmails":0,"totalThreads":0,"unreadThreads":0,"isSubscribed":false}`+"\n", buf.String())
+}
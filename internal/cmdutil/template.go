@@ -0,0 +1,53 @@
+package cmdutil
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/marckohlbrugge/fastmail-cli/internal/jmap"
+)
+
+// DefaultTemplateDateLayout is used by formatDate when no layout is given.
+const DefaultTemplateDateLayout = "2006-01-02 15:04"
+
+// templateEscapes lets users write \t and \n in shell-quoted templates.
+var templateEscapes = strings.NewReplacer(`\t`, "\t", `\n`, "\n")
+
+// TemplateFuncs are the helper functions available to --template.
+var TemplateFuncs = template.FuncMap{
+	// formatDate formats a time in local time, e.g. {{formatDate .ReceivedAt "Jan 2"}}
+	"formatDate": func(t time.Time, layout ...string) string {
+		if t.IsZero() {
+			return ""
+		}
+		if len(layout) > 0 {
+			return t.Local().Format(layout[0])
+		}
+		return t.Local().Format(DefaultTemplateDateLayout)
+	},
+	// addrs formats an address list, e.g. {{addrs .From}}
+	"addrs": jmap.FormatAddresses,
+}
+
+// ParseEmailTemplate parses a --template value rendered once per email.
+func ParseEmailTemplate(text string) (*template.Template, error) {
+	tmpl, err := template.New("email").Funcs(TemplateFuncs).Parse(templateEscapes.Replace(text))
+	if err != nil {
+		return nil, FlagErrorf("invalid --template: %w", err)
+	}
+	return tmpl, nil
+}
+
+// WriteEmailsTemplate renders each email through tmpl, one per line.
+func WriteEmailsTemplate(w io.Writer, tmpl *template.Template, emails []jmap.Email) error {
+	for _, email := range emails {
+		if err := tmpl.Execute(w, email); err != nil {
+			return fmt.Errorf("failed to render template: %w", err)
+		}
+		fmt.Fprintln(w)
+	}
+	return nil
+}
@@ -0,0 +1,62 @@
+package cmdutil
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/marckohlbrugge/fastmail-cli/internal/jmap"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteEmailsTemplate(t *testing.T) {
+	received := time.Date(2024, 7, 1, 9, 30, 0, 0, time.Local)
+	emails := []jmap.Email{
+		{
+			ID:         "email-1",
+			Subject:    "Hello",
+			From:       []jmap.EmailAddress{{Name: "Alice", Email: "alice@example.com"}},
+			ReceivedAt: received,
+		},
+		{ID: "email-2", Subject: "Bye"},
+	}
+
+	t.Run("renders fields and helpers", func(t *testing.T) {
+		tmpl, err := ParseEmailTemplate(`{{.ID}}\t{{formatDate .ReceivedAt "2006-01-02"}}\t{{addrs .From}}\t{{.Subject}}`)
+		require.NoError(t, err)
+
+		var buf bytes.Buffer
+		require.NoError(t, WriteEmailsTemplate(&buf, tmpl, emails))
+
+		assert.Equal(t, "email-1\t2024-07-01\tAlice <alice@example.com>\tHello\n"+
+			"email-2\t\t\tBye\n", buf.String())
+	})
+
+	t.Run("formatDate uses a default layout", func(t *testing.T) {
+		tmpl, err := ParseEmailTemplate(`{{formatDate .ReceivedAt}}`)
+		require.NoError(t, err)
+
+		var buf bytes.Buffer
+		require.NoError(t, WriteEmailsTemplate(&buf, tmpl, emails[:1]))
+
+		assert.Equal(t, "2024-07-01 09:30\n", buf.String())
+	})
+
+	t.Run("rejects invalid templates", func(t *testing.T) {
+		_, err := ParseEmailTemplate(`{{.Subject`)
+
+		require.Error(t, err)
+		var flagErr *FlagError
+		assert.ErrorAs(t, err, &flagErr)
+	})
+
+	t.Run("reports unknown fields when rendering", func(t *testing.T) {
+		tmpl, err := ParseEmailTemplate(`{{.Nope}}`)
+		require.NoError(t, err)
+
+		err = WriteEmailsTemplate(&bytes.Buffer{}, tmpl, emails)
+
+		assert.ErrorContains(t, err, "failed to render template")
+	})
+}
@@ -0,0 +1,44 @@
+package cmdutil
+
+import (
+	"sort"
+
+	"github.com/marckohlbrugge/fastmail-cli/internal/jmap"
+)
+
+// GroupByThread collapses emails sharing a thread into the thread's latest
+// email, with ThreadCount set to how many of the given emails it stands for.
+// Threads keep the position of their latest email, so a newest-first list
+// stays newest first (and oldest-first stays oldest first) by latest date.
+func GroupByThread(emails []jmap.Email) []jmap.Email {
+	latest := make(map[string]int) // thread ID -> index of its latest email
+	counts := make(map[string]int)
+	for i, email := range emails {
+		key := threadKey(email)
+		counts[key]++
+		if j, ok := latest[key]; !ok || email.ReceivedAt.After(emails[j].ReceivedAt) {
+			latest[key] = i
+		}
+	}
+
+	indexes := make([]int, 0, len(latest))
+	for _, i := range latest {
+		indexes = append(indexes, i)
+	}
+	sort.Ints(indexes)
+
+	grouped := make([]jmap.Email, len(indexes))
+	for n, i := range indexes {
+		grouped[n] = emails[i]
+		grouped[n].ThreadCount = counts[threadKey(emails[i])]
+	}
+	return grouped
+}
+
+// threadKey groups by thread ID; an email without one is its own thread.
+func threadKey(email jmap.Email) string {
+	if email.ThreadID == "" {
+		return "email:" + email.ID
+	}
+	return email.ThreadID
+}
@@ -0,0 +1,54 @@
+package cmdutil
+
+import (
+	"testing"
+	"time"
+
+	"github.com/marckohlbrugge/fastmail-cli/internal/jmap"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGroupByThread(t *testing.T) {
+	at := func(hours int) time.Time {
+		return time.Date(2024, 1, 15, hours, 0, 0, 0, time.UTC)
+	}
+
+	t.Run("keeps the latest email per thread, newest first", func(t *testing.T) {
+		emails := []jmap.Email{
+			{ID: "a3", ThreadID: "t-a", ReceivedAt: at(12)},
+			{ID: "b1", ThreadID: "t-b", ReceivedAt: at(11)},
+			{ID: "a2", ThreadID: "t-a", ReceivedAt: at(10)},
+			{ID: "a1", ThreadID: "t-a", ReceivedAt: at(9)},
+			{ID: "c1", ReceivedAt: at(8)},
+		}
+
+		grouped := GroupByThread(emails)
+
+		assert.Len(t, grouped, 3)
+		assert.Equal(t, "a3", grouped[0].ID)
+		assert.Equal(t, 3, grouped[0].ThreadCount)
+		assert.Equal(t, "b1", grouped[1].ID)
+		assert.Equal(t, 1, grouped[1].ThreadCount)
+		assert.Equal(t, "c1", grouped[2].ID)
+	})
+
+	t.Run("orders oldest-first lists by each thread's latest email", func(t *testing.T) {
+		emails := []jmap.Email{
+			{ID: "a1", ThreadID: "t-a", ReceivedAt: at(9)},
+			{ID: "b1", ThreadID: "t-b", ReceivedAt: at(10)},
+			{ID: "a2", ThreadID: "t-a", ReceivedAt: at(11)},
+		}
+
+		grouped := GroupByThread(emails)
+
+		assert.Len(t, grouped, 2)
+		assert.Equal(t, "b1", grouped[0].ID)
+		assert.Equal(t, "a2", grouped[1].ID)
+		assert.Equal(t, 2, grouped[1].ThreadCount)
+	})
+
+	t.Run("shows the count before the subject", func(t *testing.T) {
+		row := FormatEmailRow(jmap.Email{Subject: "Weekly digest", ThreadCount: 3}, []string{"subject"})
+		assert.Contains(t, row, "(3) Weekly digest")
+	})
+}
@@ -0,0 +1,71 @@
+package cmdutil
+
+import (
+	"fmt"
+	"net/mail"
+	"strings"
+	"time"
+
+	"github.com/marckohlbrugge/fastmail-cli/internal/jmap"
+)
+
+// ValidateEmail checks that addr is a bare email address like "alice@example.com".
+func ValidateEmail(addr string) error {
+	parsed, err := mail.ParseAddress(addr)
+	if err != nil || parsed.Address != addr {
+		return fmt.Errorf("invalid email address %q", addr)
+	}
+	return nil
+}
+
+// ParseDate parses a date given as YYYY-MM-DD (midnight local time) or as an
+// RFC 3339 timestamp. dateOnly reports whether the value had no time part.
+func ParseDate(value string) (t time.Time, dateOnly bool, err error) {
+	if t, err := time.ParseInLocation("2006-01-02", value, time.Local); err == nil {
+		return t, true, nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, false, nil
+	}
+	return time.Time{}, false, fmt.Errorf("invalid date %q: use YYYY-MM-DD or RFC 3339 (e.g. 2024-07-01T09:00:00Z)", value)
+}
+
+// ParseTime parses a time given relative to now as "+" and a duration (e.g.
+// +3h, +2d, as accepted by jmap.ParseRelativeDuration) or absolutely in any
+// form ParseDate accepts.
+func ParseTime(value string, now time.Time) (time.Time, error) {
+	if rest, ok := strings.CutPrefix(value, "+"); ok {
+		d, err := jmap.ParseRelativeDuration(rest)
+		if err != nil {
+			return time.Time{}, err
+		}
+		return now.Add(d), nil
+	}
+
+	t, _, err := ParseDate(value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid time %q: use +DURATION (e.g. +3h, +2d), YYYY-MM-DD, or RFC 3339", value)
+	}
+	return t, nil
+}
+
+// ParseLimit checks a --limit value, converting 0, which means "no limit",
+// to jmap.NoLimit.
+func ParseLimit(limit int) (int, error) {
+	switch {
+	case limit < 0:
+		return 0, FlagErrorf("invalid --limit %d: use a positive number, or 0 for no limit", limit)
+	case limit == 0:
+		return jmap.NoLimit, nil
+	}
+	return limit, nil
+}
+
+// ValidateBulkLimit checks a --limit value for a command that changes the
+// emails it selects, where "no limit" isn't offered.
+func ValidateBulkLimit(limit int) error {
+	if limit < 1 {
+		return FlagErrorf("invalid --limit %d: use a positive number", limit)
+	}
+	return nil
+}
@@ -0,0 +1,114 @@
+package cmdutil
+
+import (
+	"testing"
+	"time"
+
+	"github.com/marckohlbrugge/fastmail-cli/internal/jmap"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateEmail(t *testing.T) {
+	tests := []struct {
+		name    string
+		addr    string
+		wantErr bool
+	}{
+		{name: "simple address", addr: "alice@example.com", wantErr: false},
+		{name: "plus address", addr: "alice+news@example.co.uk", wantErr: false},
+		{name: "missing domain", addr: "alice@", wantErr: true},
+		{name: "missing at", addr: "alice.example.com", wantErr: true},
+		{name: "display name form", addr: "Alice <alice@example.com>", wantErr: true},
+		{name: "empty", addr: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateEmail(tt.addr)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestParseDate(t *testing.T) {
+	t.Run("date only", func(t *testing.T) {
+		got, dateOnly, err := ParseDate("2024-07-01")
+		require.NoError(t, err)
+		assert.True(t, dateOnly)
+		assert.Equal(t, time.Date(2024, 7, 1, 0, 0, 0, 0, time.Local), got)
+	})
+
+	t.Run("RFC 3339", func(t *testing.T) {
+		got, dateOnly, err := ParseDate("2024-07-01T09:30:00Z")
+		require.NoError(t, err)
+		assert.False(t, dateOnly)
+		assert.Equal(t, time.Date(2024, 7, 1, 9, 30, 0, 0, time.UTC), got.UTC())
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		_, _, err := ParseDate("next tuesday")
+		assert.Error(t, err)
+	})
+}
+
+func TestParseTime(t *testing.T) {
+	now := time.Date(2024, 7, 1, 9, 0, 0, 0, time.UTC)
+
+	t.Run("relative", func(t *testing.T) {
+		got, err := ParseTime("+3h", now)
+		require.NoError(t, err)
+		assert.Equal(t, now.Add(3*time.Hour), got)
+	})
+
+	t.Run("absolute", func(t *testing.T) {
+		got, err := ParseTime("2024-07-02T08:00:00Z", now)
+		require.NoError(t, err)
+		assert.Equal(t, time.Date(2024, 7, 2, 8, 0, 0, 0, time.UTC), got.UTC())
+	})
+
+	t.Run("date only", func(t *testing.T) {
+		got, err := ParseTime("2024-07-02", now)
+		require.NoError(t, err)
+		assert.Equal(t, time.Date(2024, 7, 2, 0, 0, 0, 0, time.Local), got)
+	})
+
+	t.Run("invalid relative", func(t *testing.T) {
+		_, err := ParseTime("+3x", now)
+		assert.Error(t, err)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		_, err := ParseTime("3h", now)
+		assert.Error(t, err)
+	})
+}
+
+func TestParseLimit(t *testing.T) {
+	got, err := ParseLimit(25)
+	require.NoError(t, err)
+	assert.Equal(t, 25, got)
+
+	got, err = ParseLimit(0)
+	require.NoError(t, err)
+	assert.Equal(t, jmap.NoLimit, got)
+
+	_, err = ParseLimit(-5)
+	var flagErr *FlagError
+	assert.ErrorAs(t, err, &flagErr)
+}
+
+func TestValidateBulkLimit(t *testing.T) {
+	assert.NoError(t, ValidateBulkLimit(1))
+	assert.NoError(t, ValidateBulkLimit(500))
+
+	for _, limit := range []int{0, -1} {
+		err := ValidateBulkLimit(limit)
+		var flagErr *FlagError
+		assert.ErrorAs(t, err, &flagErr, "limit %d", limit)
+	}
+}
@@ -0,0 +1,118 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Config holds user defaults read from the config file.
+// Zero values mean "not set" and fall back to built-in defaults.
+type Config struct {
+	// Limit is the default number of emails listed by inbox and search
+	Limit int `yaml:"limit,omitempty"`
+
+	// Output is the default output format (table, json, jsonl, csv, tsv)
+	Output string `yaml:"output,omitempty"`
+
+	// APIURL overrides the Fastmail API base URL
+	APIURL string `yaml:"api-url,omitempty"`
+
+	// Profile is the default authentication profile
+	Profile string `yaml:"profile,omitempty"`
+
+	// Inbox holds defaults for the inbox command
+	Inbox InboxConfig `yaml:"inbox,omitempty"`
+}
+
+// InboxConfig holds defaults for 'fm inbox'.
+type InboxConfig struct {
+	// DefaultFolder is the folder listed instead of the Inbox (ID, name, or role)
+	DefaultFolder string `yaml:"default_folder,omitempty"`
+}
+
+// template is written on first login so the available keys are discoverable.
+const template = `# fm configuration
+#
+# Settings here are defaults: command-line flags override environment
+# variables, which override this file.
+
+# Number of emails listed by 'fm inbox' and 'fm search'
+# limit: 20
+
+# Output format: table, json, jsonl, csv, or tsv
+# output: table
+
+# Fastmail API base URL
+# api-url: https://api.fastmail.com
+
+# Authentication profile to use (see 'fm auth login --profile')
+# profile: default
+
+# Folder 'fm inbox' lists instead of the Inbox (ID, name, or role)
+# inbox:
+#   default_folder: Priority
+`
+
+// DefaultPath returns the config file location: $FM_CONFIG if set, else
+// $XDG_CONFIG_HOME/fm/config.yml, else ~/.config/fm/config.yml.
+func DefaultPath() (string, error) {
+	if path := os.Getenv("FM_CONFIG"); path != "" {
+		return path, nil
+	}
+
+	dir := os.Getenv("XDG_CONFIG_HOME")
+	if dir == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", fmt.Errorf("could not determine home directory: %w", err)
+		}
+		dir = filepath.Join(home, ".config")
+	}
+
+	return filepath.Join(dir, "fm", "config.yml"), nil
+}
+
+// Load reads the config file at path. A missing file yields an empty Config.
+func Load(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return &Config{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	cfg := &Config{}
+	if err := yaml.Unmarshal(data, cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse config file %s: %w", path, err)
+	}
+
+	if cfg.Limit < 0 {
+		return nil, fmt.Errorf("invalid limit %d in %s", cfg.Limit, path)
+	}
+
+	return cfg, nil
+}
+
+// EnsureExists writes a commented template to path if no file exists there.
+// It reports whether a file was created.
+func EnsureExists(path string) (bool, error) {
+	if _, err := os.Stat(path); err == nil {
+		return false, nil
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return false, err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return false, fmt.Errorf("failed to create config directory: %w", err)
+	}
+	if err := os.WriteFile(path, []byte(template), 0600); err != nil {
+		return false, fmt.Errorf("failed to write config file: %w", err)
+	}
+
+	return true, nil
+}
@@ -0,0 +1,126 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestDefaultPath(t *testing.T) {
+	t.Run("uses FM_CONFIG", func(t *testing.T) {
+		t.Setenv("FM_CONFIG", "/tmp/custom.yml")
+
+		path, err := DefaultPath()
+
+		require.NoError(t, err)
+		assert.Equal(t, "/tmp/custom.yml", path)
+	})
+
+	t.Run("uses XDG_CONFIG_HOME", func(t *testing.T) {
+		t.Setenv("FM_CONFIG", "")
+		t.Setenv("XDG_CONFIG_HOME", "/tmp/xdg")
+
+		path, err := DefaultPath()
+
+		require.NoError(t, err)
+		assert.Equal(t, filepath.Join("/tmp/xdg", "fm", "config.yml"), path)
+	})
+
+	t.Run("falls back to ~/.config", func(t *testing.T) {
+		t.Setenv("FM_CONFIG", "")
+		t.Setenv("XDG_CONFIG_HOME", "")
+		t.Setenv("HOME", "/tmp/home")
+
+		path, err := DefaultPath()
+
+		require.NoError(t, err)
+		assert.Equal(t, filepath.Join("/tmp/home", ".config", "fm", "config.yml"), path)
+	})
+}
+
+func TestLoad(t *testing.T) {
+	t.Run("missing file is empty config", func(t *testing.T) {
+		cfg, err := Load(filepath.Join(t.TempDir(), "config.yml"))
+
+		require.NoError(t, err)
+		assert.Equal(t, &Config{}, cfg)
+	})
+
+	t.Run("reads all keys", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "config.yml")
+		require.NoError(t, os.WriteFile(path, []byte("limit: 10\noutput: json\napi-url: https://api.test.com\nprofile: work\ninbox:\n  default_folder: Priority\n"), 0600))
+
+		cfg, err := Load(path)
+
+		require.NoError(t, err)
+		assert.Equal(t, &Config{Limit: 10, Output: "json", APIURL: "https://api.test.com", Profile: "work", Inbox: InboxConfig{DefaultFolder: "Priority"}}, cfg)
+	})
+
+	t.Run("template parses to empty config", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "config.yml")
+		require.NoError(t, os.WriteFile(path, []byte(template), 0600))
+
+		cfg, err := Load(path)
+
+		require.NoError(t, err)
+		assert.Equal(t, &Config{}, cfg)
+	})
+
+	t.Run("rejects invalid YAML", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "config.yml")
+		require.NoError(t, os.WriteFile(path, []byte("limit: [oops"), 0600))
+
+		_, err := Load(path)
+
+		assert.Error(t, err)
+	})
+
+	t.Run("round-trips the inbox section", func(t *testing.T) {
+		want := &Config{Limit: 10, Inbox: InboxConfig{DefaultFolder: "Priority"}}
+		data, err := yaml.Marshal(want)
+		require.NoError(t, err)
+		assert.Contains(t, string(data), "inbox:\n    default_folder: Priority\n")
+
+		path := filepath.Join(t.TempDir(), "config.yml")
+		require.NoError(t, os.WriteFile(path, data, 0600))
+
+		cfg, err := Load(path)
+
+		require.NoError(t, err)
+		assert.Equal(t, want, cfg)
+	})
+
+	t.Run("rejects negative limit", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "config.yml")
+		require.NoError(t, os.WriteFile(path, []byte("limit: -1\n"), 0600))
+
+		_, err := Load(path)
+
+		assert.Error(t, err)
+	})
+}
+
+func TestEnsureExists(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "fm", "config.yml")
+
+	created, err := EnsureExists(path)
+	require.NoError(t, err)
+	assert.True(t, created)
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "# limit: 20")
+
+	require.NoError(t, os.WriteFile(path, []byte("limit: 5\n"), 0600))
+	created, err = EnsureExists(path)
+	require.NoError(t, err)
+	assert.False(t, created)
+
+	data, err = os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "limit: 5\n", string(data), "existing file is left alone")
+}
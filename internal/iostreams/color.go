@@ -0,0 +1,54 @@
+package iostreams
+
+// ColorScheme wraps text in ANSI escape codes when color is enabled,
+// and returns it unchanged otherwise.
+type ColorScheme struct {
+	enabled bool
+}
+
+// NewColorScheme creates a ColorScheme that colors output only if enabled.
+func NewColorScheme(enabled bool) *ColorScheme {
+	return &ColorScheme{enabled: enabled}
+}
+
+// ColorScheme returns a ColorScheme that follows ColorEnabled.
+func (s *IOStreams) ColorScheme() *ColorScheme {
+	return NewColorScheme(s.ColorEnabled())
+}
+
+// Enabled reports whether the scheme emits escape codes.
+func (c *ColorScheme) Enabled() bool {
+	return c != nil && c.enabled
+}
+
+// Bold renders text in bold.
+func (c *ColorScheme) Bold(text string) string {
+	return c.wrap("1", text)
+}
+
+// Dim renders text faint.
+func (c *ColorScheme) Dim(text string) string {
+	return c.wrap("2", text)
+}
+
+// Red renders text in red.
+func (c *ColorScheme) Red(text string) string {
+	return c.wrap("31", text)
+}
+
+// Green renders text in green.
+func (c *ColorScheme) Green(text string) string {
+	return c.wrap("32", text)
+}
+
+// Yellow renders text in yellow.
+func (c *ColorScheme) Yellow(text string) string {
+	return c.wrap("33", text)
+}
+
+func (c *ColorScheme) wrap(code, text string) string {
+	if !c.Enabled() || text == "" {
+		return text
+	}
+	return "\033[" + code + "m" + text + "\033[0m"
+}
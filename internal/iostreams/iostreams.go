@@ -70,6 +70,16 @@ func (s *IOStreams) IsStderrTTY() bool {
 	return s.stderrIsTTY
 }
 
+// SetStdinTTY overrides whether stdin is treated as a terminal (for testing).
+func (s *IOStreams) SetStdinTTY(isTTY bool) {
+	s.stdinIsTTY = isTTY
+}
+
+// SetStdoutTTY overrides whether stdout is treated as a terminal (for testing).
+func (s *IOStreams) SetStdoutTTY(isTTY bool) {
+	s.stdoutIsTTY = isTTY
+}
+
 // IsInteractive returns true if both stdin and stdout are connected to terminals.
 func (s *IOStreams) IsInteractive() bool {
 	return s.stdinIsTTY && s.stdoutIsTTY
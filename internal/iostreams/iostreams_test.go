@@ -159,6 +159,29 @@ func TestSetColorEnabled(t *testing.T) {
 	assert.True(t, ios.colorChecked)
 }
 
+func TestColorScheme(t *testing.T) {
+	t.Run("wraps text when enabled", func(t *testing.T) {
+		cs := NewColorScheme(true)
+		assert.Equal(t, "\033[1mhi\033[0m", cs.Bold("hi"))
+		assert.Equal(t, "\033[2mhi\033[0m", cs.Dim("hi"))
+		assert.Equal(t, "\033[31mhi\033[0m", cs.Red("hi"))
+	})
+
+	t.Run("returns text unchanged when disabled", func(t *testing.T) {
+		cs := NewColorScheme(false)
+		assert.Equal(t, "hi", cs.Bold("hi"))
+		assert.Equal(t, "hi", cs.Green("hi"))
+	})
+
+	t.Run("follows ColorEnabled", func(t *testing.T) {
+		ios, _, _, _ := Test()
+		assert.False(t, ios.ColorScheme().Enabled())
+
+		ios.SetColorEnabled(true)
+		assert.True(t, ios.ColorScheme().Enabled())
+	})
+}
+
 func TestTerminalWidth(t *testing.T) {
 	t.Run("returns 80 when not TTY", func(t *testing.T) {
 		ios := &IOStreams{
@@ -2,33 +2,92 @@ package jmap
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"strings"
+	"time"
 )
 
 const (
 	DefaultBaseURL = "https://api.fastmail.com"
 	SessionPath    = "/jmap/session"
+
+	// DefaultTimeout bounds each HTTP request made by the client.
+	DefaultTimeout = 60 * time.Second
 )
 
+// ErrTimeout is returned when a request does not complete within the
+// client's timeout.
+var ErrTimeout = errors.New("request timed out")
+
 // Client is a JMAP client for Fastmail.
 type Client struct {
 	token      string
 	baseURL    string
 	httpClient *http.Client
+	timeout    time.Duration
 	session    *Session
 }
 
 // Session contains JMAP session information.
 type Session struct {
-	APIURL      string                 `json:"apiUrl"`
-	DownloadURL string                 `json:"downloadUrl"`
-	UploadURL   string                 `json:"uploadUrl"`
-	AccountID   string                 // First account ID
-	Accounts    map[string]interface{} `json:"accounts"`
+	APIURL       string                     `json:"apiUrl"`
+	DownloadURL  string                     `json:"downloadUrl"`
+	UploadURL    string                     `json:"uploadUrl"`
+	AccountID    string                     // First account ID
+	Username     string                     `json:"username"`
+	Accounts     map[string]interface{}     `json:"accounts"`
+	Capabilities map[string]json.RawMessage `json:"capabilities"`
+}
+
+// HasCapability reports whether the server advertises a capability.
+func (s *Session) HasCapability(capability string) bool {
+	_, ok := s.Capabilities[capability]
+	return ok
+}
+
+// IsReadOnly reports whether the session's account is read-only, i.e. the
+// token cannot change anything.
+func (s *Session) IsReadOnly() bool {
+	account, ok := s.Accounts[s.AccountID].(map[string]interface{})
+	if !ok {
+		return false
+	}
+	readOnly, _ := account["isReadOnly"].(bool)
+	return readOnly
+}
+
+// CapabilityError is returned when the account's session does not advertise
+// a capability a command needs.
+type CapabilityError struct {
+	Capability string
+}
+
+func (e *CapabilityError) Error() string {
+	if feature, ok := capabilityFeatures[e.Capability]; ok {
+		return fmt.Sprintf("your account doesn't support %s (%s)", feature, e.Capability)
+	}
+	return fmt.Sprintf("your account doesn't support %s", e.Capability)
+}
+
+// MethodError is returned when the server answers a method call with an
+// error response instead of a result, e.g. "forbidden" or "notFound".
+type MethodError struct {
+	Type        string
+	Description string
+	MethodName  string
+}
+
+func (e *MethodError) Error() string {
+	msg := fmt.Sprintf("%s failed: %s", e.MethodName, e.Type)
+	if e.Description != "" {
+		msg += " - " + e.Description
+	}
+	return msg
 }
 
 // Request is a JMAP request.
@@ -49,6 +108,7 @@ func NewClient(token string) *Client {
 		token:      token,
 		baseURL:    DefaultBaseURL,
 		httpClient: &http.Client{},
+		timeout:    DefaultTimeout,
 	}
 }
 
@@ -62,6 +122,11 @@ func (c *Client) SetHTTPClient(client *http.Client) {
 	c.httpClient = client
 }
 
+// SetTimeout sets how long each request may take before it is aborted.
+func (c *Client) SetTimeout(timeout time.Duration) {
+	c.timeout = timeout
+}
+
 // GetSession returns the JMAP session, fetching it if necessary.
 func (c *Client) GetSession() (*Session, error) {
 	if c.session != nil {
@@ -75,7 +140,7 @@ func (c *Client) GetSession() (*Session, error) {
 
 	c.setAuthHeaders(req)
 
-	resp, err := c.httpClient.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to Fastmail: %w", err)
 	}
@@ -120,7 +185,7 @@ func (c *Client) MakeRequest(request *Request) (*Response, error) {
 
 	c.setAuthHeaders(req)
 
-	resp, err := c.httpClient.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, fmt.Errorf("JMAP request failed: %w", err)
 	}
@@ -136,9 +201,114 @@ func (c *Client) MakeRequest(request *Request) (*Response, error) {
 		return nil, fmt.Errorf("failed to decode response: %w", err)
 	}
 
+	if err := response.methodError(request); err != nil {
+		return nil, err
+	}
+
 	return &response, nil
 }
 
+// methodError returns a *MethodError for the first method call in request
+// that the server answered with an error response, or nil if none did.
+func (r *Response) methodError(request *Request) error {
+	for _, resp := range r.MethodResponses {
+		if len(resp) < 3 {
+			continue
+		}
+
+		var name, callID string
+		if json.Unmarshal(resp[0], &name) != nil || name != "error" {
+			continue
+		}
+		_ = json.Unmarshal(resp[2], &callID)
+
+		var details struct {
+			Type        string `json:"type"`
+			Description string `json:"description"`
+		}
+		_ = json.Unmarshal(resp[1], &details)
+
+		methodName := callID
+		for _, call := range request.MethodCalls {
+			if len(call) == 3 && call[2] == callID {
+				methodName, _ = call[0].(string)
+				break
+			}
+		}
+
+		return &MethodError{Type: details.Type, Description: details.Description, MethodName: methodName}
+	}
+	return nil
+}
+
+// do sends req with the client's timeout applied. The timeout covers reading
+// the response body too; it is released when the body is closed.
+func (c *Client) do(req *http.Request) (*http.Response, error) {
+	if c.timeout <= 0 {
+		return c.httpClient.Do(req)
+	}
+
+	ctx, cancel := context.WithTimeout(req.Context(), c.timeout)
+	resp, err := c.httpClient.Do(req.WithContext(ctx))
+	if err != nil {
+		cancel()
+		return nil, c.timeoutError(err)
+	}
+
+	resp.Body = &timeoutBody{ReadCloser: resp.Body, client: c, cancel: cancel}
+	return resp, nil
+}
+
+// timeoutError reports a deadline exceeded by the client's timeout as
+// ErrTimeout, and returns other errors unchanged.
+func (c *Client) timeoutError(err error) error {
+	if errors.Is(err, context.DeadlineExceeded) {
+		return fmt.Errorf("%w after %s", ErrTimeout, c.timeout)
+	}
+	return err
+}
+
+// timeoutBody is a response body whose request context is released on Close.
+type timeoutBody struct {
+	io.ReadCloser
+	client *Client
+	cancel context.CancelFunc
+}
+
+func (b *timeoutBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	if err != nil && err != io.EOF {
+		err = b.client.timeoutError(err)
+	}
+	return n, err
+}
+
+func (b *timeoutBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.cancel()
+	return err
+}
+
+// RequireCapability returns a *CapabilityError if the account does not
+// support capability, so commands can fail before doing any other work.
+func (c *Client) RequireCapability(capability string) error {
+	_, err := c.requireCapability(capability)
+	return err
+}
+
+// requireCapability returns the session, or a *CapabilityError if it does not
+// advertise capability.
+func (c *Client) requireCapability(capability string) (*Session, error) {
+	session, err := c.GetSession()
+	if err != nil {
+		return nil, err
+	}
+	if !session.HasCapability(capability) {
+		return nil, &CapabilityError{Capability: capability}
+	}
+	return session, nil
+}
+
 // setAuthHeaders sets the authorization headers on a request.
 func (c *Client) setAuthHeaders(req *http.Request) {
 	req.Header.Set("Authorization", "Bearer "+c.token)
@@ -159,4 +329,27 @@ var (
 	CoreCapability       = "urn:ietf:params:jmap:core"
 	MailCapability       = "urn:ietf:params:jmap:mail"
 	SubmissionCapability = "urn:ietf:params:jmap:submission"
+	VacationCapability   = "urn:ietf:params:jmap:vacationresponse"
+	ContactsCapability   = "urn:ietf:params:jmap:contacts"
+	QuotaCapability      = "urn:ietf:params:jmap:quota"
+	SieveCapability      = "urn:ietf:params:jmap:sieve"
+
+	// MaskedEmailCapability is Fastmail's vendor extension for masked email addresses
+	MaskedEmailCapability = "https://www.fastmail.com/dev/maskedemail"
+
+	// SnoozeCapability is the Cyrus mail extension Fastmail uses for the
+	// Email "snoozed" property
+	SnoozeCapability = "https://cyrusimap.org/ns/jmap/mail"
 )
+
+// capabilityFeatures names what each capability provides, for errors.
+var capabilityFeatures = map[string]string{
+	MailCapability:        "email",
+	SubmissionCapability:  "sending email",
+	VacationCapability:    "vacation responses",
+	ContactsCapability:    "contacts",
+	QuotaCapability:       "quotas",
+	SieveCapability:       "sieve scripts",
+	MaskedEmailCapability: "masked email",
+	SnoozeCapability:      "snoozing email",
+}
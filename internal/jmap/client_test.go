@@ -3,6 +3,7 @@ package jmap
 import (
 	"net/http"
 	"testing"
+	"time"
 
 	"github.com/jarcoal/httpmock"
 	"github.com/stretchr/testify/assert"
@@ -20,6 +21,7 @@ func TestNewClient(t *testing.T) {
 
 	assert.Equal(t, DefaultBaseURL, client.baseURL)
 	assert.NotNil(t, client.httpClient)
+	assert.Equal(t, DefaultTimeout, client.timeout)
 }
 
 func TestClient_SetBaseURL(t *testing.T) {
@@ -176,6 +178,103 @@ func TestClient_MakeRequest_Error(t *testing.T) {
 	assert.Contains(t, err.Error(), "500")
 }
 
+func TestClient_MakeRequest_MethodError(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	client := newTestClient()
+
+	httpmock.RegisterResponder("GET", "https://api.test.com/jmap/session",
+		httpmock.NewJsonResponderOrPanic(200, map[string]interface{}{
+			"apiUrl":   "https://api.test.com/jmap/api",
+			"accounts": map[string]interface{}{"acc-1": map[string]interface{}{}},
+		}))
+
+	httpmock.RegisterResponder("POST", "https://api.test.com/jmap/api",
+		httpmock.NewJsonResponderOrPanic(200, map[string]interface{}{
+			"methodResponses": [][]interface{}{
+				{"Mailbox/get", map[string]interface{}{"list": []interface{}{}}, "mailboxes"},
+				{"error", map[string]interface{}{"type": "forbidden", "description": "no write access"}, "update"},
+			},
+		}))
+
+	request := &Request{
+		Using: []string{MailCapability},
+		MethodCalls: [][]interface{}{
+			{"Mailbox/get", map[string]interface{}{}, "mailboxes"},
+			{"Email/set", map[string]interface{}{}, "update"},
+		},
+	}
+
+	_, err := client.MakeRequest(request)
+
+	var methodErr *MethodError
+	require.ErrorAs(t, err, &methodErr)
+	assert.Equal(t, "forbidden", methodErr.Type)
+	assert.Equal(t, "no write access", methodErr.Description)
+	assert.Equal(t, "Email/set", methodErr.MethodName)
+	assert.Equal(t, "Email/set failed: forbidden - no write access", err.Error())
+}
+
+func TestClient_MakeRequest_Timeout(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	client := newTestClient()
+	client.SetTimeout(10 * time.Millisecond)
+
+	httpmock.RegisterResponder("GET", "https://api.test.com/jmap/session",
+		httpmock.NewJsonResponderOrPanic(200, map[string]interface{}{
+			"apiUrl":   "https://api.test.com/jmap/api",
+			"accounts": map[string]interface{}{"acc-1": map[string]interface{}{}},
+		}))
+
+	// Hang until the request is aborted
+	httpmock.RegisterResponder("POST", "https://api.test.com/jmap/api",
+		func(req *http.Request) (*http.Response, error) {
+			<-req.Context().Done()
+			return nil, req.Context().Err()
+		})
+
+	request := &Request{
+		Using:       []string{MailCapability},
+		MethodCalls: [][]interface{}{{"Mailbox/get", map[string]interface{}{}, "0"}},
+	}
+
+	_, err := client.MakeRequest(request)
+
+	require.Error(t, err)
+	assert.ErrorIs(t, err, ErrTimeout)
+	assert.Contains(t, err.Error(), "request timed out after 10ms")
+}
+
+func TestClient_RequireCapability(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	client := newTestClient()
+
+	httpmock.RegisterResponder("GET", "https://api.test.com/jmap/session",
+		httpmock.NewJsonResponderOrPanic(200, map[string]interface{}{
+			"apiUrl":   "https://api.test.com/jmap/api",
+			"accounts": map[string]interface{}{"acc-1": map[string]interface{}{}},
+			"capabilities": map[string]interface{}{
+				CoreCapability: map[string]interface{}{},
+				MailCapability: map[string]interface{}{},
+			},
+		}))
+
+	assert.NoError(t, client.RequireCapability(MailCapability))
+
+	err := client.RequireCapability(SubmissionCapability)
+	var capErr *CapabilityError
+	require.ErrorAs(t, err, &capErr)
+	assert.Equal(t, "your account doesn't support sending email (urn:ietf:params:jmap:submission)", err.Error())
+
+	_, err = client.GetIdentities()
+	assert.ErrorAs(t, err, &capErr, "identity methods need the submission capability")
+}
+
 func TestClient_AccountID(t *testing.T) {
 	httpmock.Activate()
 	defer httpmock.DeactivateAndReset()
@@ -0,0 +1,55 @@
+package jmap
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// ErrContactsUnavailable is returned when the account has no contacts access.
+var ErrContactsUnavailable = errors.New("contacts not available for this account")
+
+// GetContacts fetches all contact cards.
+func (c *Client) GetContacts() ([]Contact, error) {
+	session, err := c.GetSession()
+	if err != nil {
+		return nil, err
+	}
+
+	if !session.HasCapability(ContactsCapability) {
+		return nil, ErrContactsUnavailable
+	}
+
+	request := &Request{
+		Using: []string{CoreCapability, ContactsCapability},
+		MethodCalls: [][]interface{}{
+			{
+				"ContactCard/get",
+				map[string]interface{}{
+					"accountId":  session.AccountID,
+					"properties": []string{"id", "kind", "name", "emails", "phones"},
+				},
+				"contacts",
+			},
+		},
+	}
+
+	resp, err := c.MakeRequest(request)
+	var methodErr *MethodError
+	if errors.As(err, &methodErr) {
+		return nil, ErrContactsUnavailable
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		List []Contact `json:"list"`
+	}
+
+	if err := json.Unmarshal(resp.MethodResponses[0][1], &result); err != nil {
+		return nil, fmt.Errorf("failed to parse contacts: %w", err)
+	}
+
+	return result.List, nil
+}
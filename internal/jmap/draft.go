@@ -4,20 +4,27 @@ import (
 	"encoding/json"
 	"fmt"
 	"html"
+	"net/mail"
 	"strings"
 )
 
 // DraftEmail contains data for creating a draft.
+//
+// Recipients are bare addresses or "Name <email>" strings.
 type DraftEmail struct {
 	To         []string
 	CC         []string
 	BCC        []string
+	ReplyTo    []string
 	Subject    string
 	TextBody   string
 	HTMLBody   string
 	From       string
 	InReplyTo  string
 	References []string
+
+	// Attachments are existing blobs, e.g. copied from another email
+	Attachments []Attachment
 }
 
 // ForwardOptions contains options for forwarding an email.
@@ -29,8 +36,26 @@ type ForwardOptions struct {
 	Body    string
 }
 
+// ReplyOptions contains options for replying to an email.
+type ReplyOptions struct {
+	EmailID  string
+	Body     string
+	ReplyAll bool
+
+	// KeepAttachments re-attaches the original email's attachments
+	KeepAttachments bool
+
+	// KeepSignature quotes the original in full instead of cutting it at
+	// the "-- " signature delimiter
+	KeepSignature bool
+}
+
 // SaveDraft creates a new draft email.
 func (c *Client) SaveDraft(draft DraftEmail) (string, error) {
+	if err := validateAddresses(draft.To, draft.CC, draft.BCC); err != nil {
+		return "", err
+	}
+
 	session, err := c.GetSession()
 	if err != nil {
 		return "", err
@@ -65,6 +90,9 @@ func (c *Client) SaveDraft(draft DraftEmail) (string, error) {
 	if len(draft.BCC) > 0 {
 		emailObject["bcc"] = addressesToMap(draft.BCC)
 	}
+	if len(draft.ReplyTo) > 0 {
+		emailObject["replyTo"] = addressesToMap(draft.ReplyTo)
+	}
 
 	if draft.InReplyTo != "" {
 		emailObject["inReplyTo"] = []string{draft.InReplyTo}
@@ -73,6 +101,18 @@ func (c *Client) SaveDraft(draft DraftEmail) (string, error) {
 		emailObject["references"] = draft.References
 	}
 
+	if len(draft.Attachments) > 0 {
+		attachments := make([]map[string]interface{}, len(draft.Attachments))
+		for i, a := range draft.Attachments {
+			attachment := map[string]interface{}{"blobId": a.BlobID, "type": a.Type}
+			if a.Name != "" {
+				attachment["name"] = a.Name
+			}
+			attachments[i] = attachment
+		}
+		emailObject["attachments"] = attachments
+	}
+
 	// Set up body - prefer both HTML and text if available
 	if draft.HTMLBody != "" && draft.TextBody != "" {
 		// Both HTML and plain text (best compatibility)
@@ -137,8 +177,8 @@ func (c *Client) SaveDraft(draft DraftEmail) (string, error) {
 }
 
 // CreateReplyDraft creates a draft reply to an email.
-func (c *Client) CreateReplyDraft(emailID, body string, replyAll bool) (string, error) {
-	original, err := c.GetEmailByID(emailID)
+func (c *Client) CreateReplyDraft(opts ReplyOptions) (string, error) {
+	original, err := c.GetEmailByID(opts.EmailID)
 	if err != nil {
 		return "", err
 	}
@@ -164,7 +204,7 @@ func (c *Client) CreateReplyDraft(emailID, body string, replyAll bool) (string,
 
 	// For reply-all, include original To and CC
 	var cc []string
-	if replyAll {
+	if opts.ReplyAll {
 		allRecipients := append(original.To, original.CC...)
 		for _, addr := range allRecipients {
 			if addr.Email != myEmail && !contains(to, addr.Email) {
@@ -213,18 +253,22 @@ func (c *Client) CreateReplyDraft(emailID, body string, replyAll bool) (string,
 		}
 	}
 
+	if !opts.KeepSignature {
+		originalTextBody = stripSignature(originalTextBody)
+	}
+
 	// Build attribution line
 	fromStr := FormatAddresses(original.From)
 	dateStr := original.ReceivedAt.Format("Mon, Jan 2, 2006 at 3:04 PM")
 	attribution := fmt.Sprintf("On %s, %s wrote:", dateStr, fromStr)
 
 	// Build plain text reply with quoted original
-	textBody := body + "\n\n" + attribution + "\n" + quoteText(originalTextBody)
+	textBody := opts.Body + "\n\n" + attribution + "\n" + quoteText(originalTextBody)
 
 	// Build HTML reply with quoted original
-	htmlBody := formatReplyHTML(body, attribution, originalHTMLBody, originalTextBody)
+	htmlBody := formatReplyHTML(opts.Body, attribution, originalHTMLBody, originalTextBody)
 
-	return c.SaveDraft(DraftEmail{
+	draft := DraftEmail{
 		To:         to,
 		CC:         cc,
 		Subject:    subject,
@@ -232,7 +276,26 @@ func (c *Client) CreateReplyDraft(emailID, body string, replyAll bool) (string,
 		HTMLBody:   htmlBody,
 		InReplyTo:  inReplyTo,
 		References: references,
-	})
+	}
+	if opts.KeepAttachments {
+		draft.Attachments = original.Attachments
+	}
+
+	return c.SaveDraft(draft)
+}
+
+// stripSignature cuts text at the standard "-- " signature delimiter line,
+// dropping the signature below it. Text without one is returned unchanged.
+// HTML bodies have no such delimiter, so only plain text is stripped.
+func stripSignature(text string) string {
+	normalized := strings.ReplaceAll(text, "\r\n", "\n")
+	if strings.HasPrefix(normalized, "-- \n") {
+		return ""
+	}
+	if i := strings.Index(normalized, "\n-- \n"); i >= 0 {
+		return normalized[:i]
+	}
+	return text
 }
 
 // quoteText prefixes each line with "> " for plain text quoting.
@@ -335,6 +398,10 @@ func textToHTMLDivs(text string) string {
 
 // CreateForwardDraft creates a forward draft with the original message.
 func (c *Client) CreateForwardDraft(opts ForwardOptions) (string, error) {
+	if err := validateAddresses(opts.To, opts.CC); err != nil {
+		return "", err
+	}
+
 	original, err := c.GetEmailByID(opts.EmailID)
 	if err != nil {
 		return "", err
@@ -388,16 +455,109 @@ Date: %s
 	})
 }
 
+// CreateResendDraft creates a draft copying the recipients, subject, body, and
+// attachments of an already-sent email, ready to be sent again with SendEmail.
+func (c *Client) CreateResendDraft(original *Email) (string, error) {
+	if original.IsDraft() {
+		return "", fmt.Errorf("email %s is a draft; use 'fm draft send' instead", original.ID)
+	}
+
+	var from string
+	if len(original.From) > 0 {
+		from = original.From[0].Email
+	}
+
+	// htmlBody falls back to the text parts for plain-text emails, so only
+	// copy it when there is real HTML
+	var textBody, htmlBody string
+	for _, part := range original.TextBody {
+		if bv, ok := original.BodyValues[part.PartID]; ok {
+			textBody = bv.Value
+			break
+		}
+	}
+	for _, part := range original.HTMLBody {
+		if bv, ok := original.BodyValues[part.PartID]; ok && part.Type == "text/html" {
+			htmlBody = bv.Value
+			break
+		}
+	}
+
+	var inReplyTo string
+	if len(original.InReplyTo) > 0 {
+		inReplyTo = original.InReplyTo[0]
+	}
+
+	return c.SaveDraft(DraftEmail{
+		To:          formatAddresses(original.To),
+		CC:          formatAddresses(original.CC),
+		BCC:         formatAddresses(original.BCC),
+		From:        from,
+		Subject:     original.Subject,
+		TextBody:    textBody,
+		HTMLBody:    htmlBody,
+		InReplyTo:   inReplyTo,
+		References:  original.References,
+		Attachments: original.Attachments,
+	})
+}
+
 // DeleteDraft deletes a draft email.
 func (c *Client) DeleteDraft(draftID string) error {
 	return c.DeleteEmail(draftID)
 }
 
-// addressesToMap converts email strings to JMAP address format.
+// DeleteDrafts moves multiple drafts to trash in a single request, like
+// DeleteDraft does for one.
+func (c *Client) DeleteDrafts(draftIDs []string) (deleted int, failed []string, err error) {
+	return c.DeleteEmails(draftIDs)
+}
+
+// addressesToMap converts email strings to JMAP address format, keeping the
+// display name of addresses written as "Name <email>".
 func addressesToMap(addrs []string) []map[string]string {
 	result := make([]map[string]string, len(addrs))
 	for i, addr := range addrs {
-		result[i] = map[string]string{"email": addr}
+		parsed, err := mail.ParseAddress(addr)
+		if err != nil {
+			result[i] = map[string]string{"email": addr}
+			continue
+		}
+		result[i] = map[string]string{"email": parsed.Address}
+		if parsed.Name != "" {
+			result[i]["name"] = parsed.Name
+		}
+	}
+	return result
+}
+
+// validateAddresses checks that every recipient parses as an address, so a
+// typo like "bob@@example.com" fails before any request is made.
+func validateAddresses(lists ...[]string) error {
+	var invalid []string
+	for _, addrs := range lists {
+		for _, addr := range addrs {
+			if _, err := mail.ParseAddress(addr); err != nil {
+				invalid = append(invalid, fmt.Sprintf("%q", addr))
+			}
+		}
+	}
+	if len(invalid) > 0 {
+		return fmt.Errorf("invalid email addresses: %s", strings.Join(invalid, ", "))
+	}
+	return nil
+}
+
+// formatAddresses returns addrs as "Name <email>" strings, or bare addresses
+// when there is no name, so addressesToMap restores the display names.
+func formatAddresses(addrs []EmailAddress) []string {
+	var result []string
+	for _, addr := range addrs {
+		if addr.Name == "" {
+			result = append(result, addr.Email)
+			continue
+		}
+		result = append(result, (&mail.Address{Name: addr.Name, Address: addr.Email}).String())
 	}
 	return result
 }
@@ -4,7 +4,9 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/jarcoal/httpmock"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestQuoteText(t *testing.T) {
@@ -48,6 +50,46 @@ func TestQuoteText(t *testing.T) {
 	}
 }
 
+func TestStripSignature(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "no signature",
+			input:    "Hello\n\nSee you -- soon\n--\nAlice",
+			expected: "Hello\n\nSee you -- soon\n--\nAlice",
+		},
+		{
+			name:     "signature after the body",
+			input:    "Hello\n\nThanks\n-- \nAlice\nACME Corp",
+			expected: "Hello\n\nThanks",
+		},
+		{
+			name:     "CRLF line endings",
+			input:    "Hello\r\n-- \r\nAlice",
+			expected: "Hello",
+		},
+		{
+			name:     "only a signature",
+			input:    "-- \nAlice",
+			expected: "",
+		},
+		{
+			name:     "empty string",
+			input:    "",
+			expected: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, stripSignature(tt.input))
+		})
+	}
+}
+
 func TestFormatReplyHTML(t *testing.T) {
 	t.Run("with HTML original", func(t *testing.T) {
 		result := formatReplyHTML(
@@ -186,3 +228,59 @@ func TestCreateReplyDraftQuoting(t *testing.T) {
 		assert.Contains(t, textBody, "> Second line")
 	})
 }
+
+func TestValidateAddresses(t *testing.T) {
+	tests := []struct {
+		name    string
+		addrs   []string
+		wantErr string
+	}{
+		{
+			name:  "plain address",
+			addrs: []string{"bob@example.com"},
+		},
+		{
+			name:  "display name",
+			addrs: []string{`"Bob" <bob@x>`, "Alice Smith <alice@example.com>"},
+		},
+		{
+			name:    "doubled at sign",
+			addrs:   []string{"bob@@example"},
+			wantErr: `invalid email addresses: "bob@@example"`,
+		},
+		{
+			name:    "lists every invalid address",
+			addrs:   []string{"bob@example.com", "bob", "alice@example.com>"},
+			wantErr: `invalid email addresses: "bob", "alice@example.com>"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateAddresses(tt.addrs)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Equal(t, tt.wantErr, err.Error())
+		})
+	}
+}
+
+func TestSaveDraftInvalidAddress(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	client := newTestClient()
+
+	_, err := client.SaveDraft(DraftEmail{
+		To:  []string{"alice@example.com"},
+		CC:  []string{"bob@@example"},
+		BCC: []string{"carol"},
+	})
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `"bob@@example", "carol"`)
+	assert.Zero(t, httpmock.GetTotalCallCount(), "should fail before any request")
+}
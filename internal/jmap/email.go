@@ -1,10 +1,16 @@
 package jmap
 
 import (
+	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"net/http"
+	neturl "net/url"
+	"slices"
 	"strings"
+	"time"
 )
 
 // EmailQueryFilter defines filters for email queries.
@@ -30,59 +36,340 @@ type SearchFilters struct {
 	HasAttachment *bool
 	IsUnread      *bool
 	MailboxID     string
+	ThreadID      string
 	Before        string
 	After         string
 	Limit         int
+
+	// ExcludeMailboxIDs drops emails that are in any of these mailboxes.
+	ExcludeMailboxIDs []string
 }
 
 // Standard email properties for list views
 var emailListProperties = []string{
-	"id", "threadId", "subject", "from", "to", "receivedAt",
-	"preview", "hasAttachment", "keywords",
+	"id", "threadId", "mailboxIds", "subject", "from", "to", "receivedAt",
+	"preview", "hasAttachment", "keywords", "size", "messageId",
 }
 
 // Extended email properties for full view
 var emailFullProperties = []string{
 	"id", "threadId", "subject", "from", "to", "cc", "bcc", "replyTo",
 	"receivedAt", "textBody", "htmlBody", "attachments", "bodyValues",
-	"messageId", "inReplyTo", "references", "keywords",
+	"messageId", "inReplyTo", "references", "keywords", "size",
+}
+
+// RecentEmailsOptions controls which emails GetRecentEmails returns.
+type RecentEmailsOptions struct {
+	Limit       int
+	OldestFirst bool      // Sort oldest first instead of newest first
+	After       time.Time // Only emails received after this time, if set
+	AfterID     string    // Only emails received after this email, if set
+	Unread      bool      // Only emails without the $seen keyword
 }
 
-// GetRecentEmails fetches recent emails from a mailbox.
-func (c *Client) GetRecentEmails(mailboxID string, limit int) ([]Email, error) {
+// MaxRecentEmails is the most emails GetRecentEmails returns.
+const MaxRecentEmails = 500
+
+// NoLimit, given as a Limit to GetRecentEmails or Search, fetches every
+// match instead. More than MaxAllEmails matches are an error.
+const NoLimit = -1
+
+// GetRecentEmails fetches recent emails from a mailbox. A server may cap the
+// results of a single query (it reports the cap as "limit"), so when it does
+// the mailbox is paged through until opts.Limit emails have been fetched.
+//
+// With AfterID, the emails are the ones that follow it oldest first, up to
+// the limit, so emails received in the same second as it are neither
+// repeated nor skipped. They are still returned newest first unless
+// OldestFirst is set.
+func (c *Client) GetRecentEmails(mailboxID string, opts RecentEmailsOptions) ([]Email, error) {
 	session, err := c.GetSession()
 	if err != nil {
 		return nil, err
 	}
 
-	if limit <= 0 {
-		limit = 20
+	filter := map[string]interface{}{"inMailbox": mailboxID}
+	if !opts.After.IsZero() {
+		filter["after"] = UTCDate(opts.After)
 	}
-	if limit > 50 {
-		limit = 50
+	if opts.Unread {
+		filter["notKeyword"] = "$seen"
+	}
+
+	ascending := opts.OldestFirst || opts.AfterID != ""
+	sort := []map[string]interface{}{{"property": "receivedAt", "isAscending": ascending}}
+
+	var emails []Email
+	if opts.Limit == NoLimit {
+		emails, err = c.queryAllEmails(map[string]interface{}{
+			"accountId": session.AccountID,
+			"filter":    filter,
+			"sort":      sort,
+		}, nil)
+		if i := slices.IndexFunc(emails, func(e Email) bool { return e.ID == opts.AfterID }); i >= 0 {
+			emails = emails[i+1:]
+		}
+	} else {
+		limit := opts.Limit
+		if limit <= 0 {
+			limit = 20
+		}
+		if limit > MaxRecentEmails {
+			limit = MaxRecentEmails
+		}
+
+		emails, err = c.queryRecentEmails(session.AccountID, filter, sort, limit, opts.AfterID)
+
+		// The email no longer matches the filter (it was moved, or read with
+		// Unread), so only the After time can be applied
+		var methodErr *MethodError
+		if errors.As(err, &methodErr) && methodErr.Type == "anchorNotFound" {
+			emails, err = c.queryRecentEmails(session.AccountID, filter, sort, limit, "")
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if ascending && !opts.OldestFirst {
+		slices.Reverse(emails)
+	}
+	return emails, nil
+}
+
+// queryRecentEmails fetches up to limit emails matching filter, page by page
+// if the server caps its results. With anchorID, the emails start right
+// after that one instead of at the top.
+func (c *Client) queryRecentEmails(accountID string, filter map[string]interface{}, sort []map[string]interface{}, limit int, anchorID string) ([]Email, error) {
+	var emails []Email
+	for len(emails) < limit {
+		want := limit - len(emails)
+		query := map[string]interface{}{
+			"accountId": accountID,
+			"filter":    filter,
+			"sort":      sort,
+			"position":  len(emails),
+			"limit":     want,
+		}
+		if anchorID != "" {
+			delete(query, "position")
+			query["anchor"] = anchorID
+			query["anchorOffset"] = len(emails) + 1
+		}
+
+		request := &Request{
+			Using: []string{CoreCapability, MailCapability},
+			MethodCalls: [][]interface{}{
+				{
+					"Email/query",
+					query,
+					"query",
+				},
+				{
+					"Email/get",
+					map[string]interface{}{
+						"accountId":  accountID,
+						"#ids":       map[string]interface{}{"resultOf": "query", "name": "Email/query", "path": "/ids"},
+						"properties": emailListProperties,
+					},
+					"emails",
+				},
+			},
+		}
+
+		resp, err := c.MakeRequest(request)
+		if err != nil {
+			return nil, err
+		}
+
+		page, err := c.parseEmailsFromResponse(resp, 1)
+		if err != nil {
+			return nil, err
+		}
+		emails = append(emails, page...)
+
+		var result struct {
+			Limit *int `json:"limit"`
+		}
+		if err := json.Unmarshal(resp.MethodResponses[0][1], &result); err != nil {
+			return nil, fmt.Errorf("failed to parse query results: %w", err)
+		}
+
+		// A short page without a server cap means the mailbox has no more emails
+		capped := result.Limit != nil && *result.Limit < want && len(page) == *result.Limit
+		if len(page) == 0 || (len(page) < want && !capped) {
+			break
+		}
+	}
+
+	return emails, nil
+}
+
+// MaxAllEmails is the most emails GetAllEmails will fetch from one mailbox.
+const MaxAllEmails = 10000
+
+// allEmailsPageSize is how many emails queryAllEmails fetches per request.
+const allEmailsPageSize = 500
+
+// GetAllEmails fetches every email in a mailbox, newest first, paging through
+// it allEmailsPageSize emails at a time. If progress is not nil it is called
+// after each page with the number of emails fetched so far and the total.
+// Mailboxes holding more than MaxAllEmails are refused rather than truncated.
+func (c *Client) GetAllEmails(mailboxID string, progress func(fetched, total int)) ([]Email, error) {
+	session, err := c.GetSession()
+	if err != nil {
+		return nil, err
+	}
+
+	return c.queryAllEmails(map[string]interface{}{
+		"accountId": session.AccountID,
+		"filter":    map[string]interface{}{"inMailbox": mailboxID},
+		"sort":      []map[string]interface{}{{"property": "receivedAt", "isAscending": false}},
+	}, progress)
+}
+
+// queryAllEmails runs an Email/query with the given arguments (account,
+// filter, and sort) page by page until every match has been fetched. More
+// than MaxAllEmails matches are refused rather than truncated.
+func (c *Client) queryAllEmails(query map[string]interface{}, progress func(fetched, total int)) ([]Email, error) {
+	var emails []Email
+	for {
+		args := map[string]interface{}{
+			"position":       len(emails),
+			"limit":          allEmailsPageSize,
+			"calculateTotal": true,
+		}
+		for k, v := range query {
+			args[k] = v
+		}
+
+		request := &Request{
+			Using: []string{CoreCapability, MailCapability},
+			MethodCalls: [][]interface{}{
+				{
+					"Email/query",
+					args,
+					"query",
+				},
+				{
+					"Email/get",
+					map[string]interface{}{
+						"accountId":  query["accountId"],
+						"#ids":       map[string]interface{}{"resultOf": "query", "name": "Email/query", "path": "/ids"},
+						"properties": emailListProperties,
+					},
+					"emails",
+				},
+			},
+		}
+
+		resp, err := c.MakeRequest(request)
+		if err != nil {
+			return nil, err
+		}
+
+		var result struct {
+			Total int `json:"total"`
+		}
+		if err := json.Unmarshal(resp.MethodResponses[0][1], &result); err != nil {
+			return nil, fmt.Errorf("failed to parse query results: %w", err)
+		}
+		if result.Total > MaxAllEmails {
+			return nil, fmt.Errorf("%d emails match, more than the %d that can be fetched at once", result.Total, MaxAllEmails)
+		}
+
+		page, err := c.parseEmailsFromResponse(resp, 1)
+		if err != nil {
+			return nil, err
+		}
+		emails = append(emails, page...)
+
+		if progress != nil {
+			progress(len(emails), result.Total)
+		}
+
+		if len(page) == 0 || len(emails) >= result.Total {
+			return emails, nil
+		}
+	}
+}
+
+// GetEmailByID fetches a single email by ID.
+func (c *Client) GetEmailByID(emailID string) (*Email, error) {
+	session, err := c.GetSession()
+	if err != nil {
+		return nil, err
 	}
 
 	request := &Request{
 		Using: []string{CoreCapability, MailCapability},
 		MethodCalls: [][]interface{}{
 			{
-				"Email/query",
+				"Email/get",
 				map[string]interface{}{
-					"accountId": session.AccountID,
-					"filter":    map[string]interface{}{"inMailbox": mailboxID},
-					"sort":      []map[string]interface{}{{"property": "receivedAt", "isAscending": false}},
-					"limit":     limit,
+					"accountId":            session.AccountID,
+					"ids":                  []string{emailID},
+					"properties":           emailFullProperties,
+					"bodyProperties":       []string{"partId", "blobId", "type", "size", "name", "disposition"},
+					"fetchTextBodyValues":  true,
+					"fetchHTMLBodyValues":  true,
 				},
-				"query",
+				"email",
 			},
+		},
+	}
+
+	resp, err := c.MakeRequest(request)
+	if err != nil {
+		return nil, err
+	}
+
+	emails, err := c.parseEmailsFromResponse(resp, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(emails) == 0 {
+		return nil, fmt.Errorf("email with ID '%s' not found", emailID)
+	}
+
+	return &emails[0], nil
+}
+
+// GetEmailSummary fetches a single email's list properties (subject,
+// addresses, size, keywords, mailboxes) without any body content.
+func (c *Client) GetEmailSummary(emailID string) (*Email, error) {
+	emails, err := c.GetEmailSummaries([]string{emailID})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(emails) == 0 {
+		return nil, fmt.Errorf("email with ID '%s' not found", emailID)
+	}
+
+	return &emails[0], nil
+}
+
+// GetEmailSummaries fetches the list properties of several emails in one
+// request. IDs that don't exist are left out of the result.
+func (c *Client) GetEmailSummaries(emailIDs []string) ([]Email, error) {
+	session, err := c.GetSession()
+	if err != nil {
+		return nil, err
+	}
+
+	request := &Request{
+		Using: []string{CoreCapability, MailCapability},
+		MethodCalls: [][]interface{}{
 			{
 				"Email/get",
 				map[string]interface{}{
 					"accountId":  session.AccountID,
-					"#ids":       map[string]interface{}{"resultOf": "query", "name": "Email/query", "path": "/ids"},
+					"ids":        emailIDs,
 					"properties": emailListProperties,
 				},
-				"emails",
+				"email",
 			},
 		},
 	}
@@ -92,11 +379,66 @@ func (c *Client) GetRecentEmails(mailboxID string, limit int) ([]Email, error) {
 		return nil, err
 	}
 
-	return c.parseEmailsFromResponse(resp, 1)
+	return c.parseEmailsFromResponse(resp, 0)
 }
 
-// GetEmailByID fetches a single email by ID.
-func (c *Client) GetEmailByID(emailID string) (*Email, error) {
+// GetEmailHeader fetches every instance of a header, decoded as text, in
+// message order. It returns an empty slice if the email has no such header.
+func (c *Client) GetEmailHeader(emailID, name string) ([]string, error) {
+	property := "header:" + name + ":asText:all"
+
+	result, err := c.getEmailProperties(emailID, []string{property})
+	if err != nil {
+		return nil, err
+	}
+
+	var values []string
+	if raw, ok := result[property]; ok {
+		if err := json.Unmarshal(raw, &values); err != nil {
+			return nil, fmt.Errorf("failed to parse header: %w", err)
+		}
+	}
+	return values, nil
+}
+
+// GetEmailBlobID fetches the ID of the blob holding an email's raw RFC 5322
+// message.
+func (c *Client) GetEmailBlobID(emailID string) (string, error) {
+	result, err := c.getEmailProperties(emailID, []string{"blobId"})
+	if err != nil {
+		return "", err
+	}
+
+	var blobID string
+	if raw, ok := result["blobId"]; ok {
+		if err := json.Unmarshal(raw, &blobID); err != nil {
+			return "", fmt.Errorf("failed to parse blob ID: %w", err)
+		}
+	}
+	if blobID == "" {
+		return "", fmt.Errorf("email with ID '%s' has no message blob", emailID)
+	}
+	return blobID, nil
+}
+
+// GetEmailHeaders fetches all raw header fields of an email in message order.
+func (c *Client) GetEmailHeaders(emailID string) ([]EmailHeader, error) {
+	result, err := c.getEmailProperties(emailID, []string{"headers"})
+	if err != nil {
+		return nil, err
+	}
+
+	var headers []EmailHeader
+	if raw, ok := result["headers"]; ok {
+		if err := json.Unmarshal(raw, &headers); err != nil {
+			return nil, fmt.Errorf("failed to parse headers: %w", err)
+		}
+	}
+	return headers, nil
+}
+
+// getEmailProperties fetches the given properties of a single email.
+func (c *Client) getEmailProperties(emailID string, properties []string) (map[string]json.RawMessage, error) {
 	session, err := c.GetSession()
 	if err != nil {
 		return nil, err
@@ -108,12 +450,9 @@ func (c *Client) GetEmailByID(emailID string) (*Email, error) {
 			{
 				"Email/get",
 				map[string]interface{}{
-					"accountId":            session.AccountID,
-					"ids":                  []string{emailID},
-					"properties":           emailFullProperties,
-					"bodyProperties":       []string{"partId", "blobId", "type", "size"},
-					"fetchTextBodyValues":  true,
-					"fetchHTMLBodyValues":  true,
+					"accountId":  session.AccountID,
+					"ids":        []string{emailID},
+					"properties": properties,
 				},
 				"email",
 			},
@@ -125,30 +464,54 @@ func (c *Client) GetEmailByID(emailID string) (*Email, error) {
 		return nil, err
 	}
 
-	emails, err := c.parseEmailsFromResponse(resp, 0)
-	if err != nil {
-		return nil, err
+	if len(resp.MethodResponses) == 0 {
+		return nil, fmt.Errorf("invalid response: missing method response")
 	}
 
-	if len(emails) == 0 {
+	var result struct {
+		List []map[string]json.RawMessage `json:"list"`
+	}
+	if err := json.Unmarshal(resp.MethodResponses[0][1], &result); err != nil {
+		return nil, fmt.Errorf("failed to parse email: %w", err)
+	}
+
+	if len(result.List) == 0 {
 		return nil, fmt.Errorf("email with ID '%s' not found", emailID)
 	}
 
-	return &emails[0], nil
+	return result.List[0], nil
 }
 
-// GetThread fetches all emails in a thread.
+// GetThread fetches all emails in a thread, including their bodies.
 func (c *Client) GetThread(emailOrThreadID string) ([]Email, error) {
+	return c.getThread(emailOrThreadID, true)
+}
+
+// GetThreadSummaries fetches all emails in a thread with only their list
+// properties, leaving out bodies, attachments, and cc/bcc.
+func (c *Client) GetThreadSummaries(emailOrThreadID string) ([]Email, error) {
+	return c.getThread(emailOrThreadID, false)
+}
+
+func (c *Client) getThread(emailOrThreadID string, withBodies bool) ([]Email, error) {
 	session, err := c.GetSession()
 	if err != nil {
 		return nil, err
 	}
 
-	// First, try to get the threadId from the email
-	threadID := emailOrThreadID
-	email, err := c.GetEmailByID(emailOrThreadID)
-	if err == nil && email.ThreadID != "" {
-		threadID = email.ThreadID
+	threadID, err := c.ResolveThreadID(emailOrThreadID)
+	if err != nil {
+		return nil, err
+	}
+
+	getArgs := map[string]interface{}{
+		"accountId":  session.AccountID,
+		"#ids":       map[string]interface{}{"resultOf": "getThread", "name": "Thread/get", "path": "/list/*/emailIds"},
+		"properties": emailListProperties,
+	}
+	if withBodies {
+		getArgs["properties"] = emailFullProperties
+		getArgs["fetchTextBodyValues"] = true
 	}
 
 	request := &Request{
@@ -164,12 +527,7 @@ func (c *Client) GetThread(emailOrThreadID string) ([]Email, error) {
 			},
 			{
 				"Email/get",
-				map[string]interface{}{
-					"accountId":           session.AccountID,
-					"#ids":                map[string]interface{}{"resultOf": "getThread", "name": "Thread/get", "path": "/list/*/emailIds"},
-					"properties":          emailFullProperties,
-					"fetchTextBodyValues": true,
-				},
+				getArgs,
 				"emails",
 			},
 		},
@@ -183,6 +541,63 @@ func (c *Client) GetThread(emailOrThreadID string) ([]Email, error) {
 	return c.parseEmailsFromResponse(resp, 1)
 }
 
+// ResolveThreadID returns the thread of the email with the given ID, or the
+// ID itself if no such email exists, so it can be given either.
+func (c *Client) ResolveThreadID(emailOrThreadID string) (string, error) {
+	emails, err := c.GetEmailSummaries([]string{emailOrThreadID})
+	if err != nil {
+		return "", err
+	}
+	if len(emails) == 0 || emails[0].ThreadID == "" {
+		return emailOrThreadID, nil
+	}
+	return emails[0].ThreadID, nil
+}
+
+// GetThreadEmailIDs returns the IDs of the emails in a thread, oldest first.
+func (c *Client) GetThreadEmailIDs(threadID string) ([]string, error) {
+	session, err := c.GetSession()
+	if err != nil {
+		return nil, err
+	}
+
+	request := &Request{
+		Using: []string{CoreCapability, MailCapability},
+		MethodCalls: [][]interface{}{
+			{
+				"Thread/get",
+				map[string]interface{}{
+					"accountId": session.AccountID,
+					"ids":       []string{threadID},
+				},
+				"getThread",
+			},
+		},
+	}
+
+	resp, err := c.MakeRequest(request)
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		List []struct {
+			ID       string   `json:"id"`
+			EmailIDs []string `json:"emailIds"`
+		} `json:"list"`
+	}
+
+	if err := json.Unmarshal(resp.MethodResponses[0][1], &result); err != nil {
+		return nil, fmt.Errorf("failed to parse thread: %w", err)
+	}
+
+	if len(result.List) == 0 {
+		return nil, fmt.Errorf("thread not found: %s", threadID)
+	}
+
+	return result.List[0].EmailIDs, nil
+}
+
 // Search searches for emails matching the given filters.
 func (c *Client) Search(filters SearchFilters) ([]Email, error) {
 	session, err := c.GetSession()
@@ -190,9 +605,25 @@ func (c *Client) Search(filters SearchFilters) ([]Email, error) {
 		return nil, err
 	}
 
-	filter := c.buildSearchFilter(filters)
+	var emails []Email
+	err = c.withThreadFallback(filters, func(filter map[string]interface{}) error {
+		emails, err = c.searchFilter(session.AccountID, filter, filters.Limit)
+		return err
+	})
+	return emails, err
+}
+
+// searchFilter runs an Email/query for filter, newest first, and fetches the
+// matching emails.
+func (c *Client) searchFilter(accountID string, filter map[string]interface{}, limit int) ([]Email, error) {
+	if limit == NoLimit {
+		return c.queryAllEmails(map[string]interface{}{
+			"accountId": accountID,
+			"filter":    filter,
+			"sort":      []map[string]interface{}{{"property": "receivedAt", "isAscending": false}},
+		}, nil)
+	}
 
-	limit := filters.Limit
 	if limit <= 0 {
 		limit = 50
 	}
@@ -206,7 +637,7 @@ func (c *Client) Search(filters SearchFilters) ([]Email, error) {
 			{
 				"Email/query",
 				map[string]interface{}{
-					"accountId": session.AccountID,
+					"accountId": accountID,
 					"filter":    filter,
 					"sort":      []map[string]interface{}{{"property": "receivedAt", "isAscending": false}},
 					"limit":     limit,
@@ -216,7 +647,7 @@ func (c *Client) Search(filters SearchFilters) ([]Email, error) {
 			{
 				"Email/get",
 				map[string]interface{}{
-					"accountId":  session.AccountID,
+					"accountId":  accountID,
 					"#ids":       map[string]interface{}{"resultOf": "query", "name": "Email/query", "path": "/ids"},
 					"properties": emailListProperties,
 				},
@@ -233,6 +664,100 @@ func (c *Client) Search(filters SearchFilters) ([]Email, error) {
 	return c.parseEmailsFromResponse(resp, 1)
 }
 
+// QueryEmailIDs returns the IDs of emails matching filters, newest first,
+// along with the total number of matches (which may exceed the limit).
+func (c *Client) QueryEmailIDs(filters SearchFilters) (ids []string, total int, err error) {
+	session, err := c.GetSession()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	limit := filters.Limit
+	if limit <= 0 {
+		limit = 50
+	}
+	if limit > 500 {
+		limit = 500
+	}
+
+	var resp *Response
+	err = c.withThreadFallback(filters, func(filter map[string]interface{}) error {
+		request := &Request{
+			Using: []string{CoreCapability, MailCapability},
+			MethodCalls: [][]interface{}{
+				{
+					"Email/query",
+					map[string]interface{}{
+						"accountId":      session.AccountID,
+						"filter":         filter,
+						"sort":           []map[string]interface{}{{"property": "receivedAt", "isAscending": false}},
+						"limit":          limit,
+						"calculateTotal": true,
+					},
+					"query",
+				},
+			},
+		}
+		resp, err = c.MakeRequest(request)
+		return err
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var result struct {
+		IDs   []string `json:"ids"`
+		Total int      `json:"total"`
+	}
+	if err := json.Unmarshal(resp.MethodResponses[0][1], &result); err != nil {
+		return nil, 0, fmt.Errorf("failed to parse query results: %w", err)
+	}
+
+	return result.IDs, result.Total, nil
+}
+
+// CountEmails returns the number of emails matching filters without fetching
+// any of them.
+func (c *Client) CountEmails(filters SearchFilters) (int, error) {
+	session, err := c.GetSession()
+	if err != nil {
+		return 0, err
+	}
+
+	var resp *Response
+	err = c.withThreadFallback(filters, func(filter map[string]interface{}) error {
+		request := &Request{
+			Using: []string{CoreCapability, MailCapability},
+			MethodCalls: [][]interface{}{
+				{
+					"Email/query",
+					map[string]interface{}{
+						"accountId":      session.AccountID,
+						"filter":         filter,
+						"limit":          0,
+						"calculateTotal": true,
+					},
+					"query",
+				},
+			},
+		}
+		resp, err = c.MakeRequest(request)
+		return err
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	var result struct {
+		Total int `json:"total"`
+	}
+	if err := json.Unmarshal(resp.MethodResponses[0][1], &result); err != nil {
+		return 0, fmt.Errorf("failed to parse query results: %w", err)
+	}
+
+	return result.Total, nil
+}
+
 // MoveEmail moves an email to a different mailbox.
 func (c *Client) MoveEmail(emailID, mailboxID string) error {
 	session, err := c.GetSession()
@@ -266,6 +791,83 @@ func (c *Client) MoveEmail(emailID, mailboxID string) error {
 	return c.checkSetError(resp, 0, emailID)
 }
 
+// SnoozeEmail moves an email into the snoozed mailbox and sets the time it
+// wakes up, at which point the server moves it back to the Inbox.
+func (c *Client) SnoozeEmail(emailID string, until time.Time) error {
+	session, err := c.requireCapability(SnoozeCapability)
+	if err != nil {
+		return err
+	}
+
+	snoozed, err := c.GetMailboxByRole("snoozed")
+	if err != nil {
+		return fmt.Errorf("could not find Snoozed folder: %w", err)
+	}
+
+	request := &Request{
+		Using: []string{CoreCapability, MailCapability, SnoozeCapability},
+		MethodCalls: [][]interface{}{
+			{
+				"Email/set",
+				map[string]interface{}{
+					"accountId": session.AccountID,
+					"update": map[string]interface{}{
+						emailID: map[string]interface{}{
+							"mailboxIds": map[string]bool{snoozed.ID: true},
+							"snoozed":    map[string]interface{}{"until": UTCDate(until)},
+						},
+					},
+				},
+				"snoozeEmail",
+			},
+		},
+	}
+
+	resp, err := c.MakeRequest(request)
+	if err != nil {
+		return err
+	}
+
+	return c.checkSetError(resp, 0, emailID)
+}
+
+// CopyEmail adds an email to mailboxes while keeping it in its current ones.
+// JMAP mailboxes behave like labels, so this is a copy without duplication.
+func (c *Client) CopyEmail(emailID string, mailboxIDs []string) error {
+	session, err := c.GetSession()
+	if err != nil {
+		return err
+	}
+
+	patch := make(map[string]interface{})
+	for _, id := range mailboxIDs {
+		patch["mailboxIds/"+id] = true
+	}
+
+	request := &Request{
+		Using: []string{CoreCapability, MailCapability},
+		MethodCalls: [][]interface{}{
+			{
+				"Email/set",
+				map[string]interface{}{
+					"accountId": session.AccountID,
+					"update": map[string]interface{}{
+						emailID: patch,
+					},
+				},
+				"copyEmail",
+			},
+		},
+	}
+
+	resp, err := c.MakeRequest(request)
+	if err != nil {
+		return err
+	}
+
+	return c.checkSetError(resp, 0, emailID)
+}
+
 // ArchiveEmail moves an email to the archive mailbox.
 func (c *Client) ArchiveEmail(emailID string) error {
 	archive, err := c.GetMailboxByRole("archive")
@@ -276,8 +878,9 @@ func (c *Client) ArchiveEmail(emailID string) error {
 	return c.MoveEmail(emailID, archive.ID)
 }
 
-// ArchiveEmails archives multiple emails.
-func (c *Client) ArchiveEmails(emailIDs []string) (archived int, failed []string, err error) {
+// ArchiveEmails archives multiple emails. With markRead they are also marked
+// read in the same Email/set update.
+func (c *Client) ArchiveEmails(emailIDs []string, markRead bool) (archived int, failed []string, err error) {
 	if len(emailIDs) == 0 {
 		return 0, nil, nil
 	}
@@ -287,6 +890,50 @@ func (c *Client) ArchiveEmails(emailIDs []string) (archived int, failed []string
 		return 0, emailIDs, fmt.Errorf("could not find Archive mailbox: %w", err)
 	}
 
+	if markRead {
+		return c.MoveEmailsAndMarkRead(emailIDs, archive.ID)
+	}
+	return c.MoveEmails(emailIDs, archive.ID)
+}
+
+// MoveEmails moves multiple emails to a mailbox in a single request.
+func (c *Client) MoveEmails(emailIDs []string, mailboxID string) (moved int, failed []string, err error) {
+	patch := map[string]interface{}{
+		"mailboxIds": map[string]bool{mailboxID: true},
+	}
+	return c.updateEmails(emailIDs, patch, "bulkMove")
+}
+
+// MoveEmailsAndMarkRead moves multiple emails to a mailbox and marks them
+// read in the same Email/set update.
+func (c *Client) MoveEmailsAndMarkRead(emailIDs []string, mailboxID string) (moved int, failed []string, err error) {
+	patch := map[string]interface{}{
+		"mailboxIds":     map[string]bool{mailboxID: true},
+		"keywords/$seen": true,
+	}
+	return c.updateEmails(emailIDs, patch, "bulkMove")
+}
+
+// SetKeyword adds or removes a keyword such as $seen or $flagged on multiple
+// emails in a single request, leaving their other keywords untouched.
+func (c *Client) SetKeyword(emailIDs []string, keyword string, set bool) (updated int, failed []string, err error) {
+	var value interface{}
+	if set {
+		value = true
+	}
+	patch := map[string]interface{}{
+		"keywords/" + keyword: value,
+	}
+	return c.updateEmails(emailIDs, patch, "setKeyword")
+}
+
+// updateEmails applies the same Email/set patch to multiple emails in a
+// single request.
+func (c *Client) updateEmails(emailIDs []string, patch map[string]interface{}, callID string) (updated int, failed []string, err error) {
+	if len(emailIDs) == 0 {
+		return 0, nil, nil
+	}
+
 	session, err := c.GetSession()
 	if err != nil {
 		return 0, emailIDs, err
@@ -294,9 +941,7 @@ func (c *Client) ArchiveEmails(emailIDs []string) (archived int, failed []string
 
 	update := make(map[string]interface{})
 	for _, id := range emailIDs {
-		update[id] = map[string]interface{}{
-			"mailboxIds": map[string]bool{archive.ID: true},
-		}
+		update[id] = patch
 	}
 
 	request := &Request{
@@ -308,7 +953,7 @@ func (c *Client) ArchiveEmails(emailIDs []string) (archived int, failed []string
 					"accountId": session.AccountID,
 					"update":    update,
 				},
-				"bulkArchive",
+				callID,
 			},
 		},
 	}
@@ -331,9 +976,9 @@ func (c *Client) ArchiveEmails(emailIDs []string) (archived int, failed []string
 	for id := range result.NotUpdated {
 		failed = append(failed, id)
 	}
-	archived = len(emailIDs) - len(failed)
+	updated = len(emailIDs) - len(failed)
 
-	return archived, failed, nil
+	return updated, failed, nil
 }
 
 // DeleteEmail moves an email to trash.
@@ -346,6 +991,20 @@ func (c *Client) DeleteEmail(emailID string) error {
 	return c.MoveEmail(emailID, trash.ID)
 }
 
+// DeleteEmails moves multiple emails to trash.
+func (c *Client) DeleteEmails(emailIDs []string) (deleted int, failed []string, err error) {
+	if len(emailIDs) == 0 {
+		return 0, nil, nil
+	}
+
+	trash, err := c.GetMailboxByRole("trash")
+	if err != nil {
+		return 0, emailIDs, fmt.Errorf("could not find Trash mailbox: %w", err)
+	}
+
+	return c.MoveEmails(emailIDs, trash.ID)
+}
+
 // MarkRead marks an email as read or unread.
 func (c *Client) MarkRead(emailID string, read bool) error {
 	session, err := c.GetSession()
@@ -386,6 +1045,18 @@ func (c *Client) MarkRead(emailID string, read bool) error {
 
 // DownloadBlob downloads an attachment blob.
 func (c *Client) DownloadBlob(blobID, name, contentType string) ([]byte, error) {
+	body, err := c.OpenBlob(blobID, name, contentType)
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	return io.ReadAll(body)
+}
+
+// OpenBlob starts downloading a blob and returns the response body, so large
+// blobs can be streamed instead of held in memory. The caller must close it.
+func (c *Client) OpenBlob(blobID, name, contentType string) (io.ReadCloser, error) {
 	session, err := c.GetSession()
 	if err != nil {
 		return nil, err
@@ -399,21 +1070,71 @@ func (c *Client) DownloadBlob(blobID, name, contentType string) ([]byte, error)
 	url := session.DownloadURL
 	url = strings.ReplaceAll(url, "{accountId}", session.AccountID)
 	url = strings.ReplaceAll(url, "{blobId}", blobID)
-	url = strings.ReplaceAll(url, "{name}", name)
-	url = strings.ReplaceAll(url, "{type}", contentType)
+	url = strings.ReplaceAll(url, "{name}", neturl.PathEscape(name))
+	url = strings.ReplaceAll(url, "{type}", neturl.QueryEscape(contentType))
 
-	resp, err := c.httpClient.Get(url)
+	req, err := http.NewRequest("GET", url, nil)
 	if err != nil {
 		return nil, err
 	}
-	defer resp.Body.Close()
 
-	data, err := io.ReadAll(resp.Body)
+	c.setAuthHeaders(req)
+
+	resp, err := c.do(req)
 	if err != nil {
 		return nil, err
 	}
 
-	return data, nil
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, fmt.Errorf("failed to download %s: %s", name, resp.Status)
+	}
+
+	return resp.Body, nil
+}
+
+// UploadBlob uploads data and returns the new blob's ID.
+func (c *Client) UploadBlob(data []byte, contentType string) (string, error) {
+	session, err := c.GetSession()
+	if err != nil {
+		return "", err
+	}
+
+	if session.UploadURL == "" {
+		return "", fmt.Errorf("upload URL not available")
+	}
+
+	url := strings.ReplaceAll(session.UploadURL, "{accountId}", session.AccountID)
+
+	req, err := http.NewRequest("POST", url, bytes.NewReader(data))
+	if err != nil {
+		return "", err
+	}
+
+	c.setAuthHeaders(req)
+	req.Header.Set("Content-Type", contentType)
+
+	resp, err := c.do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
+		return "", fmt.Errorf("failed to upload blob: %s", resp.Status)
+	}
+
+	var result struct {
+		BlobID string `json:"blobId"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return "", fmt.Errorf("failed to parse upload response: %w", err)
+	}
+	if result.BlobID == "" {
+		return "", fmt.Errorf("failed to upload blob: no blob ID returned")
+	}
+
+	return result.BlobID, nil
 }
 
 // parseEmailsFromResponse extracts emails from a JMAP response.
@@ -434,9 +1155,10 @@ func (c *Client) parseEmailsFromResponse(resp *Response, index int) ([]Email, er
 	return result.List, nil
 }
 
-// buildSearchFilter constructs a JMAP filter from SearchFilters.
-// It supports boolean operators (AND, OR, NOT) in the Query field.
-func (c *Client) buildSearchFilter(filters SearchFilters) map[string]interface{} {
+// searchFilterTree combines the query and structured fields of SearchFilters
+// into one filter, or nil if there are none. It supports boolean operators
+// (AND, OR, NOT) in the Query field.
+func searchFilterTree(filters SearchFilters) Filter {
 	// Parse the query for boolean operators
 	var queryFilter Filter
 	if filters.Query != "" {
@@ -468,6 +1190,12 @@ func (c *Client) buildSearchFilter(filters SearchFilters) map[string]interface{}
 	if filters.MailboxID != "" {
 		additionalFilters = append(additionalFilters, &TextFilter{Field: "inMailbox", Value: filters.MailboxID})
 	}
+	if len(filters.ExcludeMailboxIDs) > 0 {
+		additionalFilters = append(additionalFilters, &MailboxExclusionFilter{IDs: filters.ExcludeMailboxIDs})
+	}
+	if filters.ThreadID != "" {
+		additionalFilters = append(additionalFilters, &TextFilter{Field: "threadId", Value: filters.ThreadID})
+	}
 	if filters.Before != "" {
 		additionalFilters = append(additionalFilters, &TextFilter{Field: "before", Value: filters.Before})
 	}
@@ -484,17 +1212,145 @@ func (c *Client) buildSearchFilter(filters SearchFilters) map[string]interface{}
 
 	// No filters
 	if len(allFilters) == 0 {
-		return map[string]interface{}{}
+		return nil
 	}
 
 	// Single filter
 	if len(allFilters) == 1 {
-		return allFilters[0].ToJMAP()
+		return allFilters[0]
 	}
 
 	// Multiple filters - combine with AND
-	combined := &BoolFilter{Operator: "AND", Conditions: allFilters}
-	return combined.ToJMAP()
+	return &BoolFilter{Operator: "AND", Conditions: allFilters}
+}
+
+// withThreadFallback calls query with the JMAP filter for filters. Filtering
+// by threadId is an extension RFC 8621 doesn't define, so if the server
+// rejects the filter as unsupportedFilter, query is called again with each
+// thread condition expanded into the Message-IDs of the thread's emails.
+func (c *Client) withThreadFallback(filters SearchFilters, query func(filter map[string]interface{}) error) error {
+	tree := searchFilterTree(filters)
+	if tree == nil {
+		return query(map[string]interface{}{})
+	}
+
+	err := query(tree.ToJMAP())
+	var methodErr *MethodError
+	if !errors.As(err, &methodErr) || methodErr.Type != "unsupportedFilter" || !hasThreadFilter(tree) {
+		return err
+	}
+
+	expanded, err := c.expandThreadFilters(tree)
+	if err != nil {
+		return err
+	}
+	return query(expanded.ToJMAP())
+}
+
+// hasThreadFilter reports whether filter has a threadId condition anywhere.
+func hasThreadFilter(filter Filter) bool {
+	switch f := filter.(type) {
+	case *TextFilter:
+		return f.Field == "threadId"
+	case *BoolFilter:
+		for _, c := range f.Conditions {
+			if hasThreadFilter(c) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// expandThreadFilters returns a copy of filter with every threadId condition
+// replaced by Message-ID header conditions matching the thread's emails.
+func (c *Client) expandThreadFilters(filter Filter) (Filter, error) {
+	switch f := filter.(type) {
+	case *TextFilter:
+		if f.Field != "threadId" {
+			return f, nil
+		}
+		messageIDs, err := c.threadMessageIDs(f.Value)
+		if err != nil {
+			return nil, err
+		}
+		conditions := make([]Filter, len(messageIDs))
+		for i, id := range messageIDs {
+			conditions[i] = &HeaderFilter{Name: "Message-ID", Value: "<" + id + ">"}
+		}
+		return &BoolFilter{Operator: "OR", Conditions: conditions}, nil
+	case *BoolFilter:
+		conditions := make([]Filter, len(f.Conditions))
+		for i, cond := range f.Conditions {
+			expanded, err := c.expandThreadFilters(cond)
+			if err != nil {
+				return nil, err
+			}
+			conditions[i] = expanded
+		}
+		return &BoolFilter{Operator: f.Operator, Conditions: conditions}, nil
+	}
+	return filter, nil
+}
+
+// threadMessageIDs returns the Message-IDs of the emails in a thread. Emails
+// without one are left out.
+func (c *Client) threadMessageIDs(threadID string) ([]string, error) {
+	session, err := c.GetSession()
+	if err != nil {
+		return nil, err
+	}
+
+	request := &Request{
+		Using: []string{CoreCapability, MailCapability},
+		MethodCalls: [][]interface{}{
+			{
+				"Thread/get",
+				map[string]interface{}{
+					"accountId": session.AccountID,
+					"ids":       []string{threadID},
+				},
+				"getThread",
+			},
+			{
+				"Email/get",
+				map[string]interface{}{
+					"accountId":  session.AccountID,
+					"#ids":       map[string]interface{}{"resultOf": "getThread", "name": "Thread/get", "path": "/list/*/emailIds"},
+					"properties": []string{"id", "messageId"},
+				},
+				"emails",
+			},
+		},
+	}
+
+	resp, err := c.MakeRequest(request)
+	if err != nil {
+		return nil, err
+	}
+
+	var thread struct {
+		List []struct {
+			ID string `json:"id"`
+		} `json:"list"`
+	}
+	if err := json.Unmarshal(resp.MethodResponses[0][1], &thread); err != nil {
+		return nil, fmt.Errorf("failed to parse thread: %w", err)
+	}
+	if len(thread.List) == 0 {
+		return nil, fmt.Errorf("thread not found: %s", threadID)
+	}
+
+	emails, err := c.parseEmailsFromResponse(resp, 1)
+	if err != nil {
+		return nil, err
+	}
+
+	var ids []string
+	for _, email := range emails {
+		ids = append(ids, email.MessageID...)
+	}
+	return ids, nil
 }
 
 // checkSetError checks for errors in an Email/set response.
@@ -0,0 +1,443 @@
+package jmap
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func registerTestSession() {
+	httpmock.RegisterResponder("GET", "https://api.test.com/jmap/session",
+		httpmock.NewJsonResponderOrPanic(200, map[string]interface{}{
+			"apiUrl":   "https://api.test.com/jmap/api",
+			"accounts": map[string]interface{}{"acc-1": map[string]interface{}{}},
+		}))
+}
+
+// mockMailboxPages serves a mailbox of total emails, returning at most the
+// requested limit from the requested position, and records each position.
+func mockMailboxPages(total int, positions *[]int) httpmock.Responder {
+	return func(req *http.Request) (*http.Response, error) {
+		var jmapReq Request
+		json.NewDecoder(req.Body).Decode(&jmapReq)
+
+		args := jmapReq.MethodCalls[0][1].(map[string]interface{})
+		position := int(args["position"].(float64))
+		limit := int(args["limit"].(float64))
+		*positions = append(*positions, position)
+
+		var ids []string
+		var list []map[string]interface{}
+		for i := position; i < total && i < position+limit; i++ {
+			id := fmt.Sprintf("email-%d", i)
+			ids = append(ids, id)
+			list = append(list, map[string]interface{}{"id": id})
+		}
+
+		return httpmock.NewJsonResponse(200, map[string]interface{}{
+			"methodResponses": [][]interface{}{
+				{"Email/query", map[string]interface{}{"ids": ids, "position": position, "total": total}, "query"},
+				{"Email/get", map[string]interface{}{"list": list}, "emails"},
+			},
+		})
+	}
+}
+
+func TestClient_GetAllEmails(t *testing.T) {
+	t.Run("pages through the mailbox", func(t *testing.T) {
+		httpmock.Activate()
+		defer httpmock.DeactivateAndReset()
+		registerTestSession()
+
+		var positions []int
+		httpmock.RegisterResponder("POST", "https://api.test.com/jmap/api", mockMailboxPages(700, &positions))
+
+		var progress [][2]int
+		emails, err := newTestClient().GetAllEmails("inbox-1", func(fetched, total int) {
+			progress = append(progress, [2]int{fetched, total})
+		})
+
+		require.NoError(t, err)
+		require.Len(t, emails, 700)
+		assert.Equal(t, "email-0", emails[0].ID)
+		assert.Equal(t, "email-500", emails[500].ID)
+		assert.Equal(t, "email-699", emails[699].ID)
+		assert.Equal(t, []int{0, 500}, positions)
+		assert.Equal(t, [][2]int{{500, 700}, {700, 700}}, progress)
+	})
+
+	t.Run("returns an empty mailbox in one request", func(t *testing.T) {
+		httpmock.Activate()
+		defer httpmock.DeactivateAndReset()
+		registerTestSession()
+
+		var positions []int
+		httpmock.RegisterResponder("POST", "https://api.test.com/jmap/api", mockMailboxPages(0, &positions))
+
+		emails, err := newTestClient().GetAllEmails("inbox-1", nil)
+
+		require.NoError(t, err)
+		assert.Empty(t, emails)
+		assert.Equal(t, []int{0}, positions)
+	})
+
+	t.Run("refuses mailboxes over the cap", func(t *testing.T) {
+		httpmock.Activate()
+		defer httpmock.DeactivateAndReset()
+		registerTestSession()
+
+		var positions []int
+		httpmock.RegisterResponder("POST", "https://api.test.com/jmap/api", mockMailboxPages(MaxAllEmails+1, &positions))
+
+		_, err := newTestClient().GetAllEmails("inbox-1", nil)
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "more than the 10000")
+		assert.Equal(t, []int{0}, positions)
+	})
+}
+
+func TestClient_SearchNoLimit(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+	registerTestSession()
+
+	var positions []int
+	httpmock.RegisterResponder("POST", "https://api.test.com/jmap/api", mockMailboxPages(700, &positions))
+
+	emails, err := newTestClient().Search(SearchFilters{Query: "invoice", Limit: NoLimit})
+
+	require.NoError(t, err)
+	assert.Len(t, emails, 700)
+	assert.Equal(t, []int{0, 500}, positions)
+}
+
+func TestClient_GetRecentEmailsNoLimit(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+	registerTestSession()
+
+	var positions []int
+	httpmock.RegisterResponder("POST", "https://api.test.com/jmap/api", mockMailboxPages(620, &positions))
+
+	emails, err := newTestClient().GetRecentEmails("inbox-1", RecentEmailsOptions{Limit: NoLimit})
+
+	require.NoError(t, err)
+	assert.Len(t, emails, 620)
+	assert.Equal(t, []int{0, 500}, positions)
+}
+
+func TestClient_GetRecentEmailsAfterID(t *testing.T) {
+	// mockAnchored serves a mailbox of total emails, oldest first, honoring
+	// anchor and anchorOffset, returning at most pageCap per query, and
+	// records each query's arguments.
+	mockAnchored := func(total, pageCap int, queries *[]map[string]interface{}) httpmock.Responder {
+		return func(req *http.Request) (*http.Response, error) {
+			var jmapReq Request
+			json.NewDecoder(req.Body).Decode(&jmapReq)
+
+			args := jmapReq.MethodCalls[0][1].(map[string]interface{})
+			*queries = append(*queries, args)
+
+			start := 0
+			if position, ok := args["position"].(float64); ok {
+				start = int(position)
+			}
+			if anchor, ok := args["anchor"].(string); ok {
+				var n int
+				if _, err := fmt.Sscanf(anchor, "email-%d", &n); err != nil || n >= total {
+					return httpmock.NewJsonResponse(200, map[string]interface{}{
+						"methodResponses": [][]interface{}{
+							{"error", map[string]interface{}{"type": "anchorNotFound"}, "query"},
+						},
+					})
+				}
+				start = n + int(args["anchorOffset"].(float64))
+			}
+
+			limit := min(int(args["limit"].(float64)), pageCap)
+			var ids []string
+			var list []map[string]interface{}
+			for i := start; i < total && i < start+limit; i++ {
+				id := fmt.Sprintf("email-%d", i)
+				ids = append(ids, id)
+				list = append(list, map[string]interface{}{"id": id})
+			}
+
+			return httpmock.NewJsonResponse(200, map[string]interface{}{
+				"methodResponses": [][]interface{}{
+					{"Email/query", map[string]interface{}{"ids": ids, "limit": pageCap}, "query"},
+					{"Email/get", map[string]interface{}{"list": list}, "emails"},
+				},
+			})
+		}
+	}
+
+	emailIDs := func(emails []Email) []string {
+		ids := make([]string, len(emails))
+		for i, e := range emails {
+			ids[i] = e.ID
+		}
+		return ids
+	}
+
+	t.Run("returns the emails right after it, newest first", func(t *testing.T) {
+		httpmock.Activate()
+		defer httpmock.DeactivateAndReset()
+		registerTestSession()
+
+		var queries []map[string]interface{}
+		httpmock.RegisterResponder("POST", "https://api.test.com/jmap/api", mockAnchored(10, 2, &queries))
+
+		emails, err := newTestClient().GetRecentEmails("inbox-1", RecentEmailsOptions{Limit: 3, AfterID: "email-4"})
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"email-7", "email-6", "email-5"}, emailIDs(emails))
+		require.Len(t, queries, 2)
+		assert.Equal(t, []interface{}{map[string]interface{}{"property": "receivedAt", "isAscending": true}}, queries[0]["sort"])
+		assert.Equal(t, float64(1), queries[0]["anchorOffset"])
+		assert.Equal(t, float64(3), queries[1]["anchorOffset"])
+		assert.NotContains(t, queries[0], "position")
+	})
+
+	t.Run("keeps oldest first with OldestFirst", func(t *testing.T) {
+		httpmock.Activate()
+		defer httpmock.DeactivateAndReset()
+		registerTestSession()
+
+		var queries []map[string]interface{}
+		httpmock.RegisterResponder("POST", "https://api.test.com/jmap/api", mockAnchored(10, 500, &queries))
+
+		emails, err := newTestClient().GetRecentEmails("inbox-1", RecentEmailsOptions{Limit: 2, AfterID: "email-4", OldestFirst: true})
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"email-5", "email-6"}, emailIDs(emails))
+	})
+
+	t.Run("falls back to the time filter when it no longer matches", func(t *testing.T) {
+		httpmock.Activate()
+		defer httpmock.DeactivateAndReset()
+		registerTestSession()
+
+		var queries []map[string]interface{}
+		httpmock.RegisterResponder("POST", "https://api.test.com/jmap/api", mockAnchored(3, 500, &queries))
+
+		emails, err := newTestClient().GetRecentEmails("inbox-1", RecentEmailsOptions{Limit: 5, AfterID: "email-9"})
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"email-2", "email-1", "email-0"}, emailIDs(emails))
+		require.Len(t, queries, 2)
+		assert.NotContains(t, queries[1], "anchor")
+	})
+
+	t.Run("drops it and everything before it with NoLimit", func(t *testing.T) {
+		httpmock.Activate()
+		defer httpmock.DeactivateAndReset()
+		registerTestSession()
+
+		var positions []int
+		httpmock.RegisterResponder("POST", "https://api.test.com/jmap/api", mockMailboxPages(5, &positions))
+
+		emails, err := newTestClient().GetRecentEmails("inbox-1", RecentEmailsOptions{Limit: NoLimit, AfterID: "email-2"})
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"email-4", "email-3"}, emailIDs(emails))
+	})
+}
+
+func TestClient_GetEmailByID(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+	registerTestSession()
+
+	var bodyProperties []interface{}
+	httpmock.RegisterResponder("POST", "https://api.test.com/jmap/api",
+		func(req *http.Request) (*http.Response, error) {
+			var jmapReq Request
+			json.NewDecoder(req.Body).Decode(&jmapReq)
+
+			args := jmapReq.MethodCalls[0][1].(map[string]interface{})
+			bodyProperties = args["bodyProperties"].([]interface{})
+			return httpmock.NewJsonResponse(200, map[string]interface{}{
+				"methodResponses": [][]interface{}{
+					{"Email/get", map[string]interface{}{
+						"list": []map[string]interface{}{
+							{"id": "email-1", "attachments": []map[string]interface{}{
+								{"partId": "2", "blobId": "blob-1", "type": "application/pdf", "size": 1024,
+									"name": "invoice.pdf", "disposition": "attachment"},
+							}},
+						},
+					}, "email"},
+				},
+			})
+		})
+
+	email, err := newTestClient().GetEmailByID("email-1")
+
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{"partId", "blobId", "type", "size", "name", "disposition"}, bodyProperties)
+	require.Len(t, email.Attachments, 1)
+	assert.Equal(t, "invoice.pdf", email.Attachments[0].Name)
+	assert.Equal(t, "attachment", email.Attachments[0].Disposition)
+}
+
+func TestClient_SearchThreadFallback(t *testing.T) {
+	// mockThreadSearch answers Email/query with an error of errType whenever
+	// the filter uses threadId, and records every query filter sent.
+	mockThreadSearch := func(errType string, filters *[]interface{}) httpmock.Responder {
+		return func(req *http.Request) (*http.Response, error) {
+			var jmapReq Request
+			json.NewDecoder(req.Body).Decode(&jmapReq)
+
+			args := jmapReq.MethodCalls[0][1].(map[string]interface{})
+			switch jmapReq.MethodCalls[0][0].(string) {
+			case "Thread/get":
+				return httpmock.NewJsonResponse(200, map[string]interface{}{
+					"methodResponses": [][]interface{}{
+						{"Thread/get", map[string]interface{}{
+							"list": []map[string]interface{}{{"id": "T1", "emailIds": []string{"email-1", "email-2"}}},
+						}, "getThread"},
+						{"Email/get", map[string]interface{}{
+							"list": []map[string]interface{}{
+								{"id": "email-1", "messageId": []string{"one@example.com"}},
+								{"id": "email-2", "messageId": []string{"two@example.com"}},
+							},
+						}, "emails"},
+					},
+				})
+			case "Email/query":
+				*filters = append(*filters, args["filter"])
+				filter, _ := json.Marshal(args["filter"])
+				if errType != "" && strings.Contains(string(filter), "threadId") {
+					return httpmock.NewJsonResponse(200, map[string]interface{}{
+						"methodResponses": [][]interface{}{
+							{"error", map[string]interface{}{"type": errType}, "query"},
+						},
+					})
+				}
+				return httpmock.NewJsonResponse(200, map[string]interface{}{
+					"methodResponses": [][]interface{}{
+						{"Email/query", map[string]interface{}{"ids": []string{"email-2"}}, "query"},
+						{"Email/get", map[string]interface{}{
+							"list": []map[string]interface{}{{"id": "email-2", "threadId": "T1"}},
+						}, "emails"},
+					},
+				})
+			}
+			return httpmock.NewStringResponse(400, "unexpected"), nil
+		}
+	}
+
+	t.Run("filters by threadId when the server supports it", func(t *testing.T) {
+		httpmock.Activate()
+		defer httpmock.DeactivateAndReset()
+		registerTestSession()
+
+		var filters []interface{}
+		httpmock.RegisterResponder("POST", "https://api.test.com/jmap/api", mockThreadSearch("", &filters))
+
+		emails, err := newTestClient().Search(SearchFilters{ThreadID: "T1"})
+
+		require.NoError(t, err)
+		assert.Len(t, emails, 1)
+		assert.Equal(t, []interface{}{map[string]interface{}{"threadId": "T1"}}, filters)
+		assert.Equal(t, 1, httpmock.GetCallCountInfo()["POST https://api.test.com/jmap/api"])
+	})
+
+	t.Run("falls back to the thread's Message-IDs on unsupportedFilter", func(t *testing.T) {
+		httpmock.Activate()
+		defer httpmock.DeactivateAndReset()
+		registerTestSession()
+
+		var filters []interface{}
+		httpmock.RegisterResponder("POST", "https://api.test.com/jmap/api", mockThreadSearch("unsupportedFilter", &filters))
+
+		emails, err := newTestClient().Search(SearchFilters{Query: "from:alice inThread:T1"})
+
+		require.NoError(t, err)
+		require.Len(t, emails, 1)
+		assert.Equal(t, "email-2", emails[0].ID)
+		require.Len(t, filters, 2)
+		assert.Equal(t, map[string]interface{}{
+			"operator": "AND",
+			"conditions": []interface{}{
+				map[string]interface{}{"from": "alice"},
+				map[string]interface{}{
+					"operator": "OR",
+					"conditions": []interface{}{
+						map[string]interface{}{"header": []interface{}{"Message-ID", "<one@example.com>"}},
+						map[string]interface{}{"header": []interface{}{"Message-ID", "<two@example.com>"}},
+					},
+				},
+			},
+		}, filters[1])
+	})
+
+	t.Run("returns other errors without retrying", func(t *testing.T) {
+		httpmock.Activate()
+		defer httpmock.DeactivateAndReset()
+		registerTestSession()
+
+		var filters []interface{}
+		httpmock.RegisterResponder("POST", "https://api.test.com/jmap/api", mockThreadSearch("invalidArguments", &filters))
+
+		_, _, err := newTestClient().QueryEmailIDs(SearchFilters{ThreadID: "T1"})
+
+		var methodErr *MethodError
+		require.ErrorAs(t, err, &methodErr)
+		assert.Equal(t, "invalidArguments", methodErr.Type)
+		assert.Len(t, filters, 1)
+	})
+}
+
+func TestClient_ResolveThreadID(t *testing.T) {
+	mockEmail := func(list []map[string]interface{}) httpmock.Responder {
+		return httpmock.NewJsonResponderOrPanic(200, map[string]interface{}{
+			"methodResponses": [][]interface{}{
+				{"Email/get", map[string]interface{}{"list": list}, "email"},
+			},
+		})
+	}
+
+	t.Run("returns the thread of an email", func(t *testing.T) {
+		httpmock.Activate()
+		defer httpmock.DeactivateAndReset()
+		registerTestSession()
+		httpmock.RegisterResponder("POST", "https://api.test.com/jmap/api",
+			mockEmail([]map[string]interface{}{{"id": "email-1", "threadId": "T1"}}))
+
+		threadID, err := newTestClient().ResolveThreadID("email-1")
+
+		require.NoError(t, err)
+		assert.Equal(t, "T1", threadID)
+	})
+
+	t.Run("returns a thread ID unchanged", func(t *testing.T) {
+		httpmock.Activate()
+		defer httpmock.DeactivateAndReset()
+		registerTestSession()
+		httpmock.RegisterResponder("POST", "https://api.test.com/jmap/api", mockEmail([]map[string]interface{}{}))
+
+		threadID, err := newTestClient().ResolveThreadID("T1")
+
+		require.NoError(t, err)
+		assert.Equal(t, "T1", threadID)
+	})
+
+	t.Run("returns request errors", func(t *testing.T) {
+		httpmock.Activate()
+		defer httpmock.DeactivateAndReset()
+		registerTestSession()
+		httpmock.RegisterResponder("POST", "https://api.test.com/jmap/api", httpmock.NewStringResponder(500, "boom"))
+
+		_, err := newTestClient().ResolveThreadID("email-1")
+
+		assert.Error(t, err)
+	})
+}
@@ -1,7 +1,10 @@
 package jmap
 
 import (
+	"fmt"
+	"strconv"
 	"strings"
+	"time"
 	"unicode"
 )
 
@@ -12,7 +15,7 @@ type Filter interface {
 
 // TextFilter represents a simple field filter.
 type TextFilter struct {
-	Field string // "text", "from", "to", "subject", "inMailbox", "hasKeyword", "notKeyword"
+	Field string // "text", "from", "to", "subject", "inMailbox", "threadId", "hasKeyword", "notKeyword"
 	Value string
 }
 
@@ -49,6 +52,27 @@ func (f *HasAttachmentFilter) ToJMAP() map[string]interface{} {
 	return map[string]interface{}{"hasAttachment": f.Value}
 }
 
+// HeaderFilter matches emails with a header field whose value contains Value.
+type HeaderFilter struct {
+	Name  string
+	Value string
+}
+
+// ToJMAP converts the filter to JMAP format.
+func (f *HeaderFilter) ToJMAP() map[string]interface{} {
+	return map[string]interface{}{"header": []string{f.Name, f.Value}}
+}
+
+// MailboxExclusionFilter matches emails in none of the given mailboxes.
+type MailboxExclusionFilter struct {
+	IDs []string
+}
+
+// ToJMAP converts the filter to JMAP format.
+func (f *MailboxExclusionFilter) ToJMAP() map[string]interface{} {
+	return map[string]interface{}{"inMailboxOtherThan": f.IDs}
+}
+
 // tokenType represents the type of a token.
 type tokenType int
 
@@ -306,6 +330,10 @@ func parseFieldValue(term string) Filter {
 		switch field {
 		case "from":
 			return &TextFilter{Field: "from", Value: value}
+		case "domain":
+			// JMAP's from filter is a substring match, so this also matches
+			// longer domains that start the same (example.com.au)
+			return &TextFilter{Field: "from", Value: "@" + strings.TrimPrefix(value, "@")}
 		case "to":
 			return &TextFilter{Field: "to", Value: value}
 		case "cc":
@@ -335,12 +363,25 @@ func parseFieldValue(term string) Filter {
 			case "answered":
 				return &TextFilter{Field: "hasKeyword", Value: "$answered"}
 			}
+		case "inthread", "thread":
+			// threadId is a Fastmail extension to the standard filter conditions
+			return &TextFilter{Field: "threadId", Value: value}
 		case "in", "folder", "mailbox":
 			return &TextFilter{Field: "inMailbox", Value: value}
 		case "before":
 			return &TextFilter{Field: "before", Value: value}
 		case "after":
 			return &TextFilter{Field: "after", Value: value}
+		case "newer_than", "newer", "older_than", "older":
+			d, err := ParseRelativeDuration(value)
+			if err != nil {
+				break
+			}
+			since := UTCDate(time.Now().Add(-d))
+			if strings.HasPrefix(field, "newer") {
+				return &TextFilter{Field: "after", Value: since}
+			}
+			return &TextFilter{Field: "before", Value: since}
 		}
 	}
 
@@ -373,3 +414,34 @@ func containsBooleanOperators(query string) bool {
 	}
 	return false
 }
+
+// ParseRelativeDuration parses a duration such as "30m", "24h", "7d", or "2w".
+// A day is 24 hours and a week is 7 days.
+func ParseRelativeDuration(s string) (time.Duration, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	if len(s) < 2 {
+		return 0, fmt.Errorf("invalid duration %q: use a number followed by m, h, d, or w (e.g. 7d)", s)
+	}
+
+	n, err := strconv.Atoi(s[:len(s)-1])
+	if err != nil || n <= 0 {
+		return 0, fmt.Errorf("invalid duration %q: use a number followed by m, h, d, or w (e.g. 7d)", s)
+	}
+
+	switch s[len(s)-1] {
+	case 'm':
+		return time.Duration(n) * time.Minute, nil
+	case 'h':
+		return time.Duration(n) * time.Hour, nil
+	case 'd':
+		return time.Duration(n) * 24 * time.Hour, nil
+	case 'w':
+		return time.Duration(n) * 7 * 24 * time.Hour, nil
+	}
+	return 0, fmt.Errorf("invalid duration %q: use a number followed by m, h, d, or w (e.g. 7d)", s)
+}
+
+// UTCDate formats t as a JMAP UTCDate, as used by the before and after filters.
+func UTCDate(t time.Time) string {
+	return t.UTC().Format("2006-01-02T15:04:05Z")
+}
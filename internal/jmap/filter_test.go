@@ -3,6 +3,7 @@ package jmap
 import (
 	"encoding/json"
 	"testing"
+	"time"
 )
 
 func TestParseQuery_SimpleText(t *testing.T) {
@@ -29,6 +30,9 @@ func TestParseQuery_FieldFilter(t *testing.T) {
 		{"cc:charlie", "cc", "charlie"},
 		{"bcc:dave", "bcc", "dave"},
 		{"body:important", "body", "important"},
+		{"domain:example.com", "from", "@example.com"},
+		{"domain:@example.com", "from", "@example.com"},
+		{"inThread:T123", "threadId", "T123"},
 	}
 
 	for _, tt := range tests {
@@ -426,3 +430,74 @@ func TestParseQuery_DateFilters(t *testing.T) {
 		})
 	}
 }
+
+func TestParseQuery_RelativeDateFilters(t *testing.T) {
+	tests := []struct {
+		query string
+		field string
+		ago   time.Duration
+	}{
+		{"newer_than:7d", "after", 7 * 24 * time.Hour},
+		{"older_than:2w", "before", 14 * 24 * time.Hour},
+		{"NEWER_THAN:12h", "after", 12 * time.Hour},
+		{"older:30d", "before", 30 * 24 * time.Hour},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			jmap := ParseQuery(tt.query).ToJMAP()
+
+			value, ok := jmap[tt.field].(string)
+			if !ok {
+				t.Fatalf("expected %s filter, got %v", tt.field, jmap)
+			}
+			date, err := time.Parse(time.RFC3339, value)
+			if err != nil {
+				t.Fatalf("expected UTCDate, got %q", value)
+			}
+			if diff := time.Since(date) - tt.ago; diff < 0 || diff > time.Minute {
+				t.Errorf("expected %s ago, got %s", tt.ago, value)
+			}
+		})
+	}
+
+	t.Run("invalid duration is plain text", func(t *testing.T) {
+		jmap := ParseQuery("newer_than:soon").ToJMAP()
+		if jmap["text"] != "newer_than:soon" {
+			t.Errorf("expected text filter, got %v", jmap)
+		}
+	})
+}
+
+func TestParseRelativeDuration(t *testing.T) {
+	tests := []struct {
+		input string
+		want  time.Duration
+	}{
+		{"30m", 30 * time.Minute},
+		{"24h", 24 * time.Hour},
+		{"7d", 7 * 24 * time.Hour},
+		{"2w", 14 * 24 * time.Hour},
+		{" 1D ", 24 * time.Hour},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseRelativeDuration(tt.input)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("expected %s, got %s", tt.want, got)
+			}
+		})
+	}
+
+	for _, input := range []string{"", "d", "7", "7y", "-1d", "0h", "1.5d"} {
+		t.Run("rejects "+input, func(t *testing.T) {
+			if _, err := ParseRelativeDuration(input); err == nil {
+				t.Errorf("expected error for %q", input)
+			}
+		})
+	}
+}
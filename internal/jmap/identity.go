@@ -7,7 +7,7 @@ import (
 
 // GetIdentities fetches all sender identities.
 func (c *Client) GetIdentities() ([]Identity, error) {
-	session, err := c.GetSession()
+	session, err := c.requireCapability(SubmissionCapability)
 	if err != nil {
 		return nil, err
 	}
@@ -61,3 +61,173 @@ func (c *Client) GetDefaultIdentity() (*Identity, error) {
 
 	return &identities[0], nil
 }
+
+// GetIdentityByID finds an identity by ID.
+func (c *Client) GetIdentityByID(id string) (*Identity, error) {
+	identities, err := c.GetIdentities()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, identity := range identities {
+		if identity.ID == id {
+			return &identity, nil
+		}
+	}
+
+	return nil, fmt.Errorf("identity with ID '%s' not found", id)
+}
+
+// CreateIdentity creates a new sender identity and returns its ID.
+func (c *Client) CreateIdentity(email, name, textSignature string) (string, error) {
+	session, err := c.requireCapability(SubmissionCapability)
+	if err != nil {
+		return "", err
+	}
+
+	identityData := map[string]interface{}{
+		"email": email,
+	}
+	if name != "" {
+		identityData["name"] = name
+	}
+	if textSignature != "" {
+		identityData["textSignature"] = textSignature
+	}
+
+	request := &Request{
+		Using: []string{CoreCapability, SubmissionCapability},
+		MethodCalls: [][]interface{}{
+			{
+				"Identity/set",
+				map[string]interface{}{
+					"accountId": session.AccountID,
+					"create": map[string]interface{}{
+						"newIdentity": identityData,
+					},
+				},
+				"createIdentity",
+			},
+		},
+	}
+
+	resp, err := c.MakeRequest(request)
+	if err != nil {
+		return "", err
+	}
+
+	var result struct {
+		Created map[string]struct {
+			ID string `json:"id"`
+		} `json:"created"`
+		NotCreated map[string]struct {
+			Type        string `json:"type"`
+			Description string `json:"description"`
+		} `json:"notCreated"`
+	}
+
+	if err := json.Unmarshal(resp.MethodResponses[0][1], &result); err != nil {
+		return "", err
+	}
+
+	if e, ok := result.NotCreated["newIdentity"]; ok {
+		return "", fmt.Errorf("failed to create identity: %s - %s", e.Type, e.Description)
+	}
+
+	if created, ok := result.Created["newIdentity"]; ok {
+		return created.ID, nil
+	}
+
+	return "", fmt.Errorf("failed to create identity: no ID returned")
+}
+
+// DeleteIdentity deletes a sender identity.
+func (c *Client) DeleteIdentity(identityID string) error {
+	session, err := c.requireCapability(SubmissionCapability)
+	if err != nil {
+		return err
+	}
+
+	request := &Request{
+		Using: []string{CoreCapability, SubmissionCapability},
+		MethodCalls: [][]interface{}{
+			{
+				"Identity/set",
+				map[string]interface{}{
+					"accountId": session.AccountID,
+					"destroy":   []string{identityID},
+				},
+				"deleteIdentity",
+			},
+		},
+	}
+
+	resp, err := c.MakeRequest(request)
+	if err != nil {
+		return err
+	}
+
+	var result struct {
+		NotDestroyed map[string]struct {
+			Type        string `json:"type"`
+			Description string `json:"description"`
+		} `json:"notDestroyed"`
+	}
+
+	if err := json.Unmarshal(resp.MethodResponses[0][1], &result); err != nil {
+		return err
+	}
+
+	if e, ok := result.NotDestroyed[identityID]; ok {
+		return fmt.Errorf("failed to delete identity: %s - %s", e.Type, e.Description)
+	}
+
+	return nil
+}
+
+// UpdateIdentity patches a sender identity. Only the properties present in
+// updates are sent, so unchanged fields are left as they are on the server.
+func (c *Client) UpdateIdentity(identityID string, updates map[string]interface{}) error {
+	session, err := c.requireCapability(SubmissionCapability)
+	if err != nil {
+		return err
+	}
+
+	request := &Request{
+		Using: []string{CoreCapability, SubmissionCapability},
+		MethodCalls: [][]interface{}{
+			{
+				"Identity/set",
+				map[string]interface{}{
+					"accountId": session.AccountID,
+					"update": map[string]interface{}{
+						identityID: updates,
+					},
+				},
+				"updateIdentity",
+			},
+		},
+	}
+
+	resp, err := c.MakeRequest(request)
+	if err != nil {
+		return err
+	}
+
+	var result struct {
+		NotUpdated map[string]struct {
+			Type        string `json:"type"`
+			Description string `json:"description"`
+		} `json:"notUpdated"`
+	}
+
+	if err := json.Unmarshal(resp.MethodResponses[0][1], &result); err != nil {
+		return err
+	}
+
+	if e, ok := result.NotUpdated[identityID]; ok {
+		return fmt.Errorf("failed to update identity: %s - %s", e.Type, e.Description)
+	}
+
+	return nil
+}
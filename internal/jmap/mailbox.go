@@ -3,6 +3,7 @@ package jmap
 import (
 	"encoding/json"
 	"fmt"
+	"sort"
 	"strings"
 )
 
@@ -84,6 +85,33 @@ func (c *Client) GetMailboxByID(id string) (*Mailbox, error) {
 	return nil, fmt.Errorf("mailbox with ID '%s' not found", id)
 }
 
+// junkMailboxNames are folder names used for junk mail when no mailbox has the junk role.
+var junkMailboxNames = []string{"junk", "spam", "junk mail", "junk e-mail"}
+
+// GetJunkMailbox finds the junk mailbox by role, falling back to common folder names.
+func (c *Client) GetJunkMailbox() (*Mailbox, error) {
+	mailboxes, err := c.GetMailboxes()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, mb := range mailboxes {
+		if strings.ToLower(mb.Role) == "junk" {
+			return &mb, nil
+		}
+	}
+
+	for _, name := range junkMailboxNames {
+		for _, mb := range mailboxes {
+			if strings.ToLower(mb.Name) == name {
+				return &mb, nil
+			}
+		}
+	}
+
+	return nil, fmt.Errorf("no junk mailbox found: no mailbox has the junk role or is named Junk or Spam")
+}
+
 // GetMailboxByName finds a mailbox by name.
 func (c *Client) GetMailboxByName(name string) (*Mailbox, error) {
 	mailboxes, err := c.GetMailboxes()
@@ -102,8 +130,58 @@ func (c *Client) GetMailboxByName(name string) (*Mailbox, error) {
 	return nil, fmt.Errorf("mailbox with name '%s' not found", name)
 }
 
-// CreateMailbox creates a new mailbox.
-func (c *Client) CreateMailbox(name string, parentID string) (string, error) {
+// ResolveMailboxNames fills in MailboxNames for each email from its MailboxIDs.
+// Mailboxes are fetched once, and only if at least one email has mailbox IDs.
+func (c *Client) ResolveMailboxNames(emails []Email) error {
+	needed := false
+	for _, email := range emails {
+		if len(email.MailboxIDs) > 0 {
+			needed = true
+			break
+		}
+	}
+	if !needed {
+		return nil
+	}
+
+	mailboxes, err := c.GetMailboxes()
+	if err != nil {
+		return err
+	}
+
+	names := make(map[string]string, len(mailboxes))
+	for _, mb := range mailboxes {
+		names[mb.ID] = mb.Name
+	}
+
+	for i := range emails {
+		var resolved []string
+		for id, in := range emails[i].MailboxIDs {
+			if !in {
+				continue
+			}
+			if name, ok := names[id]; ok {
+				resolved = append(resolved, name)
+			} else {
+				resolved = append(resolved, id)
+			}
+		}
+		sort.Strings(resolved)
+		emails[i].MailboxNames = resolved
+	}
+
+	return nil
+}
+
+// MailboxRoles are the mailbox roles registered with IANA for JMAP and IMAP.
+// An account can have at most one mailbox with each role.
+var MailboxRoles = []string{
+	"all", "archive", "drafts", "flagged", "important", "inbox", "junk",
+	"memos", "scheduled", "sent", "snoozed", "subscribed", "trash",
+}
+
+// CreateMailbox creates a new mailbox. parentID and role are optional.
+func (c *Client) CreateMailbox(name, parentID, role string) (string, error) {
 	session, err := c.GetSession()
 	if err != nil {
 		return "", err
@@ -115,6 +193,9 @@ func (c *Client) CreateMailbox(name string, parentID string) (string, error) {
 	if parentID != "" {
 		mailboxData["parentId"] = parentID
 	}
+	if role != "" {
+		mailboxData["role"] = role
+	}
 
 	request := &Request{
 		Using: []string{CoreCapability, MailCapability},
@@ -152,6 +233,9 @@ func (c *Client) CreateMailbox(name string, parentID string) (string, error) {
 	}
 
 	if e, ok := result.NotCreated["newMailbox"]; ok {
+		if e.Description == "" {
+			return "", fmt.Errorf("failed to create mailbox: %s", e.Type)
+		}
 		return "", fmt.Errorf("failed to create mailbox: %s", e.Description)
 	}
 
@@ -210,6 +294,54 @@ func (c *Client) RenameMailbox(mailboxID, newName string) error {
 	return nil
 }
 
+// SetMailboxSubscribed subscribes to or unsubscribes from a mailbox.
+func (c *Client) SetMailboxSubscribed(mailboxID string, subscribed bool) error {
+	session, err := c.GetSession()
+	if err != nil {
+		return err
+	}
+
+	request := &Request{
+		Using: []string{CoreCapability, MailCapability},
+		MethodCalls: [][]interface{}{
+			{
+				"Mailbox/set",
+				map[string]interface{}{
+					"accountId": session.AccountID,
+					"update": map[string]interface{}{
+						mailboxID: map[string]interface{}{
+							"isSubscribed": subscribed,
+						},
+					},
+				},
+				"subscribeMailbox",
+			},
+		},
+	}
+
+	resp, err := c.MakeRequest(request)
+	if err != nil {
+		return err
+	}
+
+	var result struct {
+		NotUpdated map[string]struct {
+			Type        string `json:"type"`
+			Description string `json:"description"`
+		} `json:"notUpdated"`
+	}
+
+	if err := json.Unmarshal(resp.MethodResponses[0][1], &result); err != nil {
+		return err
+	}
+
+	if e, ok := result.NotUpdated[mailboxID]; ok {
+		return fmt.Errorf("failed to update subscription: %s", e.Description)
+	}
+
+	return nil
+}
+
 // DeleteMailbox deletes a mailbox.
 func (c *Client) DeleteMailbox(mailboxID string) error {
 	session, err := c.GetSession()
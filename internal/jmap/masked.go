@@ -0,0 +1,170 @@
+package jmap
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// GetMaskedEmails fetches all masked email addresses.
+func (c *Client) GetMaskedEmails() ([]MaskedEmail, error) {
+	session, err := c.requireCapability(MaskedEmailCapability)
+	if err != nil {
+		return nil, err
+	}
+
+	request := &Request{
+		Using: []string{CoreCapability, MaskedEmailCapability},
+		MethodCalls: [][]interface{}{
+			{
+				"MaskedEmail/get",
+				map[string]interface{}{
+					"accountId": session.AccountID,
+				},
+				"maskedEmails",
+			},
+		},
+	}
+
+	resp, err := c.MakeRequest(request)
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		List []MaskedEmail `json:"list"`
+	}
+
+	if err := json.Unmarshal(resp.MethodResponses[0][1], &result); err != nil {
+		return nil, fmt.Errorf("failed to parse masked emails: %w", err)
+	}
+
+	return result.List, nil
+}
+
+// CreateMaskedEmail creates a new enabled masked email address.
+func (c *Client) CreateMaskedEmail(forDomain, description string) (*MaskedEmail, error) {
+	session, err := c.requireCapability(MaskedEmailCapability)
+	if err != nil {
+		return nil, err
+	}
+
+	maskedData := map[string]interface{}{
+		"state": "enabled",
+	}
+	if forDomain != "" {
+		maskedData["forDomain"] = forDomain
+	}
+	if description != "" {
+		maskedData["description"] = description
+	}
+
+	request := &Request{
+		Using: []string{CoreCapability, MaskedEmailCapability},
+		MethodCalls: [][]interface{}{
+			{
+				"MaskedEmail/set",
+				map[string]interface{}{
+					"accountId": session.AccountID,
+					"create": map[string]interface{}{
+						"newMasked": maskedData,
+					},
+				},
+				"createMasked",
+			},
+		},
+	}
+
+	resp, err := c.MakeRequest(request)
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		Created    map[string]MaskedEmail `json:"created"`
+		NotCreated map[string]struct {
+			Type        string `json:"type"`
+			Description string `json:"description"`
+		} `json:"notCreated"`
+	}
+
+	if err := json.Unmarshal(resp.MethodResponses[0][1], &result); err != nil {
+		return nil, err
+	}
+
+	if e, ok := result.NotCreated["newMasked"]; ok {
+		return nil, fmt.Errorf("failed to create masked email: %s - %s", e.Type, e.Description)
+	}
+
+	created, ok := result.Created["newMasked"]
+	if !ok || created.Email == "" {
+		return nil, fmt.Errorf("failed to create masked email: no address returned")
+	}
+
+	return &created, nil
+}
+
+// FindMaskedEmail finds a masked email by ID or by address (case-insensitive).
+func (c *Client) FindMaskedEmail(idOrAddress string) (*MaskedEmail, error) {
+	masked, err := c.GetMaskedEmails()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, m := range masked {
+		if m.ID == idOrAddress || strings.EqualFold(m.Email, idOrAddress) {
+			return &m, nil
+		}
+	}
+
+	return nil, fmt.Errorf("masked email '%s' not found", idOrAddress)
+}
+
+// SetMaskedEmailState changes the state of a masked email. Disabled addresses
+// silently drop incoming mail; deleted addresses bounce it.
+func (c *Client) SetMaskedEmailState(maskedID, state string) error {
+	session, err := c.requireCapability(MaskedEmailCapability)
+	if err != nil {
+		return err
+	}
+
+	request := &Request{
+		Using: []string{CoreCapability, MaskedEmailCapability},
+		MethodCalls: [][]interface{}{
+			{
+				"MaskedEmail/set",
+				map[string]interface{}{
+					"accountId": session.AccountID,
+					"update": map[string]interface{}{
+						maskedID: map[string]interface{}{
+							"state": state,
+						},
+					},
+				},
+				"setMaskedState",
+			},
+		},
+	}
+
+	resp, err := c.MakeRequest(request)
+	if err != nil {
+		return err
+	}
+
+	var result struct {
+		NotUpdated map[string]struct {
+			Type        string `json:"type"`
+			Description string `json:"description"`
+		} `json:"notUpdated"`
+	}
+
+	if err := json.Unmarshal(resp.MethodResponses[0][1], &result); err != nil {
+		return err
+	}
+
+	if e, ok := result.NotUpdated[maskedID]; ok {
+		return fmt.Errorf("failed to update masked email: %s - %s", e.Type, e.Description)
+	}
+
+	return nil
+}
@@ -0,0 +1,50 @@
+package jmap
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// ErrQuotaUnavailable is returned when the server doesn't expose quotas.
+var ErrQuotaUnavailable = errors.New("quota information not available for this account")
+
+// GetQuotas fetches the account's quotas.
+func (c *Client) GetQuotas() ([]Quota, error) {
+	session, err := c.GetSession()
+	if err != nil {
+		return nil, err
+	}
+
+	if !session.HasCapability(QuotaCapability) {
+		return nil, ErrQuotaUnavailable
+	}
+
+	request := &Request{
+		Using: []string{CoreCapability, QuotaCapability},
+		MethodCalls: [][]interface{}{
+			{
+				"Quota/get",
+				map[string]interface{}{
+					"accountId": session.AccountID,
+				},
+				"quotas",
+			},
+		},
+	}
+
+	resp, err := c.MakeRequest(request)
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		List []Quota `json:"list"`
+	}
+
+	if err := json.Unmarshal(resp.MethodResponses[0][1], &result); err != nil {
+		return nil, fmt.Errorf("failed to parse quotas: %w", err)
+	}
+
+	return result.List, nil
+}
@@ -0,0 +1,156 @@
+package jmap
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// GetSieveScripts fetches the account's sieve scripts.
+func (c *Client) GetSieveScripts() ([]SieveScript, error) {
+	session, err := c.requireCapability(SieveCapability)
+	if err != nil {
+		return nil, err
+	}
+
+	request := &Request{
+		Using: []string{CoreCapability, SieveCapability},
+		MethodCalls: [][]interface{}{
+			{
+				"SieveScript/get",
+				map[string]interface{}{
+					"accountId": session.AccountID,
+				},
+				"sieveScripts",
+			},
+		},
+	}
+
+	resp, err := c.MakeRequest(request)
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		List []SieveScript `json:"list"`
+	}
+
+	if err := json.Unmarshal(resp.MethodResponses[0][1], &result); err != nil {
+		return nil, fmt.Errorf("failed to parse sieve scripts: %w", err)
+	}
+
+	return result.List, nil
+}
+
+// SieveScriptType is the media type of sieve script blobs.
+const SieveScriptType = "application/sieve"
+
+// GetActiveSieveScript returns the active sieve script, or nil if no script
+// is active.
+func (c *Client) GetActiveSieveScript() (*SieveScript, error) {
+	scripts, err := c.GetSieveScripts()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, s := range scripts {
+		if s.IsActive {
+			return &s, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// GetSieveScriptContent downloads the source of a sieve script.
+func (c *Client) GetSieveScriptContent(script *SieveScript) (string, error) {
+	data, err := c.DownloadBlob(script.BlobID, script.Name, SieveScriptType)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// UpdateSieveScript replaces the source of an existing sieve script. The
+// server validates the new source and rejects it if it does not compile.
+func (c *Client) UpdateSieveScript(scriptID, content string) error {
+	session, err := c.requireCapability(SieveCapability)
+	if err != nil {
+		return err
+	}
+
+	blobID, err := c.UploadBlob([]byte(content), SieveScriptType)
+	if err != nil {
+		return err
+	}
+
+	request := &Request{
+		Using: []string{CoreCapability, SieveCapability},
+		MethodCalls: [][]interface{}{
+			{
+				"SieveScript/set",
+				map[string]interface{}{
+					"accountId": session.AccountID,
+					"update": map[string]interface{}{
+						scriptID: map[string]interface{}{
+							"blobId": blobID,
+						},
+					},
+				},
+				"updateSieveScript",
+			},
+		},
+	}
+
+	resp, err := c.MakeRequest(request)
+	if err != nil {
+		return err
+	}
+
+	if err := c.checkSetError(resp, 0, scriptID); err != nil {
+		return fmt.Errorf("failed to update sieve script: %w", err)
+	}
+	return nil
+}
+
+// CreateSieveScript creates a sieve script and makes it the active one.
+func (c *Client) CreateSieveScript(name, content string) error {
+	session, err := c.requireCapability(SieveCapability)
+	if err != nil {
+		return err
+	}
+
+	blobID, err := c.UploadBlob([]byte(content), SieveScriptType)
+	if err != nil {
+		return err
+	}
+
+	request := &Request{
+		Using: []string{CoreCapability, SieveCapability},
+		MethodCalls: [][]interface{}{
+			{
+				"SieveScript/set",
+				map[string]interface{}{
+					"accountId": session.AccountID,
+					"create": map[string]interface{}{
+						"newScript": map[string]interface{}{
+							"name":   name,
+							"blobId": blobID,
+						},
+					},
+					"onSuccessActivateScript": "#newScript",
+				},
+				"createSieveScript",
+			},
+		},
+	}
+
+	resp, err := c.MakeRequest(request)
+	if err != nil {
+		return err
+	}
+
+	if err := c.checkSetError(resp, 0, "newScript"); err != nil {
+		return fmt.Errorf("failed to create sieve script: %w", err)
+	}
+	return nil
+}
@@ -7,7 +7,7 @@ import (
 
 // SendEmail sends a draft email.
 func (c *Client) SendEmail(draftID string) error {
-	session, err := c.GetSession()
+	session, err := c.requireCapability(SubmissionCapability)
 	if err != nil {
 		return err
 	}
@@ -55,6 +55,61 @@ func (c *Client) SendEmail(draftID string) error {
 		return err
 	}
 
+	return checkSubmission(resp)
+}
+
+// RedirectEmail re-sends an existing email, unchanged, to new recipients.
+// The envelope is addressed to the given recipients, so the message keeps
+// its original From, To, and other headers.
+func (c *Client) RedirectEmail(emailID string, to []string) error {
+	session, err := c.requireCapability(SubmissionCapability)
+	if err != nil {
+		return err
+	}
+
+	identity, err := c.GetDefaultIdentity()
+	if err != nil {
+		return err
+	}
+
+	rcptTo := make([]map[string]string, len(to))
+	for i, addr := range to {
+		rcptTo[i] = map[string]string{"email": addr}
+	}
+
+	request := &Request{
+		Using: []string{CoreCapability, MailCapability, SubmissionCapability},
+		MethodCalls: [][]interface{}{
+			{
+				"EmailSubmission/set",
+				map[string]interface{}{
+					"accountId": session.AccountID,
+					"create": map[string]interface{}{
+						"submission": map[string]interface{}{
+							"emailId":    emailID,
+							"identityId": identity.ID,
+							"envelope": map[string]interface{}{
+								"mailFrom": map[string]string{"email": identity.Email},
+								"rcptTo":   rcptTo,
+							},
+						},
+					},
+				},
+				"redirectEmail",
+			},
+		},
+	}
+
+	resp, err := c.MakeRequest(request)
+	if err != nil {
+		return err
+	}
+
+	return checkSubmission(resp)
+}
+
+// checkSubmission checks that an EmailSubmission/set response created the submission.
+func checkSubmission(resp *Response) error {
 	var result struct {
 		Created map[string]interface{} `json:"created"`
 		NotCreated map[string]struct {
@@ -1,6 +1,10 @@
 package jmap
 
-import "time"
+import (
+	"sort"
+	"strings"
+	"time"
+)
 
 // Mailbox represents a JMAP mailbox (folder).
 type Mailbox struct {
@@ -13,6 +17,7 @@ type Mailbox struct {
 	UnreadEmails int    `json:"unreadEmails"`
 	TotalThreads int    `json:"totalThreads"`
 	UnreadThreads int   `json:"unreadThreads"`
+	IsSubscribed bool   `json:"isSubscribed"`
 }
 
 // EmailAddress represents an email address with optional name.
@@ -36,6 +41,7 @@ type Email struct {
 	ReceivedAt    time.Time               `json:"receivedAt"`
 	Preview       string                  `json:"preview,omitempty"`
 	HasAttachment bool                    `json:"hasAttachment"`
+	Size          int64                   `json:"size,omitempty"`
 	TextBody      []BodyPart              `json:"textBody,omitempty"`
 	HTMLBody      []BodyPart              `json:"htmlBody,omitempty"`
 	BodyValues    map[string]BodyValue    `json:"bodyValues,omitempty"`
@@ -43,6 +49,13 @@ type Email struct {
 	MessageID     []string                `json:"messageId,omitempty"`
 	InReplyTo     []string                `json:"inReplyTo,omitempty"`
 	References    []string                `json:"references,omitempty"`
+
+	// MailboxNames is filled in by ResolveMailboxNames; it is not a JMAP property.
+	MailboxNames []string `json:"-"`
+
+	// ThreadCount is set by cmdutil.GroupByThread to the number of listed
+	// emails the row stands for; it is not a JMAP property.
+	ThreadCount int `json:"-"`
 }
 
 // BodyPart represents a part of the email body.
@@ -56,21 +69,194 @@ type BodyValue struct {
 	Value string `json:"value"`
 }
 
+// EmailHeader is a raw header field as it appears in the message.
+type EmailHeader struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
 // Attachment represents an email attachment.
 type Attachment struct {
-	PartID string `json:"partId"`
-	BlobID string `json:"blobId"`
-	Type   string `json:"type"`
-	Size   int64  `json:"size"`
-	Name   string `json:"name,omitempty"`
+	PartID      string `json:"partId"`
+	BlobID      string `json:"blobId"`
+	Type        string `json:"type"`
+	Size        int64  `json:"size"`
+	Name        string `json:"name,omitempty"`
+	Disposition string `json:"disposition,omitempty"` // attachment, inline, or empty
 }
 
 // Identity represents a sender identity.
 type Identity struct {
-	ID        string `json:"id"`
-	Email     string `json:"email"`
-	Name      string `json:"name,omitempty"`
-	MayDelete bool   `json:"mayDelete"`
+	ID            string         `json:"id"`
+	Email         string         `json:"email"`
+	Name          string         `json:"name,omitempty"`
+	TextSignature string         `json:"textSignature,omitempty"`
+	HTMLSignature string         `json:"htmlSignature,omitempty"`
+	ReplyTo       []EmailAddress `json:"replyTo,omitempty"`
+	MayDelete     bool           `json:"mayDelete"`
+}
+
+// MaskedEmail represents a Fastmail masked email address.
+type MaskedEmail struct {
+	ID            string `json:"id"`
+	Email         string `json:"email"`
+	State         string `json:"state"` // pending, enabled, disabled, or deleted
+	ForDomain     string `json:"forDomain,omitempty"`
+	Description   string `json:"description,omitempty"`
+	CreatedAt     string `json:"createdAt,omitempty"`
+	LastMessageAt string `json:"lastMessageAt,omitempty"`
+}
+
+// VacationResponse represents the account's vacation auto-reply (a singleton).
+type VacationResponse struct {
+	ID        string  `json:"id"`
+	IsEnabled bool    `json:"isEnabled"`
+	FromDate  *string `json:"fromDate"`
+	ToDate    *string `json:"toDate"`
+	Subject   *string `json:"subject"`
+	TextBody  *string `json:"textBody"`
+	HTMLBody  *string `json:"htmlBody"`
+}
+
+// SieveScript is a server-side mail filtering script (RFC 9661).
+type SieveScript struct {
+	ID       string `json:"id"`
+	Name     string `json:"name"`
+	BlobID   string `json:"blobId"`
+	IsActive bool   `json:"isActive"`
+}
+
+// Contact is a JSContact card (RFC 9553) as returned by ContactCard/get.
+type Contact struct {
+	ID     string                  `json:"id"`
+	Kind   string                  `json:"kind,omitempty"` // individual, group, org, ...
+	Name   *ContactName            `json:"name,omitempty"`
+	Emails map[string]ContactEmail `json:"emails,omitempty"`
+	Phones map[string]ContactPhone `json:"phones,omitempty"`
+}
+
+// ContactName is the name of a contact.
+type ContactName struct {
+	Full       string                 `json:"full,omitempty"`
+	Components []ContactNameComponent `json:"components,omitempty"`
+}
+
+// ContactNameComponent is one part of a contact's name, e.g. given or surname.
+type ContactNameComponent struct {
+	Kind  string `json:"kind"`
+	Value string `json:"value"`
+}
+
+// ContactEmail is one of a contact's email addresses.
+type ContactEmail struct {
+	Address string `json:"address"`
+	Pref    int    `json:"pref,omitempty"` // 1 is most preferred; 0 means unset
+}
+
+// ContactPhone is one of a contact's phone numbers.
+type ContactPhone struct {
+	Number   string          `json:"number"`
+	Features map[string]bool `json:"features,omitempty"` // mobile, voice, fax, ...
+	Contexts map[string]bool `json:"contexts,omitempty"` // work, private
+	Pref     int             `json:"pref,omitempty"`
+}
+
+// FullName returns the contact's display name.
+func (c *Contact) FullName() string {
+	if c.Name == nil {
+		return ""
+	}
+	if c.Name.Full != "" {
+		return c.Name.Full
+	}
+
+	var parts []string
+	for _, comp := range c.Name.Components {
+		if comp.Kind == "given" || comp.Kind == "surname" {
+			parts = append(parts, comp.Value)
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+// PrimaryEmail returns the contact's most preferred email address.
+func (c *Contact) PrimaryEmail() string {
+	best := ""
+	bestPref := 0
+	for _, e := range c.Emails {
+		pref := prefOrder(e.Pref)
+		if best == "" || pref < bestPref || (pref == bestPref && e.Address < best) {
+			best = e.Address
+			bestPref = pref
+		}
+	}
+	return best
+}
+
+// EmailAddresses returns all of the contact's email addresses, most
+// preferred first.
+func (c *Contact) EmailAddresses() []ContactEmail {
+	emails := make([]ContactEmail, 0, len(c.Emails))
+	for _, e := range c.Emails {
+		emails = append(emails, e)
+	}
+	sort.Slice(emails, func(i, j int) bool {
+		pi, pj := prefOrder(emails[i].Pref), prefOrder(emails[j].Pref)
+		if pi != pj {
+			return pi < pj
+		}
+		return emails[i].Address < emails[j].Address
+	})
+	return emails
+}
+
+// PhoneNumbers returns all of the contact's phone numbers, most preferred first.
+func (c *Contact) PhoneNumbers() []ContactPhone {
+	phones := make([]ContactPhone, 0, len(c.Phones))
+	for _, p := range c.Phones {
+		phones = append(phones, p)
+	}
+	sort.Slice(phones, func(i, j int) bool {
+		pi, pj := prefOrder(phones[i].Pref), prefOrder(phones[j].Pref)
+		if pi != pj {
+			return pi < pj
+		}
+		return phones[i].Number < phones[j].Number
+	})
+	return phones
+}
+
+// NameComponent returns the first name component of the given kind, such as
+// "given" or "surname".
+func (c *Contact) NameComponent(kind string) string {
+	if c.Name == nil {
+		return ""
+	}
+	for _, comp := range c.Name.Components {
+		if comp.Kind == kind {
+			return comp.Value
+		}
+	}
+	return ""
+}
+
+// prefOrder sorts an unset (zero) JSContact preference after explicit ones.
+func prefOrder(pref int) int {
+	if pref == 0 {
+		return 101
+	}
+	return pref
+}
+
+// Quota represents a JMAP quota (RFC 9425).
+type Quota struct {
+	ID           string   `json:"id"`
+	Name         string   `json:"name"`
+	ResourceType string   `json:"resourceType"` // octets or count
+	Scope        string   `json:"scope"`        // account, domain or global
+	Used         int64    `json:"used"`
+	HardLimit    int64    `json:"hardLimit"`
+	Types        []string `json:"types,omitempty"`
 }
 
 // Thread represents a JMAP thread.
@@ -89,6 +275,18 @@ func (e *Email) IsDraft() bool {
 	return e.Keywords["$draft"]
 }
 
+// KeywordList returns the email's keywords ($seen, $flagged, ...) sorted.
+func (e *Email) KeywordList() []string {
+	keywords := []string{}
+	for k, set := range e.Keywords {
+		if set {
+			keywords = append(keywords, k)
+		}
+	}
+	sort.Strings(keywords)
+	return keywords
+}
+
 // String returns a formatted string for an EmailAddress.
 func (a EmailAddress) String() string {
 	if a.Name != "" {
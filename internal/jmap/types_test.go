@@ -166,3 +166,30 @@ func TestFormatAddresses(t *testing.T) {
 		})
 	}
 }
+
+func TestContact_FullName(t *testing.T) {
+	assert.Equal(t, "", (&Contact{}).FullName())
+	assert.Equal(t, "Alice Smith", (&Contact{Name: &ContactName{Full: "Alice Smith"}}).FullName())
+
+	name := &ContactName{Components: []ContactNameComponent{
+		{Kind: "given", Value: "Bob"},
+		{Kind: "surname", Value: "Jones"},
+	}}
+	assert.Equal(t, "Bob Jones", (&Contact{Name: name}).FullName())
+}
+
+func TestContact_PrimaryEmail(t *testing.T) {
+	assert.Equal(t, "", (&Contact{}).PrimaryEmail())
+
+	c := &Contact{Emails: map[string]ContactEmail{
+		"e1": {Address: "work@example.com"},
+		"e2": {Address: "home@example.com", Pref: 1},
+	}}
+	assert.Equal(t, "home@example.com", c.PrimaryEmail())
+
+	c = &Contact{Emails: map[string]ContactEmail{
+		"e1": {Address: "b@example.com"},
+		"e2": {Address: "a@example.com"},
+	}}
+	assert.Equal(t, "a@example.com", c.PrimaryEmail(), "ties break alphabetically")
+}
@@ -0,0 +1,96 @@
+package jmap
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// VacationResponseID is the ID of the account's only VacationResponse object.
+const VacationResponseID = "singleton"
+
+// GetVacationResponse fetches the vacation auto-reply settings.
+func (c *Client) GetVacationResponse() (*VacationResponse, error) {
+	session, err := c.requireCapability(VacationCapability)
+	if err != nil {
+		return nil, err
+	}
+
+	request := &Request{
+		Using: []string{CoreCapability, VacationCapability},
+		MethodCalls: [][]interface{}{
+			{
+				"VacationResponse/get",
+				map[string]interface{}{
+					"accountId": session.AccountID,
+					"ids":       []string{VacationResponseID},
+				},
+				"vacation",
+			},
+		},
+	}
+
+	resp, err := c.MakeRequest(request)
+	if err != nil {
+		return nil, err
+	}
+
+	var result struct {
+		List []VacationResponse `json:"list"`
+	}
+
+	if err := json.Unmarshal(resp.MethodResponses[0][1], &result); err != nil {
+		return nil, fmt.Errorf("failed to parse vacation response: %w", err)
+	}
+
+	if len(result.List) == 0 {
+		return nil, fmt.Errorf("vacation response not found")
+	}
+
+	return &result.List[0], nil
+}
+
+// UpdateVacationResponse patches the vacation auto-reply settings.
+func (c *Client) UpdateVacationResponse(updates map[string]interface{}) error {
+	session, err := c.requireCapability(VacationCapability)
+	if err != nil {
+		return err
+	}
+
+	request := &Request{
+		Using: []string{CoreCapability, VacationCapability},
+		MethodCalls: [][]interface{}{
+			{
+				"VacationResponse/set",
+				map[string]interface{}{
+					"accountId": session.AccountID,
+					"update": map[string]interface{}{
+						VacationResponseID: updates,
+					},
+				},
+				"updateVacation",
+			},
+		},
+	}
+
+	resp, err := c.MakeRequest(request)
+	if err != nil {
+		return err
+	}
+
+	var result struct {
+		NotUpdated map[string]struct {
+			Type        string `json:"type"`
+			Description string `json:"description"`
+		} `json:"notUpdated"`
+	}
+
+	if err := json.Unmarshal(resp.MethodResponses[0][1], &result); err != nil {
+		return err
+	}
+
+	if e, ok := result.NotUpdated[VacationResponseID]; ok {
+		return fmt.Errorf("failed to update vacation response: %s - %s", e.Type, e.Description)
+	}
+
+	return nil
+}
@@ -58,6 +58,13 @@ func TestDelete(t *testing.T) {
 	assert.ErrorIs(t, err, ErrNotFound)
 }
 
+func TestDeleteNotFound(t *testing.T) {
+	gokeyring.MockInit()
+
+	err := Delete("test-service", "nonexistent-user")
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
 func TestSetWithError(t *testing.T) {
 	mockErr := errors.New("keyring unavailable")
 	gokeyring.MockInitWithError(mockErr)
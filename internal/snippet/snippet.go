@@ -0,0 +1,104 @@
+// Package snippet stores canned email bodies as text files.
+package snippet
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// ext is the file extension of snippet files.
+const ext = ".txt"
+
+var nameRe = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+// Store is a directory of snippets, one <name>.txt file each.
+type Store struct {
+	Dir string
+}
+
+// NewStore returns the store kept in the snippets directory beside the
+// config file at configPath (e.g. ~/.config/fm/snippets).
+func NewStore(configPath string) *Store {
+	return &Store{Dir: filepath.Join(filepath.Dir(configPath), "snippets")}
+}
+
+// ValidateName checks that a snippet name is safe to use as a file name.
+func ValidateName(name string) error {
+	if !nameRe.MatchString(name) {
+		return fmt.Errorf("invalid snippet name %q: use letters, digits, '-' or '_'", name)
+	}
+	return nil
+}
+
+// List returns the names of all snippets, sorted. A missing directory
+// yields no snippets.
+func (s *Store) List() ([]string, error) {
+	entries, err := os.ReadDir(s.Dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read snippets: %w", err)
+	}
+
+	var names []string
+	for _, entry := range entries {
+		name, ok := strings.CutSuffix(entry.Name(), ext)
+		if !ok || entry.IsDir() || ValidateName(name) != nil {
+			continue
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// Exists reports whether a snippet named name exists.
+func (s *Store) Exists(name string) bool {
+	_, err := os.Stat(s.path(name))
+	return err == nil
+}
+
+// Get returns the text of a snippet, without its trailing newlines.
+func (s *Store) Get(name string) (string, error) {
+	if err := ValidateName(name); err != nil {
+		return "", err
+	}
+
+	data, err := os.ReadFile(s.path(name))
+	if errors.Is(err, os.ErrNotExist) {
+		return "", fmt.Errorf("snippet %q not found: add it with 'fm snippet add %s'", name, name)
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to read snippet %q: %w", name, err)
+	}
+
+	return strings.TrimRight(string(data), "\r\n"), nil
+}
+
+// Save writes a snippet, replacing any existing one with the same name.
+func (s *Store) Save(name, text string) error {
+	if err := ValidateName(name); err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(s.Dir, 0700); err != nil {
+		return fmt.Errorf("failed to create snippets directory: %w", err)
+	}
+	if !strings.HasSuffix(text, "\n") {
+		text += "\n"
+	}
+	if err := os.WriteFile(s.path(name), []byte(text), 0600); err != nil {
+		return fmt.Errorf("failed to write snippet %q: %w", name, err)
+	}
+	return nil
+}
+
+func (s *Store) path(name string) string {
+	return filepath.Join(s.Dir, name+ext)
+}
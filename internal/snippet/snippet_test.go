@@ -0,0 +1,74 @@
+package snippet
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewStore(t *testing.T) {
+	store := NewStore(filepath.Join("/tmp", "fm", "config.yml"))
+
+	assert.Equal(t, filepath.Join("/tmp", "fm", "snippets"), store.Dir)
+}
+
+func TestStore(t *testing.T) {
+	t.Run("saves and reads snippets", func(t *testing.T) {
+		store := &Store{Dir: filepath.Join(t.TempDir(), "snippets")}
+
+		require.NoError(t, store.Save("thanks", "Thanks!\nBest, Me"))
+		require.NoError(t, store.Save("ack", "Got it.\n"))
+
+		text, err := store.Get("thanks")
+		require.NoError(t, err)
+		assert.Equal(t, "Thanks!\nBest, Me", text)
+
+		names, err := store.List()
+		require.NoError(t, err)
+		assert.Equal(t, []string{"ack", "thanks"}, names)
+		assert.True(t, store.Exists("ack"))
+	})
+
+	t.Run("lists nothing when the directory is missing", func(t *testing.T) {
+		store := &Store{Dir: filepath.Join(t.TempDir(), "missing")}
+
+		names, err := store.List()
+
+		require.NoError(t, err)
+		assert.Empty(t, names)
+	})
+
+	t.Run("ignores other files", func(t *testing.T) {
+		dir := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "notes.md"), []byte("x"), 0o600))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "bad name.txt"), []byte("x"), 0o600))
+		store := &Store{Dir: dir}
+		require.NoError(t, store.Save("ok", "x"))
+
+		names, err := store.List()
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"ok"}, names)
+	})
+
+	t.Run("errors on a missing snippet", func(t *testing.T) {
+		store := &Store{Dir: t.TempDir()}
+
+		_, err := store.Get("nope")
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `snippet "nope" not found`)
+	})
+
+	t.Run("rejects names that could escape the directory", func(t *testing.T) {
+		store := &Store{Dir: t.TempDir()}
+
+		err := store.Save("../evil", "x")
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid snippet name")
+	})
+}